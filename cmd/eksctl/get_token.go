@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/credentials"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/eks/auth"
+)
+
+type getTokenOptions struct {
+	clusterID string
+	roleARN   string
+	cacheDir  string
+	noCache   bool
+}
+
+func getTokenCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("get-token", "Get a token to authenticate with an EKS cluster",
+		"Prints an ExecCredential with a token for the cluster, for use as a kubectl exec credential plugin. "+
+			"Tokens are cached until shortly before they expire.")
+
+	var options getTokenOptions
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doGetToken(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.clusterID, "cluster-name", "", "name of the cluster, or its ID for local clusters on Outposts")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&options.roleARN, "role-arn", "", "IAM role to assume to generate the token")
+		fs.StringVar(&options.cacheDir, "cache-dir", "", "directory of the token cache (default ~/.eksctl/cache/tokens)")
+		fs.BoolVar(&options.noCache, "no-cache", false, "always generate a new token, without using the token cache")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doGetToken(cmd *cmdutils.Cmd, options getTokenOptions) error {
+	// stdout holds the ExecCredential read by kubectl
+	logger.Writer = os.Stderr
+
+	if options.clusterID == "" {
+		return cmdutils.ErrMustBeSet("--cluster-name")
+	}
+
+	apiVersion, err := auth.ExecCredentialAPIVersion()
+	if err != nil {
+		return err
+	}
+
+	generator, err := eks.NewTokenGenerator(&cmd.ProviderConfig, options.roleARN)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	var tokenGenerator auth.TokenGenerator = generator
+	if !options.noCache {
+		accessKeyID, err := eks.SourceAccessKeyID(ctx, &cmd.ProviderConfig)
+		if err != nil {
+			return err
+		}
+		cacheDir := options.cacheDir
+		if cacheDir == "" {
+			if cacheDir, err = auth.DefaultTokenCacheDir(); err != nil {
+				return fmt.Errorf("getting token cache directory: %w", err)
+			}
+		}
+		tokenGenerator = auth.CachedGenerator{
+			TokenGenerator: generator,
+			Dir:            cacheDir,
+			Scope:          tokenCacheScope(cmd.ProviderConfig, accessKeyID, options.roleARN),
+			Leeway:         auth.DefaultTokenCacheLeeway,
+			Clock:          &credentials.RealClock{},
+		}
+	}
+
+	token, err := tokenGenerator.GetWithSTS(ctx, options.clusterID)
+	if err != nil {
+		return err
+	}
+	execCredential, err := auth.ExecCredential(token, apiVersion)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(execCredential))
+	return err
+}
+
+// tokenCacheScope identifies the credentials tokens are generated with: the access key ID of the credentials
+// of the profile and the roles assumed with them
+func tokenCacheScope(providerConfig api.ProviderConfig, accessKeyID, roleARN string) string {
+	profile := providerConfig.Profile
+	return strings.Join([]string{
+		providerConfig.Region,
+		profile.Name,
+		accessKeyID,
+		strings.Join(profile.AssumeRole.RoleARNs, ","),
+		profile.AssumeRole.ExternalID,
+		profile.AssumeRole.MFASerial,
+		profile.AssumeRole.RoleSessionName,
+		roleARN,
+	}, "\n")
+}
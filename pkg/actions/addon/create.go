@@ -104,9 +104,9 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 	if err != nil {
 		return err
 	}
-	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	configurationValues, err := a.makeConfigurationValues(addon)
+	if err != nil {
+		return err
 	}
 	createAddonInput := &eks.CreateAddonInput{
 		AddonName:           &addon.Name,
@@ -510,6 +510,27 @@ var _ = Describe("Create", func() {
 			},
 		}),
 
+		Entry("[ConfigurationValues] enable custom networking for vpc-cni when pod subnets are configured", createAddonEntry{
+			addon: api.Addon{
+				Name:                api.VPCCNIAddon,
+				Version:             "1.0.0",
+				ConfigurationValues: "{\"env\":{\"WARM_IP_TARGET\":\"5\"}}",
+			},
+			mockClusterConfig: func(clusterConfig *api.ClusterConfig) {
+				podSubnetsCIDR := api.DefaultPodSubnetsCIDR()
+				clusterConfig.VPC.PodSubnets = &api.PodSubnets{CIDR: &podSubnetsCIDR}
+			},
+			mockK8s: true,
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockCreateAddon(provider.MockEKS(), nil)
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(*input.ConfigurationValues).To(MatchJSON(`{"env":{"WARM_IP_TARGET":"5","AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENI_CONFIG_LABEL_DEF":"topology.kubernetes.io/zone"}}`))
+			},
+		}),
+
 		Entry("[Tags] are set", createAddonEntry{
 			addon: api.Addon{
 				Version: "1.0.0",
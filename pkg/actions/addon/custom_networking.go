@@ -0,0 +1,51 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	customNetworkCfgEnv = "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"
+	eniConfigLabelEnv   = "ENI_CONFIG_LABEL_DEF"
+)
+
+// makeConfigurationValues returns the configuration values of the addon. When the cluster uses custom networking, the
+// vpc-cni addon is configured to assign pod IPs from the subnet of the ENIConfig matching the zone of each node.
+func (a *Manager) makeConfigurationValues(addon *api.Addon) (*string, error) {
+	if addon.CanonicalName() != api.VPCCNIAddon || !a.clusterConfig.HasCustomNetworking() {
+		if addon.ConfigurationValues == "" {
+			return nil, nil
+		}
+		return &addon.ConfigurationValues, nil
+	}
+
+	values := map[string]interface{}{}
+	if addon.ConfigurationValues != "" {
+		// configuration values can be either JSON or YAML
+		if err := yaml.Unmarshal([]byte(addon.ConfigurationValues), &values); err != nil {
+			return nil, fmt.Errorf("parsing configuration values of %q addon: %w", addon.Name, err)
+		}
+	}
+	env, ok := values["env"].(map[string]interface{})
+	if !ok {
+		env = map[string]interface{}{}
+	}
+	env[customNetworkCfgEnv] = "true"
+	if _, ok := env[eniConfigLabelEnv]; !ok {
+		env[eniConfigLabelEnv] = addons.ENIConfigLabelDef
+	}
+	values["env"] = env
+
+	configurationValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return aws.String(string(configurationValues)), nil
+}
@@ -28,9 +28,9 @@ type PodIdentityIAMUpdater interface {
 func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUpdater PodIdentityIAMUpdater, waitTimeout time.Duration) error {
 	logger.Debug("addon: %v", addon)
 
-	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	configurationValues, err := a.makeConfigurationValues(addon)
+	if err != nil {
+		return err
 	}
 	updateAddonInput := &eks.UpdateAddonInput{
 		AddonName:           &addon.Name,
@@ -13,6 +13,7 @@ import (
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 
 	"github.com/kris-nova/logger"
 
@@ -34,7 +35,7 @@ type NodeGroupDrainer interface {
 }
 type vpcCniDeleter func(clusterConfig *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface)
 
-func deleteSharedResources(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager, clusterOperable bool, clientSet kubernetes.Interface) error {
+func deleteSharedResources(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager, clusterOperable bool, clientSet kubernetes.Interface, newDynamicClient func() (dynamic.Interface, error)) error {
 	if clusterOperable && !cfg.IsControlPlaneOnOutposts() {
 		if err := deleteFargateProfiles(ctx, cfg.Metadata, ctl, stackManager); err != nil {
 			return err
@@ -59,8 +60,12 @@ func deleteSharedResources(ctx context.Context, cfg *api.ClusterConfig, ctl *eks
 
 		cfg.Metadata.Version = *ctl.Status.ClusterInfo.Cluster.Version
 
-		logger.Info("cleaning up AWS load balancers created by Kubernetes objects of Kind Service or Ingress")
-		if err := elb.Cleanup(ctx, ctl.AWSProvider.EC2(), ctl.AWSProvider.ELB(), ctl.AWSProvider.ELBV2(), clientSet, cfg); err != nil {
+		dynamicClient, err := newDynamicClient()
+		if err != nil {
+			return err
+		}
+		logger.Info("cleaning up AWS load balancers created by Kubernetes objects of Kind Service, Ingress, TargetGroupBinding or Gateway")
+		if err := elb.Cleanup(ctx, ctl.AWSProvider.EC2(), ctl.AWSProvider.ELB(), ctl.AWSProvider.ELBV2(), clientSet, dynamicClient, cfg); err != nil {
 			return err
 		}
 	}
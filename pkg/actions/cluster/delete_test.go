@@ -6,9 +6,14 @@ import (
 	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/weaveworks/eksctl/pkg/actions/cluster"
@@ -20,6 +25,21 @@ import (
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 )
 
+// newFakeDynamicClient returns a dynamic client serving the resources listed by the load balancer cleanup
+func newFakeDynamicClient() dynamic.Interface {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "elbv2.k8s.aws", Version: "v1beta1", Resource: "targetgroupbindings"}:   "TargetGroupBindingList",
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}:       "GatewayList",
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}: "GatewayClassList",
+	})
+}
+
+// mockLoadBalancerCleanup mocks the ELBv2 calls of the load balancer cleanup, for a cluster with no load balancers
+func mockLoadBalancerCleanup(p *mockprovider.MockProvider) {
+	p.MockELBV2().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{}, nil)
+	p.MockELBV2().On("DescribeTargetGroups", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{}, nil)
+}
+
 type drainerMock struct {
 	mock.Mock
 }
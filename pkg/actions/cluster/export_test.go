@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"k8s.io/client-go/dynamic"
+
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
@@ -16,6 +18,14 @@ func (c *OwnedCluster) SetNewClientSet(newClientSet func() (kubernetes.Interface
 	c.newClientSet = newClientSet
 }
 
+func (c *UnownedCluster) SetNewDynamicClient(newDynamicClient func() (dynamic.Interface, error)) {
+	c.newDynamicClient = newDynamicClient
+}
+
+func (c *OwnedCluster) SetNewDynamicClient(newDynamicClient func() (dynamic.Interface, error)) {
+	c.newDynamicClient = newDynamicClient
+}
+
 func (c *UnownedCluster) SetNewNodeGroupDrainer(newNodeGroupDrainer func(clientSet kubernetes.Interface) NodeGroupDrainer) {
 	c.newNodeGroupDrainer = newNodeGroupDrainer
 }
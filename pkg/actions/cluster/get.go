@@ -43,20 +43,12 @@ func GetClusters(ctx context.Context, provider api.ClusterProvider, listAllRegio
 	}
 
 	var clusters []Description
-	authorizedRegionsList, err := provider.EC2().DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	regions, err := authorizedRegions(ctx, provider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe regions: %w", err)
-	}
-
-	authorizedRegions := map[string]struct{}{}
-	for _, r := range authorizedRegionsList.Regions {
-		authorizedRegions[*r.RegionName] = struct{}{}
+		return nil, err
 	}
 
-	for _, region := range api.SupportedRegions() {
-		if _, authorized := authorizedRegions[region]; !authorized {
-			continue
-		}
+	for _, region := range regions {
 		// Reset region and recreate the client.
 		ctl, err := newClusterProvider(ctx, &api.ProviderConfig{
 			Region:      region,
@@ -81,6 +73,27 @@ func GetClusters(ctx context.Context, provider api.ClusterProvider, listAllRegio
 	return clusters, nil
 }
 
+// authorizedRegions returns the supported regions enabled in the account of the provider
+func authorizedRegions(ctx context.Context, provider api.ClusterProvider) ([]string, error) {
+	authorizedRegionsList, err := provider.EC2().DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	authorized := map[string]struct{}{}
+	for _, r := range authorizedRegionsList.Regions {
+		authorized[*r.RegionName] = struct{}{}
+	}
+
+	var regions []string
+	for _, region := range api.SupportedRegions() {
+		if _, ok := authorized[region]; ok {
+			regions = append(regions, region)
+		}
+	}
+	return regions, nil
+}
+
 func listClusters(ctx context.Context, provider api.ClusterProvider, chunkSize int32) ([]Description, error) {
 	var allClusters []Description
 
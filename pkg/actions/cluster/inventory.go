@@ -0,0 +1,372 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const inventoryImageIDPath = "Resources.NodeGroupLaunchTemplate.Properties.LaunchTemplateData.ImageId"
+
+// Inventory describes a cluster along with its nodegroups and addons
+type Inventory struct {
+	Account               string
+	Profile               string `json:",omitempty"`
+	Region                string
+	Name                  string
+	Version               string
+	PlatformVersion       string
+	Status                string
+	AuthenticationMode    string
+	EndpointPublicAccess  bool
+	EndpointPrivateAccess bool
+	PublicAccessCIDRs     []string `json:",omitempty"`
+	EksctlCreated         api.EKSCTLCreated
+	NodeGroups            []NodeGroupInventory
+	Addons                []AddonInventory
+	// Error is set when the cluster could not be fully described
+	Error string `json:",omitempty"`
+}
+
+// NodeGroupInventory describes a nodegroup of a cluster
+type NodeGroupInventory struct {
+	Name   string
+	Type   api.NodeGroupType
+	Status string
+	// Version and ReleaseVersion are only known for managed nodegroups
+	Version        string `json:",omitempty"`
+	ReleaseVersion string `json:",omitempty"`
+	AMIType        string `json:",omitempty"`
+	// ImageID is only known for unmanaged nodegroups with a custom AMI or an AMI resolved by eksctl
+	ImageID string `json:",omitempty"`
+}
+
+// AddonInventory describes an addon of a cluster
+type AddonInventory struct {
+	Name    string
+	Version string
+	Status  string
+}
+
+// InventoryOptions sets the clusters included in the inventory and how they are looked up
+type InventoryOptions struct {
+	// Regions to look up clusters in, defaults to the region of the provider
+	Regions []string
+	// AllRegions looks up clusters in all supported regions enabled in each account
+	AllRegions bool
+	// Profiles are the AWS profiles of the accounts to look up clusters in, defaults to the profile of the provider
+	Profiles []string
+	// Parallelism is the maximum number of regions and clusters looked up concurrently
+	Parallelism int
+	// RequestsPerSecond limits the rate of AWS API calls made by all lookups, 0 disables the limit
+	RequestsPerSecond float64
+	ChunkSize         int
+}
+
+type inventoryTarget struct {
+	account  string
+	profile  string
+	provider api.ClusterProvider
+}
+
+// GetInventory returns the inventory of the clusters of each account and region. Errors looking up a region
+// are logged, and errors looking up a cluster are recorded in its Inventory, so that a single failure does not
+// fail the whole inventory.
+func GetInventory(ctx context.Context, provider api.ClusterProvider, options InventoryOptions) ([]Inventory, error) {
+	targets, err := getInventoryTargets(ctx, provider, options)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, 1)
+	if options.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(options.RequestsPerSecond), 1)
+	}
+	parallelism := options.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		mu          sync.Mutex
+		inventories []Inventory
+	)
+	listGroup, listCtx := errgroup.WithContext(ctx)
+	listGroup.SetLimit(parallelism)
+	describeGroup, describeCtx := errgroup.WithContext(ctx)
+	describeGroup.SetLimit(parallelism)
+	for _, target := range targets {
+		target := target
+		listGroup.Go(func() error {
+			if err := limiter.Wait(listCtx); err != nil {
+				return err
+			}
+			clusters, err := listClusters(listCtx, target.provider, int32(options.ChunkSize))
+			if err != nil {
+				logger.Critical("error listing clusters of account %s: %v", target.account, err)
+				return nil
+			}
+			for _, description := range clusters {
+				description := description
+				describeGroup.Go(func() error {
+					inventory := describeClusterInventory(describeCtx, target, description, limiter)
+					mu.Lock()
+					defer mu.Unlock()
+					inventories = append(inventories, inventory)
+					return nil
+				})
+			}
+			return nil
+		})
+	}
+	if err := listGroup.Wait(); err != nil {
+		return nil, err
+	}
+	if err := describeGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(inventories, func(i, j int) bool {
+		a, b := inventories[i], inventories[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.Name < b.Name
+	})
+	return inventories, nil
+}
+
+// getInventoryTargets creates a provider for each profile and region
+func getInventoryTargets(ctx context.Context, provider api.ClusterProvider, options InventoryOptions) ([]inventoryTarget, error) {
+	profiles := options.Profiles
+	if len(profiles) == 0 {
+		profiles = []string{""}
+	}
+
+	newProvider := func(profile, region string) (api.ClusterProvider, error) {
+		if profile == "" && region == provider.Region() {
+			return provider, nil
+		}
+		profileConfig := provider.Profile()
+		if profile != "" {
+			profileConfig = api.Profile{Name: profile}
+		}
+		ctl, err := newClusterProvider(ctx, &api.ProviderConfig{
+			Region:      region,
+			Profile:     profileConfig,
+			WaitTimeout: provider.WaitTimeout(),
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ctl.AWSProvider, nil
+	}
+
+	var targets []inventoryTarget
+	for _, profile := range profiles {
+		profileProvider, err := newProvider(profile, provider.Region())
+		if err != nil {
+			return nil, fmt.Errorf("creating provider for profile %q: %w", profile, err)
+		}
+		identity, err := profileProvider.STS().GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return nil, fmt.Errorf("getting the account of profile %q: %w", profile, err)
+		}
+		account := aws.ToString(identity.Account)
+
+		regions := options.Regions
+		if options.AllRegions {
+			if regions, err = authorizedRegions(ctx, profileProvider); err != nil {
+				return nil, err
+			}
+		} else if len(regions) == 0 {
+			regions = []string{provider.Region()}
+		}
+
+		for _, region := range regions {
+			regionProvider := profileProvider
+			if region != profileProvider.Region() {
+				if regionProvider, err = newProvider(profile, region); err != nil {
+					logger.Critical("error creating provider in %q region: %v", region, err)
+					continue
+				}
+			}
+			targets = append(targets, inventoryTarget{account: account, profile: profile, provider: regionProvider})
+		}
+	}
+	return targets, nil
+}
+
+func describeClusterInventory(ctx context.Context, target inventoryTarget, description Description, limiter *rate.Limiter) Inventory {
+	inventory := Inventory{
+		Account:       target.account,
+		Profile:       target.profile,
+		Region:        description.Region,
+		Name:          description.Name,
+		EksctlCreated: description.Owned,
+	}
+	if err := describeCluster(ctx, target.provider, &inventory, limiter); err != nil {
+		logger.Warning("error describing cluster %q in region %q: %v", description.Name, description.Region, err)
+		inventory.Error = err.Error()
+	}
+	return inventory
+}
+
+func describeCluster(ctx context.Context, provider api.ClusterProvider, inventory *Inventory, limiter *rate.Limiter) error {
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+	output, err := provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: aws.String(inventory.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("describing cluster: %w", err)
+	}
+	cluster := output.Cluster
+	inventory.Version = aws.ToString(cluster.Version)
+	inventory.PlatformVersion = aws.ToString(cluster.PlatformVersion)
+	inventory.Status = string(cluster.Status)
+	if cluster.AccessConfig != nil {
+		inventory.AuthenticationMode = string(cluster.AccessConfig.AuthenticationMode)
+	}
+	if vpcConfig := cluster.ResourcesVpcConfig; vpcConfig != nil {
+		inventory.EndpointPublicAccess = vpcConfig.EndpointPublicAccess
+		inventory.EndpointPrivateAccess = vpcConfig.EndpointPrivateAccess
+		inventory.PublicAccessCIDRs = vpcConfig.PublicAccessCidrs
+	}
+
+	if inventory.NodeGroups, err = getNodeGroupInventory(ctx, provider, inventory.Name, limiter); err != nil {
+		return err
+	}
+	if inventory.EksctlCreated == eksctlCreatedTrue {
+		unmanaged, err := getUnmanagedNodeGroupInventory(ctx, provider, inventory.Name, limiter)
+		if err != nil {
+			return err
+		}
+		inventory.NodeGroups = append(inventory.NodeGroups, unmanaged...)
+	}
+	slices.SortFunc(inventory.NodeGroups, func(a, b NodeGroupInventory) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	inventory.Addons, err = getAddonInventory(ctx, provider, inventory.Name, limiter)
+	return err
+}
+
+func getNodeGroupInventory(ctx context.Context, provider api.ClusterProvider, clusterName string, limiter *rate.Limiter) ([]NodeGroupInventory, error) {
+	var nodeGroups []NodeGroupInventory
+	paginator := awseks.NewListNodegroupsPaginator(provider.EKS(), &awseks.ListNodegroupsInput{
+		ClusterName: aws.String(clusterName),
+	})
+	for paginator.HasMorePages() {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing nodegroups: %w", err)
+		}
+		for _, name := range output.Nodegroups {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+			ng, err := provider.EKS().DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String(name),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+			}
+			nodeGroups = append(nodeGroups, NodeGroupInventory{
+				Name:           name,
+				Type:           api.NodeGroupTypeManaged,
+				Status:         string(ng.Nodegroup.Status),
+				Version:        aws.ToString(ng.Nodegroup.Version),
+				ReleaseVersion: aws.ToString(ng.Nodegroup.ReleaseVersion),
+				AMIType:        string(ng.Nodegroup.AmiType),
+			})
+		}
+	}
+	return nodeGroups, nil
+}
+
+func getUnmanagedNodeGroupInventory(ctx context.Context, provider api.ClusterProvider, clusterName string, limiter *rate.Limiter) ([]NodeGroupInventory, error) {
+	stackManager := newStackCollection(provider, &api.ClusterConfig{Metadata: &api.ClusterMeta{Name: clusterName}})
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	stacks, err := stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	var nodeGroups []NodeGroupInventory
+	for _, stack := range stacks {
+		if stack.Type != api.NodeGroupTypeUnmanaged {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		template, err := stackManager.GetStackTemplate(ctx, aws.ToString(stack.Stack.StackName))
+		if err != nil {
+			return nil, fmt.Errorf("getting template of nodegroup %q: %w", stack.NodeGroupName, err)
+		}
+		nodeGroups = append(nodeGroups, NodeGroupInventory{
+			Name:    stack.NodeGroupName,
+			Type:    api.NodeGroupTypeUnmanaged,
+			Status:  string(stack.Stack.StackStatus),
+			ImageID: gjson.Get(template, inventoryImageIDPath).String(),
+		})
+	}
+	return nodeGroups, nil
+}
+
+func getAddonInventory(ctx context.Context, provider api.ClusterProvider, clusterName string, limiter *rate.Limiter) ([]AddonInventory, error) {
+	var addons []AddonInventory
+	paginator := awseks.NewListAddonsPaginator(provider.EKS(), &awseks.ListAddonsInput{
+		ClusterName: aws.String(clusterName),
+	})
+	for paginator.HasMorePages() {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing addons: %w", err)
+		}
+		for _, name := range output.Addons {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+			addon, err := provider.EKS().DescribeAddon(ctx, &awseks.DescribeAddonInput{
+				ClusterName: aws.String(clusterName),
+				AddonName:   aws.String(name),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("describing addon %q: %w", name, err)
+			}
+			addons = append(addons, AddonInventory{
+				Name:    name,
+				Version: aws.ToString(addon.Addon.AddonVersion),
+				Status:  string(addon.Addon.Status),
+			})
+		}
+	}
+	return addons, nil
+}
@@ -0,0 +1,290 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	mgrfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("GetInventory", func() {
+	var (
+		awsProvider             *fakes.FakeProviderConstructor
+		stackCollectionProvider *fakes.FakeStackManagerConstructor
+		stackManager            *mgrfakes.FakeStackManager
+		initialProvider         *mockprovider.MockProvider
+	)
+
+	mockAccount := func(provider *mockprovider.MockProvider, account string) {
+		provider.MockSTS().On("GetCallerIdentity", mock.Anything, mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String(account),
+		}, nil)
+	}
+
+	mockEmptyCluster := func(provider *mockprovider.MockProvider, clusterName string) {
+		provider.MockEKS().On("ListClusters", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListClustersOutput{
+			Clusters: []string{clusterName},
+		}, nil)
+		provider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String(clusterName)}).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Name:    aws.String(clusterName),
+				Version: aws.String("1.29"),
+			},
+		}, nil)
+		provider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListNodegroupsOutput{}, nil)
+		provider.MockEKS().On("ListAddons", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{}, nil)
+	}
+
+	BeforeEach(func() {
+		initialProvider = mockprovider.NewMockProvider()
+		initialProvider.SetRegion("us-west-2")
+		awsProvider = new(fakes.FakeProviderConstructor)
+		stackCollectionProvider = new(fakes.FakeStackManagerConstructor)
+		stackManager = new(mgrfakes.FakeStackManager)
+		stackCollectionProvider.Returns(stackManager)
+		cluster.SetProviderConstructor(awsProvider.Spy)
+		cluster.SetStackManagerConstructor(stackCollectionProvider.Spy)
+	})
+
+	When("looking up clusters in the region of the provider", func() {
+		BeforeEach(func() {
+			mockAccount(initialProvider, "111122223333")
+			initialProvider.MockEKS().On("ListClusters", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListClustersOutput{
+				Clusters: []string{"owned", "broken"},
+			}, nil)
+			stackManager.HasClusterStackFromListStub = func(_ context.Context, _ []string, clusterName string) (bool, error) {
+				return clusterName == "owned", nil
+			}
+
+			initialProvider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String("owned")}).Return(&awseks.DescribeClusterOutput{
+				Cluster: &ekstypes.Cluster{
+					Name:            aws.String("owned"),
+					Version:         aws.String("1.29"),
+					PlatformVersion: aws.String("eks.7"),
+					Status:          ekstypes.ClusterStatusActive,
+					AccessConfig: &ekstypes.AccessConfigResponse{
+						AuthenticationMode: ekstypes.AuthenticationModeApiAndConfigMap,
+					},
+					ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+						EndpointPublicAccess:  true,
+						EndpointPrivateAccess: true,
+						PublicAccessCidrs:     []string{"0.0.0.0/0"},
+					},
+				},
+			}, nil)
+			initialProvider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String("broken")}).Return(nil, fmt.Errorf("access denied"))
+
+			initialProvider.MockEKS().On("ListNodegroups", mock.Anything, &awseks.ListNodegroupsInput{ClusterName: aws.String("owned")}, mock.Anything).Return(&awseks.ListNodegroupsOutput{
+				Nodegroups: []string{"mng-1"},
+			}, nil)
+			initialProvider.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+				ClusterName:   aws.String("owned"),
+				NodegroupName: aws.String("mng-1"),
+			}).Return(&awseks.DescribeNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{
+					Status:         ekstypes.NodegroupStatusActive,
+					Version:        aws.String("1.29"),
+					ReleaseVersion: aws.String("1.29.0-20240415"),
+					AmiType:        ekstypes.AMITypesAl2X8664,
+				},
+			}, nil)
+			stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+				{
+					NodeGroupName: "mng-1",
+					Type:          api.NodeGroupTypeManaged,
+					Stack:         &manager.Stack{StackName: aws.String("eksctl-owned-nodegroup-mng-1")},
+				},
+				{
+					NodeGroupName: "ng-1",
+					Type:          api.NodeGroupTypeUnmanaged,
+					Stack: &manager.Stack{
+						StackName:   aws.String("eksctl-owned-nodegroup-ng-1"),
+						StackStatus: cfntypes.StackStatusCreateComplete,
+					},
+				},
+			}, nil)
+			stackManager.GetStackTemplateReturns(`{"Resources": {"NodeGroupLaunchTemplate": {"Properties": {"LaunchTemplateData": {"ImageId": "ami-123"}}}}}`, nil)
+
+			initialProvider.MockEKS().On("ListAddons", mock.Anything, &awseks.ListAddonsInput{ClusterName: aws.String("owned")}, mock.Anything).Return(&awseks.ListAddonsOutput{
+				Addons: []string{"vpc-cni"},
+			}, nil)
+			initialProvider.MockEKS().On("DescribeAddon", mock.Anything, &awseks.DescribeAddonInput{
+				ClusterName: aws.String("owned"),
+				AddonName:   aws.String("vpc-cni"),
+			}).Return(&awseks.DescribeAddonOutput{
+				Addon: &ekstypes.Addon{
+					AddonVersion: aws.String("v1.18.0-eksbuild.1"),
+					Status:       ekstypes.AddonStatusActive,
+				},
+			}, nil)
+		})
+
+		It("returns the inventory of each cluster, recording lookup errors", func() {
+			inventory, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{
+				Parallelism:       2,
+				RequestsPerSecond: 100,
+				ChunkSize:         100,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inventory).To(Equal([]cluster.Inventory{
+				{
+					Account:       "111122223333",
+					Region:        "us-west-2",
+					Name:          "broken",
+					EksctlCreated: "False",
+					Error:         "describing cluster: access denied",
+				},
+				{
+					Account:               "111122223333",
+					Region:                "us-west-2",
+					Name:                  "owned",
+					Version:               "1.29",
+					PlatformVersion:       "eks.7",
+					Status:                "ACTIVE",
+					AuthenticationMode:    "API_AND_CONFIG_MAP",
+					EndpointPublicAccess:  true,
+					EndpointPrivateAccess: true,
+					PublicAccessCIDRs:     []string{"0.0.0.0/0"},
+					EksctlCreated:         "True",
+					NodeGroups: []cluster.NodeGroupInventory{
+						{
+							Name:           "mng-1",
+							Type:           api.NodeGroupTypeManaged,
+							Status:         "ACTIVE",
+							Version:        "1.29",
+							ReleaseVersion: "1.29.0-20240415",
+							AMIType:        "AL2_x86_64",
+						},
+						{
+							Name:    "ng-1",
+							Type:    api.NodeGroupTypeUnmanaged,
+							Status:  "CREATE_COMPLETE",
+							ImageID: "ami-123",
+						},
+					},
+					Addons: []cluster.AddonInventory{
+						{
+							Name:    "vpc-cni",
+							Version: "v1.18.0-eksbuild.1",
+							Status:  "ACTIVE",
+						},
+					},
+				},
+			}))
+			Expect(awsProvider.CallCount()).To(Equal(0))
+			Expect(stackManager.GetStackTemplateCallCount()).To(Equal(1))
+			_, stackName := stackManager.GetStackTemplateArgsForCall(0)
+			Expect(stackName).To(Equal("eksctl-owned-nodegroup-ng-1"))
+		})
+	})
+
+	When("looking up clusters in other regions and profiles", func() {
+		var (
+			providerRegion    *mockprovider.MockProvider
+			providerProfile   *mockprovider.MockProvider
+			providerProfileEU *mockprovider.MockProvider
+		)
+
+		BeforeEach(func() {
+			mockAccount(initialProvider, "111122223333")
+			mockEmptyCluster(initialProvider, "cluster-a")
+
+			providerRegion = mockprovider.NewMockProvider()
+			providerRegion.SetRegion("eu-west-1")
+			mockEmptyCluster(providerRegion, "cluster-b")
+
+			providerProfile = mockprovider.NewMockProvider()
+			providerProfile.SetRegion("us-west-2")
+			mockAccount(providerProfile, "444455556666")
+			mockEmptyCluster(providerProfile, "cluster-c")
+
+			providerProfileEU = mockprovider.NewMockProvider()
+			providerProfileEU.SetRegion("eu-west-1")
+			mockEmptyCluster(providerProfileEU, "cluster-d")
+
+			awsProvider.Stub = func(_ context.Context, spec *api.ProviderConfig, _ *api.ClusterConfig) (*eks.ClusterProvider, error) {
+				switch {
+				case spec.Profile.Name != "prod" && spec.Region == "eu-west-1":
+					return &eks.ClusterProvider{AWSProvider: providerRegion}, nil
+				case spec.Profile.Name == "prod" && spec.Region == "us-west-2":
+					return &eks.ClusterProvider{AWSProvider: providerProfile}, nil
+				case spec.Profile.Name == "prod" && spec.Region == "eu-west-1":
+					return &eks.ClusterProvider{AWSProvider: providerProfileEU}, nil
+				}
+				return nil, fmt.Errorf("unexpected provider config %+v", *spec)
+			}
+		})
+
+		It("creates a provider for each profile and region", func() {
+			inventory, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{
+				Regions:     []string{"us-west-2", "eu-west-1"},
+				Profiles:    []string{"", "prod"},
+				Parallelism: 4,
+				ChunkSize:   100,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, c := range inventory {
+				Expect(c.Error).To(BeEmpty())
+				names = append(names, fmt.Sprintf("%s/%s/%s", c.Account, c.Region, c.Name))
+			}
+			Expect(names).To(Equal([]string{
+				"111122223333/eu-west-1/cluster-b",
+				"111122223333/us-west-2/cluster-a",
+				"444455556666/eu-west-1/cluster-d",
+				"444455556666/us-west-2/cluster-c",
+			}))
+			Expect(awsProvider.CallCount()).To(Equal(3))
+		})
+
+		It("looks up clusters in all supported regions enabled in each account", func() {
+			initialProvider.MockEC2().On("DescribeRegions", mock.Anything, mock.Anything).Return(&ec2.DescribeRegionsOutput{
+				Regions: []ec2types.Region{
+					{RegionName: aws.String("us-west-2")},
+					{RegionName: aws.String("eu-west-1")},
+					{RegionName: aws.String("unsupported-region-1")},
+				},
+			}, nil)
+
+			inventory, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{
+				AllRegions:  true,
+				Parallelism: 1,
+				ChunkSize:   100,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inventory).To(HaveLen(2))
+			Expect(inventory[0].Name).To(Equal("cluster-b"))
+			Expect(inventory[1].Name).To(Equal("cluster-a"))
+		})
+	})
+
+	When("the account of a profile cannot be determined", func() {
+		BeforeEach(func() {
+			initialProvider.MockSTS().On("GetCallerIdentity", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("expired token"))
+		})
+
+		It("errors", func() {
+			_, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{Parallelism: 1})
+			Expect(err).To(MatchError(`getting the account of profile "": expired token`))
+		})
+	})
+})
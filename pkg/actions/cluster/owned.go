@@ -7,6 +7,8 @@ import (
 
 	"github.com/kris-nova/logger"
 
+	"k8s.io/client-go/dynamic"
+
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
 	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
@@ -26,6 +28,7 @@ type OwnedCluster struct {
 	clusterStack        *manager.Stack
 	stackManager        manager.StackManager
 	newClientSet        func() (kubernetes.Interface, error)
+	newDynamicClient    func() (dynamic.Interface, error)
 	newNodeGroupDrainer func(clientSet kubernetes.Interface) NodeGroupDrainer
 	autoModeDeleter     AutoModeDeleter
 }
@@ -44,6 +47,9 @@ func NewOwnedCluster(cfg *api.ClusterConfig, ctl *eks.ClusterProvider, clusterSt
 		newClientSet: func() (kubernetes.Interface, error) {
 			return ctl.NewStdClientSet(cfg)
 		},
+		newDynamicClient: func() (dynamic.Interface, error) {
+			return ctl.NewDynamicClient(cfg)
+		},
 		newNodeGroupDrainer: func(clientSet kubernetes.Interface) NodeGroupDrainer {
 			return &nodegroup.Drainer{
 				ClientSet: clientSet,
@@ -111,7 +117,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}
 	}
 
-	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet); err != nil {
+	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet, c.newDynamicClient); err != nil {
 		if force {
 			logger.Warning("error occurred during deletion: %v", err)
 		} else {
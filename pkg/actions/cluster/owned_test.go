@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/weaveworks/eksctl/pkg/actions/cluster"
@@ -190,6 +191,10 @@ var _ = Describe("Delete", func() {
 				c.SetNewClientSet(func() (kubernetes.Interface, error) {
 					return fakeClientSet, nil
 				})
+				c.SetNewDynamicClient(func() (dynamic.Interface, error) {
+					return newFakeDynamicClient(), nil
+				})
+				mockLoadBalancerCleanup(p)
 
 				mockedDrainInput := &nodegroup.DrainInput{
 					NodeGroups:     cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups),
@@ -255,6 +260,10 @@ var _ = Describe("Delete", func() {
 				c.SetNewClientSet(func() (kubernetes.Interface, error) {
 					return fakeClientSet, nil
 				})
+				c.SetNewDynamicClient(func() (dynamic.Interface, error) {
+					return newFakeDynamicClient(), nil
+				})
+				mockLoadBalancerCleanup(p)
 
 				mockedDrainInput := &nodegroup.DrainInput{
 					NodeGroups:     cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups),
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// SetDeletionProtection enables or disables CloudFormation termination protection on the cluster stack, and returns
+// the names of the stacks that were updated. Termination protection is disabled on the other stacks of the cluster,
+// as it would prevent deleting its nodegroups, addons and service accounts.
+func SetDeletionProtection(ctx context.Context, clusterName string, stackManager manager.StackManager, enabled bool) ([]string, error) {
+	stacks, err := listClusterStacks(ctx, clusterName, stackManager)
+	if err != nil {
+		return nil, err
+	}
+	var updated []string
+	for _, s := range stacks {
+		protect := enabled && isClusterStack(clusterName, *s.StackName)
+		if aws.ToBool(s.EnableTerminationProtection) == protect || s.StackStatus == cfntypes.StackStatusDeleteInProgress {
+			continue
+		}
+		if err := stackManager.SetTerminationProtection(ctx, *s.StackName, protect); err != nil {
+			return updated, err
+		}
+		updated = append(updated, *s.StackName)
+	}
+	return updated, nil
+}
+
+// CheckDeletionProtection returns an error if the cluster has deletion protection enabled, either in the config
+// file or on any of its stacks, unless disableProtection is set, in which case termination protection is disabled
+// on the stacks of the cluster so that they can be deleted
+func CheckDeletionProtection(ctx context.Context, cfg *api.ClusterConfig, stackManager manager.StackManager, disableProtection bool) error {
+	stacks, err := listClusterStacks(ctx, cfg.Metadata.Name, stackManager)
+	if err != nil {
+		return err
+	}
+	var protectedStacks []string
+	for _, s := range stacks {
+		if aws.ToBool(s.EnableTerminationProtection) {
+			protectedStacks = append(protectedStacks, *s.StackName)
+		}
+	}
+	if len(protectedStacks) == 0 && !api.IsEnabled(cfg.Metadata.DeletionProtection) {
+		return nil
+	}
+
+	if !disableProtection {
+		reason := "metadata.deletionProtection is enabled"
+		if len(protectedStacks) > 0 {
+			reason = fmt.Sprintf("termination protection is enabled on stack(s) %s", strings.Join(protectedStacks, ", "))
+		}
+		return fmt.Errorf("cluster %q has deletion protection enabled (%s); to delete it, rerun with --disable-deletion-protection", cfg.Metadata.Name, reason)
+	}
+
+	logger.Warning("disabling deletion protection of cluster %q", cfg.Metadata.Name)
+	updated, err := SetDeletionProtection(ctx, cfg.Metadata.Name, stackManager, false)
+	if err != nil {
+		return err
+	}
+	if len(updated) > 0 {
+		logger.Info("disabled termination protection on stack(s) %s", strings.Join(updated, ", "))
+	}
+	return nil
+}
+
+// listClusterStacks lists all stacks created by eksctl for the cluster, including IAM service account and access
+// entry stacks, which are not matched by the stack name patterns used for deletion
+func listClusterStacks(ctx context.Context, clusterName string, stackManager manager.StackManager) ([]*manager.Stack, error) {
+	stacks, err := stackManager.ListStacksMatching(ctx, fmt.Sprintf("^(eksctl|EKS)-%s-.+$", regexp.QuoteMeta(clusterName)))
+	if err != nil {
+		return nil, fmt.Errorf("listing stacks of cluster %q: %w", clusterName, err)
+	}
+	var clusterStacks []*manager.Stack
+	for _, s := range stacks {
+		if stackClusterName(s.Tags) == clusterName {
+			clusterStacks = append(clusterStacks, s)
+		}
+	}
+	return clusterStacks, nil
+}
+
+// isClusterStack returns true if the stack is the cluster stack, named as created by current or older versions of eksctl
+func isClusterStack(clusterName, stackName string) bool {
+	return stackName == "eksctl-"+clusterName+"-cluster" || stackName == "EKS-"+clusterName+"-ControlPlane"
+}
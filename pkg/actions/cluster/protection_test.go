@@ -0,0 +1,234 @@
+package cluster_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Deletion protection", func() {
+	var (
+		cfg              *api.ClusterConfig
+		fakeStackManager *fakes.FakeStackManager
+	)
+
+	newStack := func(name, clusterName string, protected bool) *manager.Stack {
+		return &manager.Stack{
+			StackName:                   aws.String(name),
+			StackStatus:                 cfntypes.StackStatusCreateComplete,
+			EnableTerminationProtection: aws.Bool(protected),
+			Tags: []cfntypes.Tag{
+				{Key: aws.String(api.ClusterNameTag), Value: aws.String(clusterName)},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		fakeStackManager = new(fakes.FakeStackManager)
+	})
+
+	Describe("SetDeletionProtection", func() {
+		It("protects only the cluster stack of the cluster", func() {
+			fakeStackManager.ListStacksMatchingReturns([]*manager.Stack{
+				newStack("eksctl-my-cluster-cluster", "my-cluster", false),
+				newStack("eksctl-my-cluster-nodegroup-ng-1", "my-cluster", true),
+				newStack("eksctl-my-cluster-nodegroup-ng-2", "my-cluster", false),
+				newStack("eksctl-my-cluster-nodegroup-ng-3", "my-cluster-2", true),
+			}, nil)
+
+			updated, err := cluster.SetDeletionProtection(context.Background(), "my-cluster", fakeStackManager, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(Equal([]string{"eksctl-my-cluster-cluster", "eksctl-my-cluster-nodegroup-ng-1"}))
+
+			Expect(fakeStackManager.SetTerminationProtectionCallCount()).To(Equal(2))
+			_, stackName, enabled := fakeStackManager.SetTerminationProtectionArgsForCall(0)
+			Expect(stackName).To(Equal("eksctl-my-cluster-cluster"))
+			Expect(enabled).To(BeTrue())
+			_, stackName, enabled = fakeStackManager.SetTerminationProtectionArgsForCall(1)
+			Expect(stackName).To(Equal("eksctl-my-cluster-nodegroup-ng-1"))
+			Expect(enabled).To(BeFalse())
+		})
+	})
+
+	Describe("CheckDeletionProtection", func() {
+		It("allows deleting a cluster without deletion protection", func() {
+			fakeStackManager.ListStacksMatchingReturns([]*manager.Stack{
+				newStack("eksctl-my-cluster-cluster", "my-cluster", false),
+			}, nil)
+
+			Expect(cluster.CheckDeletionProtection(context.Background(), cfg, fakeStackManager, false)).To(Succeed())
+			Expect(fakeStackManager.SetTerminationProtectionCallCount()).To(Equal(0))
+		})
+
+		It("refuses to delete a cluster whose stacks have termination protection enabled", func() {
+			fakeStackManager.ListStacksMatchingReturns([]*manager.Stack{
+				newStack("eksctl-my-cluster-cluster", "my-cluster", true),
+			}, nil)
+
+			err := cluster.CheckDeletionProtection(context.Background(), cfg, fakeStackManager, false)
+			Expect(err).To(MatchError(ContainSubstring("termination protection is enabled on stack(s) eksctl-my-cluster-cluster")))
+			Expect(err).To(MatchError(ContainSubstring("--disable-deletion-protection")))
+			Expect(fakeStackManager.SetTerminationProtectionCallCount()).To(Equal(0))
+		})
+
+		It("refuses to delete a cluster with metadata.deletionProtection enabled", func() {
+			cfg.Metadata.DeletionProtection = api.Enabled()
+
+			err := cluster.CheckDeletionProtection(context.Background(), cfg, fakeStackManager, false)
+			Expect(err).To(MatchError(ContainSubstring("metadata.deletionProtection is enabled")))
+		})
+
+		It("disables termination protection when explicitly requested", func() {
+			fakeStackManager.ListStacksMatchingReturns([]*manager.Stack{
+				newStack("eksctl-my-cluster-cluster", "my-cluster", true),
+				newStack("eksctl-my-cluster-addon-vpc-cni", "my-cluster", false),
+			}, nil)
+
+			Expect(cluster.CheckDeletionProtection(context.Background(), cfg, fakeStackManager, true)).To(Succeed())
+			Expect(fakeStackManager.SetTerminationProtectionCallCount()).To(Equal(1))
+			_, stackName, enabled := fakeStackManager.SetTerminationProtectionArgsForCall(0)
+			Expect(stackName).To(Equal("eksctl-my-cluster-cluster"))
+			Expect(enabled).To(BeFalse())
+		})
+
+		It("returns an error if disabling termination protection fails", func() {
+			fakeStackManager.ListStacksMatchingReturns([]*manager.Stack{
+				newStack("eksctl-my-cluster-cluster", "my-cluster", true),
+			}, nil)
+			fakeStackManager.SetTerminationProtectionReturns(errors.New("access denied"))
+
+			Expect(cluster.CheckDeletionProtection(context.Background(), cfg, fakeStackManager, true)).To(MatchError("access denied"))
+		})
+	})
+})
+
+var _ = Describe("NewDeletionReport", func() {
+	var (
+		p             *mockprovider.MockProvider
+		fakeClientSet *fake.Clientset
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		fakeClientSet = fake.NewSimpleClientset(
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+					ClaimRef:                      &corev1.ObjectReference{Namespace: "db", Name: "data"},
+				},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-2"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+			},
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "db"},
+				Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+			},
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: metav1.NamespaceSystem},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		)
+	})
+
+	It("reports the Kubernetes resources and the stacks referencing the cluster", func() {
+		clusterStacks := []*manager.Stack{
+			{
+				StackName: aws.String("eksctl-my-cluster-cluster"),
+				Outputs: []cfntypes.Output{
+					{OutputKey: aws.String("VPC"), ExportName: aws.String("eksctl-my-cluster-cluster::VPC")},
+					{OutputKey: aws.String("SecurityGroup"), ExportName: aws.String("eksctl-my-cluster-cluster::SecurityGroup")},
+				},
+			},
+			{
+				StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1"),
+				Outputs: []cfntypes.Output{
+					{OutputKey: aws.String("InstanceRoleARN"), ExportName: aws.String("eksctl-my-cluster-nodegroup-ng-1::InstanceRoleARN")},
+				},
+			},
+		}
+		p.MockCloudFormation().On("ListImports", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, input *cloudformation.ListImportsInput, _ ...func(*cloudformation.Options)) *cloudformation.ListImportsOutput {
+			switch *input.ExportName {
+			case "eksctl-my-cluster-cluster::VPC":
+				return &cloudformation.ListImportsOutput{Imports: []string{"eksctl-my-cluster-nodegroup-ng-1", "app-stack"}}
+			case "eksctl-my-cluster-nodegroup-ng-1::InstanceRoleARN":
+				return &cloudformation.ListImportsOutput{Imports: []string{"app-stack", "database"}}
+			}
+			return &cloudformation.ListImportsOutput{}
+		}, nil)
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{}, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []cfntypes.Stack{
+				{
+					StackName:  aws.String("eksctl-my-cluster-nodegroup-ng-1"),
+					Tags:       []cfntypes.Tag{{Key: aws.String(api.ClusterNameTag), Value: aws.String("my-cluster")}},
+					Parameters: []cfntypes.Parameter{{ParameterKey: aws.String("VpcId"), ParameterValue: aws.String("vpc-1")}},
+				},
+				{
+					StackName:  aws.String("cache"),
+					Parameters: []cfntypes.Parameter{{ParameterKey: aws.String("VpcId"), ParameterValue: aws.String("vpc-1")}},
+				},
+				{
+					StackName:  aws.String("unrelated"),
+					Parameters: []cfntypes.Parameter{{ParameterKey: aws.String("VpcId"), ParameterValue: aws.String("vpc-2")}},
+				},
+			},
+		}, nil)
+
+		report := cluster.NewDeletionReport(context.Background(), fakeClientSet, p.CloudFormation(), "my-cluster", "vpc-1", clusterStacks)
+		Expect(*report).To(Equal(cluster.DeletionReport{
+			ClusterName:                  "my-cluster",
+			LoadBalancerServices:         []string{"default/web"},
+			RetainedVolumes:              []string{"pv-1 (db/data)"},
+			DeletedVolumes:               []string{"pv-2"},
+			PodDisruptionBudgets:         2,
+			BlockingPodDisruptionBudgets: []string{"db/db"},
+			RunningPods:                  1,
+			ReferencingStacks: []string{
+				"app-stack (imports eksctl-my-cluster-cluster::VPC)",
+				"database (imports eksctl-my-cluster-nodegroup-ng-1::InstanceRoleARN)",
+				"cache (uses vpc-1)",
+			},
+		}))
+	})
+
+	It("skips Kubernetes resources when the cluster is not operable", func() {
+		report := cluster.NewDeletionReport(context.Background(), nil, p.CloudFormation(), "my-cluster", "", nil)
+		Expect(*report).To(Equal(cluster.DeletionReport{ClusterName: "my-cluster"}))
+	})
+})
@@ -0,0 +1,232 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// maxReportedNames is the maximum number of names of resources listed per line of the report
+const maxReportedNames = 10
+
+// DeletionReport summarises the resources affected by the deletion of a cluster
+type DeletionReport struct {
+	ClusterName string
+	// LoadBalancerServices are the Services of type LoadBalancer, whose load balancers are deleted with the cluster
+	LoadBalancerServices []string
+	// RetainedVolumes are the PersistentVolumes with a Retain reclaim policy
+	RetainedVolumes []string
+	// DeletedVolumes are the PersistentVolumes with a Delete reclaim policy
+	DeletedVolumes []string
+	// PodDisruptionBudgets is the number of PodDisruptionBudgets
+	PodDisruptionBudgets int
+	// BlockingPodDisruptionBudgets are the PodDisruptionBudgets that currently allow no disruptions
+	BlockingPodDisruptionBudgets []string
+	// RunningPods is the number of running pods outside of kube-system
+	RunningPods int
+	// ReferencingStacks are the stacks not created by eksctl for the cluster that import the outputs of its stacks
+	// or take its VPC as a parameter
+	ReferencingStacks []string
+}
+
+// ReportDeletion logs the resources affected by the deletion of the cluster
+func ReportDeletion(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager) {
+	var (
+		clientSet kubernetes.Interface
+		vpcID     string
+	)
+	if operable, _ := ctl.CanOperate(cfg); operable {
+		cluster := ctl.Status.ClusterInfo.Cluster
+		if cluster.ResourcesVpcConfig != nil {
+			vpcID = aws.ToString(cluster.ResourcesVpcConfig.VpcId)
+		}
+		var err error
+		if clientSet, err = ctl.NewStdClientSet(cfg); err != nil {
+			logger.Warning("unable to create Kubernetes client to report resources of cluster %q: %v", cfg.Metadata.Name, err)
+			clientSet = nil
+		}
+	}
+	clusterStacks, err := listClusterStacks(ctx, cfg.Metadata.Name, stackManager)
+	if err != nil {
+		logger.Warning("unable to list the stacks of cluster %q: %v", cfg.Metadata.Name, err)
+	}
+	NewDeletionReport(ctx, clientSet, ctl.AWSProvider.CloudFormation(), cfg.Metadata.Name, vpcID, clusterStacks).Log()
+}
+
+// NewDeletionReport builds the report of the resources affected by the deletion of the cluster; clientSet may be
+// nil when the cluster is not operable, in which case Kubernetes resources are not reported. Resources that cannot
+// be listed are skipped with a warning, as the report is informational.
+func NewDeletionReport(ctx context.Context, clientSet kubernetes.Interface, cfnAPI awsapi.CloudFormation, clusterName, vpcID string, clusterStacks []*manager.Stack) *DeletionReport {
+	report := &DeletionReport{ClusterName: clusterName}
+	if clientSet != nil {
+		report.addKubernetesResources(ctx, clientSet)
+	}
+	if err := report.addReferencingStacks(ctx, cfnAPI, vpcID, clusterStacks); err != nil {
+		logger.Warning("unable to find stacks referencing cluster %q: %v", clusterName, err)
+	}
+	return report
+}
+
+func (r *DeletionReport) addKubernetesResources(ctx context.Context, clientSet kubernetes.Interface) {
+	services, err := clientSet.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warning("unable to list Services: %v", err)
+	} else {
+		for _, s := range services.Items {
+			if s.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				r.LoadBalancerServices = append(r.LoadBalancerServices, s.Namespace+"/"+s.Name)
+			}
+		}
+	}
+
+	volumes, err := clientSet.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warning("unable to list PersistentVolumes: %v", err)
+	} else {
+		for _, pv := range volumes.Items {
+			name := pv.Name
+			if claim := pv.Spec.ClaimRef; claim != nil {
+				name = fmt.Sprintf("%s (%s/%s)", pv.Name, claim.Namespace, claim.Name)
+			}
+			switch pv.Spec.PersistentVolumeReclaimPolicy {
+			case corev1.PersistentVolumeReclaimRetain:
+				r.RetainedVolumes = append(r.RetainedVolumes, name)
+			case corev1.PersistentVolumeReclaimDelete:
+				r.DeletedVolumes = append(r.DeletedVolumes, name)
+			}
+		}
+	}
+
+	pdbs, err := clientSet.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warning("unable to list PodDisruptionBudgets: %v", err)
+	} else {
+		r.PodDisruptionBudgets = len(pdbs.Items)
+		for _, pdb := range pdbs.Items {
+			if pdb.Status.DisruptionsAllowed == 0 {
+				r.BlockingPodDisruptionBudgets = append(r.BlockingPodDisruptionBudgets, pdb.Namespace+"/"+pdb.Name)
+			}
+		}
+	}
+
+	pods, err := clientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase=" + string(corev1.PodRunning),
+	})
+	if err != nil {
+		logger.Warning("unable to list Pods: %v", err)
+	} else {
+		for _, pod := range pods.Items {
+			if pod.Namespace != metav1.NamespaceSystem {
+				r.RunningPods++
+			}
+		}
+	}
+}
+
+// addReferencingStacks finds the stacks not created by eksctl for the cluster that import the exports of its stacks,
+// which prevent their deletion, or that take the VPC of the cluster as a parameter
+func (r *DeletionReport) addReferencingStacks(ctx context.Context, cfnAPI awsapi.CloudFormation, vpcID string, clusterStacks []*manager.Stack) error {
+	seen := map[string]bool{}
+	for _, s := range clusterStacks {
+		seen[aws.ToString(s.StackName)] = true
+	}
+	add := func(stackName, reason string) {
+		if !seen[stackName] {
+			seen[stackName] = true
+			r.ReferencingStacks = append(r.ReferencingStacks, fmt.Sprintf("%s (%s)", stackName, reason))
+		}
+	}
+
+	for _, s := range clusterStacks {
+		for _, output := range s.Outputs {
+			exportName := aws.ToString(output.ExportName)
+			if exportName == "" {
+				continue
+			}
+			paginator := cloudformation.NewListImportsPaginator(cfnAPI, &cloudformation.ListImportsInput{
+				ExportName: aws.String(exportName),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					if strings.Contains(err.Error(), "is not imported by any stack") {
+						break
+					}
+					return fmt.Errorf("listing imports of %q: %w", exportName, err)
+				}
+				for _, stackName := range page.Imports {
+					add(stackName, "imports "+exportName)
+				}
+			}
+		}
+	}
+
+	if vpcID == "" {
+		return nil
+	}
+	paginator := cloudformation.NewDescribeStacksPaginator(cfnAPI, &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("describing stacks: %w", err)
+		}
+		for _, s := range page.Stacks {
+			if stackClusterName(s.Tags) == r.ClusterName {
+				continue
+			}
+			for _, p := range s.Parameters {
+				if aws.ToString(p.ParameterValue) == vpcID {
+					add(aws.ToString(s.StackName), "uses "+vpcID)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Log logs the report
+func (r *DeletionReport) Log() {
+	logger.Info("resources affected by the deletion of cluster %q:", r.ClusterName)
+	logger.Info("%d Service(s) of type LoadBalancer, whose load balancers will be deleted%s", len(r.LoadBalancerServices), formatNames(r.LoadBalancerServices))
+	logger.Info("%d PersistentVolume(s) with reclaim policy Retain, whose volumes will be kept%s", len(r.RetainedVolumes), formatNames(r.RetainedVolumes))
+	logger.Info("%d PersistentVolume(s) with reclaim policy Delete, whose volumes are only deleted if their claims are deleted before the cluster%s", len(r.DeletedVolumes), formatNames(r.DeletedVolumes))
+	logger.Info("%d PodDisruptionBudget(s), %d of which currently allow no disruptions and may block draining nodes%s", r.PodDisruptionBudgets, len(r.BlockingPodDisruptionBudgets), formatNames(r.BlockingPodDisruptionBudgets))
+	logger.Info("%d running pod(s) outside of %s", r.RunningPods, metav1.NamespaceSystem)
+	if len(r.ReferencingStacks) > 0 {
+		logger.Warning("%d stack(s) not created by eksctl for this cluster import its outputs or use its VPC, and may cause its deletion to fail%s", len(r.ReferencingStacks), formatNames(r.ReferencingStacks))
+	}
+}
+
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	if len(names) > maxReportedNames {
+		return fmt.Sprintf(": %s and %d more", strings.Join(names[:maxReportedNames], ", "), len(names)-maxReportedNames)
+	}
+	return ": " + strings.Join(names, ", ")
+}
+
+func stackClusterName(tags []cfntypes.Tag) string {
+	for _, tag := range tags {
+		switch aws.ToString(tag.Key) {
+		case api.ClusterNameTag, api.OldClusterNameTag:
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
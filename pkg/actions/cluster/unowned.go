@@ -10,6 +10,8 @@ import (
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/kris-nova/logger"
 
+	"k8s.io/client-go/dynamic"
+
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
 	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -27,6 +29,7 @@ type UnownedCluster struct {
 	stackManager        manager.StackManager
 	autoModeDeleter     AutoModeDeleter
 	newClientSet        func() (kubernetes.Interface, error)
+	newDynamicClient    func() (dynamic.Interface, error)
 	newNodeGroupDrainer func(clientSet kubernetes.Interface) NodeGroupDrainer
 }
 
@@ -39,6 +42,9 @@ func NewUnownedCluster(cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackMa
 		newClientSet: func() (kubernetes.Interface, error) {
 			return ctl.NewStdClientSet(cfg)
 		},
+		newDynamicClient: func() (dynamic.Interface, error) {
+			return ctl.NewDynamicClient(cfg)
+		},
 		newNodeGroupDrainer: func(clientSet kubernetes.Interface) NodeGroupDrainer {
 			return &nodegroup.Drainer{
 				ClientSet: clientSet,
@@ -94,7 +100,7 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 		}
 	}
 
-	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet); err != nil {
+	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet, c.newDynamicClient); err != nil {
 		if force {
 			logger.Warning("error occurred during deletion: %v", err)
 		} else {
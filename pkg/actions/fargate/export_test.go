@@ -1,7 +1,15 @@
 package fargate
 
-import "k8s.io/client-go/kubernetes"
+import (
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
 
 func (m *Manager) SetNewClientSet(newClientSet func() (kubernetes.Interface, error)) {
 	m.newStdClientSet = newClientSet
 }
+
+func (m *Manager) SetPollInterval(pollInterval time.Duration) {
+	m.pollInterval = pollInterval
+}
@@ -1,6 +1,8 @@
 package fargate
 
 import (
+	"time"
+
 	"k8s.io/client-go/kubernetes"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -13,6 +15,7 @@ type Manager struct {
 	cfg             *api.ClusterConfig
 	stackManager    manager.StackManager
 	newStdClientSet func() (kubernetes.Interface, error)
+	pollInterval    time.Duration
 }
 
 func New(cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager) *Manager {
@@ -21,5 +24,6 @@ func New(cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.
 		cfg:             cfg,
 		stackManager:    stackManager,
 		newStdClientSet: func() (kubernetes.Interface, error) { return ctl.NewStdClientSet(cfg) },
+		pollInterval:    defaultPollInterval,
 	}
 }
@@ -0,0 +1,233 @@
+package fargate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kris-nova/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+const (
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	defaultPollInterval   = 5 * time.Second
+)
+
+// UpdateOptions groups the parameters of Manager.Update.
+type UpdateOptions struct {
+	// ProfileName limits the update to the profile with this name. All profiles of the config are updated when empty.
+	ProfileName string
+	// RolloutDeployments restarts the deployments selected by the old or the new profile before deleting the old
+	// profile, so that their pods move without waiting for the deletion of the old profile to evict them.
+	RolloutDeployments bool
+	Plan               bool
+}
+
+// Update replaces the existing Fargate profiles which differ from the ones in the config. Fargate profiles are
+// immutable and their names cannot be reused until they are deleted, so each one is first replaced by a temporary
+// profile with the desired spec, which is then replaced by a profile with the configured name. The matching
+// deployments are optionally rolled before each old profile is deleted. Temporary profiles left behind by an
+// interrupted update are replaced on the next one.
+func (m *Manager) Update(ctx context.Context, options UpdateOptions) error {
+	cfg := m.cfg
+	if ok, err := m.ctl.CanOperate(cfg); !ok {
+		return fmt.Errorf("couldn't check cluster operable status: %w", err)
+	}
+
+	if options.ProfileName != "" && fargate.FindProfile(cfg.FargateProfiles, options.ProfileName) == nil {
+		return fmt.Errorf("Fargate profile %q not found in the config", options.ProfileName)
+	}
+
+	client := fargate.NewFromProvider(cfg.Metadata.Name, m.ctl.AWSProvider, m.stackManager)
+	existing, err := client.ReadProfiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	var replacements []replacement
+	for _, desired := range cfg.FargateProfiles {
+		name := fargate.ProfileName(desired)
+		if options.ProfileName != "" && name != options.ProfileName {
+			continue
+		}
+		current := fargate.FindProfile(existing, name)
+		if current == nil {
+			return fmt.Errorf("Fargate profile %q not found, use 'eksctl create fargateprofile' to create it", name)
+		}
+		r := replacement{
+			name:        name,
+			temporaries: fargate.TemporaryProfiles(existing, name),
+			desired:     desired,
+		}
+		if current.Name == name {
+			r.current = current
+		}
+		if r.current != nil && len(r.temporaries) == 0 && !fargate.RequiresReplacement(r.current, desired) {
+			logger.Info("Fargate profile %q is up to date", name)
+			continue
+		}
+		replacements = append(replacements, r)
+	}
+
+	for _, r := range replacements {
+		if options.Plan {
+			logger.Info("(plan) would replace Fargate profile %q through a temporary profile", r.name)
+			continue
+		}
+		if err := m.replace(ctx, &client, r, options.RolloutDeployments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type replacement struct {
+	name string
+	// current is the profile with the configured name, nil when an interrupted update already deleted it.
+	current *api.FargateProfile
+	// temporaries are the profiles standing in for the profile while it is recreated.
+	temporaries []*api.FargateProfile
+	desired     *api.FargateProfile
+}
+
+// withDefaults keeps the pod execution role and subnets of the base profile unless the config overrides them.
+func withDefaults(base, desired *api.FargateProfile) *api.FargateProfile {
+	profile := *desired
+	profile.Status = ""
+	if profile.PodExecutionRoleARN == "" {
+		profile.PodExecutionRoleARN = base.PodExecutionRoleARN
+	}
+	if len(profile.Subnets) == 0 {
+		profile.Subnets = base.Subnets
+	}
+	return &profile
+}
+
+func (m *Manager) replace(ctx context.Context, client *fargate.Client, r replacement, rollout bool) error {
+	if r.current != nil && fargate.RequiresReplacement(r.current, r.desired) {
+		temporary := fargate.NewTemporaryProfile(withDefaults(r.current, r.desired))
+		if err := m.move(ctx, client, r.current, temporary, rollout); err != nil {
+			return err
+		}
+		r.current = nil
+		r.temporaries = append(r.temporaries, temporary)
+	}
+
+	if r.current == nil {
+		profile := withDefaults(r.temporaries[len(r.temporaries)-1], r.desired)
+		profile.Name = r.name
+		if err := m.createProfile(ctx, client, profile, append(r.temporaries, profile), rollout); err != nil {
+			return err
+		}
+	}
+
+	for _, temporary := range r.temporaries {
+		if err := m.deleteProfile(ctx, client, temporary.Name); err != nil {
+			return err
+		}
+	}
+	logger.Success("replaced Fargate profile %q", r.name)
+	return nil
+}
+
+// move replaces the current profile with the new one.
+func (m *Manager) move(ctx context.Context, client *fargate.Client, current, profile *api.FargateProfile, rollout bool) error {
+	if err := m.createProfile(ctx, client, profile, []*api.FargateProfile{current, profile}, rollout); err != nil {
+		return err
+	}
+	return m.deleteProfile(ctx, client, current.Name)
+}
+
+// createProfile creates the profile and, when rollout is set, restarts the deployments selected by any of the
+// profiles so that their pods move to it.
+func (m *Manager) createProfile(ctx context.Context, client *fargate.Client, profile *api.FargateProfile, profiles []*api.FargateProfile, rollout bool) error {
+	logger.Info("creating Fargate profile %q", profile.Name)
+	if err := client.CreateProfileWhenIdle(ctx, profile); err != nil {
+		return err
+	}
+	logger.Success("created Fargate profile %q", profile.Name)
+
+	if !rollout {
+		return nil
+	}
+	clientSet, err := m.newStdClientSet()
+	if err != nil {
+		return fmt.Errorf("couldn't create kubernetes client: %w", err)
+	}
+	return m.rolloutDeployments(ctx, clientSet, profiles)
+}
+
+func (m *Manager) deleteProfile(ctx context.Context, client *fargate.Client, name string) error {
+	logger.Info("deleting Fargate profile %q", name)
+	if err := client.DeleteProfileWhenIdle(ctx, name); err != nil {
+		return err
+	}
+	logger.Success("deleted Fargate profile %q", name)
+	return nil
+}
+
+// rolloutDeployments restarts the deployments selected by any of the profiles and waits for the rollouts to complete.
+func (m *Manager) rolloutDeployments(ctx context.Context, clientSet kubernetes.Interface, profiles []*api.FargateProfile) error {
+	deployments, err := clientSet.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing deployments: %w", err)
+	}
+	restartedAt := time.Now().Format(time.RFC3339)
+	var restarted []appsv1.Deployment
+	for _, d := range deployments.Items {
+		if len(fargate.MatchingProfiles(profiles, d.Namespace, d.Spec.Template.Labels)) == 0 {
+			continue
+		}
+		logger.Info("restarting deployment %s/%s", d.Namespace, d.Name)
+		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, restartedAt)
+		updated, err := clientSet.AppsV1().Deployments(d.Namespace).Patch(ctx, d.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("restarting deployment %s/%s: %w", d.Namespace, d.Name, err)
+		}
+		restarted = append(restarted, *updated)
+	}
+	for _, d := range restarted {
+		if err := m.waitForRollout(ctx, clientSet, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) waitForRollout(ctx context.Context, clientSet kubernetes.Interface, d appsv1.Deployment) error {
+	ctx, cancel := context.WithTimeout(ctx, m.ctl.AWSProvider.WaitTimeout())
+	defer cancel()
+	for {
+		current, err := clientSet.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("getting deployment %s/%s: %w", d.Namespace, d.Name, err)
+		}
+		if rolledOut(current) {
+			logger.Info("deployment %s/%s rolled out", d.Namespace, d.Name)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for deployment %s/%s to roll out: %w", d.Namespace, d.Name, ctx.Err())
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+func rolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.Replicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
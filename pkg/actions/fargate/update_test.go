@@ -0,0 +1,194 @@
+package fargate_test
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/fargate"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Update", func() {
+	const clusterName = "my-cluster"
+
+	var (
+		mockProvider   *mockprovider.MockProvider
+		cfg            *api.ClusterConfig
+		fargateManager *fargate.Manager
+		fakeClientSet  *fake.Clientset
+		profiles       map[string]*ekstypes.FargateProfile
+		operations     []string
+	)
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		cfg.FargateProfiles = []*api.FargateProfile{{
+			Name:      "fp-dev",
+			Selectors: []api.FargateProfileSelector{{Namespace: "dev", Labels: map[string]string{"app": "web"}}},
+		}}
+		ctl := &eks.ClusterProvider{AWSProvider: mockProvider, Status: &eks.ProviderStatus{
+			ClusterInfo: &eks.ClusterInfo{
+				Cluster: &ekstypes.Cluster{Status: ekstypes.ClusterStatusActive, Version: aws.String("1.30")},
+			},
+		}}
+		fargateManager = fargate.New(cfg, ctl, &fakes.FakeStackManager{})
+		fakeClientSet = fake.NewSimpleClientset()
+		fargateManager.SetNewClientSet(func() (kubernetes.Interface, error) {
+			return fakeClientSet, nil
+		})
+		fargateManager.SetPollInterval(time.Millisecond)
+
+		mockProvider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&awseks.DescribeClusterOutput{
+			Cluster: testutils.NewFakeCluster(clusterName, ekstypes.ClusterStatusActive),
+		}, nil)
+
+		profiles = map[string]*ekstypes.FargateProfile{
+			"fp-dev": {
+				FargateProfileName:  aws.String("fp-dev"),
+				PodExecutionRoleArn: aws.String("arn:aws:iam::123:role/fargate"),
+				Subnets:             []string{"subnet-1"},
+				Selectors:           []ekstypes.FargateProfileSelector{{Namespace: aws.String("dev")}},
+				Status:              ekstypes.FargateProfileStatusActive,
+			},
+		}
+		operations = nil
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(
+			func(context.Context, *awseks.ListFargateProfilesInput, ...func(*awseks.Options)) (*awseks.ListFargateProfilesOutput, error) {
+				var names []string
+				for name := range profiles {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				return &awseks.ListFargateProfilesOutput{FargateProfileNames: names}, nil
+			})
+		mockProvider.MockEKS().On("DescribeFargateProfile", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, input *awseks.DescribeFargateProfileInput, _ ...func(*awseks.Options)) (*awseks.DescribeFargateProfileOutput, error) {
+				return &awseks.DescribeFargateProfileOutput{FargateProfile: profiles[*input.FargateProfileName]}, nil
+			})
+		mockProvider.MockEKS().On("CreateFargateProfile", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, input *awseks.CreateFargateProfileInput, _ ...func(*awseks.Options)) (*awseks.CreateFargateProfileOutput, error) {
+				operations = append(operations, "create")
+				profiles[*input.FargateProfileName] = &ekstypes.FargateProfile{
+					FargateProfileName:  input.FargateProfileName,
+					PodExecutionRoleArn: input.PodExecutionRoleArn,
+					Subnets:             input.Subnets,
+					Selectors:           input.Selectors,
+					Tags:                input.Tags,
+					Status:              ekstypes.FargateProfileStatusActive,
+				}
+				return &awseks.CreateFargateProfileOutput{}, nil
+			})
+		mockProvider.MockEKS().On("DeleteFargateProfile", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, input *awseks.DeleteFargateProfileInput, _ ...func(*awseks.Options)) (*awseks.DeleteFargateProfileOutput, error) {
+				operations = append(operations, "delete")
+				delete(profiles, *input.FargateProfileName)
+				return &awseks.DeleteFargateProfileOutput{}, nil
+			})
+	})
+
+	It("replaces profiles which differ from the config", func() {
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{})).To(Succeed())
+		Expect(operations).To(Equal([]string{"create", "delete", "create", "delete"}))
+		Expect(profiles).To(HaveLen(1))
+		profile := profiles["fp-dev"]
+		Expect(profile).NotTo(BeNil())
+		Expect(profile.Tags).NotTo(HaveKey(api.FargateProfileNameTag))
+		Expect(*profile.PodExecutionRoleArn).To(Equal("arn:aws:iam::123:role/fargate"))
+		Expect(profile.Subnets).To(Equal([]string{"subnet-1"}))
+		Expect(profile.Selectors).To(Equal([]ekstypes.FargateProfileSelector{
+			{Namespace: aws.String("dev"), Labels: map[string]string{"app": "web"}},
+		}))
+
+		By("leaving the replaced profile alone")
+		operations = nil
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{})).To(Succeed())
+		Expect(operations).To(BeEmpty())
+	})
+
+	It("replaces temporary profiles left behind by an interrupted update", func() {
+		profiles = map[string]*ekstypes.FargateProfile{
+			"fp-dev-0a1b2": {
+				FargateProfileName:  aws.String("fp-dev-0a1b2"),
+				PodExecutionRoleArn: aws.String("arn:aws:iam::123:role/fargate"),
+				Subnets:             []string{"subnet-1"},
+				Selectors:           []ekstypes.FargateProfileSelector{{Namespace: aws.String("dev"), Labels: map[string]string{"app": "web"}}},
+				Tags:                map[string]string{api.FargateProfileNameTag: "fp-dev"},
+				Status:              ekstypes.FargateProfileStatusActive,
+			},
+		}
+
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{})).To(Succeed())
+		Expect(operations).To(Equal([]string{"create", "delete"}))
+		Expect(profiles).To(HaveLen(1))
+		Expect(profiles).To(HaveKey("fp-dev"))
+		Expect(*profiles["fp-dev"].PodExecutionRoleArn).To(Equal("arn:aws:iam::123:role/fargate"))
+	})
+
+	It("does not change anything in plan mode", func() {
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{Plan: true})).To(Succeed())
+		Expect(operations).To(BeEmpty())
+	})
+
+	It("fails if the profile does not exist", func() {
+		cfg.FargateProfiles[0].Name = "fp-prod"
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{})).
+			To(MatchError(ContainSubstring(`Fargate profile "fp-prod" not found`)))
+	})
+
+	It("fails if the named profile is not in the config", func() {
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{ProfileName: "fp-prod"})).
+			To(MatchError(`Fargate profile "fp-prod" not found in the config`))
+	})
+
+	It("restarts the matching deployments before deleting the old profile", func() {
+		newDeployment := func(namespace, name string, labels map[string]string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: aws.Int32(1),
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels}},
+				},
+				Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}
+		}
+		for _, d := range []*appsv1.Deployment{
+			newDeployment("dev", "web", map[string]string{"app": "web"}),
+			newDeployment("dev", "api", map[string]string{"app": "api"}),
+			newDeployment("prod", "web", map[string]string{"app": "web"}),
+		} {
+			_, err := fakeClientSet.AppsV1().Deployments(d.Namespace).Create(context.Background(), d, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(fargateManager.Update(context.Background(), fargate.UpdateOptions{RolloutDeployments: true})).To(Succeed())
+
+		restarted := func(namespace, name string) bool {
+			d, err := fakeClientSet.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := d.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"]
+			return ok
+		}
+		Expect(restarted("dev", "web")).To(BeTrue())
+		Expect(restarted("dev", "api")).To(BeTrue())
+		Expect(restarted("prod", "web")).To(BeFalse())
+	})
+})
@@ -29,10 +29,7 @@ func (i *Installer) Create(ctx context.Context) error {
 			return i.ClientSet, nil
 		},
 	}
-	instanceProfileName := fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, i.Config.Metadata.Name)
-	if i.Config.Karpenter.DefaultInstanceProfile != nil {
-		instanceProfileName = aws.ToString(i.Config.Karpenter.DefaultInstanceProfile)
-	}
+	instanceProfileName := makeInstanceProfileName(i.Config)
 
 	// Create IAM roles
 	taskTree := newTasksToInstallKarpenterIAMRoles(ctx, i.Config, i.StackManager, i.CTL.AWSProvider.EC2(), instanceProfileName)
@@ -43,7 +40,7 @@ func (i *Installer) Create(ctx context.Context) error {
 	// Set up service account
 	// Because we prefix with eksctl and to avoid having to get the name again,
 	// we always pass in the name and overwrite with the service account label.
-	roleName := fmt.Sprintf("eksctl-%s-iamservice-role", i.Config.Metadata.Name)
+	roleName := makeServiceAccountRoleName(i.Config)
 	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", parsedARN.Partition, parsedARN.AccountID, roleName)
 	policyArn := fmt.Sprintf("arn:%s:iam::%s:policy/eksctl-%s-%s", parsedARN.Partition, parsedARN.AccountID, builder.KarpenterManagedPolicy, i.Config.Metadata.Name)
 	iamServiceAccount := &api.ClusterIAMServiceAccount{
@@ -69,7 +66,7 @@ func (i *Installer) Create(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create client for auth config: %w", err)
 	}
-	identityArn := fmt.Sprintf("arn:%s:iam::%s:role/eksctl-%s-%s", parsedARN.Partition, parsedARN.AccountID, builder.KarpenterNodeRoleName, i.Config.Metadata.Name)
+	identityArn := makeNodeRoleARN(parsedARN, i.Config)
 	id, err := iam.NewIdentity(identityArn, authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups)
 	if err != nil {
 		return fmt.Errorf("failed to create new identity: %w", err)
@@ -84,3 +81,18 @@ func (i *Installer) Create(ctx context.Context) error {
 	// Install Karpenter
 	return i.KarpenterInstaller.Install(context.Background(), roleARN, instanceProfileName)
 }
+
+func makeInstanceProfileName(cfg *api.ClusterConfig) string {
+	if cfg.Karpenter.DefaultInstanceProfile != nil {
+		return aws.ToString(cfg.Karpenter.DefaultInstanceProfile)
+	}
+	return fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, cfg.Metadata.Name)
+}
+
+func makeServiceAccountRoleName(cfg *api.ClusterConfig) string {
+	return fmt.Sprintf("eksctl-%s-iamservice-role", cfg.Metadata.Name)
+}
+
+func makeNodeRoleARN(clusterARN arn.ARN, cfg *api.ClusterConfig) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/eksctl-%s-%s", clusterARN.Partition, clusterARN.AccountID, builder.KarpenterNodeRoleName, cfg.Metadata.Name)
+}
@@ -0,0 +1,348 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	nodePoolLabel        = "karpenter.sh/nodepool"
+	provisionerNameLabel = "karpenter.sh/provisioner-name"
+	managedByTag         = "karpenter.sh/managed-by"
+	launchTemplateTag    = "karpenter.k8s.aws/cluster"
+)
+
+// Deleter removes a Karpenter installation created by eksctl, along with the nodes, instance profiles
+// and launch templates Karpenter created.
+type Deleter struct {
+	StackManager       manager.StackManager
+	Config             *api.ClusterConfig
+	KarpenterInstaller karpenter.ChartInstaller
+	ClientSet          kubeclient.Interface
+	DynamicClient      dynamic.Interface
+	EC2API             awsapi.EC2
+	IAMAPI             awsapi.IAM
+	PollInterval       time.Duration
+	// NodeTerminationTimeout is how long to wait for Karpenter to terminate its nodes before
+	// the remaining instances are terminated directly.
+	NodeTerminationTimeout time.Duration
+}
+
+// NewDeleter creates a new Karpenter deleter.
+func NewDeleter(cfg *api.ClusterConfig, ec2API awsapi.EC2, iamAPI awsapi.IAM, stackManager manager.StackManager, clientSet kubeclient.Interface, dynamicClient dynamic.Interface, restClientGetter *kubernetes.SimpleRESTClientGetter) (*Deleter, error) {
+	karpenterInstaller, err := newChartInstaller(cfg, restClientGetter)
+	if err != nil {
+		return nil, err
+	}
+	return &Deleter{
+		StackManager:           stackManager,
+		Config:                 cfg,
+		KarpenterInstaller:     karpenterInstaller,
+		ClientSet:              clientSet,
+		DynamicClient:          dynamicClient,
+		EC2API:                 ec2API,
+		IAMAPI:                 iamAPI,
+		PollInterval:           10 * time.Second,
+		NodeTerminationTimeout: 10 * time.Minute,
+	}, nil
+}
+
+// Delete deletes the NodePools and NodeClasses created by eksctl so that Karpenter drains and terminates its nodes,
+// uninstalls Karpenter and then removes the instance profiles, launch templates, aws-auth mapping and stacks.
+// Karpenter is not deleted while NodePools or NodeClasses not created by eksctl exist, and nothing is changed
+// in plan mode.
+func (d *Deleter) Delete(ctx context.Context, wait, plan bool) error {
+	parsedARN, err := arn.Parse(d.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", d.Config.Status.ARN, err)
+	}
+
+	nodePoolManager := karpenter.NewNodePoolManager(d.DynamicClient, d.Config)
+	managed, unmanaged, err := nodePoolManager.List(ctx)
+	if err != nil {
+		return err
+	}
+	if !unmanaged.IsEmpty() {
+		return fmt.Errorf("cannot delete Karpenter while NodePools %v and NodeClasses %v not created by eksctl exist, "+
+			"as their nodes would no longer be managed; delete them first", unmanaged.NodePools, unmanaged.NodeClasses)
+	}
+
+	if plan {
+		logger.Info("NodePools %v and NodeClasses %v will be deleted and the nodes launched by Karpenter will be terminated", managed.NodePools, managed.NodeClasses)
+		logger.Info("Karpenter will be uninstalled and its instance profiles, launch templates, aws-auth mapping and stacks will be deleted")
+		return nil
+	}
+
+	if err := nodePoolManager.Delete(ctx, managed.NodePools, managed.NodeClasses); err != nil {
+		return err
+	}
+	if err := d.waitForNodesToTerminate(ctx); err != nil {
+		return err
+	}
+	if err := d.removeNodeClassFinalizers(ctx, managed.NodeClasses); err != nil {
+		return err
+	}
+	if err := d.terminateInstances(ctx); err != nil {
+		return err
+	}
+
+	if err := d.KarpenterInstaller.Uninstall(ctx); err != nil {
+		return err
+	}
+
+	if err := d.deleteInstanceProfiles(ctx); err != nil {
+		return err
+	}
+	if err := d.deleteLaunchTemplates(ctx); err != nil {
+		return err
+	}
+	if err := d.removeIdentityMapping(makeNodeRoleARN(parsedARN, d.Config)); err != nil {
+		return err
+	}
+	if err := d.deleteServiceAccount(ctx, wait); err != nil {
+		return err
+	}
+	return d.deleteStack(ctx, wait)
+}
+
+func (d *Deleter) waitForNodesToTerminate(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, d.NodeTerminationTimeout)
+	defer cancel()
+	for {
+		nodes, err := d.countNodes(ctx)
+		if err != nil {
+			return err
+		}
+		if nodes == 0 {
+			return nil
+		}
+		logger.Info("waiting for Karpenter to terminate %d node(s)", nodes)
+		select {
+		case <-ctx.Done():
+			logger.Warning("timed out waiting for Karpenter to terminate its nodes, terminating the remaining instances")
+			return nil
+		case <-time.After(d.PollInterval):
+		}
+	}
+}
+
+func (d *Deleter) countNodes(ctx context.Context) (int, error) {
+	count := 0
+	for _, label := range []string{nodePoolLabel, provisionerNameLabel} {
+		nodes, err := d.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: label})
+		if err != nil {
+			return 0, fmt.Errorf("listing Karpenter nodes: %w", err)
+		}
+		count += len(nodes.Items)
+	}
+	return count, nil
+}
+
+// removeNodeClassFinalizers ensures that NodeClasses whose finalizer has not been removed by Karpenter
+// do not block the deletion of the CRDs once Karpenter is uninstalled.
+func (d *Deleter) removeNodeClassFinalizers(ctx context.Context, nodeClassNames []string) error {
+	_, nodeClassGVR, err := karpenter.NodePoolResources(d.Config.Karpenter.Version)
+	if err != nil {
+		return err
+	}
+	patch := []byte(`{"metadata":{"finalizers":null}}`)
+	for _, name := range nodeClassNames {
+		if _, err := d.DynamicClient.Resource(nodeClassGVR).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("removing finalizers of %s %q: %w", nodeClassGVR.Resource, name, err)
+		}
+	}
+	return nil
+}
+
+func (d *Deleter) terminateInstances(ctx context.Context) error {
+	var instanceIDs []string
+	paginator := ec2.NewDescribeInstancesPaginator(d.EC2API, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{nodePoolLabel, provisionerNameLabel},
+			},
+			{
+				Name:   aws.String("tag:" + fmt.Sprintf(kubernetesTagFormat, d.Config.Metadata.Name)),
+				Values: []string{"owned"},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{"pending", "running", "stopping", "stopped"},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("describing Karpenter instances: %w", err)
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+			}
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	logger.Info("terminating Karpenter instances %s", strings.Join(instanceIDs, ", "))
+	if _, err := d.EC2API.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: instanceIDs}); err != nil {
+		return fmt.Errorf("terminating Karpenter instances: %w", err)
+	}
+	return nil
+}
+
+// deleteInstanceProfiles deletes the instance profiles Karpenter creates for EC2NodeClasses that set a role.
+// They must be deleted before the Karpenter stack, as they reference the node role.
+func (d *Deleter) deleteInstanceProfiles(ctx context.Context) error {
+	clusterName := d.Config.Metadata.Name
+	paginator := iam.NewListInstanceProfilesPaginator(d.IAMAPI, &iam.ListInstanceProfilesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing instance profiles: %w", err)
+		}
+		for _, instanceProfile := range output.InstanceProfiles {
+			name := aws.ToString(instanceProfile.InstanceProfileName)
+			if !strings.HasPrefix(name, clusterName+"_") {
+				continue
+			}
+			tags, err := d.IAMAPI.ListInstanceProfileTags(ctx, &iam.ListInstanceProfileTagsInput{
+				InstanceProfileName: instanceProfile.InstanceProfileName,
+			})
+			if err != nil {
+				return fmt.Errorf("listing tags of instance profile %q: %w", name, err)
+			}
+			managedByCluster := false
+			for _, tag := range tags.Tags {
+				if aws.ToString(tag.Key) == managedByTag && aws.ToString(tag.Value) == clusterName {
+					managedByCluster = true
+				}
+			}
+			if !managedByCluster {
+				continue
+			}
+			for _, role := range instanceProfile.Roles {
+				if _, err := d.IAMAPI.RemoveRoleFromInstanceProfile(ctx, &iam.RemoveRoleFromInstanceProfileInput{
+					InstanceProfileName: instanceProfile.InstanceProfileName,
+					RoleName:            role.RoleName,
+				}); err != nil {
+					return fmt.Errorf("removing role %q from instance profile %q: %w", aws.ToString(role.RoleName), name, err)
+				}
+			}
+			if _, err := d.IAMAPI.DeleteInstanceProfile(ctx, &iam.DeleteInstanceProfileInput{
+				InstanceProfileName: instanceProfile.InstanceProfileName,
+			}); err != nil {
+				return fmt.Errorf("deleting instance profile %q: %w", name, err)
+			}
+			logger.Info("deleted instance profile %q", name)
+		}
+	}
+	return nil
+}
+
+func (d *Deleter) deleteLaunchTemplates(ctx context.Context) error {
+	paginator := ec2.NewDescribeLaunchTemplatesPaginator(d.EC2API, &ec2.DescribeLaunchTemplatesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag:" + launchTemplateTag),
+				Values: []string{d.Config.Metadata.Name},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("describing Karpenter launch templates: %w", err)
+		}
+		for _, launchTemplate := range output.LaunchTemplates {
+			if _, err := d.EC2API.DeleteLaunchTemplate(ctx, &ec2.DeleteLaunchTemplateInput{
+				LaunchTemplateId: launchTemplate.LaunchTemplateId,
+			}); err != nil {
+				return fmt.Errorf("deleting launch template %q: %w", aws.ToString(launchTemplate.LaunchTemplateName), err)
+			}
+			logger.Info("deleted launch template %q", aws.ToString(launchTemplate.LaunchTemplateName))
+		}
+	}
+	return nil
+}
+
+func (d *Deleter) removeIdentityMapping(nodeRoleARN string) error {
+	acm, err := authconfigmap.NewFromClientSet(d.ClientSet)
+	if err != nil {
+		return fmt.Errorf("failed to create client for auth config: %w", err)
+	}
+	if err := acm.RemoveIdentity(nodeRoleARN, true); err != nil {
+		return fmt.Errorf("failed to remove identity: %w", err)
+	}
+	if err := acm.Save(); err != nil {
+		return fmt.Errorf("failed to save the identity config: %w", err)
+	}
+	return nil
+}
+
+func (d *Deleter) deleteServiceAccount(ctx context.Context, wait bool) error {
+	clientSetGetter := &kubernetes.CallbackClientSet{
+		Callback: func() (kubernetes.Interface, error) {
+			return d.ClientSet, nil
+		},
+	}
+	serviceAccount := fmt.Sprintf("%s/%s", karpenter.DefaultNamespace, karpenter.DefaultServiceAccountName)
+	taskTree, err := d.StackManager.NewTasksToDeleteIAMServiceAccounts(ctx, []string{serviceAccount}, clientSetGetter, wait)
+	if err != nil {
+		return err
+	}
+	if taskTree.Len() == 0 {
+		return nil
+	}
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return fmt.Errorf("failed to delete Karpenter service account %q", serviceAccount)
+	}
+	return nil
+}
+
+func (d *Deleter) deleteStack(ctx context.Context, wait bool) error {
+	stack, err := d.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("getting Karpenter stack: %w", err)
+	}
+	if stack == nil {
+		logger.Info("no Karpenter stack found")
+		return nil
+	}
+	logger.Info("deleting stack %q", *stack.StackName)
+	if wait {
+		return d.StackManager.DeleteStackSync(ctx, stack)
+	}
+	_, err = d.StackManager.DeleteStackBySpec(ctx, stack)
+	return err
+}
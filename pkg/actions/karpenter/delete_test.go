@@ -0,0 +1,190 @@
+package karpenter_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("Delete", func() {
+	var (
+		p                      *mockprovider.MockProvider
+		cfg                    *api.ClusterConfig
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		fakeClientSet          *fake.Clientset
+		dynamicClient          *dynamicfake.FakeDynamicClient
+		deleter                *karpenteractions.Deleter
+
+		nodePoolGVR  = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+		nodeClassGVR = schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1", Resource: "ec2nodeclasses"}
+	)
+
+	newObject := func(gvr schema.GroupVersionResource, kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(gvr.GroupVersion().String())
+		obj.SetKind(kind)
+		obj.SetName(name)
+		obj.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "eksctl"})
+		return obj
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.0.6",
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.NewTasksToDeleteIAMServiceAccountsReturns(&tasks.TaskTree{}, nil)
+		fakeStackManager.GetKarpenterStackReturns(&cfntypes.Stack{
+			StackName: aws.String("eksctl-my-cluster-karpenter"),
+		}, nil)
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		fakeClientSet = fake.NewSimpleClientset()
+		dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			nodePoolGVR:  "NodePoolList",
+			nodeClassGVR: "EC2NodeClassList",
+		}, newObject(nodePoolGVR, "NodePool", "general"), newObject(nodeClassGVR, "EC2NodeClass", "default"))
+
+		p.MockEC2().On("DescribeInstances", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{Instances: []ec2types.Instance{{InstanceId: aws.String("i-1")}}},
+			},
+		}, nil)
+		p.MockEC2().On("TerminateInstances", mock.Anything, &ec2.TerminateInstancesInput{
+			InstanceIds: []string{"i-1"},
+		}).Return(&ec2.TerminateInstancesOutput{}, nil)
+		p.MockEC2().On("DescribeLaunchTemplates", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeLaunchTemplatesOutput{
+			LaunchTemplates: []ec2types.LaunchTemplate{
+				{LaunchTemplateId: aws.String("lt-1"), LaunchTemplateName: aws.String("karpenter.k8s.aws/1234")},
+			},
+		}, nil)
+		p.MockEC2().On("DeleteLaunchTemplate", mock.Anything, &ec2.DeleteLaunchTemplateInput{
+			LaunchTemplateId: aws.String("lt-1"),
+		}).Return(&ec2.DeleteLaunchTemplateOutput{}, nil)
+
+		p.MockIAM().On("ListInstanceProfiles", mock.Anything, mock.Anything, mock.Anything).Return(&iam.ListInstanceProfilesOutput{
+			InstanceProfiles: []iamtypes.InstanceProfile{
+				{
+					InstanceProfileName: aws.String("my-cluster_1234"),
+					Roles:               []iamtypes.Role{{RoleName: aws.String("eksctl-KarpenterNodeRole-my-cluster")}},
+				},
+				{InstanceProfileName: aws.String("my-cluster_5678")},
+				{InstanceProfileName: aws.String("other-cluster_1234")},
+			},
+		}, nil)
+		p.MockIAM().On("ListInstanceProfileTags", mock.Anything, &iam.ListInstanceProfileTagsInput{
+			InstanceProfileName: aws.String("my-cluster_1234"),
+		}).Return(&iam.ListInstanceProfileTagsOutput{
+			Tags: []iamtypes.Tag{{Key: aws.String("karpenter.sh/managed-by"), Value: aws.String("my-cluster")}},
+		}, nil)
+		p.MockIAM().On("ListInstanceProfileTags", mock.Anything, &iam.ListInstanceProfileTagsInput{
+			InstanceProfileName: aws.String("my-cluster_5678"),
+		}).Return(&iam.ListInstanceProfileTagsOutput{}, nil)
+		p.MockIAM().On("RemoveRoleFromInstanceProfile", mock.Anything, &iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: aws.String("my-cluster_1234"),
+			RoleName:            aws.String("eksctl-KarpenterNodeRole-my-cluster"),
+		}).Return(&iam.RemoveRoleFromInstanceProfileOutput{}, nil)
+		p.MockIAM().On("DeleteInstanceProfile", mock.Anything, &iam.DeleteInstanceProfileInput{
+			InstanceProfileName: aws.String("my-cluster_1234"),
+		}).Return(&iam.DeleteInstanceProfileOutput{}, nil)
+
+		deleter = &karpenteractions.Deleter{
+			StackManager:           fakeStackManager,
+			Config:                 cfg,
+			KarpenterInstaller:     fakeKarpenterInstaller,
+			ClientSet:              fakeClientSet,
+			DynamicClient:          dynamicClient,
+			EC2API:                 p.EC2(),
+			IAMAPI:                 p.IAM(),
+			PollInterval:           time.Millisecond,
+			NodeTerminationTimeout: 50 * time.Millisecond,
+		}
+	})
+
+	It("removes NodePools, nodes, Karpenter and its AWS resources", func() {
+		_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "karpenter-node",
+				Labels: map[string]string{"karpenter.sh/nodepool": "general"},
+			},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(deleter.Delete(context.Background(), true, false)).To(Succeed())
+
+		_, err = dynamicClient.Resource(nodePoolGVR).Get(context.Background(), "general", metav1.GetOptions{})
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+		_, err = dynamicClient.Resource(nodeClassGVR).Get(context.Background(), "default", metav1.GetOptions{})
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+
+		Expect(fakeKarpenterInstaller.UninstallCallCount()).To(Equal(1))
+		Expect(p.MockEC2().AssertCalled(GinkgoT(), "TerminateInstances", mock.Anything, mock.Anything)).To(BeTrue())
+		Expect(p.MockEC2().AssertCalled(GinkgoT(), "DeleteLaunchTemplate", mock.Anything, mock.Anything)).To(BeTrue())
+		Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "DeleteInstanceProfile", 1)).To(BeTrue())
+
+		Expect(fakeStackManager.NewTasksToDeleteIAMServiceAccountsCallCount()).To(Equal(1))
+		_, serviceAccounts, _, wait := fakeStackManager.NewTasksToDeleteIAMServiceAccountsArgsForCall(0)
+		Expect(serviceAccounts).To(Equal([]string{"karpenter/karpenter"}))
+		Expect(wait).To(BeTrue())
+		Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(1))
+	})
+
+	It("does not wait for the stack deletion unless requested", func() {
+		Expect(deleter.Delete(context.Background(), false, false)).To(Succeed())
+		Expect(fakeStackManager.DeleteStackSyncCallCount()).To(BeZero())
+		Expect(fakeStackManager.DeleteStackBySpecCallCount()).To(Equal(1))
+	})
+
+	It("does not change anything in plan mode", func() {
+		Expect(deleter.Delete(context.Background(), true, true)).To(Succeed())
+
+		_, err := dynamicClient.Resource(nodePoolGVR).Get(context.Background(), "general", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeKarpenterInstaller.UninstallCallCount()).To(BeZero())
+		p.MockEC2().AssertNotCalled(GinkgoT(), "TerminateInstances", mock.Anything, mock.Anything)
+		Expect(fakeStackManager.DeleteStackSyncCallCount()).To(BeZero())
+	})
+
+	It("refuses to delete Karpenter while NodePools not created by eksctl exist", func() {
+		nodePool := newObject(nodePoolGVR, "NodePool", "team-a")
+		nodePool.SetLabels(nil)
+		_, err := dynamicClient.Resource(nodePoolGVR).Create(context.Background(), nodePool, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(deleter.Delete(context.Background(), true, false)).To(MatchError(ContainSubstring("NodePools [team-a] and NodeClasses [] not created by eksctl exist")))
+
+		_, err = dynamicClient.Resource(nodePoolGVR).Get(context.Background(), "general", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeKarpenterInstaller.UninstallCallCount()).To(BeZero())
+		p.MockEC2().AssertNotCalled(GinkgoT(), "TerminateInstances", mock.Anything, mock.Anything)
+	})
+})
@@ -7,7 +7,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/kris-nova/logger"
+	"k8s.io/apimachinery/pkg/runtime"
 	kubeclient "k8s.io/client-go/kubernetes"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
@@ -16,6 +18,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/karpenter"
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 	"github.com/weaveworks/eksctl/pkg/utils/waiters"
 )
@@ -43,18 +46,10 @@ type WaitFunc func(name, msg string, acceptors []request.WaiterAcceptor, newRequ
 
 // NewInstaller creates a new Karpenter installer.
 func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager, clientSet kubeclient.Interface, restClientGetter *kubernetes.SimpleRESTClientGetter) (InstallerTaskCreator, error) {
-	helmInstaller, err := helm.NewInstaller(helm.Options{
-		Namespace:        karpenter.DefaultNamespace,
-		RESTClientGetter: restClientGetter,
-	})
+	karpenterInstaller, err := newChartInstaller(cfg, restClientGetter)
 	if err != nil {
 		return nil, err
 	}
-	karpenterInstaller := karpenter.NewKarpenterInstaller(karpenter.Options{
-		HelmInstaller: helmInstaller,
-		Namespace:     karpenter.DefaultNamespace,
-		ClusterConfig: cfg,
-	})
 	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -80,6 +75,31 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 	}, nil
 }
 
+// NewRESTClientGetter creates the REST client getter used by Helm to manage Karpenter in an existing cluster.
+func NewRESTClientGetter(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) (*kubernetes.SimpleRESTClientGetter, error) {
+	config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile())
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, config)
+	if err != nil {
+		return nil, fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	return kubernetes.NewRESTClientGetter(karpenter.DefaultNamespace, string(kubeConfigBytes)), nil
+}
+
+func newChartInstaller(cfg *api.ClusterConfig, restClientGetter *kubernetes.SimpleRESTClientGetter) (karpenter.ChartInstaller, error) {
+	helmInstaller, err := helm.NewInstaller(helm.Options{
+		Namespace:        karpenter.DefaultNamespace,
+		RESTClientGetter: restClientGetter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return karpenter.NewKarpenterInstaller(karpenter.Options{
+		HelmInstaller: helmInstaller,
+		Namespace:     karpenter.DefaultNamespace,
+		ClusterConfig: cfg,
+	}), nil
+}
+
 func doTasks(taskTree *tasks.TaskTree) error {
 	logger.Info(taskTree.Describe())
 	if errs := taskTree.DoAllSync(); len(errs) > 0 {
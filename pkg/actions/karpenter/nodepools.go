@@ -0,0 +1,55 @@
+package karpenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+)
+
+// NewNodePoolManager creates a manager for the Karpenter NodePools and EC2NodeClasses defined in cfg.
+func NewNodePoolManager(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) (*karpenter.NodePoolManager, error) {
+	dynamicClient, err := ctl.NewDynamicClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes client: %w", err)
+	}
+	return karpenter.NewNodePoolManager(dynamicClient, cfg), nil
+}
+
+// LoadClusterVPC loads the VPC of an existing cluster from its stack, so that NodeClasses
+// can default to the cluster's subnets.
+func LoadClusterVPC(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.ClusterConfig, stackManager manager.StackManager) error {
+	clusterStack, err := stackManager.DescribeClusterStack(ctx)
+	if err != nil {
+		var stackNotFoundErr *manager.StackNotFoundErr
+		if !errors.As(err, &stackNotFoundErr) {
+			return fmt.Errorf("getting existing configuration for cluster %q: %w", cfg.Metadata.Name, err)
+		}
+		logger.Warning("%s, NodeClasses without subnetSelectorTerms will select subnets by cluster tag", err.Error())
+		return nil
+	}
+	return ctl.LoadClusterVPC(ctx, cfg, clusterStack, true)
+}
+
+// GetInstalledVersion returns the Karpenter version recorded on the Karpenter stack of the cluster.
+func GetInstalledVersion(ctx context.Context, stackManager manager.StackManager) (string, error) {
+	stack, err := stackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting Karpenter stack: %w", err)
+	}
+	if stack == nil {
+		return "", errors.New("no Karpenter stack found; Karpenter must be installed by eksctl")
+	}
+	for _, tag := range stack.Tags {
+		if *tag.Key == api.KarpenterVersionTag {
+			return *tag.Value, nil
+		}
+	}
+	return "", fmt.Errorf("stack %q does not have tag %q", *stack.StackName, api.KarpenterVersionTag)
+}
@@ -52,7 +52,7 @@ func newTasksToInstallKarpenterIAMRoles(ctx context.Context, cfg *api.ClusterCon
 
 // createKarpenterIAMRolesTask creates Karpenter IAM Roles.
 func (k *karpenterIAMRolesTask) createKarpenterIAMRolesTask(ctx context.Context, errs chan error) error {
-	name := k.makeKarpenterStackName()
+	name := makeKarpenterStackName(k.cfg)
 
 	logger.Info("building nodegroup stack %q", name)
 	stack := builder.NewKarpenterResourceSet(k.cfg, k.instanceProfileName)
@@ -70,9 +70,9 @@ func (k *karpenterIAMRolesTask) createKarpenterIAMRolesTask(ctx context.Context,
 	return k.ensureSubnetsHaveTags(ctx)
 }
 
-// makeNodeGroupStackName generates the name of the Karpenter stack identified by its name, isolated by the cluster this StackCollection operates on
-func (k *karpenterIAMRolesTask) makeKarpenterStackName() string {
-	return fmt.Sprintf("eksctl-%s-karpenter", k.cfg.Metadata.Name)
+// makeKarpenterStackName generates the name of the Karpenter stack, isolated by the cluster it belongs to
+func makeKarpenterStackName(cfg *api.ClusterConfig) string {
+	return fmt.Sprintf("eksctl-%s-karpenter", cfg.Metadata.Name)
 }
 
 // ensureSubnetsHaveTags sets of overwrites kubernetes.io/cluster/<name> tags on subnets with the current value.
@@ -0,0 +1,193 @@
+package karpenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+// Upgrader upgrades a Karpenter installation created by eksctl.
+type Upgrader struct {
+	StackManager       manager.StackManager
+	Config             *api.ClusterConfig
+	KarpenterInstaller karpenter.ChartInstaller
+	ClientSet          kubeclient.Interface
+	DynamicClient      dynamic.Interface
+}
+
+// NewUpgrader creates a new Karpenter upgrader.
+func NewUpgrader(cfg *api.ClusterConfig, stackManager manager.StackManager, clientSet kubeclient.Interface, dynamicClient dynamic.Interface, restClientGetter *kubernetes.SimpleRESTClientGetter) (*Upgrader, error) {
+	karpenterInstaller, err := newChartInstaller(cfg, restClientGetter)
+	if err != nil {
+		return nil, err
+	}
+	return &Upgrader{
+		StackManager:       stackManager,
+		Config:             cfg,
+		KarpenterInstaller: karpenterInstaller,
+		ClientSet:          clientSet,
+		DynamicClient:      dynamicClient,
+	}, nil
+}
+
+// LoadInstalledSettings sets the Karpenter settings that were used to install Karpenter, so that
+// upgrading without a config file keeps the existing interruption queue, instance profile and service account.
+func (u *Upgrader) LoadInstalledSettings(ctx context.Context) error {
+	stackName := makeKarpenterStackName(u.Config)
+	template, err := u.StackManager.GetStackTemplate(ctx, stackName)
+	if err != nil {
+		return fmt.Errorf("getting template of stack %q: %w", stackName, err)
+	}
+	resources := gjson.Get(template, "Resources")
+	if resources.Get(builder.KarpenterInterruptionQueue).Exists() {
+		u.Config.Karpenter.WithSpotInterruptionQueue = api.Enabled()
+	}
+	instanceProfileName := resources.Get(builder.KarpenterNodeInstanceProfile + ".Properties.InstanceProfileName")
+	if instanceProfileName.Type == gjson.String && instanceProfileName.String() != makeInstanceProfileName(u.Config) {
+		u.Config.Karpenter.DefaultInstanceProfile = aws.String(instanceProfileName.String())
+	}
+
+	serviceAccount, err := u.ClientSet.CoreV1().ServiceAccounts(karpenter.DefaultNamespace).Get(ctx, karpenter.DefaultServiceAccountName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("getting Karpenter service account: %w", err)
+	}
+	if err == nil && serviceAccount.Labels["app.kubernetes.io/managed-by"] == "Helm" {
+		u.Config.Karpenter.CreateServiceAccount = api.Enabled()
+	}
+	return nil
+}
+
+// Upgrade updates the Karpenter stack, the Karpenter CRDs and the Karpenter chart from currentVersion to
+// the version set in the config. NodePools, NodeClaims and EC2NodeClasses are migrated to v1 when
+// upgrading from a version prior to v1.
+func (u *Upgrader) Upgrade(ctx context.Context, currentVersion string, plan bool) error {
+	targetVersion := u.Config.Karpenter.Version
+	compare, err := utils.CompareVersions(targetVersion, currentVersion)
+	if err != nil {
+		return err
+	}
+	if compare == 0 {
+		logger.Info("Karpenter is already at version %s", currentVersion)
+		return nil
+	}
+	if err := validateUpgrade(currentVersion, targetVersion); err != nil {
+		return err
+	}
+	wasV1, err := api.IsKarpenterV1(currentVersion)
+	if err != nil {
+		return err
+	}
+	isV1, err := api.IsKarpenterV1(targetVersion)
+	if err != nil {
+		return err
+	}
+	migrateToV1 := isV1 && !wasV1
+
+	if plan {
+		logger.Info("Karpenter will be upgraded from version %s to %s", currentVersion, targetVersion)
+		if migrateToV1 {
+			logger.Info("NodePools, NodeClaims and EC2NodeClasses will be migrated from v1beta1 to v1")
+		}
+		return nil
+	}
+
+	parsedARN, err := arn.Parse(u.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", u.Config.Status.ARN, err)
+	}
+	instanceProfileName := makeInstanceProfileName(u.Config)
+	if err := u.updateStack(ctx, instanceProfileName); err != nil {
+		return err
+	}
+
+	if err := karpenter.AdoptCRDs(ctx, u.DynamicClient); err != nil {
+		return err
+	}
+	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", parsedARN.Partition, parsedARN.AccountID, makeServiceAccountRoleName(u.Config))
+	if err := u.KarpenterInstaller.Upgrade(ctx, roleARN, instanceProfileName); err != nil {
+		return err
+	}
+
+	if migrateToV1 {
+		logger.Info("migrating NodePools, NodeClaims and EC2NodeClasses to v1")
+		if err := karpenter.MigrateStoredVersions(ctx, u.DynamicClient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Upgrader) updateStack(ctx context.Context, instanceProfileName string) error {
+	stack, err := u.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("getting Karpenter stack: %w", err)
+	}
+	if stack == nil {
+		return errors.New("no Karpenter stack found; Karpenter must be installed by eksctl")
+	}
+
+	resourceSet := builder.NewKarpenterResourceSet(u.Config, instanceProfileName)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return err
+	}
+	template, err := resourceSet.RenderJSON()
+	if err != nil {
+		return fmt.Errorf("rendering template for stack %q: %w", *stack.StackName, err)
+	}
+
+	// the version tag is carried over to the stack by the change set
+	var tags []cfntypes.Tag
+	for _, tag := range stack.Tags {
+		if aws.ToString(tag.Key) != api.KarpenterVersionTag {
+			tags = append(tags, tag)
+		}
+	}
+	stack.Tags = append(tags, cfntypes.Tag{
+		Key:   aws.String(api.KarpenterVersionTag),
+		Value: aws.String(u.Config.Karpenter.Version),
+	})
+
+	return u.StackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+		Stack:         stack,
+		ChangeSetName: u.StackManager.MakeChangeSetName("update-karpenter"),
+		Description:   fmt.Sprintf("updating Karpenter stack %q to version %s", *stack.StackName, u.Config.Karpenter.Version),
+		TemplateData:  manager.TemplateBody(template),
+		Wait:          true,
+	})
+}
+
+// validateUpgrade rejects downgrades and upgrades that skip a required manual migration.
+func validateUpgrade(currentVersion, targetVersion string) error {
+	isAtLeast := func(v, minimum string) bool {
+		compare, err := utils.CompareVersions(v, minimum)
+		return err == nil && compare >= 0
+	}
+	if !isAtLeast(targetVersion, currentVersion) {
+		return fmt.Errorf("downgrading Karpenter from %s to %s is not supported", currentVersion, targetVersion)
+	}
+	if !isAtLeast(currentVersion, "0.32.0") && isAtLeast(targetVersion, "0.32.0") {
+		return fmt.Errorf("upgrading Karpenter from %s to %s requires migrating Provisioners and AWSNodeTemplates to NodePools and EC2NodeClasses; "+
+			"see https://karpenter.sh/v0.32/upgrading/v1beta1-migration/", currentVersion, targetVersion)
+	}
+	if !isAtLeast(currentVersion, "0.33.0") && isAtLeast(targetVersion, "1.0.0") {
+		return fmt.Errorf("Karpenter must be upgraded to v0.33.0 or newer before upgrading to %s", targetVersion)
+	}
+	return nil
+}
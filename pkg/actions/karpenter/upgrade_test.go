@@ -0,0 +1,132 @@
+package karpenter_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+)
+
+func newCRD(name string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+	return crd
+}
+
+var _ = Describe("Upgrade", func() {
+	var (
+		cfg                    *api.ClusterConfig
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		fakeClientSet          *fake.Clientset
+		upgrader               *karpenteractions.Upgrader
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.0.6",
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.GetKarpenterStackReturns(&cfntypes.Stack{
+			StackName: aws.String("eksctl-my-cluster-karpenter"),
+			Tags: []cfntypes.Tag{
+				{Key: aws.String(api.KarpenterNameTag), Value: aws.String("eksctl-my-cluster-karpenter")},
+				{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("0.37.0")},
+			},
+		}, nil)
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		fakeClientSet = fake.NewSimpleClientset()
+		crdResource := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+		upgrader = &karpenteractions.Upgrader{
+			StackManager:       fakeStackManager,
+			Config:             cfg,
+			KarpenterInstaller: fakeKarpenterInstaller,
+			ClientSet:          fakeClientSet,
+			DynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}:           "NodePoolList",
+				{Group: "karpenter.sh", Version: "v1", Resource: "nodeclaims"}:          "NodeClaimList",
+				{Group: "karpenter.k8s.aws", Version: "v1", Resource: "ec2nodeclasses"}: "EC2NodeClassList",
+				crdResource: "CustomResourceDefinitionList",
+			}, newCRD("nodepools.karpenter.sh"), newCRD("nodeclaims.karpenter.sh"), newCRD("ec2nodeclasses.karpenter.k8s.aws")),
+		}
+	})
+
+	It("updates the stack and the chart and migrates objects to v1", func() {
+		Expect(upgrader.Upgrade(context.Background(), "0.37.0", false)).To(Succeed())
+
+		Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(1))
+		_, options := fakeStackManager.UpdateStackArgsForCall(0)
+		Expect(options.Stack.Tags).To(ContainElement(cfntypes.Tag{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("1.0.6")}))
+		Expect(options.Stack.Tags).NotTo(ContainElement(cfntypes.Tag{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("0.37.0")}))
+		Expect(string(options.TemplateData.(manager.TemplateBody))).To(ContainSubstring("eks:DescribeCluster"))
+
+		Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(1))
+		_, roleARN, instanceProfileName := fakeKarpenterInstaller.UpgradeArgsForCall(0)
+		Expect(roleARN).To(Equal("arn:aws:iam::123456789012:role/eksctl-my-cluster-iamservice-role"))
+		Expect(instanceProfileName).To(Equal("eksctl-KarpenterNodeInstanceProfile-my-cluster"))
+	})
+
+	It("only logs the upgrade in plan mode", func() {
+		Expect(upgrader.Upgrade(context.Background(), "0.37.0", true)).To(Succeed())
+		Expect(fakeStackManager.UpdateStackCallCount()).To(BeZero())
+		Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(BeZero())
+	})
+
+	It("does nothing when Karpenter is already at the version", func() {
+		Expect(upgrader.Upgrade(context.Background(), "1.0.6", false)).To(Succeed())
+		Expect(fakeStackManager.UpdateStackCallCount()).To(BeZero())
+	})
+
+	DescribeTable("unsupported upgrades", func(currentVersion, targetVersion, expectedErr string) {
+		cfg.Karpenter.Version = targetVersion
+		Expect(upgrader.Upgrade(context.Background(), currentVersion, false)).To(MatchError(ContainSubstring(expectedErr)))
+		Expect(fakeStackManager.UpdateStackCallCount()).To(BeZero())
+	},
+		Entry("downgrade", "1.0.6", "0.37.0", "downgrading Karpenter from 1.0.6 to 0.37.0 is not supported"),
+		Entry("Provisioners to NodePools", "0.31.0", "0.32.0", "requires migrating Provisioners and AWSNodeTemplates"),
+		Entry("v1 from before v0.33", "0.32.10", "1.0.6", "Karpenter must be upgraded to v0.33.0 or newer before upgrading to 1.0.6"),
+	)
+
+	It("loads the settings of the existing installation", func() {
+		fakeStackManager.GetStackTemplateReturns(`{
+			"Resources": {
+				"KarpenterInterruptionQueue": {"Type": "AWS::SQS::Queue"},
+				"KarpenterNodeInstanceProfile": {"Properties": {"InstanceProfileName": "custom-profile"}}
+			}
+		}`, nil)
+		_, err := fakeClientSet.CoreV1().ServiceAccounts("karpenter").Create(context.Background(), &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "karpenter",
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(upgrader.LoadInstalledSettings(context.Background())).To(Succeed())
+		Expect(api.IsEnabled(cfg.Karpenter.WithSpotInterruptionQueue)).To(BeTrue())
+		Expect(api.IsEnabled(cfg.Karpenter.CreateServiceAccount)).To(BeTrue())
+		Expect(cfg.Karpenter.DefaultInstanceProfile).To(Equal(aws.String("custom-profile")))
+	})
+})
@@ -10,9 +10,9 @@ import (
 )
 
 type Summary struct {
-	Cluster   string
-	NodeGroup string
-	Labels    map[string]string
+	Cluster   string            `json:"cluster"`
+	NodeGroup string            `json:"nodegroup"`
+	Labels    map[string]string `json:"labels,omitempty"`
 }
 
 func (m *Manager) Get(ctx context.Context, nodeGroupName string) ([]Summary, error) {
@@ -0,0 +1,91 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AuditEvent holds the fields of a Kubernetes audit event that identify who did what.
+type AuditEvent struct {
+	AuditID    string `json:"auditID"`
+	Stage      string `json:"stage"`
+	User       string `json:"user"`
+	UserAgent  string `json:"userAgent,omitempty"`
+	Verb       string `json:"verb"`
+	RequestURI string `json:"requestURI"`
+	// Resource is the resource of the request, including its subresource, e.g. pods/log
+	Resource  string `json:"resource,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Code      int    `json:"code,omitempty"`
+}
+
+// rawAuditEvent is the subset of the audit.k8s.io/v1 Event written to audit logs that is parsed
+type rawAuditEvent struct {
+	Kind       string `json:"kind"`
+	AuditID    string `json:"auditID"`
+	Stage      string `json:"stage"`
+	RequestURI string `json:"requestURI"`
+	Verb       string `json:"verb"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	UserAgent string `json:"userAgent"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Subresource string `json:"subresource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+}
+
+// ParseAuditEvent parses an audit log message.
+func ParseAuditEvent(message string) (*AuditEvent, error) {
+	var raw rawAuditEvent
+	if err := json.Unmarshal([]byte(message), &raw); err != nil {
+		return nil, fmt.Errorf("parsing audit event: %w", err)
+	}
+	if raw.Kind != "Event" {
+		return nil, fmt.Errorf("parsing audit event: unexpected kind %q", raw.Kind)
+	}
+
+	event := &AuditEvent{
+		AuditID:    raw.AuditID,
+		Stage:      raw.Stage,
+		User:       raw.User.Username,
+		UserAgent:  raw.UserAgent,
+		Verb:       raw.Verb,
+		RequestURI: raw.RequestURI,
+	}
+	if ref := raw.ObjectRef; ref != nil {
+		event.Resource = ref.Resource
+		if ref.Subresource != "" {
+			event.Resource += "/" + ref.Subresource
+		}
+		event.Namespace = ref.Namespace
+		event.Name = ref.Name
+	}
+	if raw.ResponseStatus != nil {
+		event.Code = raw.ResponseStatus.Code
+	}
+	return event, nil
+}
+
+// Object returns the namespaced name of the object of the request, or the request URI for non-resource requests.
+func (e *AuditEvent) Object() string {
+	switch {
+	case e.Resource == "":
+		return e.RequestURI
+	case e.Namespace != "" && e.Name != "":
+		return fmt.Sprintf("%s %s/%s", e.Resource, e.Namespace, e.Name)
+	case e.Name != "":
+		return fmt.Sprintf("%s %s", e.Resource, e.Name)
+	case e.Namespace != "":
+		return fmt.Sprintf("%s -n %s", e.Resource, e.Namespace)
+	default:
+		return e.Resource
+	}
+}
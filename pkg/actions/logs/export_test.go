@@ -0,0 +1,14 @@
+package logs
+
+import (
+	"context"
+	"time"
+)
+
+func (r *Reader) SetNow(now func() time.Time) {
+	r.now = now
+}
+
+func (r *Reader) SetSleep(sleep func(ctx context.Context, d time.Duration) error) {
+	r.sleep = sleep
+}
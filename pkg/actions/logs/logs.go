@@ -0,0 +1,237 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+const (
+	// DefaultFollowInterval is the default interval between polls when following logs
+	DefaultFollowInterval = 5 * time.Second
+	// maxFollowErrors is the number of consecutive failed polls after which following stops
+	maxFollowErrors = 5
+	// followLag is how late CloudWatch Logs may make events available after later ones, which polls read again
+	followLag = 2 * time.Minute
+	// apiServerAuditStreamPrefix is the prefix of audit log streams, which also match the prefix of API server log streams
+	apiServerAuditStreamPrefix = "kube-apiserver-audit-"
+)
+
+// streamPrefixes are the prefixes of the log streams of each control plane log type
+var streamPrefixes = map[string]string{
+	"api":               "kube-apiserver-",
+	"audit":             apiServerAuditStreamPrefix,
+	"authenticator":     "authenticator-",
+	"controllerManager": "kube-controller-manager-",
+	"scheduler":         "kube-scheduler-",
+}
+
+// Event is a control plane log event.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Stream    string    `json:"stream"`
+	// Message is the raw message, set unless the event is a parsed audit event
+	Message string      `json:"message,omitempty"`
+	Audit   *AuditEvent `json:"audit,omitempty"`
+}
+
+// Options configures which control plane logs are read.
+type Options struct {
+	// Type is the control plane log type, one of api.SupportedCloudWatchClusterLogTypes
+	Type string
+	// Since is how far back to read logs from
+	Since time.Duration
+	// FilterPattern is a CloudWatch Logs filter pattern that events must match
+	FilterPattern string
+	// Follow keeps polling for new events until the context is cancelled
+	Follow bool
+	// FollowInterval is the interval between polls when following logs
+	FollowInterval time.Duration
+}
+
+// Reader reads the control plane logs of a cluster from CloudWatch Logs.
+type Reader struct {
+	logs        awsapi.CloudWatchLogs
+	clusterName string
+	now         func() time.Time
+	// sleep waits between polls when following logs
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New creates a new reader.
+func New(logs awsapi.CloudWatchLogs, clusterName string) *Reader {
+	return &Reader{
+		logs:        logs,
+		clusterName: clusterName,
+		now:         time.Now,
+		sleep:       sleep,
+	}
+}
+
+// LogGroupName returns the name of the log group of the control plane logs of the cluster.
+func LogGroupName(clusterName string) string {
+	return fmt.Sprintf("/aws/eks/%s/cluster", clusterName)
+}
+
+// Read calls handle with the events of the log type, in order. When following logs, it polls for new events
+// until ctx is cancelled, and events made available late are handled when they arrive.
+func (r *Reader) Read(ctx context.Context, options Options, handle func(Event) error) error {
+	prefix, ok := streamPrefixes[options.Type]
+	if !ok {
+		return fmt.Errorf("unsupported log type %q, must be one of %s", options.Type, strings.Join(api.SupportedCloudWatchClusterLogTypes(), ", "))
+	}
+	interval := options.FollowInterval
+	if interval <= 0 {
+		interval = DefaultFollowInterval
+	}
+
+	c := &cursor{
+		from: r.now().Add(-options.Since),
+		seen: map[string]time.Time{},
+	}
+	if err := r.poll(ctx, options, prefix, c, handle); err != nil {
+		return err
+	}
+	if !options.Follow {
+		return nil
+	}
+
+	failures := 0
+	for {
+		if err := r.sleep(ctx, interval); err != nil {
+			// interrupted by the user
+			return nil
+		}
+		if err := r.poll(ctx, options, prefix, c, handle); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var handleErr *handleError
+			if errors.As(err, &handleErr) {
+				return handleErr.err
+			}
+			failures++
+			if failures >= maxFollowErrors {
+				return err
+			}
+			logger.Warning("error polling logs, retrying: %v", err)
+			continue
+		}
+		failures = 0
+	}
+}
+
+// cursor tracks the events already read. CloudWatch Logs may make events available after later events, so polls
+// start followLag before the latest event read and skip the events already read since then
+type cursor struct {
+	// from is the time to read events from
+	from time.Time
+	// latest is the time of the latest event read
+	latest time.Time
+	// seen are the times of the events read, by ID
+	seen map[string]time.Time
+}
+
+// startTime returns the time to poll from, and forgets the events read before it
+func (c *cursor) startTime() time.Time {
+	start := c.latest.Add(-followLag)
+	if start.Before(c.from) {
+		start = c.from
+	}
+	for id, timestamp := range c.seen {
+		if timestamp.Before(start) {
+			delete(c.seen, id)
+		}
+	}
+	return start
+}
+
+// handleError is an error returned by the event handler, which stops reading logs
+type handleError struct {
+	err error
+}
+
+func (e *handleError) Error() string {
+	return e.err.Error()
+}
+
+func (e *handleError) Unwrap() error {
+	return e.err
+}
+
+func (r *Reader) poll(ctx context.Context, options Options, prefix string, c *cursor, handle func(Event) error) error {
+	logGroupName := LogGroupName(r.clusterName)
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:        aws.String(logGroupName),
+		LogStreamNamePrefix: aws.String(prefix),
+		StartTime:           aws.Int64(c.startTime().UnixMilli()),
+	}
+	if options.FilterPattern != "" {
+		input.FilterPattern = aws.String(options.FilterPattern)
+	}
+
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(r.logs, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			var notFound *cwltypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return fmt.Errorf("log group %q not found, control plane logging must be enabled with cloudWatch.clusterLogging", logGroupName)
+			}
+			return fmt.Errorf("reading %s logs: %w", options.Type, err)
+		}
+		for _, e := range output.Events {
+			stream := aws.ToString(e.LogStreamName)
+			// API server log streams share their prefix with audit log streams
+			if options.Type == "api" && strings.HasPrefix(stream, apiServerAuditStreamPrefix) {
+				continue
+			}
+			id := aws.ToString(e.EventId)
+			timestamp := time.UnixMilli(aws.ToInt64(e.Timestamp))
+			if _, ok := c.seen[id]; ok {
+				continue
+			}
+			c.seen[id] = timestamp
+			if timestamp.After(c.latest) {
+				c.latest = timestamp
+			}
+
+			event := Event{
+				Timestamp: timestamp.UTC(),
+				Stream:    stream,
+				Message:   aws.ToString(e.Message),
+			}
+			if options.Type == "audit" {
+				if audit, err := ParseAuditEvent(event.Message); err == nil {
+					event.Audit = audit
+					event.Message = ""
+				}
+			}
+			if err := handle(event); err != nil {
+				return &handleError{err: err}
+			}
+		}
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
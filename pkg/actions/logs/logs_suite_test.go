@@ -0,0 +1,11 @@
+package logs_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestLogs(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
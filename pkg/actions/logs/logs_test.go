@@ -0,0 +1,169 @@
+package logs_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/logs"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+const auditMessage = `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"a1","stage":"ResponseComplete",` +
+	`"requestURI":"/api/v1/namespaces/default/pods/web/log","verb":"get","user":{"username":"kubernetes-admin","groups":["system:masters"]},` +
+	`"userAgent":"kubectl/v1.29.0","objectRef":{"resource":"pods","namespace":"default","name":"web","subresource":"log","apiVersion":"v1"},` +
+	`"responseStatus":{"metadata":{},"code":200}}`
+
+func logEvent(id, stream string, timestamp time.Time, message string) cwltypes.FilteredLogEvent {
+	return cwltypes.FilteredLogEvent{
+		EventId:       aws.String(id),
+		LogStreamName: aws.String(stream),
+		Timestamp:     aws.Int64(timestamp.UnixMilli()),
+		Message:       aws.String(message),
+	}
+}
+
+var _ = Describe("Logs", func() {
+	var (
+		provider *mockprovider.MockProvider
+		reader   *logs.Reader
+		now      time.Time
+		events   []logs.Event
+		handle   func(logs.Event) error
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		now = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+		reader = logs.New(provider.CloudWatchLogs(), "dev")
+		reader.SetNow(func() time.Time { return now })
+		events = nil
+		handle = func(e logs.Event) error {
+			events = append(events, e)
+			return nil
+		}
+	})
+
+	It("reads and parses audit events since the given duration", func() {
+		provider.MockCloudWatchLogs().On("FilterLogEvents", mock.Anything, &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:        aws.String("/aws/eks/dev/cluster"),
+			LogStreamNamePrefix: aws.String("kube-apiserver-audit-"),
+			StartTime:           aws.Int64(now.Add(-time.Hour).UnixMilli()),
+			FilterPattern:       aws.String(`{ $.verb = "get" }`),
+		}, mock.Anything).Return(&cloudwatchlogs.FilterLogEventsOutput{
+			Events: []cwltypes.FilteredLogEvent{
+				logEvent("1", "kube-apiserver-audit-abc", now.Add(-time.Minute), auditMessage),
+				logEvent("2", "kube-apiserver-audit-abc", now.Add(-time.Second), "not an audit event"),
+			},
+		}, nil)
+
+		Expect(reader.Read(context.Background(), logs.Options{
+			Type:          "audit",
+			Since:         time.Hour,
+			FilterPattern: `{ $.verb = "get" }`,
+		}, handle)).To(Succeed())
+		Expect(events).To(Equal([]logs.Event{
+			{
+				Timestamp: now.Add(-time.Minute),
+				Stream:    "kube-apiserver-audit-abc",
+				Audit: &logs.AuditEvent{
+					AuditID:    "a1",
+					Stage:      "ResponseComplete",
+					User:       "kubernetes-admin",
+					UserAgent:  "kubectl/v1.29.0",
+					Verb:       "get",
+					RequestURI: "/api/v1/namespaces/default/pods/web/log",
+					Resource:   "pods/log",
+					Namespace:  "default",
+					Name:       "web",
+					Code:       200,
+				},
+			},
+			{
+				Timestamp: now.Add(-time.Second),
+				Stream:    "kube-apiserver-audit-abc",
+				Message:   "not an audit event",
+			},
+		}))
+		Expect(events[0].Audit.Object()).To(Equal("pods/log default/web"))
+	})
+
+	It("excludes audit streams from API server logs", func() {
+		provider.MockCloudWatchLogs().On("FilterLogEvents", mock.Anything, mock.Anything, mock.Anything).Return(&cloudwatchlogs.FilterLogEventsOutput{
+			Events: []cwltypes.FilteredLogEvent{
+				logEvent("1", "kube-apiserver-abc", now, "I0101 started"),
+				logEvent("2", "kube-apiserver-audit-abc", now, auditMessage),
+			},
+		}, nil)
+
+		Expect(reader.Read(context.Background(), logs.Options{Type: "api"}, handle)).To(Succeed())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Message).To(Equal("I0101 started"))
+	})
+
+	It("rejects unsupported log types", func() {
+		Expect(reader.Read(context.Background(), logs.Options{Type: "kubelet"}, handle)).To(MatchError(ContainSubstring(`unsupported log type "kubelet"`)))
+	})
+
+	It("reports that logging is not enabled when the log group does not exist", func() {
+		provider.MockCloudWatchLogs().On("FilterLogEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, &cwltypes.ResourceNotFoundException{})
+
+		err := reader.Read(context.Background(), logs.Options{Type: "scheduler"}, handle)
+		Expect(err).To(MatchError(ContainSubstring(`log group "/aws/eks/dev/cluster" not found`)))
+	})
+
+	It("follows new and late events without repeating events already read", func() {
+		startTimes := []int64{}
+		polls := [][]cwltypes.FilteredLogEvent{
+			{logEvent("1", "authenticator-abc", now, "first")},
+			{logEvent("1", "authenticator-abc", now, "first"), logEvent("2", "authenticator-abc", now, "second")},
+			{
+				logEvent("late", "authenticator-def", now.Add(-30*time.Second), "late"),
+				logEvent("2", "authenticator-abc", now, "second"),
+				logEvent("3", "authenticator-abc", now.Add(time.Second), "third"),
+			},
+		}
+		provider.MockCloudWatchLogs().On("FilterLogEvents", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, input *cloudwatchlogs.FilterLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+			startTimes = append(startTimes, aws.ToInt64(input.StartTime))
+			output := &cloudwatchlogs.FilterLogEventsOutput{Events: polls[0]}
+			polls = polls[1:]
+			return output, nil
+		}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		reader.SetSleep(func(ctx context.Context, _ time.Duration) error {
+			if len(polls) == 0 {
+				cancel()
+			}
+			return ctx.Err()
+		})
+
+		Expect(reader.Read(ctx, logs.Options{Type: "authenticator", Since: time.Hour, Follow: true}, handle)).To(Succeed())
+		var messages []string
+		for _, e := range events {
+			messages = append(messages, e.Message)
+		}
+		Expect(messages).To(Equal([]string{"first", "second", "late", "third"}))
+		Expect(startTimes).To(Equal([]int64{now.Add(-time.Hour).UnixMilli(), now.Add(-2 * time.Minute).UnixMilli(), now.Add(-2 * time.Minute).UnixMilli()}))
+	})
+
+	It("stops following when the handler fails", func() {
+		provider.MockCloudWatchLogs().On("FilterLogEvents", mock.Anything, mock.Anything, mock.Anything).Return(&cloudwatchlogs.FilterLogEventsOutput{
+			Events: []cwltypes.FilteredLogEvent{logEvent("1", "kube-scheduler-abc", now, "scheduled")},
+		}, nil)
+		reader.SetSleep(func(context.Context, time.Duration) error { return nil })
+
+		err := reader.Read(context.Background(), logs.Options{Type: "scheduler", Follow: true}, func(logs.Event) error {
+			return errors.New("broken pipe")
+		})
+		Expect(err).To(MatchError("broken pipe"))
+	})
+})
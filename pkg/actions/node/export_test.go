@@ -0,0 +1,20 @@
+package node
+
+import (
+	"context"
+	"time"
+)
+
+var S3ObjectURL = s3ObjectURL
+
+func (m *Manager) SetS3URL(s3URL func(bucket, key string) string) {
+	m.s3URL = s3URL
+}
+
+func (m *Manager) SetRunCommand(runCommand func(ctx context.Context, name string, args ...string) error) {
+	m.runCommand = runCommand
+}
+
+func (m *Manager) SetNow(now func() time.Time) {
+	m.now = now
+}
@@ -0,0 +1,218 @@
+package node
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/kballard/go-shellquote"
+	"github.com/kris-nova/logger"
+)
+
+const (
+	runShellScriptDocument = "AWS-RunShellScript"
+	logsKeyPrefix          = "eksctl-node-logs"
+	// maxLogCollectorSize limits the size of the downloaded log collector script
+	maxLogCollectorSize = 1 << 20
+)
+
+// CollectLogsOptions configures the collection of node logs.
+type CollectLogsOptions struct {
+	// Bucket is the S3 bucket nodes upload the log archives to, from which they are downloaded;
+	// it must be in the region of the cluster
+	Bucket string
+	// OutputDir is the directory the log archives are downloaded to
+	OutputDir string
+	// CollectorScript is the path of a local copy of the EKS log collector script
+	CollectorScript string
+	// CollectorURL is the URL the EKS log collector script is downloaded from, when CollectorScript is not set
+	CollectorURL string
+	// CollectorSHA256 is the SHA-256 checksum the log collector script must match, required with CollectorURL
+	CollectorSHA256 string
+	// Timeout is how long to wait for nodes to collect and upload logs
+	Timeout time.Duration
+}
+
+type logCollection struct {
+	node      Node
+	key       string
+	commandID string
+}
+
+// CollectLogs runs the EKS log collector on the nodes using Run Command, and downloads the log archives to
+// the output directory. It returns the paths of the archives, and an error if logs could not be collected
+// from some nodes.
+func (m *Manager) CollectLogs(ctx context.Context, clusterName string, nodes []Node, options CollectLogsOptions) ([]string, error) {
+	collector, err := m.readLogCollector(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(options.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s/%s/%s", logsKeyPrefix, clusterName, m.now().UTC().Format("20060102T150405Z"))
+	var (
+		collections []logCollection
+		errs        []error
+	)
+	for _, node := range nodes {
+		collection, err := m.startLogCollection(ctx, node, options, collector, prefix)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logger.Info("collecting logs on node %q (instance %s), command %s", node.Name, node.InstanceID, collection.commandID)
+		collections = append(collections, collection)
+	}
+
+	var paths []string
+	for _, c := range collections {
+		if err := m.waitForCommand(ctx, c, options.Timeout); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		path := filepath.Join(options.OutputDir, c.node.Name+".tar.gz")
+		if err := m.downloadS3(ctx, options.Bucket, c.key, path); err != nil {
+			errs = append(errs, fmt.Errorf("downloading logs of node %q: %w", c.node.Name, err))
+			continue
+		}
+		logger.Success("downloaded logs of node %q to %q", c.node.Name, path)
+		paths = append(paths, path)
+	}
+	return paths, errors.Join(errs...)
+}
+
+func (m *Manager) startLogCollection(ctx context.Context, node Node, options CollectLogsOptions, collector []byte, prefix string) (logCollection, error) {
+	key := fmt.Sprintf("%s/%s.tar.gz", prefix, node.Name)
+	// the upload URL must remain valid until the collector is done
+	uploadURL, err := m.presignS3(ctx, http.MethodPut, options.Bucket, key, options.Timeout+5*time.Minute)
+	if err != nil {
+		return logCollection{}, err
+	}
+
+	output, err := m.ssm.SendCommand(ctx, &ssm.SendCommandInput{
+		DocumentName: aws.String(runShellScriptDocument),
+		InstanceIds:  []string{node.InstanceID},
+		Comment:      aws.String("eksctl utils collect-node-logs"),
+		Parameters: map[string][]string{
+			"commands":         {logCollectorScript(collector, uploadURL)},
+			"executionTimeout": {strconv.Itoa(int(options.Timeout.Seconds()))},
+		},
+	})
+	if err != nil {
+		return logCollection{}, fmt.Errorf("sending log collection command to node %q (instance %s): %w", node.Name, node.InstanceID, err)
+	}
+	return logCollection{
+		node:      node,
+		key:       key,
+		commandID: aws.ToString(output.Command.CommandId),
+	}, nil
+}
+
+// readLogCollector returns the log collector script, read from CollectorScript or downloaded from CollectorURL,
+// after checking it against CollectorSHA256
+func (m *Manager) readLogCollector(ctx context.Context, options CollectLogsOptions) ([]byte, error) {
+	var (
+		collector []byte
+		err       error
+	)
+	switch {
+	case options.CollectorScript != "":
+		if collector, err = os.ReadFile(options.CollectorScript); err != nil {
+			return nil, fmt.Errorf("reading log collector script: %w", err)
+		}
+	case options.CollectorURL != "":
+		if options.CollectorSHA256 == "" {
+			return nil, errors.New("the SHA-256 checksum of the log collector script is required to download it")
+		}
+		if collector, err = m.download(ctx, options.CollectorURL); err != nil {
+			return nil, fmt.Errorf("downloading log collector script: %w", err)
+		}
+	default:
+		return nil, errors.New("the log collector script or its URL must be set")
+	}
+
+	if options.CollectorSHA256 != "" {
+		sum := sha256.Sum256(collector)
+		if checksum := hex.EncodeToString(sum[:]); !strings.EqualFold(checksum, options.CollectorSHA256) {
+			return nil, fmt.Errorf("the SHA-256 checksum of the log collector script is %s, expected %s", checksum, options.CollectorSHA256)
+		}
+	}
+	return collector, nil
+}
+
+func (m *Manager) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxLogCollectorSize))
+}
+
+// logCollectorScript returns a script that writes the log collector, which is embedded in the script so that
+// nodes do not need internet access, runs it, which writes an archive to /var/log, and uploads the archive
+func logCollectorScript(collector []byte, uploadURL string) string {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	// writes to a bytes.Buffer do not fail
+	_, _ = gz.Write(collector)
+	_ = gz.Close()
+	return strings.Join([]string{
+		"set -eu",
+		"echo " + base64.StdEncoding.EncodeToString(compressed.Bytes()) + " | base64 -d | gunzip > /tmp/eks-log-collector.sh",
+		"bash /tmp/eks-log-collector.sh",
+		"archive=$(ls -t /var/log/eks_*.tar.gz | head -n 1)",
+		`curl -fsS --retry 3 -X PUT -T "$archive" ` + shellquote.Join(uploadURL),
+		`rm -f "$archive" /tmp/eks-log-collector.sh`,
+	}, "\n")
+}
+
+func (m *Manager) waitForCommand(ctx context.Context, c logCollection, timeout time.Duration) error {
+	input := &ssm.GetCommandInvocationInput{
+		CommandId:  aws.String(c.commandID),
+		InstanceId: aws.String(c.node.InstanceID),
+	}
+	waiter := ssm.NewCommandExecutedWaiter(m.ssm, func(o *ssm.CommandExecutedWaiterOptions) {
+		o.MinDelay = 2 * time.Second
+		o.MaxDelay = 15 * time.Second
+	})
+	if err := waiter.Wait(ctx, input, timeout); err != nil {
+		reason := err.Error()
+		if invocation, getErr := m.ssm.GetCommandInvocation(ctx, input); getErr == nil {
+			reason = fmt.Sprintf("command %s: %s", invocation.Status, lastLines(aws.ToString(invocation.StandardErrorContent), 5))
+		}
+		return fmt.Errorf("collecting logs on node %q (instance %s): %s", c.node.Name, c.node.InstanceID, reason)
+	}
+	return nil
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,158 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// Node is a Kubernetes node and the EC2 instance it runs on.
+type Node struct {
+	Name       string
+	InstanceID string
+}
+
+// Manager opens sessions on, and collects logs from, the nodes of a cluster using Session Manager.
+type Manager struct {
+	ssm         awsapi.SSM
+	clientSet   kubernetes.Interface
+	region      string
+	profile     string
+	credentials aws.CredentialsProvider
+	httpClient  *http.Client
+	// s3URL returns the URL of an object in an S3 bucket
+	s3URL func(bucket, key string) string
+	// runCommand runs a local command attached to the terminal
+	runCommand func(ctx context.Context, name string, args ...string) error
+	now        func() time.Time
+}
+
+// New creates a new manager.
+func New(provider api.ClusterProvider, clientSet kubernetes.Interface) *Manager {
+	region := provider.Region()
+	return &Manager{
+		ssm:         provider.SSM(),
+		clientSet:   clientSet,
+		region:      region,
+		profile:     provider.Profile().Name,
+		credentials: provider.CredentialsProvider(),
+		httpClient:  http.DefaultClient,
+		s3URL: func(bucket, key string) string {
+			return s3ObjectURL(bucket, region, key)
+		},
+		runCommand: func(ctx context.Context, name string, args ...string) error {
+			cmd := exec.CommandContext(ctx, name, args...)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		},
+		now: time.Now,
+	}
+}
+
+// s3ObjectURL returns the URL of an object in an S3 bucket of the region, which is path-style when the bucket name
+// contains dots, as they are not covered by the wildcard certificate of virtual-hosted-style URLs
+func s3ObjectURL(bucket, region, key string) string {
+	endpoint := fmt.Sprintf("s3.%s.%s", region, api.Partitions.DNSSuffixForRegion(region))
+	if strings.Contains(bucket, ".") {
+		return fmt.Sprintf("https://%s/%s/%s", endpoint, bucket, key)
+	}
+	return fmt.Sprintf("https://%s.%s/%s", bucket, endpoint, key)
+}
+
+var (
+	instanceIDPattern = regexp.MustCompile(`^i-[0-9a-f]+$`)
+	// providerIDPattern matches the provider ID of nodes, aws:///<zone>/<instance ID>
+	providerIDPattern = regexp.MustCompile(`^aws:///[^/]+/(i-[0-9a-f]+)$`)
+)
+
+// GetNode returns the node with the given name, or the node running the given instance.
+func (m *Manager) GetNode(ctx context.Context, nameOrInstanceID string) (Node, error) {
+	if instanceIDPattern.MatchString(nameOrInstanceID) {
+		return Node{Name: nameOrInstanceID, InstanceID: nameOrInstanceID}, nil
+	}
+	node, err := m.clientSet.CoreV1().Nodes().Get(ctx, nameOrInstanceID, metav1.GetOptions{})
+	if err != nil {
+		return Node{}, fmt.Errorf("getting node %q: %w", nameOrInstanceID, err)
+	}
+	return toNode(node)
+}
+
+// ListNodes returns the nodes with the given names or instance IDs, or the nodes of the nodegroup.
+func (m *Manager) ListNodes(ctx context.Context, nodeGroup string, names []string) ([]Node, error) {
+	if nodeGroup == "" {
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no nodes selected")
+		}
+		var nodes []Node
+		for _, name := range names {
+			node, err := m.GetNode(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes, nil
+	}
+
+	var nodeList *corev1.NodeList
+	// nodes of unowned managed nodegroups only have the EKS nodegroup label
+	for _, label := range []string{api.NodeGroupNameLabel, api.EKSNodeGroupNameLabel} {
+		var err error
+		nodeList, err = m.clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", label, nodeGroup),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing nodes of nodegroup %q: %w", nodeGroup, err)
+		}
+		if len(nodeList.Items) > 0 {
+			break
+		}
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	var nodes []Node
+	for i := range nodeList.Items {
+		node, err := toNode(&nodeList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 || selected[node.Name] || selected[node.InstanceID] {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes found in nodegroup %q", nodeGroup)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
+
+func toNode(node *corev1.Node) (Node, error) {
+	match := providerIDPattern.FindStringSubmatch(node.Spec.ProviderID)
+	if match == nil {
+		return Node{}, fmt.Errorf("node %q does not run on an EC2 instance (provider ID %q)", node.Name, node.Spec.ProviderID)
+	}
+	return Node{Name: node.Name, InstanceID: match[1]}, nil
+}
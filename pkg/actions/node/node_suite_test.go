@@ -0,0 +1,11 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestNode(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,339 @@
+package node_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/kballard/go-shellquote"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/node"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func newNode(name, instanceID string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2a/" + instanceID},
+	}
+}
+
+// mockGetCommandInvocation mocks GetCommandInvocation as called directly and by the command waiter, which passes options
+func mockGetCommandInvocation(provider *mockprovider.MockProvider, input interface{}, output *ssm.GetCommandInvocationOutput) {
+	provider.MockSSM().On("GetCommandInvocation", mock.Anything, input).Return(output, nil)
+	provider.MockSSM().On("GetCommandInvocation", mock.Anything, input, mock.Anything).Return(output, nil)
+}
+
+var _ = Describe("Node", func() {
+	var (
+		provider  *mockprovider.MockProvider
+		clientSet *fake.Clientset
+		manager   *node.Manager
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		provider.MockCredentialsProvider().On("Retrieve", mock.Anything).Return(aws.Credentials{
+			AccessKeyID:     "AKID",
+			SecretAccessKey: "secret",
+		}, nil)
+		clientSet = fake.NewSimpleClientset(
+			newNode("ip-10-0-0-1.ec2.internal", "i-0001", map[string]string{api.NodeGroupNameLabel: "ng-1"}),
+			newNode("ip-10-0-0-2.ec2.internal", "i-0002", map[string]string{api.NodeGroupNameLabel: "ng-1"}),
+			newNode("ip-10-0-0-3.ec2.internal", "i-0003", map[string]string{api.EKSNodeGroupNameLabel: "unowned"}),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "fargate-ip-10-0-0-4.ec2.internal"}},
+		)
+		manager = node.New(provider, clientSet)
+	})
+
+	Context("resolving nodes", func() {
+		It("resolves node names and instance IDs to instances", func() {
+			n, err := manager.GetNode(context.Background(), "ip-10-0-0-1.ec2.internal")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(node.Node{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"}))
+
+			n, err = manager.GetNode(context.Background(), "i-0abc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(node.Node{Name: "i-0abc", InstanceID: "i-0abc"}))
+
+			_, err = manager.GetNode(context.Background(), "fargate-ip-10-0-0-4.ec2.internal")
+			Expect(err).To(MatchError(ContainSubstring("does not run on an EC2 instance")))
+		})
+
+		It("lists the nodes of a nodegroup", func() {
+			nodes, err := manager.ListNodes(context.Background(), "ng-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(Equal([]node.Node{
+				{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				{Name: "ip-10-0-0-2.ec2.internal", InstanceID: "i-0002"},
+			}))
+
+			nodes, err = manager.ListNodes(context.Background(), "ng-1", []string{"i-0002"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(Equal([]node.Node{{Name: "ip-10-0-0-2.ec2.internal", InstanceID: "i-0002"}}))
+
+			nodes, err = manager.ListNodes(context.Background(), "unowned", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(Equal([]node.Node{{Name: "ip-10-0-0-3.ec2.internal", InstanceID: "i-0003"}}))
+
+			_, err = manager.ListNodes(context.Background(), "missing", nil)
+			Expect(err).To(MatchError(`no nodes found in nodegroup "missing"`))
+		})
+	})
+
+	Context("opening a shell", func() {
+		BeforeEach(func() {
+			provider.MockSSM().On("StartSession", mock.Anything, mock.MatchedBy(func(input *ssm.StartSessionInput) bool {
+				return aws.ToString(input.Target) == "i-0001"
+			})).Return(&ssm.StartSessionOutput{
+				SessionId:  aws.String("session-1"),
+				StreamUrl:  aws.String("wss://ssmmessages"),
+				TokenValue: aws.String("token"),
+			}, nil)
+		})
+
+		It("runs the Session Manager plugin with the session", func() {
+			var args []string
+			manager.SetRunCommand(func(_ context.Context, name string, a ...string) error {
+				args = append([]string{name}, a...)
+				return nil
+			})
+
+			Expect(manager.Shell(context.Background(), node.Node{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"})).To(Succeed())
+			Expect(args).To(Equal([]string{
+				"session-manager-plugin",
+				`{"SessionId":"session-1","StreamUrl":"wss://ssmmessages","TokenValue":"token"}`,
+				"us-west-2",
+				"StartSession",
+				"default",
+				`{"Target":"i-0001"}`,
+				"https://ssm.us-west-2.amazonaws.com",
+			}))
+		})
+
+		It("uses the SSM endpoint of the partition of the region", func() {
+			provider.SetRegion(api.RegionCNNorth1)
+			manager = node.New(provider, clientSet)
+			var args []string
+			manager.SetRunCommand(func(_ context.Context, name string, a ...string) error {
+				args = append([]string{name}, a...)
+				return nil
+			})
+
+			Expect(manager.Shell(context.Background(), node.Node{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"})).To(Succeed())
+			Expect(args[2]).To(Equal(api.RegionCNNorth1))
+			Expect(args[len(args)-1]).To(Equal("https://ssm.cn-north-1.amazonaws.com.cn"))
+		})
+
+		It("terminates the session if the plugin is not installed", func() {
+			provider.MockSSM().On("TerminateSession", mock.Anything, mock.Anything).Return(&ssm.TerminateSessionOutput{}, nil)
+			manager.SetRunCommand(func(context.Context, string, ...string) error {
+				return &exec.Error{Name: "session-manager-plugin", Err: exec.ErrNotFound}
+			})
+
+			err := manager.Shell(context.Background(), node.Node{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"})
+			Expect(err).To(MatchError(ContainSubstring("session-manager-plugin is required to open sessions")))
+			provider.MockSSM().AssertCalled(GinkgoT(), "TerminateSession", mock.Anything, &ssm.TerminateSessionInput{SessionId: aws.String("session-1")})
+		})
+	})
+
+	Context("collecting logs", func() {
+		const collector = "#!/bin/bash\necho collecting logs\n"
+
+		var (
+			server        *httptest.Server
+			mu            sync.Mutex
+			objects       map[string]string
+			outputDir     string
+			collectorPath string
+		)
+
+		embeddedCollectorPattern := regexp.MustCompile(`(?m)^echo (\S+) \| base64 -d \| gunzip > /tmp/eks-log-collector.sh$`)
+		decodeCollector := func(script string) string {
+			match := embeddedCollectorPattern.FindStringSubmatch(script)
+			Expect(match).To(HaveLen(2))
+			compressed, err := base64.StdEncoding.DecodeString(match[1])
+			Expect(err).NotTo(HaveOccurred())
+			gz, err := gzip.NewReader(bytes.NewReader(compressed))
+			Expect(err).NotTo(HaveOccurred())
+			decoded, err := io.ReadAll(gz)
+			Expect(err).NotTo(HaveOccurred())
+			return string(decoded)
+		}
+
+		BeforeEach(func() {
+			objects = map[string]string{}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.URL.Query().Get("X-Amz-Signature")).NotTo(BeEmpty())
+				mu.Lock()
+				defer mu.Unlock()
+				switch r.Method {
+				case http.MethodPut:
+					body, err := io.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					objects[r.URL.Path] = string(body)
+				case http.MethodGet:
+					body, ok := objects[r.URL.Path]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					fmt.Fprint(w, body)
+				case http.MethodDelete:
+					delete(objects, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			DeferCleanup(server.Close)
+			manager.SetS3URL(func(bucket, key string) string {
+				return fmt.Sprintf("%s/%s/%s", server.URL, bucket, key)
+			})
+			manager.SetNow(func() time.Time {
+				return time.Date(2022, 1, 1, 1, 1, 1, 0, time.UTC)
+			})
+			outputDir = filepath.Join(GinkgoT().TempDir(), "logs")
+			collectorPath = filepath.Join(GinkgoT().TempDir(), "eks-log-collector.sh")
+			Expect(os.WriteFile(collectorPath, []byte(collector), 0644)).To(Succeed())
+
+			uploadCommandPattern := regexp.MustCompile(`(?m)^curl .* -X PUT .*$`)
+			provider.MockSSM().On("SendCommand", mock.Anything, mock.Anything).Return(func(_ context.Context, input *ssm.SendCommandInput, _ ...func(*ssm.Options)) (*ssm.SendCommandOutput, error) {
+				defer GinkgoRecover()
+				Expect(aws.ToString(input.DocumentName)).To(Equal("AWS-RunShellScript"))
+				Expect(input.Parameters["executionTimeout"]).To(Equal([]string{"600"}))
+				script := input.Parameters["commands"][0]
+				Expect(decodeCollector(script)).To(Equal(collector))
+
+				// upload the archive as the node would
+				instanceID := input.InstanceIds[0]
+				match := uploadCommandPattern.FindString(script)
+				Expect(match).NotTo(BeEmpty())
+				words, err := shellquote.Split(match)
+				Expect(err).NotTo(HaveOccurred())
+				req, err := http.NewRequest(http.MethodPut, words[len(words)-1], strings.NewReader("logs of "+instanceID))
+				Expect(err).NotTo(HaveOccurred())
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Body.Close()).To(Succeed())
+
+				return &ssm.SendCommandOutput{
+					Command: &ssmtypes.Command{CommandId: aws.String("command-" + instanceID)},
+				}, nil
+			})
+		})
+
+		It("runs the log collector on nodes and downloads the archives", func() {
+			mockGetCommandInvocation(provider, mock.Anything, &ssm.GetCommandInvocationOutput{
+				Status: ssmtypes.CommandInvocationStatusSuccess,
+			})
+
+			paths, err := manager.CollectLogs(context.Background(), "dev", []node.Node{
+				{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				{Name: "ip-10-0-0-2.ec2.internal", InstanceID: "i-0002"},
+			}, node.CollectLogsOptions{Bucket: "bucket", OutputDir: outputDir, CollectorScript: collectorPath, Timeout: 10 * time.Minute})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(Equal([]string{
+				filepath.Join(outputDir, "ip-10-0-0-1.ec2.internal.tar.gz"),
+				filepath.Join(outputDir, "ip-10-0-0-2.ec2.internal.tar.gz"),
+			}))
+			data, err := os.ReadFile(paths[1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("logs of i-0002"))
+			Expect(objects).To(BeEmpty())
+		})
+
+		Context("downloading the log collector", func() {
+			var collectorURL string
+
+			BeforeEach(func() {
+				collectorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					fmt.Fprint(w, collector)
+				}))
+				DeferCleanup(collectorServer.Close)
+				collectorURL = collectorServer.URL + "/eks-log-collector.sh"
+			})
+
+			It("sends the downloaded log collector when its checksum matches", func() {
+				mockGetCommandInvocation(provider, mock.Anything, &ssm.GetCommandInvocationOutput{
+					Status: ssmtypes.CommandInvocationStatusSuccess,
+				})
+				sum := sha256.Sum256([]byte(collector))
+
+				paths, err := manager.CollectLogs(context.Background(), "dev", []node.Node{
+					{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				}, node.CollectLogsOptions{Bucket: "bucket", OutputDir: outputDir, CollectorURL: collectorURL, CollectorSHA256: hex.EncodeToString(sum[:]), Timeout: 10 * time.Minute})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(paths).To(HaveLen(1))
+			})
+
+			It("does not run the log collector when its checksum does not match", func() {
+				_, err := manager.CollectLogs(context.Background(), "dev", []node.Node{
+					{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				}, node.CollectLogsOptions{Bucket: "bucket", OutputDir: outputDir, CollectorURL: collectorURL, CollectorSHA256: "0123", Timeout: 10 * time.Minute})
+				Expect(err).To(MatchError(ContainSubstring("expected 0123")))
+				provider.MockSSM().AssertNotCalled(GinkgoT(), "SendCommand", mock.Anything, mock.Anything)
+			})
+
+			It("requires a checksum to download the log collector", func() {
+				_, err := manager.CollectLogs(context.Background(), "dev", []node.Node{
+					{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				}, node.CollectLogsOptions{Bucket: "bucket", OutputDir: outputDir, CollectorURL: collectorURL, Timeout: 10 * time.Minute})
+				Expect(err).To(MatchError(ContainSubstring("checksum of the log collector script is required")))
+				provider.MockSSM().AssertNotCalled(GinkgoT(), "SendCommand", mock.Anything, mock.Anything)
+			})
+		})
+
+		It("reports nodes where the log collector failed", func() {
+			mockGetCommandInvocation(provider, mock.MatchedBy(func(input *ssm.GetCommandInvocationInput) bool {
+				return aws.ToString(input.InstanceId) == "i-0001"
+			}), &ssm.GetCommandInvocationOutput{
+				Status:               ssmtypes.CommandInvocationStatusFailed,
+				StandardErrorContent: aws.String("curl: (6) Could not resolve host\n"),
+			})
+			mockGetCommandInvocation(provider, mock.Anything, &ssm.GetCommandInvocationOutput{
+				Status: ssmtypes.CommandInvocationStatusSuccess,
+			})
+
+			paths, err := manager.CollectLogs(context.Background(), "dev", []node.Node{
+				{Name: "ip-10-0-0-1.ec2.internal", InstanceID: "i-0001"},
+				{Name: "ip-10-0-0-2.ec2.internal", InstanceID: "i-0002"},
+			}, node.CollectLogsOptions{Bucket: "bucket", OutputDir: outputDir, CollectorScript: collectorPath, Timeout: 10 * time.Minute})
+			Expect(err).To(MatchError(`collecting logs on node "ip-10-0-0-1.ec2.internal" (instance i-0001): command Failed: curl: (6) Could not resolve host`))
+			Expect(errors.Unwrap(err)).To(BeNil())
+			Expect(paths).To(Equal([]string{filepath.Join(outputDir, "ip-10-0-0-2.ec2.internal.tar.gz")}))
+		})
+	})
+
+	DescribeTable("URLs of S3 objects", func(bucket, region, expectedURL string) {
+		Expect(node.S3ObjectURL(bucket, region, "logs/node.tar.gz")).To(Equal(expectedURL))
+	},
+		Entry("virtual-hosted-style", "bucket", "us-west-2", "https://bucket.s3.us-west-2.amazonaws.com/logs/node.tar.gz"),
+		Entry("in China", "bucket", "cn-north-1", "https://bucket.s3.cn-north-1.amazonaws.com.cn/logs/node.tar.gz"),
+		Entry("path-style when the bucket name contains dots", "my.bucket", "us-west-2", "https://s3.us-west-2.amazonaws.com/my.bucket/logs/node.tar.gz"),
+	)
+})
@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// unsignedPayload is the payload hash of presigned S3 requests, whose body is not known when signing
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// presignS3 returns a URL that allows the request on the S3 object to be made without credentials until it expires.
+// S3 requests are presigned directly so that nodes can upload objects without S3 permissions or the AWS CLI.
+func (m *Manager) presignS3(ctx context.Context, method, bucket, key string, expires time.Duration) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, m.s3URL(bucket, key), nil)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	req.URL.RawQuery = query.Encode()
+
+	creds, err := m.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("retrieving credentials: %w", err)
+	}
+	signer := v4.NewSigner(func(o *v4.SignerOptions) {
+		// S3 paths are not escaped twice
+		o.DisableURIPathEscaping = true
+	})
+	url, _, err := signer.PresignHTTP(ctx, creds, req, unsignedPayload, "s3", m.region, m.now())
+	if err != nil {
+		return "", fmt.Errorf("presigning %s s3://%s/%s: %w", method, bucket, key, err)
+	}
+	return url, nil
+}
+
+// doS3 makes a request on the S3 object, and returns the response if it succeeds
+func (m *Manager) doS3(ctx context.Context, method, bucket, key string) (*http.Response, error) {
+	url, err := m.presignS3(ctx, method, bucket, key, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s s3://%s/%s: %w", method, bucket, key, err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("%s s3://%s/%s: %s: %s", method, bucket, key, resp.Status, body)
+	}
+	return resp, nil
+}
+
+// downloadS3 downloads the S3 object to path and deletes it
+func (m *Manager) downloadS3(ctx context.Context, bucket, key, path string) error {
+	resp, err := m.doS3(ctx, http.MethodGet, bucket, key)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("downloading s3://%s/%s to %q: %w", bucket, key, path, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	resp, err = m.doS3(ctx, http.MethodDelete, bucket, key)
+	if err != nil {
+		return fmt.Errorf("deleting downloaded archive: %w", err)
+	}
+	return resp.Body.Close()
+}
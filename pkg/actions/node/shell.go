@@ -0,0 +1,61 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// SessionManagerPlugin is the command that connects the terminal to Session Manager sessions, as used by the AWS CLI.
+const SessionManagerPlugin = "session-manager-plugin"
+
+// Shell opens an interactive Session Manager session on the node, which requires the SSM agent to run on the node
+// with the AmazonSSMManagedInstanceCore policy, as set up by enableSsm.
+func (m *Manager) Shell(ctx context.Context, node Node) error {
+	input := &ssm.StartSessionInput{
+		Target: aws.String(node.InstanceID),
+	}
+	output, err := m.ssm.StartSession(ctx, input)
+	if err != nil {
+		return fmt.Errorf("starting session on node %q (instance %s): %w", node.Name, node.InstanceID, err)
+	}
+	sessionID := aws.ToString(output.SessionId)
+	logger.Info("started session %s on node %q (instance %s)", sessionID, node.Name, node.InstanceID)
+
+	session, err := json.Marshal(map[string]string{
+		"SessionId":  sessionID,
+		"TokenValue": aws.ToString(output.TokenValue),
+		"StreamUrl":  aws.ToString(output.StreamUrl),
+	})
+	if err != nil {
+		return err
+	}
+	parameters, err := json.Marshal(map[string]string{
+		"Target": node.InstanceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = m.runCommand(ctx, SessionManagerPlugin, string(session), m.region, "StartSession", m.profile, string(parameters),
+		fmt.Sprintf("https://ssm.%s.%s", m.region, api.Partitions.DNSSuffixForRegion(m.region)))
+	if err == nil {
+		return nil
+	}
+	// the plugin terminates the session when it exits, which it has not done if it could not run
+	if _, terminateErr := m.ssm.TerminateSession(context.Background(), &ssm.TerminateSessionInput{SessionId: output.SessionId}); terminateErr != nil {
+		logger.Warning("failed to terminate session %s: %v", sessionID, terminateErr)
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s is required to open sessions, see https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html: %w", SessionManagerPlugin, err)
+	}
+	return fmt.Errorf("running %s: %w", SessionManagerPlugin, err)
+}
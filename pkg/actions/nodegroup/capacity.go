@@ -0,0 +1,202 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+const prefixDelegationEnv = "ENABLE_PREFIX_DELEGATION"
+
+// SubnetCapacities returns the free IPs of the subnets of the nodegroups, along with the IPs the nodegroups can
+// consume at their desired and max sizes.
+func (m *Manager) SubnetCapacities(ctx context.Context, nodePools []api.NodePool) ([]vpc.SubnetCapacity, error) {
+	options, err := m.ipUsageOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := vpc.NewNodeGroupIPUsage(ctx, m.ctl.AWSProvider.EC2(), m.cfg, nodePools, options)
+	if err != nil {
+		return nil, err
+	}
+	return vpc.SubnetCapacities(ctx, m.ctl.AWSProvider.EC2(), usages)
+}
+
+// CheckScaleSubnetCapacity checks that the subnets of the nodegroup have enough free IPs for the nodes added by
+// scaling it. It warns if the subnets cannot fit the nodegroup at its new max size, and returns an error if they
+// cannot fit its new desired capacity.
+func (m *Manager) CheckScaleSubnetCapacity(ctx context.Context, ng *api.NodeGroupBase) error {
+	capacities, err := m.scaleSubnetCapacities(ctx, ng)
+	if err != nil {
+		logger.Warning("unable to check the free IPs of the subnets of nodegroup %q: %v", ng.Name, err)
+		return nil
+	}
+	return vpc.CheckSubnetCapacities(capacities)
+}
+
+func (m *Manager) checkSubnetCapacity(ctx context.Context, nodePools []api.NodePool) error {
+	capacities, err := m.SubnetCapacities(ctx, nodePools)
+	if err != nil {
+		logger.Warning("unable to check the free IPs of nodegroup subnets: %v", err)
+		return nil
+	}
+	return vpc.CheckSubnetCapacities(capacities)
+}
+
+func (m *Manager) scaleSubnetCapacities(ctx context.Context, ng *api.NodeGroupBase) ([]vpc.SubnetCapacity, error) {
+	nodegroupStackInfos, err := m.stackManager.DescribeNodeGroupStacksAndResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var usage *vpc.NodeGroupIPUsage
+	if stackInfo, ok := nodegroupStackInfos[ng.Name]; ok && isUnmanagedNodeGroupStack(stackInfo) {
+		usage, err = m.unmanagedNodeGroupScaleUsage(ctx, ng, stackInfo)
+	} else {
+		usage, err = m.managedNodeGroupScaleUsage(ctx, ng)
+	}
+	if err != nil || usage == nil {
+		return nil, err
+	}
+	return vpc.SubnetCapacities(ctx, m.ctl.AWSProvider.EC2(), []vpc.NodeGroupIPUsage{*usage})
+}
+
+func (m *Manager) managedNodeGroupScaleUsage(ctx context.Context, ng *api.NodeGroupBase) (*vpc.NodeGroupIPUsage, error) {
+	output, err := m.ctl.AWSProvider.EKS().DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(m.cfg.Metadata.Name),
+		NodegroupName: aws.String(ng.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing nodegroup %q: %w", ng.Name, err)
+	}
+	nodeGroup := output.Nodegroup
+	var currentSize int
+	if nodeGroup.ScalingConfig != nil {
+		currentSize = int(aws.ToInt32(nodeGroup.ScalingConfig.DesiredSize))
+	}
+	return m.scaleUsage(ctx, ng, currentSize, nodeGroup.Subnets, nodeGroup.InstanceTypes)
+}
+
+func (m *Manager) unmanagedNodeGroupScaleUsage(ctx context.Context, ng *api.NodeGroupBase, stackInfo manager.StackInfo) (*vpc.NodeGroupIPUsage, error) {
+	asgName, err := getASGName(stackInfo)
+	if err != nil {
+		return nil, err
+	}
+	output, err := m.ctl.AWSProvider.ASG().DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{asgName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing Auto Scaling group %q: %w", asgName, err)
+	}
+	if len(output.AutoScalingGroups) != 1 {
+		return nil, fmt.Errorf("expected to find exactly one Auto Scaling group for nodegroup; got %d", len(output.AutoScalingGroups))
+	}
+	asg := output.AutoScalingGroups[0]
+
+	var instanceTypes []string
+	for _, instance := range asg.Instances {
+		instanceTypes = append(instanceTypes, aws.ToString(instance.InstanceType))
+	}
+	if asg.MixedInstancesPolicy != nil && asg.MixedInstancesPolicy.LaunchTemplate != nil {
+		for _, override := range asg.MixedInstancesPolicy.LaunchTemplate.Overrides {
+			instanceTypes = append(instanceTypes, aws.ToString(override.InstanceType))
+		}
+	}
+	var subnetIDs []string
+	if zoneIdentifier := aws.ToString(asg.VPCZoneIdentifier); zoneIdentifier != "" {
+		subnetIDs = strings.Split(zoneIdentifier, ",")
+	}
+	return m.scaleUsage(ctx, ng, int(aws.ToInt32(asg.DesiredCapacity)), subnetIDs, instanceTypes)
+}
+
+// scaleUsage returns the IP usage of the nodes added by scaling the nodegroup from its current size, or nil if it is
+// not scaled out.
+func (m *Manager) scaleUsage(ctx context.Context, ng *api.NodeGroupBase, currentSize int, subnetIDs, instanceTypes []string) (*vpc.NodeGroupIPUsage, error) {
+	maxNodes := max(aws.ToInt(ng.MaxSize), aws.ToInt(ng.DesiredCapacity)) - currentSize
+	if maxNodes <= 0 || len(subnetIDs) == 0 {
+		return nil, nil
+	}
+	var types []string
+	for _, it := range instanceTypes {
+		if it != "" {
+			types = append(types, it)
+		}
+	}
+	if len(types) == 0 {
+		logger.Debug("skipping subnet capacity check of nodegroup %q without known instance types", ng.Name)
+		return nil, nil
+	}
+
+	options, err := m.ipUsageOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ipsPerNode, err := vpc.InstanceTypesIPsPerNode(ctx, m.ctl.AWSProvider.EC2(), types, 0, options)
+	if err != nil || ipsPerNode == 0 {
+		return nil, err
+	}
+	return &vpc.NodeGroupIPUsage{
+		Name:         ng.Name,
+		SubnetIDs:    subnetIDs,
+		DesiredNodes: max(aws.ToInt(ng.DesiredCapacity)-currentSize, 0),
+		MaxNodes:     maxNodes,
+		IPsPerNode:   ipsPerNode,
+	}, nil
+}
+
+// ipUsageOptions returns how the VPC CNI of the cluster assigns IPs, using the configuration values of the vpc-cni
+// addon in the config file, or else of the vpc-cni addon installed in the cluster.
+func (m *Manager) ipUsageOptions(ctx context.Context) (vpc.IPUsageOptions, error) {
+	options := vpc.IPUsageOptions{
+		CustomNetworking: m.cfg.HasCustomNetworking(),
+		IPv6:             m.cfg.IPv6Enabled(),
+	}
+	configurationValues, found := "", false
+	for _, a := range m.cfg.Addons {
+		if a.CanonicalName() == api.VPCCNIAddon {
+			configurationValues, found = a.ConfigurationValues, true
+			break
+		}
+	}
+	if !found {
+		output, err := m.ctl.AWSProvider.EKS().DescribeAddon(ctx, &awseks.DescribeAddonInput{
+			ClusterName: aws.String(m.cfg.Metadata.Name),
+			AddonName:   aws.String(api.VPCCNIAddon),
+		})
+		if err != nil {
+			var notFoundErr *ekstypes.ResourceNotFoundException
+			if !errors.As(err, &notFoundErr) {
+				return options, fmt.Errorf("describing %q addon: %w", api.VPCCNIAddon, err)
+			}
+		} else if output.Addon != nil {
+			configurationValues = aws.ToString(output.Addon.ConfigurationValues)
+		}
+	}
+
+	if configurationValues != "" {
+		var values struct {
+			Env map[string]string `json:"env"`
+		}
+		if err := yaml.Unmarshal([]byte(configurationValues), &values); err != nil {
+			return options, fmt.Errorf("parsing configuration values of %q addon: %w", api.VPCCNIAddon, err)
+		}
+		options.PrefixDelegation = values.Env[prefixDelegationEnv] == "true"
+	}
+	return options, nil
+}
+
+func isUnmanagedNodeGroupStack(stackInfo manager.StackInfo) bool {
+	nodeGroupType, err := manager.GetNodeGroupType(stackInfo.Stack.Tags)
+	return err == nil && nodeGroupType == api.NodeGroupTypeUnmanaged
+}
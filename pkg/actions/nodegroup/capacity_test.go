@@ -0,0 +1,126 @@
+package nodegroup_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("CheckScaleSubnetCapacity", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+		m   *nodegroup.Manager
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fake.NewSimpleClientset(), nil)
+		fakeStackManager := new(fakes.FakeStackManager)
+		fakeStackManager.DescribeNodeGroupStacksAndResourcesReturns(map[string]manager.StackInfo{}, nil)
+		m.SetStackManager(fakeStackManager)
+
+		p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{
+				InstanceTypes: []string{"m5.large"},
+				Subnets:       []string{"subnet-a", "subnet-b"},
+				ScalingConfig: &ekstypes.NodegroupScalingConfig{
+					DesiredSize: aws.Int32(2),
+				},
+			},
+		}, nil)
+		p.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				ConfigurationValues: aws.String(`{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`),
+			},
+		}, nil)
+		p.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []ec2types.InstanceTypeInfo{
+				{
+					InstanceType: "m5.large",
+					NetworkInfo: &ec2types.NetworkInfo{
+						MaximumNetworkInterfaces:  aws.Int32(3),
+						Ipv4AddressesPerInterface: aws.Int32(10),
+					},
+				},
+			},
+		}, nil)
+		p.MockEC2().On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{
+			SubnetIds: []string{"subnet-a", "subnet-b"},
+		}).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{
+				{
+					SubnetId:                aws.String("subnet-a"),
+					AvailabilityZone:        aws.String("us-west-2a"),
+					AvailableIpAddressCount: aws.Int32(1000),
+				},
+				{
+					SubnetId:                aws.String("subnet-b"),
+					AvailabilityZone:        aws.String("us-west-2b"),
+					AvailableIpAddressCount: aws.Int32(200),
+				},
+			},
+		}, nil)
+	})
+
+	It("allows scaling when the subnets fit the added nodes", func() {
+		ng := &api.NodeGroupBase{
+			Name:          "my-ng",
+			ScalingConfig: &api.ScalingConfig{DesiredCapacity: aws.Int(4)},
+		}
+		Expect(m.CheckScaleSubnetCapacity(context.Background(), ng)).To(Succeed())
+	})
+
+	It("refuses scaling when a subnet cannot fit the added nodes", func() {
+		ng := &api.NodeGroupBase{
+			Name:          "my-ng",
+			ScalingConfig: &api.ScalingConfig{DesiredCapacity: aws.Int(6)},
+		}
+		// 4 nodes are added, using 129 IPs each with prefix delegation
+		err := m.CheckScaleSubnetCapacity(context.Background(), ng)
+		Expect(err).To(MatchError(ContainSubstring("subnet subnet-b (us-west-2b) has 200 free IPs, but nodegroup(s) my-ng need up to 258 IPs")))
+	})
+
+	It("does not check nodegroups which are scaled in", func() {
+		ng := &api.NodeGroupBase{
+			Name:          "my-ng",
+			ScalingConfig: &api.ScalingConfig{DesiredCapacity: aws.Int(1)},
+		}
+		Expect(m.CheckScaleSubnetCapacity(context.Background(), ng)).To(Succeed())
+		p.MockEC2().AssertNotCalled(GinkgoT(), "DescribeSubnets", mock.Anything, mock.Anything)
+	})
+
+	It("does not refuse scaling when the nodegroup cannot be looked up", func() {
+		p = mockprovider.NewMockProvider()
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fake.NewSimpleClientset(), nil)
+		fakeStackManager := new(fakes.FakeStackManager)
+		fakeStackManager.DescribeNodeGroupStacksAndResourcesReturns(nil, errors.New("no stacks"))
+		m.SetStackManager(fakeStackManager)
+
+		ng := &api.NodeGroupBase{
+			Name:          "my-ng",
+			ScalingConfig: &api.ScalingConfig{DesiredCapacity: aws.Int(6)},
+		}
+		Expect(m.CheckScaleSubnetCapacity(context.Background(), ng)).To(Succeed())
+	})
+})
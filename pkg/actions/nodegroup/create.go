@@ -40,6 +40,7 @@ type CreateOpts struct {
 	SkipOutdatedAddonsCheck   bool
 	ConfigFileProvided        bool
 	Parallelism               int
+	SkipSubnetCapacityCheck   bool
 }
 
 type DryRunSettings struct {
@@ -94,7 +95,7 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		}
 		isOwnedCluster = false
 		if len(cfg.NodeGroups) > 0 {
-			skipEgressRules, err = validateSecurityGroup(ctx, ctl.AWSProvider.EC2(), cfg.VPC.SecurityGroup)
+			skipEgressRules, err = validateSecurityGroup(ctx, ctl.AWSProvider.EC2(), cfg.VPC)
 			if err != nil {
 				return err
 			}
@@ -183,6 +184,12 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		return cmdutils.PrintNodeGroupDryRunConfig(clusterConfigCopy, options.DryRunSettings.OutStream)
 	}
 
+	if !options.SkipSubnetCapacityCheck {
+		if err := m.checkSubnetCapacity(ctx, nodes.ToNodePools(cfg)); err != nil {
+			return fmt.Errorf("%w; use --skip-subnet-capacity-check to create the nodegroups anyway", err)
+		}
+	}
+
 	if err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism); err != nil {
 		return err
 	}
@@ -367,7 +374,8 @@ func loadVPCFromConfig(ctx context.Context, provider api.ClusterProvider, cfg *a
 	return cfg.CanUseForPrivateNodeGroups()
 }
 
-func validateSecurityGroup(ctx context.Context, ec2API awsapi.EC2, securityGroupID string) (hasDefaultEgressRule bool, err error) {
+func validateSecurityGroup(ctx context.Context, ec2API awsapi.EC2, vpc *api.ClusterVPC) (hasDefaultEgressRule bool, err error) {
+	securityGroupID := vpc.SecurityGroup
 	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(ec2API, &ec2.DescribeSecurityGroupRulesInput{
 		Filters: []ec2types.Filter{
 			{
@@ -394,6 +402,12 @@ func validateSecurityGroup(ctx context.Context, ec2API awsapi.EC2, securityGroup
 		return aws.ToString(sgRule.IpProtocol) == "-1" && aws.ToInt32(sgRule.FromPort) == -1 && aws.ToInt32(sgRule.ToPort) == -1 && aws.ToString(sgRule.CidrIpv4) == "0.0.0.0/0"
 	}
 
+	// rules attached by eksctl for nodegroups created with either security group policy
+	eksctlEgressRules := append([]builder.PartialEgressRule{}, builder.ControlPlaneNodeGroupEgressRules...)
+	strictVPC := *vpc
+	strictVPC.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+	eksctlEgressRules = append(eksctlEgressRules, builder.ControlPlaneToNodeRules(&strictVPC)...)
+
 	for _, sgRule := range sgRules {
 		if !aws.ToBool(sgRule.IsEgress) {
 			continue
@@ -406,7 +420,7 @@ func validateSecurityGroup(ctx context.Context, ec2API awsapi.EC2, securityGroup
 			return false, makeError(aws.ToString(sgRule.SecurityGroupRuleId))
 		}
 		matched := false
-		for _, egressRule := range builder.ControlPlaneNodeGroupEgressRules {
+		for _, egressRule := range eksctlEgressRules {
 			if aws.ToString(sgRule.IpProtocol) == egressRule.IPProtocol &&
 				aws.ToInt32(sgRule.FromPort) == int32(egressRule.FromPort) &&
 				aws.ToInt32(sgRule.ToPort) == int32(egressRule.ToPort) {
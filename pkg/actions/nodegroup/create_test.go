@@ -944,32 +944,36 @@ func mockProviderWithConfig(p *mockprovider.MockProvider, describeStacksOutput [
 	}
 
 	subnetPublic1 := ec2types.Subnet{
-		SubnetId:            aws.String("subnet-public-1"),
-		CidrBlock:           aws.String("192.168.64.0/20"),
-		AvailabilityZone:    aws.String("us-west-2a"),
-		VpcId:               aws.String("vpc-1"),
-		MapPublicIpOnLaunch: aws.Bool(true),
+		SubnetId:                aws.String("subnet-public-1"),
+		CidrBlock:               aws.String("192.168.64.0/20"),
+		AvailabilityZone:        aws.String("us-west-2a"),
+		VpcId:                   aws.String("vpc-1"),
+		MapPublicIpOnLaunch:     aws.Bool(true),
+		AvailableIpAddressCount: aws.Int32(4091),
 	}
 	subnetPrivate1 := ec2types.Subnet{
-		SubnetId:            aws.String("subnet-private-1"),
-		CidrBlock:           aws.String("192.168.128.0/20"),
-		AvailabilityZone:    aws.String("us-west-2a"),
-		VpcId:               aws.String("vpc-1"),
-		MapPublicIpOnLaunch: aws.Bool(false),
+		SubnetId:                aws.String("subnet-private-1"),
+		CidrBlock:               aws.String("192.168.128.0/20"),
+		AvailabilityZone:        aws.String("us-west-2a"),
+		VpcId:                   aws.String("vpc-1"),
+		MapPublicIpOnLaunch:     aws.Bool(false),
+		AvailableIpAddressCount: aws.Int32(4091),
 	}
 	subnetPublic2 := ec2types.Subnet{
-		SubnetId:            aws.String("subnet-public-2"),
-		CidrBlock:           aws.String("192.168.80.0/20"),
-		AvailabilityZone:    aws.String("us-west-2b"),
-		VpcId:               aws.String("vpc-1"),
-		MapPublicIpOnLaunch: aws.Bool(true),
+		SubnetId:                aws.String("subnet-public-2"),
+		CidrBlock:               aws.String("192.168.80.0/20"),
+		AvailabilityZone:        aws.String("us-west-2b"),
+		VpcId:                   aws.String("vpc-1"),
+		MapPublicIpOnLaunch:     aws.Bool(true),
+		AvailableIpAddressCount: aws.Int32(4091),
 	}
 	subnetPrivate2 := ec2types.Subnet{
-		SubnetId:            aws.String("subnet-private-2"),
-		CidrBlock:           aws.String("192.168.32.0/20"),
-		AvailabilityZone:    aws.String("us-west-2b"),
-		VpcId:               aws.String("vpc-1"),
-		MapPublicIpOnLaunch: aws.Bool(false),
+		SubnetId:                aws.String("subnet-private-2"),
+		CidrBlock:               aws.String("192.168.32.0/20"),
+		AvailabilityZone:        aws.String("us-west-2b"),
+		VpcId:                   aws.String("vpc-1"),
+		MapPublicIpOnLaunch:     aws.Bool(false),
+		AvailableIpAddressCount: aws.Int32(4091),
 	}
 
 	subnetsForID := map[string]ec2types.Subnet{
@@ -1004,6 +1008,8 @@ func mockProviderWithConfig(p *mockprovider.MockProvider, describeStacksOutput [
 	mockDescribeSubnets(p, "", subnets.privateIDs)
 	mockDescribeSubnets(p, "vpc-1", append(subnets.publicIDs, subnets.privateIDs...))
 
+	mockSubnetCapacity(p)
+
 	p.MockEC2().On("DescribeVpcs", mock.Anything, mock.Anything).Return(&ec2.DescribeVpcsOutput{
 		Vpcs: []ec2types.Vpc{
 			{
@@ -1019,7 +1025,26 @@ func mockProviderWithConfig(p *mockprovider.MockProvider, describeStacksOutput [
 	}, nil)
 }
 
+func mockSubnetCapacity(p *mockprovider.MockProvider) {
+	p.MockEKS().On("DescribeAddon", mock.Anything, &awseks.DescribeAddonInput{
+		ClusterName: aws.String("my-cluster"),
+		AddonName:   aws.String(api.VPCCNIAddon),
+	}).Return(nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")})
+	p.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []ec2types.InstanceTypeInfo{
+			{
+				InstanceType: "m5.large",
+				NetworkInfo: &ec2types.NetworkInfo{
+					MaximumNetworkInterfaces:  aws.Int32(3),
+					Ipv4AddressesPerInterface: aws.Int32(10),
+				},
+			},
+		},
+	}, nil)
+}
+
 func mockProviderForUnownedCluster(p *mockprovider.MockProvider, k *eksfakes.FakeKubeProvider, extraSGRules ...ec2types.SecurityGroupRule) {
+	mockSubnetCapacity(p)
 	k.NewRawClientReturns(&kubernetes.RawClient{}, nil)
 	k.ServerVersionReturns("1.27", nil)
 	p.MockCloudFormation().On("ListStacks", mock.Anything, mock.Anything, mock.Anything).Return(&cloudformation.ListStacksOutput{
@@ -1056,16 +1081,18 @@ func mockProviderForUnownedCluster(p *mockprovider.MockProvider, k *eksfakes.Fak
 	p.MockEC2().On("DescribeSubnets", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{
 		Subnets: []ec2types.Subnet{
 			{
-				SubnetId:         aws.String("subnet-custom1"),
-				CidrBlock:        aws.String("192.168.160.0/19"),
-				AvailabilityZone: aws.String("us-west-2a"),
-				VpcId:            vpcID,
+				SubnetId:                aws.String("subnet-custom1"),
+				CidrBlock:               aws.String("192.168.160.0/19"),
+				AvailabilityZone:        aws.String("us-west-2a"),
+				VpcId:                   vpcID,
+				AvailableIpAddressCount: aws.Int32(8187),
 			},
 			{
-				SubnetId:         aws.String("subnet-custom2"),
-				CidrBlock:        aws.String("192.168.96.0/19"),
-				AvailabilityZone: aws.String("us-west-2b"),
-				VpcId:            vpcID,
+				SubnetId:                aws.String("subnet-custom2"),
+				CidrBlock:               aws.String("192.168.96.0/19"),
+				AvailabilityZone:        aws.String("us-west-2b"),
+				VpcId:                   vpcID,
+				AvailableIpAddressCount: aws.Int32(8187),
 			},
 		},
 	}, nil)
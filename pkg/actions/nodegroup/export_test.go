@@ -1,6 +1,10 @@
 package nodegroup
 
 import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/eks"
 )
@@ -21,3 +25,11 @@ func (m *Manager) GetStackManager() manager.StackManager {
 func (m *Manager) MockKubeProvider(k eks.KubeProvider) {
 	m.ctl.KubeProvider = k
 }
+
+func (m *Migrator) EvictedPodOwners(ctx context.Context, kubeNodeGroup eks.KubeNodeGroup) (map[types.UID]bool, error) {
+	return m.evictedPodOwners(ctx, kubeNodeGroup)
+}
+
+func (m *Migrator) WaitForPendingPods(ctx context.Context, owners map[types.UID]bool) error {
+	return m.waitForPendingPods(ctx, owners)
+}
@@ -43,6 +43,16 @@ type Summary struct {
 	AutoScalingGroupName string
 	Version              string
 	NodeGroupType        api.NodeGroupType `json:"Type"`
+	// ReleaseVersion and HealthIssues are only set for managed nodegroups
+	ReleaseVersion string        `json:",omitempty"`
+	HealthIssues   []HealthIssue `json:",omitempty"`
+}
+
+// HealthIssue is an issue reported by EKS in the health of a managed nodegroup
+type HealthIssue struct {
+	Code        string
+	Message     string
+	ResourceIDs []string
 }
 
 func (m *Manager) GetAll(ctx context.Context) ([]*Summary, error) {
@@ -316,9 +326,26 @@ func (m *Manager) getManagedSummary(ctx context.Context, nodeGroupName string) (
 		AutoScalingGroupName: strings.Join(asgs, ","),
 		Version:              getOptionalValue(ng.Version),
 		NodeGroupType:        api.NodeGroupTypeManaged,
+		ReleaseVersion:       aws.ToString(ng.ReleaseVersion),
+		HealthIssues:         getHealthIssues(ng.Health),
 	}, nil
 }
 
+func getHealthIssues(health *ekstypes.NodegroupHealth) []HealthIssue {
+	if health == nil {
+		return nil
+	}
+	var issues []HealthIssue
+	for _, issue := range health.Issues {
+		issues = append(issues, HealthIssue{
+			Code:        string(issue.Code),
+			Message:     aws.ToString(issue.Message),
+			ResourceIDs: issue.ResourceIds,
+		})
+	}
+	return issues
+}
+
 func (m *Manager) getInstanceTypes(ctx context.Context, ng *ekstypes.Nodegroup) string {
 	if len(ng.InstanceTypes) > 0 {
 		return strings.Join(ng.InstanceTypes, ",")
@@ -302,6 +302,15 @@ var _ = Describe("Get", func() {
 							CreatedAt:      &t,
 							NodeRole:       aws.String("node-role"),
 							ReleaseVersion: aws.String("ami-custom"),
+							Health: &ekstypes.NodegroupHealth{
+								Issues: []ekstypes.Issue{
+									{
+										Code:        ekstypes.NodegroupIssueCodeAsgInstanceLaunchFailures,
+										Message:     aws.String("instance launch failed"),
+										ResourceIds: []string{"asg-1"},
+									},
+								},
+							},
 							Resources: &ekstypes.NodegroupResources{
 								AutoScalingGroups: []ekstypes.AutoScalingGroup{
 									{
@@ -342,6 +351,14 @@ var _ = Describe("Get", func() {
 						AutoScalingGroupName: "asg-1,asg-2",
 						Version:              "1.18",
 						NodeGroupType:        api.NodeGroupTypeManaged,
+						ReleaseVersion:       "ami-custom",
+						HealthIssues: []nodegroup.HealthIssue{
+							{
+								Code:        "AsgInstanceLaunchFailures",
+								Message:     "instance launch failed",
+								ResourceIDs: []string{"asg-1"},
+							},
+						},
 					}))
 				})
 			})
@@ -0,0 +1,530 @@
+package nodegroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	kubeeks "github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+)
+
+// MigrationTarget is the node provisioner a nodegroup is migrated to.
+type MigrationTarget string
+
+const (
+	// MigrationTargetKarpenter migrates a nodegroup to a Karpenter NodePool.
+	MigrationTargetKarpenter MigrationTarget = "karpenter"
+	// MigrationTargetAutoMode migrates a nodegroup to an EKS Auto Mode NodePool.
+	MigrationTargetAutoMode MigrationTarget = "automode"
+)
+
+const (
+	instanceTypeLabel = "node.kubernetes.io/instance-type"
+	archLabel         = "kubernetes.io/arch"
+
+	unmanagedLaunchTemplateDataPath  = resourcesRootPath + ".NodeGroupLaunchTemplate.Properties.LaunchTemplateData"
+	unmanagedNodeGroupPropertiesPath = resourcesRootPath + ".NodeGroup.Properties"
+)
+
+// systemLabelDomains are label and taint domains managed by Kubernetes, EKS, eksctl or Karpenter
+// that are not carried over to NodePools.
+var systemLabelDomains = []string{
+	"kubernetes.io",
+	"k8s.io",
+	"eks.amazonaws.com",
+	"eksctl.io",
+	"karpenter.sh",
+	"karpenter.k8s.aws",
+}
+
+// MigrationSpec holds the settings of a nodegroup that are carried over to a NodePool.
+type MigrationSpec struct {
+	Name          string
+	InstanceTypes []string
+	// CapacityType is the Karpenter capacity type, `on-demand` or `spot`.
+	CapacityType string
+	Architecture string
+	// AMIFamily is the Karpenter AMI family.
+	AMIFamily  string
+	Labels     map[string]string
+	Taints     []api.NodeGroupTaint
+	VolumeSize *int
+	VolumeType *string
+	SubnetIDs  []string
+}
+
+// GetMigrationSpec describes the nodegroup and its nodes to determine the settings of an equivalent NodePool.
+func (m *Manager) GetMigrationSpec(ctx context.Context, kubeNodeGroup kubeeks.KubeNodeGroup, nodeGroupType api.NodeGroupType) (*MigrationSpec, error) {
+	var (
+		spec *MigrationSpec
+		err  error
+	)
+	switch nodeGroupType {
+	case api.NodeGroupTypeManaged, api.NodeGroupTypeUnowned:
+		spec, err = m.getManagedMigrationSpec(ctx, kubeNodeGroup.NameString())
+	case api.NodeGroupTypeUnmanaged:
+		spec, err = m.getUnmanagedMigrationSpec(ctx, kubeNodeGroup.NameString())
+	default:
+		return nil, fmt.Errorf("unsupported nodegroup type %q", nodeGroupType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := m.clientSet.CoreV1().Nodes().List(ctx, kubeNodeGroup.ListOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes of nodegroup %q: %w", spec.Name, err)
+	}
+	if err := spec.fillFromNodes(nodes.Items); err != nil {
+		return nil, err
+	}
+	if len(spec.InstanceTypes) == 0 {
+		return nil, fmt.Errorf("could not determine the instance types of nodegroup %q", spec.Name)
+	}
+	return spec, nil
+}
+
+func (m *Manager) getManagedMigrationSpec(ctx context.Context, name string) (*MigrationSpec, error) {
+	output, err := m.ctl.AWSProvider.EKS().DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(m.cfg.Metadata.Name),
+		NodegroupName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+	}
+	ng := output.Nodegroup
+
+	spec := &MigrationSpec{
+		Name:          name,
+		InstanceTypes: ng.InstanceTypes,
+		Labels:        withoutSystemLabels(ng.Labels),
+		SubnetIDs:     ng.Subnets,
+	}
+	if len(spec.InstanceTypes) == 0 {
+		if instanceType := m.getInstanceTypes(ctx, ng); instanceType != "-" {
+			spec.InstanceTypes = strings.Split(instanceType, ",")
+		}
+	}
+	if ng.DiskSize != nil {
+		spec.VolumeSize = aws.Int(int(*ng.DiskSize))
+	}
+
+	switch ng.CapacityType {
+	case ekstypes.CapacityTypesSpot:
+		spec.CapacityType = "spot"
+	case ekstypes.CapacityTypesOnDemand, "":
+		spec.CapacityType = "on-demand"
+	default:
+		return nil, fmt.Errorf("nodegroup %q uses capacity type %q, which cannot be migrated", name, ng.CapacityType)
+	}
+
+	amiType := string(ng.AmiType)
+	switch {
+	case strings.HasPrefix(amiType, "AL2023"):
+		spec.AMIFamily = api.KarpenterAMIFamilyAL2023
+	case strings.HasPrefix(amiType, "AL2_"):
+		spec.AMIFamily = api.KarpenterAMIFamilyAL2
+	case strings.HasPrefix(amiType, "BOTTLEROCKET"):
+		spec.AMIFamily = api.KarpenterAMIFamilyBottlerocket
+	case strings.HasPrefix(amiType, "WINDOWS"):
+		return nil, fmt.Errorf("nodegroup %q uses Windows AMI type %q, which cannot be migrated", name, amiType)
+	}
+	if ng.AmiType != ekstypes.AMITypesCustom && amiType != "" {
+		spec.Architecture = "amd64"
+		if strings.Contains(amiType, "ARM_64") {
+			spec.Architecture = "arm64"
+		}
+	}
+
+	for _, t := range ng.Taints {
+		if isSystemLabel(aws.ToString(t.Key)) {
+			continue
+		}
+		spec.Taints = append(spec.Taints, api.NodeGroupTaint{
+			Key:    aws.ToString(t.Key),
+			Value:  aws.ToString(t.Value),
+			Effect: mapEKSTaintEffect(t.Effect),
+		})
+	}
+	return spec, nil
+}
+
+func (m *Manager) getUnmanagedMigrationSpec(ctx context.Context, name string) (*MigrationSpec, error) {
+	stack, err := m.stackManager.DescribeNodeGroupStack(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("describing stack for nodegroup %q: %w", name, err)
+	}
+	template, err := m.stackManager.GetStackTemplate(ctx, *stack.StackName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CloudFormation template for stack %s: %w", *stack.StackName, err)
+	}
+
+	spec := &MigrationSpec{
+		Name:         name,
+		CapacityType: "on-demand",
+	}
+	if instanceType := gjson.Get(template, unmanagedLaunchTemplateDataPath+".InstanceType").String(); instanceType != "" {
+		spec.InstanceTypes = []string{instanceType}
+	}
+	mixedInstancesPolicy := gjson.Get(template, unmanagedNodeGroupPropertiesPath+".MixedInstancesPolicy")
+	if mixedInstancesPolicy.Exists() {
+		for _, instanceType := range mixedInstancesPolicy.Get("LaunchTemplate.Overrides.#.InstanceType").Array() {
+			spec.InstanceTypes = append(spec.InstanceTypes, instanceType.String())
+		}
+		if onDemand := mixedInstancesPolicy.Get("InstancesDistribution.OnDemandPercentageAboveBaseCapacity"); onDemand.Exists() && onDemand.Int() == 0 {
+			spec.CapacityType = "spot"
+		}
+	}
+
+	ebs := gjson.Get(template, unmanagedLaunchTemplateDataPath+".BlockDeviceMappings.0.Ebs")
+	if volumeSize := ebs.Get("VolumeSize"); volumeSize.Exists() {
+		spec.VolumeSize = aws.Int(int(volumeSize.Int()))
+	}
+	if volumeType := ebs.Get("VolumeType"); volumeType.Exists() {
+		spec.VolumeType = aws.String(volumeType.String())
+	}
+
+	// subnets imported from other stacks are not plain strings and are left to the NodePool defaults.
+	for _, subnet := range gjson.Get(template, unmanagedNodeGroupPropertiesPath+".VPCZoneIdentifier").Array() {
+		if subnet.Type == gjson.String {
+			spec.SubnetIDs = append(spec.SubnetIDs, subnet.String())
+		}
+	}
+	return spec, nil
+}
+
+// fillFromNodes fills the settings that are not available from the nodegroup's definition from its nodes.
+func (s *MigrationSpec) fillFromNodes(nodes []corev1.Node) error {
+	if len(nodes) == 0 {
+		if s.AMIFamily == "" {
+			logger.Warning("nodegroup %q has no nodes, defaulting to AMI family %s", s.Name, api.KarpenterAMIFamilyAL2023)
+			s.AMIFamily = api.KarpenterAMIFamilyAL2023
+		}
+		return nil
+	}
+	node := nodes[0]
+
+	if s.AMIFamily == "" {
+		osImage := node.Status.NodeInfo.OSImage
+		switch {
+		case strings.Contains(osImage, "Bottlerocket"):
+			s.AMIFamily = api.KarpenterAMIFamilyBottlerocket
+		case strings.Contains(osImage, "Amazon Linux 2023"):
+			s.AMIFamily = api.KarpenterAMIFamilyAL2023
+		case strings.Contains(osImage, "Amazon Linux 2"):
+			s.AMIFamily = api.KarpenterAMIFamilyAL2
+		case strings.Contains(osImage, "Windows"):
+			return fmt.Errorf("nodegroup %q runs Windows nodes, which cannot be migrated", s.Name)
+		default:
+			logger.Warning("could not determine the AMI family of nodegroup %q from OS image %q, defaulting to %s", s.Name, osImage, api.KarpenterAMIFamilyAL2023)
+			s.AMIFamily = api.KarpenterAMIFamilyAL2023
+		}
+	}
+	if s.Architecture == "" {
+		s.Architecture = node.Status.NodeInfo.Architecture
+	}
+	if len(s.InstanceTypes) == 0 {
+		if instanceType := node.Labels[instanceTypeLabel]; instanceType != "" {
+			s.InstanceTypes = []string{instanceType}
+		}
+	}
+
+	// labels and taints of managed nodegroups are known from the EKS API; for unmanaged
+	// nodegroups they are only set on the nodes by kubelet.
+	if s.Labels == nil {
+		s.Labels = withoutSystemLabels(node.Labels)
+	}
+	if s.Taints == nil {
+		for _, t := range node.Spec.Taints {
+			if !isSystemLabel(t.Key) {
+				s.Taints = append(s.Taints, api.NodeGroupTaint{
+					Key:    t.Key,
+					Value:  t.Value,
+					Effect: t.Effect,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (s *MigrationSpec) requirements() []api.KarpenterRequirement {
+	requirements := []api.KarpenterRequirement{
+		{
+			Key:      instanceTypeLabel,
+			Operator: "In",
+			Values:   s.InstanceTypes,
+		},
+		{
+			Key:      api.KarpenterCapacityTypeLabel,
+			Operator: "In",
+			Values:   []string{s.CapacityType},
+		},
+	}
+	if s.Architecture != "" {
+		requirements = append(requirements, api.KarpenterRequirement{
+			Key:      archLabel,
+			Operator: "In",
+			Values:   []string{s.Architecture},
+		})
+	}
+	return requirements
+}
+
+// ToKarpenterNodePool translates the nodegroup into a Karpenter NodePool and EC2NodeClass named after the nodegroup.
+func (s *MigrationSpec) ToKarpenterNodePool() (*api.KarpenterNodePool, *api.KarpenterNodeClass) {
+	nodeClass := &api.KarpenterNodeClass{
+		Name:       s.Name,
+		AMIFamily:  s.AMIFamily,
+		VolumeSize: s.VolumeSize,
+		VolumeType: s.VolumeType,
+	}
+	if nodeClass.VolumeSize == nil {
+		nodeClass.VolumeSize = aws.Int(api.DefaultNodeVolumeSize)
+	}
+	if nodeClass.VolumeType == nil {
+		nodeClass.VolumeType = aws.String(api.DefaultNodeVolumeType)
+	}
+	for _, subnetID := range s.SubnetIDs {
+		nodeClass.SubnetSelectorTerms = append(nodeClass.SubnetSelectorTerms, api.KarpenterSelectorTerm{ID: subnetID})
+	}
+	return s.nodePool(), nodeClass
+}
+
+// ToAutoModeNodePool translates the nodegroup into an EKS Auto Mode NodePool and NodeClass named after the nodegroup.
+// Auto Mode manages the node AMI, so the AMI family of the nodegroup is not carried over.
+func (s *MigrationSpec) ToAutoModeNodePool(nodeRoleName string) (*api.KarpenterNodePool, karpenter.AutoModeNodeClass) {
+	return s.nodePool(), karpenter.AutoModeNodeClass{
+		Name:                 s.Name,
+		Role:                 nodeRoleName,
+		SubnetIDs:            s.SubnetIDs,
+		EphemeralStorageSize: s.VolumeSize,
+	}
+}
+
+func (s *MigrationSpec) nodePool() *api.KarpenterNodePool {
+	return &api.KarpenterNodePool{
+		Name:         s.Name,
+		NodeClass:    s.Name,
+		Requirements: s.requirements(),
+		Labels:       s.Labels,
+		Taints:       s.Taints,
+	}
+}
+
+// MigrateOptions holds the options for migrating a nodegroup.
+type MigrateOptions struct {
+	Target MigrationTarget
+	// KarpenterVersion is the version of Karpenter installed in the cluster, required when migrating to Karpenter.
+	KarpenterVersion string
+	// AutoModeNodeRoleName is the name of the Auto Mode node role, required when migrating to Auto Mode.
+	AutoModeNodeRoleName string
+
+	// BatchSize is the number of nodes that are drained at the same time.
+	BatchSize             int
+	MaxGracePeriod        time.Duration
+	NodeDrainWaitPeriod   time.Duration
+	PodEvictionWaitPeriod time.Duration
+	DisableEviction       bool
+
+	Plan bool
+	Wait bool
+}
+
+// A Migrator moves the workloads of a nodegroup onto a NodePool and deletes the nodegroup.
+type Migrator struct {
+	ClusterConfig *api.ClusterConfig
+	ClientSet     kubernetes.Interface
+	DynamicClient dynamic.Interface
+	Deleter       *Deleter
+	PollInterval  time.Duration
+}
+
+// Migrate creates a NodePool equivalent to the nodegroup, waits for it to become ready, drains the
+// nodegroup in batches and deletes it once all evicted pods have been scheduled on the new capacity.
+func (m *Migrator) Migrate(ctx context.Context, spec *MigrationSpec, kubeNodeGroup kubeeks.KubeNodeGroup, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup, options MigrateOptions) error {
+	logger.Info("nodegroup %q: instance types %v, capacity type %s, AMI family %s, %d label(s), %d taint(s)",
+		spec.Name, spec.InstanceTypes, spec.CapacityType, spec.AMIFamily, len(spec.Labels), len(spec.Taints))
+	if options.Plan {
+		logger.Info("(plan) would create %s NodePool %q, drain nodegroup %q in batches of %d and delete it", options.Target, spec.Name, spec.Name, options.BatchSize)
+		return nil
+	}
+
+	nodePoolResource, err := m.createNodePool(ctx, spec, options)
+	if err != nil {
+		return err
+	}
+	if err := karpenter.WaitForNodePoolReady(ctx, m.DynamicClient, nodePoolResource, spec.Name, m.PollInterval); err != nil {
+		return err
+	}
+
+	owners, err := m.evictedPodOwners(ctx, kubeNodeGroup)
+	if err != nil {
+		return err
+	}
+	drainer := &Drainer{
+		ClientSet: m.ClientSet,
+	}
+	if err := drainer.Drain(ctx, &DrainInput{
+		NodeGroups:            []kubeeks.KubeNodeGroup{kubeNodeGroup},
+		MaxGracePeriod:        options.MaxGracePeriod,
+		NodeDrainWaitPeriod:   options.NodeDrainWaitPeriod,
+		PodEvictionWaitPeriod: options.PodEvictionWaitPeriod,
+		DisableEviction:       options.DisableEviction,
+		Parallel:              options.BatchSize,
+	}); err != nil {
+		return fmt.Errorf("%w; run `eksctl drain nodegroup --cluster %s --name %s --undo` to uncordon the remaining nodes", err, m.ClusterConfig.Metadata.Name, spec.Name)
+	}
+
+	if err := m.waitForPendingPods(ctx, owners); err != nil {
+		return fmt.Errorf("%w; nodegroup %q has not been deleted", err, spec.Name)
+	}
+
+	logger.Info("deleting nodegroup %q", spec.Name)
+	return m.Deleter.Delete(ctx, nodeGroups, managedNodeGroups, DeleteOptions{
+		Wait:                options.Wait,
+		UpdateAuthConfigMap: true,
+	})
+}
+
+func (m *Migrator) createNodePool(ctx context.Context, spec *MigrationSpec, options MigrateOptions) (schema.GroupVersionResource, error) {
+	switch options.Target {
+	case MigrationTargetKarpenter:
+		nodePool, nodeClass := spec.ToKarpenterNodePool()
+		cfg := *m.ClusterConfig
+		cfg.Karpenter = &api.Karpenter{
+			Version:     options.KarpenterVersion,
+			NodePools:   []*api.KarpenterNodePool{nodePool},
+			NodeClasses: []*api.KarpenterNodeClass{nodeClass},
+		}
+		if err := api.ValidateKarpenterNodePools(cfg.Karpenter); err != nil {
+			return schema.GroupVersionResource{}, fmt.Errorf("translating nodegroup %q: %w", spec.Name, err)
+		}
+		nodePoolResource, _, err := karpenter.NodePoolResources(options.KarpenterVersion)
+		if err != nil {
+			return schema.GroupVersionResource{}, err
+		}
+		if err := karpenter.NewNodePoolManager(m.DynamicClient, &cfg).Create(ctx); err != nil {
+			return schema.GroupVersionResource{}, err
+		}
+		return nodePoolResource, nil
+
+	case MigrationTargetAutoMode:
+		nodePool, nodeClass := spec.ToAutoModeNodePool(options.AutoModeNodeRoleName)
+		if err := karpenter.CreateAutoModeNodePool(ctx, m.DynamicClient, karpenter.NewAutoModeNodeClass(nodeClass), karpenter.NewAutoModeNodePool(nodePool)); err != nil {
+			return schema.GroupVersionResource{}, err
+		}
+		return karpenter.AutoModeNodePoolResource, nil
+
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("unsupported migration target %q", options.Target)
+	}
+}
+
+// evictedPodOwners returns the UIDs of the controllers of the pods the drain evicts from the nodes of the nodegroup,
+// as evicted pods are replaced by new pods of the same controllers. DaemonSet and mirror pods are not evicted.
+func (m *Migrator) evictedPodOwners(ctx context.Context, kubeNodeGroup kubeeks.KubeNodeGroup) (map[types.UID]bool, error) {
+	nodes, err := m.ClientSet.CoreV1().Nodes().List(ctx, kubeNodeGroup.ListOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes of nodegroup %q: %w", kubeNodeGroup.NameString(), err)
+	}
+	owners := map[types.UID]bool{}
+	for _, node := range nodes.Items {
+		pods, err := m.ClientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing pods of node %q: %w", node.Name, err)
+		}
+		for _, pod := range pods.Items {
+			owner := metav1.GetControllerOf(&pod)
+			if owner == nil || owner.Kind == "DaemonSet" || owner.Kind == "Node" {
+				continue
+			}
+			owners[owner.UID] = true
+		}
+	}
+	return owners, nil
+}
+
+// waitForPendingPods waits until the pods created by the given controllers to replace the pods evicted from
+// the nodegroup have been scheduled on the new capacity. Pending pods of other workloads are ignored.
+func (m *Migrator) waitForPendingPods(ctx context.Context, owners map[types.UID]bool) error {
+	if len(owners) == 0 {
+		return nil
+	}
+	for {
+		pods, err := m.ClientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: "status.phase=Pending,spec.nodeName=",
+		})
+		if err != nil {
+			return fmt.Errorf("listing pending pods: %w", err)
+		}
+		pending := 0
+		for _, pod := range pods.Items {
+			if owner := metav1.GetControllerOf(&pod); owner != nil && owners[owner.UID] {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return nil
+		}
+		logger.Info("waiting for %d pending pod(s) to be scheduled", pending)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %d pending pod(s) to be scheduled: %w", pending, ctx.Err())
+		case <-time.After(m.PollInterval):
+		}
+	}
+}
+
+// withoutSystemLabels returns a copy of labels without the labels managed by Kubernetes, EKS, eksctl or Karpenter.
+func withoutSystemLabels(labels map[string]string) map[string]string {
+	filtered := map[string]string{}
+	for k, v := range labels {
+		if !isSystemLabel(k) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+func isSystemLabel(key string) bool {
+	domain, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, d := range systemLabelDomains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapEKSTaintEffect(effect ekstypes.TaintEffect) corev1.TaintEffect {
+	switch effect {
+	case ekstypes.TaintEffectNoExecute:
+		return corev1.TaintEffectNoExecute
+	case ekstypes.TaintEffectPreferNoSchedule:
+		return corev1.TaintEffectPreferNoSchedule
+	default:
+		return corev1.TaintEffectNoSchedule
+	}
+}
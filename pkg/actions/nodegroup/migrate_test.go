@@ -0,0 +1,247 @@
+package nodegroup_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Migrate", func() {
+	const (
+		clusterName = "my-cluster"
+		ngName      = "my-nodegroup"
+	)
+
+	var (
+		p                *mockprovider.MockProvider
+		m                *nodegroup.Manager
+		fakeStackManager *fakes.FakeStackManager
+		fakeClientSet    *fake.Clientset
+	)
+
+	newNode := func(labels map[string]string, taints []corev1.Taint, osImage string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: labels,
+			},
+			Spec: corev1.NodeSpec{
+				Taints: taints,
+			},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{
+					OSImage:      osImage,
+					Architecture: "amd64",
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		p = mockprovider.NewMockProvider()
+		fakeClientSet = fake.NewSimpleClientset()
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fakeClientSet, nil)
+		fakeStackManager = new(fakes.FakeStackManager)
+		m.SetStackManager(fakeStackManager)
+	})
+
+	Context("managed nodegroup", func() {
+		BeforeEach(func() {
+			p.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String(ngName),
+			}).Return(&awseks.DescribeNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{
+					NodegroupName: aws.String(ngName),
+					InstanceTypes: []string{"m5.large", "m5a.large"},
+					AmiType:       ekstypes.AMITypesAl2023Arm64Standard,
+					CapacityType:  ekstypes.CapacityTypesSpot,
+					DiskSize:      aws.Int32(50),
+					Subnets:       []string{"subnet-1", "subnet-2"},
+					Labels: map[string]string{
+						"team":                        "platform",
+						api.ClusterNameLabel:          clusterName,
+						api.NodeGroupNameLabel:        ngName,
+						"eks.amazonaws.com/nodegroup": ngName,
+					},
+					Taints: []ekstypes.Taint{
+						{
+							Key:    aws.String("dedicated"),
+							Value:  aws.String("platform"),
+							Effect: ekstypes.TaintEffectNoExecute,
+						},
+					},
+				},
+			}, nil)
+		})
+
+		It("translates the nodegroup into a Karpenter NodePool", func() {
+			mng := &api.ManagedNodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: ngName}}
+			spec, err := m.GetMigrationSpec(context.Background(), mng, api.NodeGroupTypeManaged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Labels).To(Equal(map[string]string{"team": "platform"}))
+
+			nodePool, nodeClass := spec.ToKarpenterNodePool()
+			Expect(nodePool.Name).To(Equal(ngName))
+			Expect(nodePool.NodeClass).To(Equal(ngName))
+			Expect(nodePool.Labels).To(Equal(map[string]string{"team": "platform"}))
+			Expect(nodePool.Taints).To(ConsistOf(api.NodeGroupTaint{
+				Key:    "dedicated",
+				Value:  "platform",
+				Effect: corev1.TaintEffectNoExecute,
+			}))
+			Expect(nodePool.Requirements).To(ConsistOf(
+				api.KarpenterRequirement{Key: "node.kubernetes.io/instance-type", Operator: "In", Values: []string{"m5.large", "m5a.large"}},
+				api.KarpenterRequirement{Key: api.KarpenterCapacityTypeLabel, Operator: "In", Values: []string{"spot"}},
+				api.KarpenterRequirement{Key: "kubernetes.io/arch", Operator: "In", Values: []string{"arm64"}},
+			))
+
+			Expect(nodeClass.AMIFamily).To(Equal(api.KarpenterAMIFamilyAL2023))
+			Expect(*nodeClass.VolumeSize).To(Equal(50))
+			Expect(nodeClass.SubnetSelectorTerms).To(Equal([]api.KarpenterSelectorTerm{{ID: "subnet-1"}, {ID: "subnet-2"}}))
+		})
+
+		It("translates the nodegroup into an Auto Mode NodePool", func() {
+			mng := &api.ManagedNodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: ngName}}
+			spec, err := m.GetMigrationSpec(context.Background(), mng, api.NodeGroupTypeManaged)
+			Expect(err).NotTo(HaveOccurred())
+
+			nodePool, nodeClass := spec.ToAutoModeNodePool("auto-mode-node-role")
+			Expect(nodePool.NodeClass).To(Equal(ngName))
+			Expect(nodeClass.Role).To(Equal("auto-mode-node-role"))
+			Expect(nodeClass.SubnetIDs).To(Equal([]string{"subnet-1", "subnet-2"}))
+			Expect(*nodeClass.EphemeralStorageSize).To(Equal(50))
+		})
+	})
+
+	Context("unmanaged nodegroup", func() {
+		BeforeEach(func() {
+			fakeStackManager.DescribeNodeGroupStackReturns(&cftypes.Stack{
+				StackName: aws.String("eksctl-my-cluster-nodegroup-my-nodegroup"),
+			}, nil)
+			fakeStackManager.GetStackTemplateReturns(`{
+				"Resources": {
+					"NodeGroupLaunchTemplate": {
+						"Properties": {
+							"LaunchTemplateData": {
+								"InstanceType": "c5.xlarge",
+								"BlockDeviceMappings": [{"Ebs": {"VolumeSize": 100, "VolumeType": "gp3"}}]
+							}
+						}
+					},
+					"NodeGroup": {
+						"Properties": {
+							"VPCZoneIdentifier": ["subnet-1", {"Fn::ImportValue": "eksctl-my-cluster-cluster::SubnetsPrivate"}]
+						}
+					}
+				}
+			}`, nil)
+		})
+
+		It("reads labels, taints and the AMI family from the nodes", func() {
+			ng := &api.NodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: ngName}}
+			node := newNode(map[string]string{
+				api.NodeGroupNameLabel:             ngName,
+				"node.kubernetes.io/instance-type": "c5.xlarge",
+				"team":                             "data",
+			}, []corev1.Taint{
+				{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+			}, "Bottlerocket OS 1.20.0 (aws-k8s-1.30)")
+			_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			spec, err := m.GetMigrationSpec(context.Background(), ng, api.NodeGroupTypeUnmanaged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(Equal(&nodegroup.MigrationSpec{
+				Name:          ngName,
+				InstanceTypes: []string{"c5.xlarge"},
+				CapacityType:  "on-demand",
+				Architecture:  "amd64",
+				AMIFamily:     api.KarpenterAMIFamilyBottlerocket,
+				Labels:        map[string]string{"team": "data"},
+				Taints: []api.NodeGroupTaint{
+					{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				},
+				VolumeSize: aws.Int(100),
+				VolumeType: aws.String("gp3"),
+				SubnetIDs:  []string{"subnet-1"},
+			}))
+		})
+
+		It("fails for Windows nodes", func() {
+			ng := &api.NodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: ngName}}
+			node := newNode(map[string]string{api.NodeGroupNameLabel: ngName}, nil, "Windows Server 2022 Datacenter")
+			_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = m.GetMigrationSpec(context.Background(), ng, api.NodeGroupTypeUnmanaged)
+			Expect(err).To(MatchError(ContainSubstring("runs Windows nodes, which cannot be migrated")))
+		})
+	})
+	Context("waiting for evicted pods", func() {
+		var migrator *nodegroup.Migrator
+
+		newPod := func(name, nodeName, ownerKind string, ownerUID types.UID, phase corev1.PodPhase) {
+			_, err := fakeClientSet.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: ownerKind, Name: string(ownerUID), UID: ownerUID, Controller: aws.Bool(true)},
+					},
+				},
+				Spec:   corev1.PodSpec{NodeName: nodeName},
+				Status: corev1.PodStatus{Phase: phase},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			migrator = &nodegroup.Migrator{
+				ClientSet:    fakeClientSet,
+				PollInterval: time.Millisecond,
+			}
+		})
+
+		It("waits only for the replacements of pods evicted from the nodegroup", func() {
+			ng := &api.NodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: ngName}}
+			_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), newNode(map[string]string{api.NodeGroupNameLabel: ngName}, nil, "Amazon Linux 2023"), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			newPod("app-1", "node-1", "ReplicaSet", "app", corev1.PodRunning)
+			newPod("agent-1", "node-1", "DaemonSet", "agent", corev1.PodRunning)
+
+			owners, err := migrator.EvictedPodOwners(context.Background(), ng)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owners).To(Equal(map[types.UID]bool{"app": true}))
+
+			Expect(fakeClientSet.CoreV1().Pods("default").Delete(context.Background(), "app-1", metav1.DeleteOptions{})).To(Succeed())
+			Expect(fakeClientSet.CoreV1().Pods("default").Delete(context.Background(), "agent-1", metav1.DeleteOptions{})).To(Succeed())
+			newPod("unrelated", "", "ReplicaSet", "unrelated", corev1.PodPending)
+			Expect(migrator.WaitForPendingPods(context.Background(), owners)).To(Succeed())
+
+			newPod("app-2", "", "ReplicaSet", "app", corev1.PodPending)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			Expect(migrator.WaitForPendingPods(ctx, owners)).To(MatchError(ContainSubstring("timed out waiting for 1 pending pod(s) to be scheduled")))
+		})
+	})
+})
@@ -53,15 +53,9 @@ func (m *Manager) Scale(ctx context.Context, ng *api.NodeGroupBase, wait bool) e
 }
 
 func (m *Manager) scaleUnmanagedNodeGroup(ctx context.Context, ng *api.NodeGroupBase, stackInfo manager.StackInfo, wait bool) error {
-	asgName := ""
-	for _, resource := range stackInfo.Resources {
-		if *resource.LogicalResourceId == "NodeGroup" {
-			asgName = *resource.PhysicalResourceId
-			break
-		}
-	}
-	if asgName == "" {
-		return fmt.Errorf("failed to find NodeGroup auto scaling group")
+	asgName, err := getASGName(stackInfo)
+	if err != nil {
+		return err
 	}
 
 	if err := validateNodeGroupAMI(ctx, m.ctl.AWSProvider, asgName); err != nil {
@@ -153,6 +147,15 @@ func (m *Manager) scaleManagedNodeGroup(ctx context.Context, ng *api.NodeGroupBa
 	return nil
 }
 
+func getASGName(stackInfo manager.StackInfo) (string, error) {
+	for _, resource := range stackInfo.Resources {
+		if *resource.LogicalResourceId == "NodeGroup" {
+			return *resource.PhysicalResourceId, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find NodeGroup auto scaling group")
+}
+
 func validateNodeGroupAMI(ctx context.Context, awsProvider api.ClusterProvider, asgName string) error {
 	asg, err := awsProvider.ASG().DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
 		AutoScalingGroupNames: []string{asgName},
@@ -0,0 +1,210 @@
+package orphans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/kris-nova/logger"
+)
+
+// Action is the outcome of deleting an orphaned resource
+type Action string
+
+// Outcomes of deleting an orphaned resource
+const (
+	ActionDeleted         Action = "deleted"
+	ActionDeletionStarted Action = "deletion-started"
+	ActionDeletionFailed  Action = "deletion-failed"
+	ActionDeletionSkipped Action = "deletion-skipped"
+)
+
+// AuditRecord records the deletion of an orphaned resource
+type AuditRecord struct {
+	Time   time.Time `json:"time"`
+	Region string    `json:"region"`
+	Resource
+	Action Action `json:"action"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Delete deletes the orphaned resources, dependent resources first, and writes an audit record per resource
+// to auditTrail as a line of JSON. Deletion continues past failures, which are reported in the returned error.
+func (f *Finder) Delete(ctx context.Context, resources []Resource, auditTrail io.Writer) error {
+	resources = slices.Clone(resources)
+	sortResources(resources)
+
+	var (
+		failed int
+		stacks []Resource
+	)
+	audit := func(r Resource, action Action, err error) error {
+		record := AuditRecord{
+			Time:     time.Now().UTC(),
+			Region:   f.provider.Region(),
+			Resource: r,
+			Action:   action,
+		}
+		if err != nil {
+			failed++
+			record.Error = err.Error()
+			logger.Warning("failed to delete %s %q of cluster %q: %v", r.Kind, r.ID, r.Cluster, err)
+		} else {
+			logger.Info("%s: %s %q of cluster %q", action, r.Kind, r.ID, r.Cluster)
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(auditTrail, string(data)); err != nil {
+			return fmt.Errorf("writing audit trail: %w", err)
+		}
+		return nil
+	}
+
+	for _, r := range resources {
+		if r.Kind == KindStack {
+			stacks = append(stacks, r)
+			continue
+		}
+		err := f.deleteResource(ctx, r)
+		action := ActionDeleted
+		if err != nil {
+			action = ActionDeletionFailed
+		}
+		if err := audit(r, action, err); err != nil {
+			return err
+		}
+	}
+	if err := f.deleteStacks(ctx, stacks, audit); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d orphaned resource(s); resources that are still in use by other orphaned resources may be deleted by running the command again", failed, len(resources))
+	}
+	return nil
+}
+
+func (f *Finder) deleteResource(ctx context.Context, r Resource) error {
+	var err error
+	switch r.Kind {
+	case KindLoadBalancer:
+		_, err = f.provider.ELBV2().DeleteLoadBalancer(ctx, &elasticloadbalancingv2.DeleteLoadBalancerInput{
+			LoadBalancerArn: aws.String(r.ID),
+		})
+	case KindClassicLoadBalancer:
+		_, err = f.provider.ELB().DeleteLoadBalancer(ctx, &elasticloadbalancing.DeleteLoadBalancerInput{
+			LoadBalancerName: aws.String(r.ID),
+		})
+	case KindNetworkInterface:
+		_, err = f.provider.EC2().DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(r.ID),
+		})
+	case KindVolume:
+		_, err = f.provider.EC2().DeleteVolume(ctx, &ec2.DeleteVolumeInput{
+			VolumeId: aws.String(r.ID),
+		})
+	case KindSecurityGroup:
+		_, err = f.provider.EC2().DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{
+			GroupId: aws.String(r.ID),
+		})
+	case KindLogGroup:
+		_, err = f.provider.CloudWatchLogs().DeleteLogGroup(ctx, &cloudwatchlogs.DeleteLogGroupInput{
+			LogGroupName: aws.String(r.ID),
+		})
+	case KindOIDCProvider:
+		_, err = f.provider.IAM().DeleteOpenIDConnectProvider(ctx, &iam.DeleteOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(r.ID),
+		})
+	default:
+		err = fmt.Errorf("unexpected resource kind %q", r.Kind)
+	}
+	return err
+}
+
+// deleteStacks deletes the stacks of each cluster, waiting for the deletion of nodegroup, addon and other stacks
+// before starting the deletion of the cluster stack, whose resources they depend on
+func (f *Finder) deleteStacks(ctx context.Context, stacks []Resource, audit func(Resource, Action, error) error) error {
+	var (
+		clusters      []string
+		clusterStacks = map[string]*Resource{}
+		dependents    = map[string][]Resource{}
+	)
+	for i, stack := range stacks {
+		if !slices.Contains(clusters, stack.Cluster) {
+			clusters = append(clusters, stack.Cluster)
+		}
+		if stack.ID == clusterStackName(stack.Cluster) {
+			clusterStacks[stack.Cluster] = &stacks[i]
+		} else {
+			dependents[stack.Cluster] = append(dependents[stack.Cluster], stack)
+		}
+	}
+
+	for _, clusterName := range clusters {
+		var dependentsFailed bool
+		for _, stack := range dependents[clusterName] {
+			err := f.deleteStack(ctx, stack.ID)
+			if err == nil {
+				err = cloudformation.NewStackDeleteCompleteWaiter(f.provider.CloudFormation()).Wait(ctx, &cloudformation.DescribeStacksInput{
+					StackName: aws.String(stack.ID),
+				}, f.provider.WaitTimeout())
+			}
+			action := ActionDeleted
+			if err != nil {
+				dependentsFailed = true
+				action = ActionDeletionFailed
+			}
+			if err := audit(stack, action, err); err != nil {
+				return err
+			}
+		}
+
+		clusterStack := clusterStacks[clusterName]
+		if clusterStack == nil {
+			continue
+		}
+		if dependentsFailed {
+			if err := audit(*clusterStack, ActionDeletionSkipped, errors.New("other stacks of the cluster could not be deleted")); err != nil {
+				return err
+			}
+			continue
+		}
+		err := f.deleteStack(ctx, clusterStack.ID)
+		action := ActionDeletionStarted
+		if err != nil {
+			action = ActionDeletionFailed
+		}
+		if err := audit(*clusterStack, action, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Finder) deleteStack(ctx context.Context, stackName string) error {
+	input := &cloudformation.DeleteStackInput{
+		StackName: aws.String(stackName),
+	}
+	if roleARN := f.provider.CloudFormationRoleARN(); roleARN != "" {
+		input.RoleARN = aws.String(roleARN)
+	}
+	_, err := f.provider.CloudFormation().DeleteStack(ctx, input)
+	return err
+}
+
+func clusterStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-cluster", clusterName)
+}
@@ -0,0 +1,344 @@
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// describeTagsBatchSize is the maximum number of load balancers per DescribeTags call
+	describeTagsBatchSize = 20
+	logGroupPrefix        = "/aws/eks/"
+	logGroupSuffix        = "/cluster"
+	pvcNamespaceTag       = "kubernetes.io/created-for/pvc/namespace"
+	pvcNameTag            = "kubernetes.io/created-for/pvc/name"
+)
+
+// listEksctlStacks returns the stacks created by eksctl
+func (f *Finder) listEksctlStacks(ctx context.Context) ([]cfntypes.Stack, error) {
+	var stacks []cfntypes.Stack
+	paginator := cloudformation.NewDescribeStacksPaginator(f.provider.CloudFormation(), &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing stacks: %w", err)
+		}
+		for _, stack := range output.Stacks {
+			if clusterNameFromStack(stack) != "" {
+				stacks = append(stacks, stack)
+			}
+		}
+	}
+	return stacks, nil
+}
+
+func clusterNameFromStack(stack cfntypes.Stack) string {
+	for _, tag := range stack.Tags {
+		switch aws.ToString(tag.Key) {
+		case api.ClusterNameTag, api.OldClusterNameTag:
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+func findStacks(stacks []cfntypes.Stack, isOrphan func(string) bool) []Resource {
+	var resources []Resource
+	for _, stack := range stacks {
+		if clusterName := clusterNameFromStack(stack); isOrphan(clusterName) {
+			resources = append(resources, Resource{
+				Cluster: clusterName,
+				Kind:    KindStack,
+				ID:      aws.ToString(stack.StackName),
+				Details: string(stack.StackStatus),
+			})
+		}
+	}
+	return resources
+}
+
+func (f *Finder) findLoadBalancers(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	loadBalancers := map[string]string{}
+	var arns []string
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(f.provider.ELBV2(), &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing load balancers: %w", err)
+		}
+		for _, lb := range output.LoadBalancers {
+			arn := aws.ToString(lb.LoadBalancerArn)
+			loadBalancers[arn] = fmt.Sprintf("%s load balancer %s in %s", lb.Type, aws.ToString(lb.LoadBalancerName), aws.ToString(lb.VpcId))
+			arns = append(arns, arn)
+		}
+	}
+
+	var resources []Resource
+	for start := 0; start < len(arns); start += describeTagsBatchSize {
+		batch := arns[start:min(start+describeTagsBatchSize, len(arns))]
+		output, err := f.provider.ELBV2().DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{
+			ResourceArns: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing tags of load balancers: %w", err)
+		}
+		for _, description := range output.TagDescriptions {
+			tags := map[string]string{}
+			for _, tag := range description.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			arn := aws.ToString(description.ResourceArn)
+			if clusterName := clusterNameFromTags(tags); isOrphan(clusterName) {
+				resources = append(resources, Resource{
+					Cluster: clusterName,
+					Kind:    KindLoadBalancer,
+					ID:      arn,
+					Details: loadBalancers[arn],
+				})
+			}
+		}
+	}
+	return resources, nil
+}
+
+func (f *Finder) findClassicLoadBalancers(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	loadBalancers := map[string]string{}
+	var names []string
+	paginator := elasticloadbalancing.NewDescribeLoadBalancersPaginator(f.provider.ELB(), &elasticloadbalancing.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing classic load balancers: %w", err)
+		}
+		for _, lb := range output.LoadBalancerDescriptions {
+			name := aws.ToString(lb.LoadBalancerName)
+			loadBalancers[name] = fmt.Sprintf("classic load balancer in %s", aws.ToString(lb.VPCId))
+			names = append(names, name)
+		}
+	}
+
+	var resources []Resource
+	for start := 0; start < len(names); start += describeTagsBatchSize {
+		batch := names[start:min(start+describeTagsBatchSize, len(names))]
+		output, err := f.provider.ELB().DescribeTags(ctx, &elasticloadbalancing.DescribeTagsInput{
+			LoadBalancerNames: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing tags of classic load balancers: %w", err)
+		}
+		for _, description := range output.TagDescriptions {
+			tags := map[string]string{}
+			for _, tag := range description.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			name := aws.ToString(description.LoadBalancerName)
+			if clusterName := clusterNameFromTags(tags); isOrphan(clusterName) {
+				resources = append(resources, Resource{
+					Cluster: clusterName,
+					Kind:    KindClassicLoadBalancer,
+					ID:      name,
+					Details: loadBalancers[name],
+				})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// findSecurityGroupsAndNetworkInterfaces finds the security groups of deleted clusters, and the detached network
+// interfaces that are either tagged for a deleted cluster or use one of its security groups
+func (f *Finder) findSecurityGroupsAndNetworkInterfaces(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	var securityGroups []Resource
+	securityGroupClusters := map[string]string{}
+	sgPaginator := ec2.NewDescribeSecurityGroupsPaginator(f.provider.EC2(), &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: clusterNameTags,
+			},
+		},
+	})
+	for sgPaginator.HasMorePages() {
+		output, err := sgPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing security groups: %w", err)
+		}
+		for _, sg := range output.SecurityGroups {
+			if aws.ToString(sg.GroupName) == "default" {
+				continue
+			}
+			if clusterName := clusterNameFromTags(ec2Tags(sg.Tags)); isOrphan(clusterName) {
+				securityGroupClusters[aws.ToString(sg.GroupId)] = clusterName
+				securityGroups = append(securityGroups, Resource{
+					Cluster: clusterName,
+					Kind:    KindSecurityGroup,
+					ID:      aws.ToString(sg.GroupId),
+					Details: fmt.Sprintf("%s in %s", aws.ToString(sg.GroupName), aws.ToString(sg.VpcId)),
+				})
+			}
+		}
+	}
+
+	var networkInterfaces []Resource
+	eniPaginator := ec2.NewDescribeNetworkInterfacesPaginator(f.provider.EC2(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("status"),
+				Values: []string{string(ec2types.NetworkInterfaceStatusAvailable)},
+			},
+		},
+	})
+	for eniPaginator.HasMorePages() {
+		output, err := eniPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing network interfaces: %w", err)
+		}
+		for _, eni := range output.NetworkInterfaces {
+			clusterName := clusterNameFromTags(ec2Tags(eni.TagSet))
+			if clusterName == "" {
+				for _, group := range eni.Groups {
+					if sgCluster, ok := securityGroupClusters[aws.ToString(group.GroupId)]; ok {
+						clusterName = sgCluster
+						break
+					}
+				}
+			}
+			if isOrphan(clusterName) {
+				details := "in " + aws.ToString(eni.SubnetId)
+				if description := aws.ToString(eni.Description); description != "" {
+					details = description + " " + details
+				}
+				networkInterfaces = append(networkInterfaces, Resource{
+					Cluster: clusterName,
+					Kind:    KindNetworkInterface,
+					ID:      aws.ToString(eni.NetworkInterfaceId),
+					Details: details,
+				})
+			}
+		}
+	}
+	return append(networkInterfaces, securityGroups...), nil
+}
+
+// findVolumes finds the detached EBS volumes provisioned for persistent volume claims of deleted clusters
+func (f *Finder) findVolumes(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	var resources []Resource
+	paginator := ec2.NewDescribeVolumesPaginator(f.provider.EC2(), &ec2.DescribeVolumesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("status"),
+				Values: []string{string(ec2types.VolumeStateAvailable)},
+			},
+			{
+				Name:   aws.String("tag-key"),
+				Values: clusterNameTags,
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing volumes: %w", err)
+		}
+		for _, volume := range output.Volumes {
+			tags := ec2Tags(volume.Tags)
+			if clusterName := clusterNameFromTags(tags); isOrphan(clusterName) {
+				details := fmt.Sprintf("%d GiB", aws.ToInt32(volume.Size))
+				if pvc := tags[pvcNameTag]; pvc != "" {
+					details += fmt.Sprintf(" for PVC %s/%s", tags[pvcNamespaceTag], pvc)
+				}
+				resources = append(resources, Resource{
+					Cluster: clusterName,
+					Kind:    KindVolume,
+					ID:      aws.ToString(volume.VolumeId),
+					Details: details,
+				})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// findLogGroups finds the control plane log groups of deleted clusters, which are named /aws/eks/<cluster>/cluster
+func (f *Finder) findLogGroups(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	var resources []Resource
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(f.provider.CloudWatchLogs(), &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(logGroupPrefix),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing log groups: %w", err)
+		}
+		for _, logGroup := range output.LogGroups {
+			name := aws.ToString(logGroup.LogGroupName)
+			clusterName, ok := strings.CutSuffix(strings.TrimPrefix(name, logGroupPrefix), logGroupSuffix)
+			if !ok || strings.Contains(clusterName, "/") || !isOrphan(clusterName) {
+				continue
+			}
+			resources = append(resources, Resource{
+				Cluster: clusterName,
+				Kind:    KindLogGroup,
+				ID:      name,
+				Details: fmt.Sprintf("%d bytes stored", aws.ToInt64(logGroup.StoredBytes)),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// findOIDCProviders finds the IAM OIDC providers created by eksctl for deleted clusters in the region
+func (f *Finder) findOIDCProviders(ctx context.Context, isOrphan func(string) bool) ([]Resource, error) {
+	output, err := f.provider.IAM().ListOpenIDConnectProviders(ctx, &iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, fmt.Errorf("listing OIDC providers: %w", err)
+	}
+
+	var resources []Resource
+	regionIssuer := fmt.Sprintf("/oidc.eks.%s.", f.provider.Region())
+	for _, provider := range output.OpenIDConnectProviderList {
+		arn := aws.ToString(provider.Arn)
+		if !strings.Contains(arn, regionIssuer) {
+			continue
+		}
+		tagsOutput, err := f.provider.IAM().ListOpenIDConnectProviderTags(ctx, &iam.ListOpenIDConnectProviderTagsInput{
+			OpenIDConnectProviderArn: provider.Arn,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing tags of OIDC provider %q: %w", arn, err)
+		}
+		tags := map[string]string{}
+		for _, tag := range tagsOutput.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		if clusterName := tags[api.ClusterNameTag]; isOrphan(clusterName) {
+			resources = append(resources, Resource{
+				Cluster: clusterName,
+				Kind:    KindOIDCProvider,
+				ID:      arn,
+			})
+		}
+	}
+	return resources, nil
+}
+
+func ec2Tags(tags []ec2types.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return m
+}
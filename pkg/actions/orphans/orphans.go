@@ -0,0 +1,181 @@
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Kind is the kind of an orphaned resource
+type Kind string
+
+// Kinds of orphaned resources, in the order in which they are deleted, so that resources are deleted before
+// the resources they depend on
+const (
+	KindLoadBalancer        Kind = "LoadBalancer"
+	KindClassicLoadBalancer Kind = "ClassicLoadBalancer"
+	KindNetworkInterface    Kind = "NetworkInterface"
+	KindVolume              Kind = "Volume"
+	KindSecurityGroup       Kind = "SecurityGroup"
+	KindLogGroup            Kind = "LogGroup"
+	KindOIDCProvider        Kind = "OIDCProvider"
+	KindStack               Kind = "CloudFormationStack"
+)
+
+var deletionOrder = []Kind{
+	KindLoadBalancer,
+	KindClassicLoadBalancer,
+	KindNetworkInterface,
+	KindVolume,
+	KindSecurityGroup,
+	KindLogGroup,
+	KindOIDCProvider,
+	KindStack,
+}
+
+const (
+	eksClusterNameTag     = "aws:eks:cluster-name"
+	eksResourceClusterTag = "eks:cluster-name"
+	eksAutoModeClusterTag = "eks:eks-cluster-name"
+	elbv2ClusterTag       = "elbv2.k8s.aws/cluster"
+)
+
+// clusterNameTags are the tags set on resources of a cluster by eksctl, EKS and the AWS Load Balancer Controller,
+// in order of precedence. Generic Kubernetes tags such as kubernetes.io/cluster/<name> and KubernetesCluster are
+// not used, as they are also set for clusters that are not managed by EKS, e.g. by kops, which are not returned
+// by eks:ListClusters and whose resources would otherwise be reported as orphans
+var clusterNameTags = []string{
+	api.ClusterNameTag,
+	api.OldClusterNameTag,
+	eksClusterNameTag,
+	eksResourceClusterTag,
+	eksAutoModeClusterTag,
+	elbv2ClusterTag,
+}
+
+// Resource is a resource of a cluster that no longer exists
+type Resource struct {
+	Cluster string `json:"cluster"`
+	Kind    Kind   `json:"kind"`
+	ID      string `json:"id"`
+	// Details describes the resource, e.g. the status of a stack or the VPC of a security group
+	Details string `json:"details,omitempty"`
+}
+
+// Finder finds and deletes the resources of clusters that no longer exist
+type Finder struct {
+	provider api.ClusterProvider
+	// ClusterName limits the search to the resources of a single cluster
+	ClusterName string
+}
+
+// New creates a new Finder
+func New(provider api.ClusterProvider, clusterName string) *Finder {
+	return &Finder{
+		provider:    provider,
+		ClusterName: clusterName,
+	}
+}
+
+// Find returns the resources of clusters that no longer exist in the region, sorted in deletion order
+func (f *Finder) Find(ctx context.Context) ([]Resource, error) {
+	stacks, err := f.listEksctlStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	liveClusters, err := f.liveClusters(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
+	if f.ClusterName != "" && liveClusters[f.ClusterName] {
+		return nil, fmt.Errorf("cluster %q still exists or one of its stacks is in progress; find-orphans only reports resources of deleted clusters", f.ClusterName)
+	}
+
+	isOrphan := func(clusterName string) bool {
+		if clusterName == "" || liveClusters[clusterName] {
+			return false
+		}
+		return f.ClusterName == "" || clusterName == f.ClusterName
+	}
+
+	var resources []Resource
+	for _, find := range []func(context.Context, func(string) bool) ([]Resource, error){
+		f.findLoadBalancers,
+		f.findClassicLoadBalancers,
+		f.findSecurityGroupsAndNetworkInterfaces,
+		f.findVolumes,
+		f.findLogGroups,
+		f.findOIDCProviders,
+	} {
+		found, err := find(ctx, isOrphan)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, found...)
+	}
+	resources = append(resources, findStacks(stacks, isOrphan)...)
+	sortResources(resources)
+	return resources, nil
+}
+
+// liveClusters returns the clusters that exist in the region, along with clusters that have a stack in progress,
+// which may be being created or deleted
+func (f *Finder) liveClusters(ctx context.Context, stacks []cfntypes.Stack) (map[string]bool, error) {
+	liveClusters := map[string]bool{}
+	paginator := awseks.NewListClustersPaginator(f.provider.EKS(), &awseks.ListClustersInput{
+		Include: []string{"all"},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing clusters in region %q: %w", f.provider.Region(), err)
+		}
+		for _, clusterName := range output.Clusters {
+			liveClusters[clusterName] = true
+		}
+	}
+
+	for _, stack := range stacks {
+		if strings.HasSuffix(string(stack.StackStatus), "_IN_PROGRESS") {
+			if clusterName := clusterNameFromStack(stack); clusterName != "" && !liveClusters[clusterName] {
+				logger.Debug("ignoring resources of cluster %q as stack %q is in status %s", clusterName, *stack.StackName, stack.StackStatus)
+				liveClusters[clusterName] = true
+			}
+		}
+	}
+	return liveClusters, nil
+}
+
+// clusterNameFromTags returns the name of the cluster a resource belongs to, or an empty string if the resource
+// is not tagged for a cluster
+func clusterNameFromTags(tags map[string]string) string {
+	for _, key := range clusterNameTags {
+		if value := tags[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func sortResources(resources []Resource) {
+	order := map[Kind]int{}
+	for i, kind := range deletionOrder {
+		order[kind] = i
+	}
+	slices.SortStableFunc(resources, func(a, b Resource) int {
+		if order[a.Kind] != order[b.Kind] {
+			return order[a.Kind] - order[b.Kind]
+		}
+		if a.Cluster != b.Cluster {
+			return strings.Compare(a.Cluster, b.Cluster)
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
@@ -0,0 +1,258 @@
+package orphans_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/orphans"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Orphans", func() {
+	var provider *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+	})
+
+	Describe("Find", func() {
+		BeforeEach(func() {
+			provider.MockEKS().On("ListClusters", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListClustersOutput{
+				Clusters: []string{"live"},
+			}, nil)
+			provider.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []cfntypes.Stack{
+					makeStack("eksctl-gone-cluster", "gone", cfntypes.StackStatusDeleteFailed),
+					makeStack("eksctl-gone-nodegroup-ng1", "gone", cfntypes.StackStatusCreateComplete),
+					makeStack("eksctl-live-cluster", "live", cfntypes.StackStatusCreateComplete),
+					makeStack("eksctl-creating-cluster", "creating", cfntypes.StackStatusCreateInProgress),
+					{StackName: aws.String("unrelated"), StackStatus: cfntypes.StackStatusCreateComplete},
+				},
+			}, nil)
+
+			provider.MockELBV2().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []elbv2types.LoadBalancer{
+					{LoadBalancerArn: aws.String("arn:gone"), LoadBalancerName: aws.String("k8s-gone"), Type: elbv2types.LoadBalancerTypeEnumApplication, VpcId: aws.String("vpc-1")},
+					{LoadBalancerArn: aws.String("arn:live"), LoadBalancerName: aws.String("k8s-live"), Type: elbv2types.LoadBalancerTypeEnumNetwork, VpcId: aws.String("vpc-2")},
+				},
+			}, nil)
+			provider.MockELBV2().On("DescribeTags", mock.Anything, &elasticloadbalancingv2.DescribeTagsInput{
+				ResourceArns: []string{"arn:gone", "arn:live"},
+			}).Return(&elasticloadbalancingv2.DescribeTagsOutput{
+				TagDescriptions: []elbv2types.TagDescription{
+					{ResourceArn: aws.String("arn:gone"), Tags: []elbv2types.Tag{{Key: aws.String("elbv2.k8s.aws/cluster"), Value: aws.String("gone")}}},
+					{ResourceArn: aws.String("arn:live"), Tags: []elbv2types.Tag{{Key: aws.String("kubernetes.io/cluster/live"), Value: aws.String("owned")}}},
+				},
+			}, nil)
+
+			provider.MockELB().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancing.DescribeLoadBalancersOutput{
+				LoadBalancerDescriptions: []elbtypes.LoadBalancerDescription{
+					{LoadBalancerName: aws.String("classic-gone"), VPCId: aws.String("vpc-1")},
+					{LoadBalancerName: aws.String("classic-kops"), VPCId: aws.String("vpc-3")},
+				},
+			}, nil)
+			provider.MockELB().On("DescribeTags", mock.Anything, mock.Anything).Return(&elasticloadbalancing.DescribeTagsOutput{
+				TagDescriptions: []elbtypes.TagDescription{
+					{LoadBalancerName: aws.String("classic-gone"), Tags: []elbtypes.Tag{{Key: aws.String("eks:cluster-name"), Value: aws.String("gone")}}},
+					{LoadBalancerName: aws.String("classic-kops"), Tags: []elbtypes.Tag{
+						{Key: aws.String("kubernetes.io/cluster/kops"), Value: aws.String("owned")},
+						{Key: aws.String("KubernetesCluster"), Value: aws.String("kops")},
+					}},
+				},
+			}, nil)
+
+			provider.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []ec2types.SecurityGroup{
+					{GroupId: aws.String("sg-gone"), GroupName: aws.String("eksctl-gone-cluster-ClusterSharedNodeSecurityGroup"), VpcId: aws.String("vpc-1"), Tags: ec2Tags(api.ClusterNameTag, "gone")},
+					{GroupId: aws.String("sg-live"), GroupName: aws.String("eks-cluster-sg-live"), VpcId: aws.String("vpc-2"), Tags: ec2Tags("aws:eks:cluster-name", "live")},
+					{GroupId: aws.String("sg-default"), GroupName: aws.String("default"), VpcId: aws.String("vpc-1"), Tags: ec2Tags(api.ClusterNameTag, "gone")},
+				},
+			}, nil)
+			provider.MockEC2().On("DescribeNetworkInterfaces", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeNetworkInterfacesOutput{
+				NetworkInterfaces: []ec2types.NetworkInterface{
+					{NetworkInterfaceId: aws.String("eni-tagged"), Description: aws.String("aws-K8S-i-1234"), SubnetId: aws.String("subnet-1"), TagSet: ec2Tags("eks:cluster-name", "gone")},
+					{NetworkInterfaceId: aws.String("eni-kops"), SubnetId: aws.String("subnet-3"), TagSet: ec2Tags("cluster.k8s.amazonaws.com/name", "kops")},
+					{NetworkInterfaceId: aws.String("eni-sg"), SubnetId: aws.String("subnet-1"), Groups: []ec2types.GroupIdentifier{{GroupId: aws.String("sg-gone")}}},
+					{NetworkInterfaceId: aws.String("eni-other"), SubnetId: aws.String("subnet-1"), Groups: []ec2types.GroupIdentifier{{GroupId: aws.String("sg-other")}}},
+				},
+			}, nil)
+			provider.MockEC2().On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeVolumesOutput{
+				Volumes: []ec2types.Volume{
+					{
+						VolumeId: aws.String("vol-gone"),
+						Size:     aws.Int32(10),
+						Tags: append(ec2Tags("eks:eks-cluster-name", "gone"),
+							ec2types.Tag{Key: aws.String("kubernetes.io/created-for/pvc/namespace"), Value: aws.String("default")},
+							ec2types.Tag{Key: aws.String("kubernetes.io/created-for/pvc/name"), Value: aws.String("data")},
+						),
+					},
+					{VolumeId: aws.String("vol-creating"), Size: aws.Int32(10), Tags: ec2Tags("eks:eks-cluster-name", "creating")},
+					{VolumeId: aws.String("vol-kops"), Size: aws.Int32(10), Tags: append(ec2Tags("kubernetes.io/cluster/kops", "owned"), ec2Tags("KubernetesCluster", "kops")...)},
+				},
+			}, nil)
+
+			provider.MockCloudWatchLogs().On("DescribeLogGroups", mock.Anything, mock.Anything, mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []cwltypes.LogGroup{
+					{LogGroupName: aws.String("/aws/eks/gone/cluster"), StoredBytes: aws.Int64(1024)},
+					{LogGroupName: aws.String("/aws/eks/live/cluster")},
+					{LogGroupName: aws.String("/aws/eks/gone/other")},
+				},
+			}, nil)
+
+			provider.MockIAM().On("ListOpenIDConnectProviders", mock.Anything, mock.Anything).Return(&iam.ListOpenIDConnectProvidersOutput{
+				OpenIDConnectProviderList: []iamtypes.OpenIDConnectProviderListEntry{
+					{Arn: aws.String("arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/GONE")},
+					{Arn: aws.String("arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-east-1.amazonaws.com/id/OTHER")},
+				},
+			}, nil)
+			provider.MockIAM().On("ListOpenIDConnectProviderTags", mock.Anything, mock.Anything).Return(&iam.ListOpenIDConnectProviderTagsOutput{
+				Tags: []iamtypes.Tag{{Key: aws.String(api.ClusterNameTag), Value: aws.String("gone")}},
+			}, nil)
+		})
+
+		It("finds the resources of clusters that no longer exist, in deletion order, ignoring resources with only generic Kubernetes tags", func() {
+			resources, err := orphans.New(provider, "").Find(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal([]orphans.Resource{
+				{Cluster: "gone", Kind: orphans.KindLoadBalancer, ID: "arn:gone", Details: "application load balancer k8s-gone in vpc-1"},
+				{Cluster: "gone", Kind: orphans.KindClassicLoadBalancer, ID: "classic-gone", Details: "classic load balancer in vpc-1"},
+				{Cluster: "gone", Kind: orphans.KindNetworkInterface, ID: "eni-sg", Details: "in subnet-1"},
+				{Cluster: "gone", Kind: orphans.KindNetworkInterface, ID: "eni-tagged", Details: "aws-K8S-i-1234 in subnet-1"},
+				{Cluster: "gone", Kind: orphans.KindVolume, ID: "vol-gone", Details: "10 GiB for PVC default/data"},
+				{Cluster: "gone", Kind: orphans.KindSecurityGroup, ID: "sg-gone", Details: "eksctl-gone-cluster-ClusterSharedNodeSecurityGroup in vpc-1"},
+				{Cluster: "gone", Kind: orphans.KindLogGroup, ID: "/aws/eks/gone/cluster", Details: "1024 bytes stored"},
+				{Cluster: "gone", Kind: orphans.KindOIDCProvider, ID: "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/GONE"},
+				{Cluster: "gone", Kind: orphans.KindStack, ID: "eksctl-gone-cluster", Details: "DELETE_FAILED"},
+				{Cluster: "gone", Kind: orphans.KindStack, ID: "eksctl-gone-nodegroup-ng1", Details: "CREATE_COMPLETE"},
+			}))
+			provider.MockIAM().AssertNumberOfCalls(GinkgoT(), "ListOpenIDConnectProviderTags", 1)
+		})
+
+		It("only finds the resources of the specified cluster", func() {
+			resources, err := orphans.New(provider, "other").Find(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+		})
+
+		It("errors when the specified cluster exists", func() {
+			_, err := orphans.New(provider, "live").Find(context.Background())
+			Expect(err).To(MatchError(ContainSubstring(`cluster "live" still exists`)))
+		})
+
+		It("errors when the specified cluster has a stack in progress", func() {
+			_, err := orphans.New(provider, "creating").Find(context.Background())
+			Expect(err).To(MatchError(ContainSubstring(`cluster "creating" still exists or one of its stacks is in progress`)))
+		})
+	})
+
+	Describe("Delete", func() {
+		var (
+			resources       []orphans.Resource
+			nodeGroupStatus cfntypes.StackStatus
+		)
+
+		BeforeEach(func() {
+			resources = []orphans.Resource{
+				{Cluster: "gone", Kind: orphans.KindStack, ID: "eksctl-gone-cluster"},
+				{Cluster: "gone", Kind: orphans.KindStack, ID: "eksctl-gone-nodegroup-ng1"},
+				{Cluster: "gone", Kind: orphans.KindSecurityGroup, ID: "sg-gone"},
+				{Cluster: "gone", Kind: orphans.KindLoadBalancer, ID: "arn:gone"},
+				{Cluster: "gone", Kind: orphans.KindVolume, ID: "vol-gone"},
+			}
+			nodeGroupStatus = cfntypes.StackStatusDeleteComplete
+			provider.MockELBV2().On("DeleteLoadBalancer", mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DeleteLoadBalancerOutput{}, nil)
+			provider.MockEC2().On("DeleteVolume", mock.Anything, mock.Anything).Return(&ec2.DeleteVolumeOutput{}, nil)
+			provider.MockCloudFormation().On("DeleteStack", mock.Anything, mock.Anything).Return(&cloudformation.DeleteStackOutput{}, nil)
+			provider.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, *cloudformation.DescribeStacksInput, ...func(*cloudformation.Options)) *cloudformation.DescribeStacksOutput {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []cfntypes.Stack{makeStack("eksctl-gone-nodegroup-ng1", "gone", nodeGroupStatus)},
+				}
+			}, nil)
+		})
+
+		It("deletes dependent resources first and records an audit trail", func() {
+			provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+
+			var auditTrail bytes.Buffer
+			Expect(orphans.New(provider, "").Delete(context.Background(), resources, &auditTrail)).To(Succeed())
+
+			var deleted []string
+			for _, r := range decodeAuditTrail(auditTrail.Bytes()) {
+				Expect(r.Region).To(Equal("us-west-2"))
+				Expect(r.Error).To(BeEmpty())
+				deleted = append(deleted, r.ID+" "+string(r.Action))
+			}
+			Expect(deleted).To(Equal([]string{
+				"arn:gone deleted",
+				"vol-gone deleted",
+				"sg-gone deleted",
+				"eksctl-gone-nodegroup-ng1 deleted",
+				"eksctl-gone-cluster deletion-started",
+			}))
+		})
+
+		It("continues past failures, and skips the cluster stack when its other stacks could not be deleted", func() {
+			provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(nil, errors.New("DependencyViolation"))
+			nodeGroupStatus = cfntypes.StackStatusDeleteFailed
+
+			var auditTrail bytes.Buffer
+			err := orphans.New(provider, "").Delete(context.Background(), resources, &auditTrail)
+			Expect(err).To(MatchError(ContainSubstring("failed to delete 3 of 5 orphaned resource(s)")))
+
+			records := decodeAuditTrail(auditTrail.Bytes())
+			Expect(records).To(HaveLen(5))
+			Expect(records[2].ID).To(Equal("sg-gone"))
+			Expect(records[2].Action).To(Equal(orphans.ActionDeletionFailed))
+			Expect(records[2].Error).To(Equal("DependencyViolation"))
+			Expect(records[3].ID).To(Equal("eksctl-gone-nodegroup-ng1"))
+			Expect(records[3].Action).To(Equal(orphans.ActionDeletionFailed))
+			Expect(records[4].ID).To(Equal("eksctl-gone-cluster"))
+			Expect(records[4].Action).To(Equal(orphans.ActionDeletionSkipped))
+			provider.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)
+		})
+	})
+})
+
+func makeStack(name, clusterName string, status cfntypes.StackStatus) cfntypes.Stack {
+	return cfntypes.Stack{
+		StackName:   aws.String(name),
+		StackStatus: status,
+		Tags:        []cfntypes.Tag{{Key: aws.String(api.ClusterNameTag), Value: aws.String(clusterName)}},
+	}
+}
+
+func ec2Tags(key, value string) []ec2types.Tag {
+	return []ec2types.Tag{{Key: aws.String(key), Value: aws.String(value)}}
+}
+
+func decodeAuditTrail(data []byte) []orphans.AuditRecord {
+	var records []orphans.AuditRecord
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var record orphans.AuditRecord
+		ExpectWithOffset(1, json.Unmarshal(line, &record)).To(Succeed())
+		records = append(records, record)
+	}
+	return records
+}
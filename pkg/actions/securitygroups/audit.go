@@ -0,0 +1,286 @@
+package securitygroups
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+// Roles of the security groups of a cluster
+const (
+	RoleControlPlane   = "control plane"
+	RoleSharedNode     = "shared node"
+	RoleClusterDefault = "cluster default"
+	RoleNodeGroup      = "nodegroup"
+)
+
+const (
+	cfnLogicalIDTag = "aws:cloudformation:logical-id"
+	cfnStackNameTag = "aws:cloudformation:stack-name"
+
+	logicalIDControlPlane = "ControlPlaneSecurityGroup"
+	logicalIDSharedNode   = "ClusterSharedNodeSecurityGroup"
+	logicalIDNodeGroup    = "SG"
+
+	// anySource matches rules allowing traffic from an IP range or a prefix list
+	anySource = "*"
+)
+
+var (
+	ruleHTTPS = builder.PartialEgressRule{FromPort: 443, ToPort: 443, IPProtocol: "tcp"}
+	ruleSSH   = builder.PartialEgressRule{FromPort: 22, ToPort: 22, IPProtocol: "tcp"}
+	// ruleSelf is the rule EKS creates on the cluster default security group
+	ruleSelf = builder.PartialEgressRule{FromPort: 0, ToPort: 65535, IPProtocol: "-1"}
+)
+
+// Finding is a live ingress rule that allows more traffic than the rules eksctl would generate
+type Finding struct {
+	SecurityGroupID string `json:"securityGroupID"`
+	// Role is the role of the security group in the cluster, e.g. shared node or nodegroup ng-1
+	Role    string `json:"role"`
+	RuleID  string `json:"ruleID"`
+	Source  string `json:"source"`
+	Traffic string `json:"traffic"`
+	Reason  string `json:"reason"`
+}
+
+// SecurityGroup is a security group of a cluster, along with the ingress rules eksctl would generate for it
+type SecurityGroup struct {
+	ID   string
+	Role string
+	// Expected maps the source of traffic, either a security group ID or anySource, to the rules
+	// allowing traffic from it
+	Expected map[string][]builder.PartialEgressRule
+}
+
+// Auditor compares the live rules of the security groups of a cluster with the rules eksctl would generate
+type Auditor struct {
+	provider      api.ClusterProvider
+	clusterConfig *api.ClusterConfig
+}
+
+// NewAuditor creates a new Auditor. The rules eksctl would generate are derived from the security group policy
+// in clusterConfig.VPC.
+func NewAuditor(provider api.ClusterProvider, clusterConfig *api.ClusterConfig) *Auditor {
+	return &Auditor{
+		provider:      provider,
+		clusterConfig: clusterConfig,
+	}
+}
+
+// Audit returns the ingress rules of the security groups of the cluster that allow more traffic than required
+func (a *Auditor) Audit(ctx context.Context) ([]Finding, error) {
+	securityGroups, err := a.SecurityGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, sg := range securityGroups {
+		rules, err := a.describeIngressRules(ctx, sg.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if finding := sg.audit(rule); finding != nil {
+				findings = append(findings, *finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// SecurityGroups returns the security groups of the cluster created by eksctl and EKS, along with the ingress
+// rules eksctl would generate for them
+func (a *Auditor) SecurityGroups(ctx context.Context) ([]SecurityGroup, error) {
+	clusterName := a.clusterConfig.Metadata.Name
+	cluster, err := a.provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing cluster %q: %w", clusterName, err)
+	}
+	var clusterDefaultSG string
+	if vpcConfig := cluster.Cluster.ResourcesVpcConfig; vpcConfig != nil {
+		clusterDefaultSG = aws.ToString(vpcConfig.ClusterSecurityGroupId)
+	}
+
+	var controlPlaneSG, sharedNodeSG string
+	nodeGroupSGs := map[string]string{}
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(a.provider.EC2(), &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag:" + api.ClusterNameTag),
+				Values: []string{clusterName},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing security groups of cluster %q: %w", clusterName, err)
+		}
+		for _, sg := range output.SecurityGroups {
+			tags := map[string]string{}
+			for _, tag := range sg.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			switch tags[cfnLogicalIDTag] {
+			case logicalIDControlPlane:
+				controlPlaneSG = aws.ToString(sg.GroupId)
+			case logicalIDSharedNode:
+				sharedNodeSG = aws.ToString(sg.GroupId)
+			case logicalIDNodeGroup:
+				if nodeGroupName := tags[api.NodeGroupNameTag]; nodeGroupName != "" {
+					nodeGroupSGs[aws.ToString(sg.GroupId)] = nodeGroupName
+				} else {
+					nodeGroupSGs[aws.ToString(sg.GroupId)] = tags[cfnStackNameTag]
+				}
+			}
+		}
+	}
+	// control plane security groups may be supplied in vpc.securityGroup
+	if controlPlaneSG == "" {
+		controlPlaneSG = a.clusterConfig.VPC.SecurityGroup
+	}
+
+	vpc := a.clusterConfig.VPC
+	var securityGroups []SecurityGroup
+	if controlPlaneSG != "" {
+		expected := map[string][]builder.PartialEgressRule{
+			// vpc.extraCIDRs and remote networks are only allowed to reach the API server
+			anySource: {ruleHTTPS},
+		}
+		for nodeGroupSG := range nodeGroupSGs {
+			expected[nodeGroupSG] = []builder.PartialEgressRule{ruleHTTPS}
+		}
+		securityGroups = append(securityGroups, SecurityGroup{ID: controlPlaneSG, Role: RoleControlPlane, Expected: expected})
+	}
+	if sharedNodeSG != "" {
+		expected := map[string][]builder.PartialEgressRule{
+			sharedNodeSG: builder.NodeToNodeRules(vpc),
+		}
+		if clusterDefaultSG != "" {
+			expected[clusterDefaultSG] = builder.ClusterToNodeRules(vpc)
+		}
+		if a.clusterConfig.IsControlPlaneOnOutposts() && a.clusterConfig.IsFullyPrivate() {
+			expected[anySource] = []builder.PartialEgressRule{ruleHTTPS}
+		}
+		securityGroups = append(securityGroups, SecurityGroup{ID: sharedNodeSG, Role: RoleSharedNode, Expected: expected})
+	}
+	if clusterDefaultSG != "" {
+		expected := map[string][]builder.PartialEgressRule{
+			clusterDefaultSG: {ruleSelf},
+		}
+		if sharedNodeSG != "" {
+			expected[sharedNodeSG] = builder.NodeToClusterRules(vpc)
+		}
+		securityGroups = append(securityGroups, SecurityGroup{ID: clusterDefaultSG, Role: RoleClusterDefault, Expected: expected})
+	}
+	var nodeGroupSGIDs []string
+	for id := range nodeGroupSGs {
+		nodeGroupSGIDs = append(nodeGroupSGIDs, id)
+	}
+	slices.Sort(nodeGroupSGIDs)
+	for _, id := range nodeGroupSGIDs {
+		// SSH rules are generated from nodeGroups[].ssh, which may allow any source
+		expected := map[string][]builder.PartialEgressRule{
+			anySource: {ruleSSH},
+		}
+		if controlPlaneSG != "" {
+			expected[controlPlaneSG] = builder.ControlPlaneToNodeRules(vpc)
+		}
+		securityGroups = append(securityGroups, SecurityGroup{ID: id, Role: RoleNodeGroup + " " + nodeGroupSGs[id], Expected: expected})
+	}
+
+	return securityGroups, nil
+}
+
+func (a *Auditor) describeIngressRules(ctx context.Context, securityGroupID string) ([]ec2types.SecurityGroupRule, error) {
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(a.provider.EC2(), &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("group-id"),
+				Values: []string{securityGroupID},
+			},
+		},
+	})
+	var rules []ec2types.SecurityGroupRule
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing rules of security group %q: %w", securityGroupID, err)
+		}
+		for _, rule := range output.SecurityGroupRules {
+			if !aws.ToBool(rule.IsEgress) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// audit returns a finding if the rule allows traffic not allowed by the expected rules
+func (sg SecurityGroup) audit(sgRule ec2types.SecurityGroupRule) *Finding {
+	source, expectedSource := ruleSource(sgRule)
+	rule := toPartialRule(sgRule)
+	expected := sg.Expected[expectedSource]
+	for _, e := range expected {
+		if e.Covers(rule) {
+			return nil
+		}
+	}
+
+	reason := "not generated by eksctl"
+	var allowed []string
+	for _, e := range expected {
+		if rule.Covers(e) {
+			allowed = append(allowed, e.String())
+		}
+	}
+	if len(allowed) > 0 {
+		reason = fmt.Sprintf("broader than required (%s)", strings.Join(allowed, ", "))
+	}
+	return &Finding{
+		SecurityGroupID: sg.ID,
+		Role:            sg.Role,
+		RuleID:          aws.ToString(sgRule.SecurityGroupRuleId),
+		Source:          source,
+		Traffic:         rule.String(),
+		Reason:          reason,
+	}
+}
+
+// ruleSource returns the source of a rule, along with the key of the expected rules for that source
+func ruleSource(sgRule ec2types.SecurityGroupRule) (source, expectedSource string) {
+	switch {
+	case sgRule.ReferencedGroupInfo != nil:
+		groupID := aws.ToString(sgRule.ReferencedGroupInfo.GroupId)
+		return groupID, groupID
+	case sgRule.CidrIpv4 != nil:
+		return aws.ToString(sgRule.CidrIpv4), anySource
+	case sgRule.CidrIpv6 != nil:
+		return aws.ToString(sgRule.CidrIpv6), anySource
+	default:
+		return aws.ToString(sgRule.PrefixListId), anySource
+	}
+}
+
+func toPartialRule(sgRule ec2types.SecurityGroupRule) builder.PartialEgressRule {
+	if protocol := aws.ToString(sgRule.IpProtocol); protocol == "-1" {
+		return builder.PartialEgressRule{FromPort: 0, ToPort: 65535, IPProtocol: protocol}
+	}
+	return builder.PartialEgressRule{
+		FromPort:   int(aws.ToInt32(sgRule.FromPort)),
+		ToPort:     int(aws.ToInt32(sgRule.ToPort)),
+		IPProtocol: aws.ToString(sgRule.IpProtocol),
+	}
+}
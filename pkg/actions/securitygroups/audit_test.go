@@ -0,0 +1,190 @@
+package securitygroups_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/securitygroups"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Auditor", func() {
+	const (
+		controlPlaneSG   = "sg-controlplane"
+		sharedNodeSG     = "sg-sharednode"
+		clusterDefaultSG = "sg-clusterdefault"
+		nodeGroupSG      = "sg-ng-1"
+	)
+
+	var (
+		p     *mockprovider.MockProvider
+		cfg   *api.ClusterConfig
+		rules map[string][]ec2types.SecurityGroupRule
+	)
+
+	securityGroup := func(id, logicalID string, tags ...ec2types.Tag) ec2types.SecurityGroup {
+		return ec2types.SecurityGroup{
+			GroupId: aws.String(id),
+			Tags: append(tags,
+				ec2types.Tag{Key: aws.String(api.ClusterNameTag), Value: aws.String("my-cluster")},
+				ec2types.Tag{Key: aws.String("aws:cloudformation:logical-id"), Value: aws.String(logicalID)},
+			),
+		}
+	}
+
+	groupRule := func(id, sourceGroupID, protocol string, fromPort, toPort int32) ec2types.SecurityGroupRule {
+		return ec2types.SecurityGroupRule{
+			SecurityGroupRuleId: aws.String(id),
+			IsEgress:            aws.Bool(false),
+			IpProtocol:          aws.String(protocol),
+			FromPort:            aws.Int32(fromPort),
+			ToPort:              aws.Int32(toPort),
+			ReferencedGroupInfo: &ec2types.ReferencedSecurityGroup{GroupId: aws.String(sourceGroupID)},
+		}
+	}
+
+	cidrRule := func(id, cidr, protocol string, fromPort, toPort int32) ec2types.SecurityGroupRule {
+		return ec2types.SecurityGroupRule{
+			SecurityGroupRuleId: aws.String(id),
+			IsEgress:            aws.Bool(false),
+			IpProtocol:          aws.String(protocol),
+			FromPort:            aws.Int32(fromPort),
+			ToPort:              aws.Int32(toPort),
+			CidrIpv4:            aws.String(cidr),
+		}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+
+		p.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{ClusterSecurityGroupId: aws.String(clusterDefaultSG)},
+			},
+		}, nil)
+		p.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []ec2types.SecurityGroup{
+				securityGroup(controlPlaneSG, "ControlPlaneSecurityGroup"),
+				securityGroup(sharedNodeSG, "ClusterSharedNodeSecurityGroup"),
+				securityGroup(nodeGroupSG, "SG", ec2types.Tag{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("ng-1")}),
+			},
+		}, nil)
+
+		// the rules eksctl generates with the default security group policy
+		rules = map[string][]ec2types.SecurityGroupRule{
+			controlPlaneSG: {
+				groupRule("sgr-cp-1", nodeGroupSG, "tcp", 443, 443),
+				cidrRule("sgr-cp-2", "10.0.0.0/8", "tcp", 443, 443),
+			},
+			sharedNodeSG: {
+				groupRule("sgr-sn-1", sharedNodeSG, "-1", -1, -1),
+				groupRule("sgr-sn-2", clusterDefaultSG, "-1", -1, -1),
+			},
+			clusterDefaultSG: {
+				groupRule("sgr-cd-1", clusterDefaultSG, "-1", -1, -1),
+				groupRule("sgr-cd-2", sharedNodeSG, "-1", -1, -1),
+			},
+			nodeGroupSG: {
+				groupRule("sgr-ng-1", controlPlaneSG, "tcp", 1025, 65535),
+				groupRule("sgr-ng-2", controlPlaneSG, "tcp", 443, 443),
+				cidrRule("sgr-ng-3", "0.0.0.0/0", "tcp", 22, 22),
+				{SecurityGroupRuleId: aws.String("sgr-ng-egress"), IsEgress: aws.Bool(true), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("0.0.0.0/0")},
+			},
+		}
+		p.MockEC2().On("DescribeSecurityGroupRules", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, input *ec2.DescribeSecurityGroupRulesInput, _ ...func(*ec2.Options)) *ec2.DescribeSecurityGroupRulesOutput {
+				return &ec2.DescribeSecurityGroupRulesOutput{SecurityGroupRules: rules[input.Filters[0].Values[0]]}
+			}, nil)
+	})
+
+	audit := func() []securitygroups.Finding {
+		findings, err := securitygroups.NewAuditor(p, cfg).Audit(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		return findings
+	}
+
+	It("finds the security groups of the cluster", func() {
+		securityGroups, err := securitygroups.NewAuditor(p, cfg).SecurityGroups(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		var roles []string
+		for _, sg := range securityGroups {
+			roles = append(roles, sg.ID+" "+sg.Role)
+		}
+		Expect(roles).To(Equal([]string{
+			controlPlaneSG + " control plane",
+			sharedNodeSG + " shared node",
+			clusterDefaultSG + " cluster default",
+			nodeGroupSG + " nodegroup ng-1",
+		}))
+	})
+
+	It("reports no findings for the rules generated with the default policy", func() {
+		Expect(audit()).To(BeEmpty())
+	})
+
+	It("reports rules not generated by eksctl", func() {
+		rules[nodeGroupSG] = append(rules[nodeGroupSG], cidrRule("sgr-extra", "0.0.0.0/0", "tcp", 8080, 8080))
+		Expect(audit()).To(Equal([]securitygroups.Finding{
+			{
+				SecurityGroupID: nodeGroupSG,
+				Role:            "nodegroup ng-1",
+				RuleID:          "sgr-extra",
+				Source:          "0.0.0.0/0",
+				Traffic:         "TCP port 8080",
+				Reason:          "not generated by eksctl",
+			},
+		}))
+	})
+
+	When("auditing against the strict policy", func() {
+		BeforeEach(func() {
+			cfg.VPC.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+			cfg.VPC.WebhookPorts = []int{9443}
+		})
+
+		It("reports rules broader than required", func() {
+			findings := audit()
+			var ruleIDs []string
+			for _, f := range findings {
+				ruleIDs = append(ruleIDs, f.RuleID)
+			}
+			Expect(ruleIDs).To(Equal([]string{"sgr-sn-1", "sgr-sn-2", "sgr-cd-2", "sgr-ng-1"}))
+			Expect(findings[0]).To(Equal(securitygroups.Finding{
+				SecurityGroupID: sharedNodeSG,
+				Role:            "shared node",
+				RuleID:          "sgr-sn-1",
+				Source:          sharedNodeSG,
+				Traffic:         "all traffic",
+				Reason:          "broader than required (TCP port 10250, TCP port 53, UDP port 53, TCP port 61678)",
+			}))
+		})
+
+		It("reports no findings for the rules generated with the strict policy", func() {
+			rules[sharedNodeSG] = []ec2types.SecurityGroupRule{
+				groupRule("sgr-sn-1", sharedNodeSG, "tcp", 10250, 10250),
+				groupRule("sgr-sn-2", sharedNodeSG, "udp", 53, 53),
+				groupRule("sgr-sn-3", clusterDefaultSG, "tcp", 443, 443),
+			}
+			rules[clusterDefaultSG] = []ec2types.SecurityGroupRule{
+				groupRule("sgr-cd-1", clusterDefaultSG, "-1", -1, -1),
+				groupRule("sgr-cd-2", sharedNodeSG, "tcp", 53, 53),
+			}
+			rules[nodeGroupSG] = []ec2types.SecurityGroupRule{
+				groupRule("sgr-ng-1", controlPlaneSG, "tcp", 10250, 10250),
+				groupRule("sgr-ng-2", controlPlaneSG, "tcp", 9443, 9443),
+			}
+			Expect(audit()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kris-nova/logger"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// ENIConfigLabelDef is the node label whose value is the name of the ENIConfig used by the node.
+	ENIConfigLabelDef = "topology.kubernetes.io/zone"
+
+	eniConfigKind = "ENIConfig"
+)
+
+// ENIConfigGVR is the resource of the ENIConfig custom resource definition installed by the VPC CNI.
+var ENIConfigGVR = schema.GroupVersionResource{
+	Group:    "crd.k8s.amazonaws.com",
+	Version:  "v1alpha1",
+	Resource: "eniconfigs",
+}
+
+// NewENIConfigs returns an ENIConfig for each pod subnet, named after its availability zone, so that the VPC CNI
+// assigns pod IPs from the pod subnet in the zone of the node.
+func NewENIConfigs(podSubnets *api.PodSubnets, securityGroups []string) []*unstructured.Unstructured {
+	var eniConfigs []*unstructured.Unstructured
+	for _, subnet := range podSubnets.Subnets {
+		spec := map[string]interface{}{
+			"subnet": subnet.ID,
+		}
+		if len(securityGroups) > 0 {
+			groups := make([]interface{}, len(securityGroups))
+			for i, sg := range securityGroups {
+				groups[i] = sg
+			}
+			spec["securityGroups"] = groups
+		}
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		obj.SetAPIVersion(ENIConfigGVR.GroupVersion().String())
+		obj.SetKind(eniConfigKind)
+		obj.SetName(subnet.AZ)
+		eniConfigs = append(eniConfigs, obj)
+	}
+	sort.Slice(eniConfigs, func(i, j int) bool {
+		return eniConfigs[i].GetName() < eniConfigs[j].GetName()
+	})
+	return eniConfigs
+}
+
+// ApplyENIConfigs creates or updates the ENIConfigs. As the ENIConfig CRD is installed along with the VPC CNI, which
+// may still be being created, it retries until the resource is served or the timeout expires.
+func ApplyENIConfigs(ctx context.Context, client dynamic.Interface, eniConfigs []*unstructured.Unstructured, pollInterval, timeout time.Duration) error {
+	for _, obj := range eniConfigs {
+		var lastErr error
+		err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			lastErr = applyENIConfig(ctx, client, obj)
+			if lastErr == nil {
+				return true, nil
+			}
+			if apierrors.IsNotFound(lastErr) || meta.IsNoMatchError(lastErr) {
+				logger.Debug("waiting for the %s resource to be served: %v", eniConfigKind, lastErr)
+				return false, nil
+			}
+			return false, lastErr
+		})
+		if err != nil {
+			if lastErr != nil {
+				err = lastErr
+			}
+			return fmt.Errorf("applying %s %q: %w", eniConfigKind, obj.GetName(), err)
+		}
+		logger.Info("applied %s %q", eniConfigKind, obj.GetName())
+	}
+	return nil
+}
+
+func applyENIConfig(ctx context.Context, client dynamic.Interface, obj *unstructured.Unstructured) error {
+	resourceClient := client.Resource(ENIConfigGVR)
+	existing, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = resourceClient.Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	}
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	_, err = resourceClient.Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
@@ -19,6 +19,9 @@ const (
 	PodIdentityAgentAddon = "eks-pod-identity-agent"
 	AWSEBSCSIDriverAddon  = "aws-ebs-csi-driver"
 	AWSEFSCSIDriverAddon  = "aws-efs-csi-driver"
+
+	CloudWatchObservabilityAddon = "amazon-cloudwatch-observability"
+	ADOTAddon                    = "adot"
 )
 
 // Addon holds the EKS addon configuration
@@ -31,6 +31,32 @@ type ClusterCloudWatchLogging struct {
 	// 1827, and 3653.
 	//+optional
 	LogRetentionInDays int `json:"logRetentionInDays,omitempty"`
+	// LogGroupKMSKeyARN is the ARN of the KMS key that encrypts the control plane log group. The key policy
+	// must allow the CloudWatch Logs service principal of the region to use the key
+	//+optional
+	LogGroupKMSKeyARN string `json:"logGroupKMSKeyARN,omitempty"`
+	// SubscriptionFilters stream the control plane logs to other destinations, at most 2 per log group
+	//+optional
+	SubscriptionFilters []LogSubscriptionFilter `json:"subscriptionFilters,omitempty"`
+}
+
+// LogSubscriptionFilter configures a CloudWatch Logs subscription filter
+type LogSubscriptionFilter struct {
+	// Name of the subscription filter
+	// +required
+	Name string `json:"name"`
+	// FilterPattern of the events to stream, all events are streamed if empty
+	// (see [CloudWatch docs](https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/FilterAndPatternSyntax.html))
+	//+optional
+	FilterPattern string `json:"filterPattern,omitempty"`
+	// DestinationARN is the ARN of the Kinesis stream, Firehose delivery stream, Lambda function or
+	// CloudWatch Logs destination to stream events to
+	// +required
+	DestinationARN string `json:"destinationARN"`
+	// RoleARN is the ARN of the role that grants CloudWatch Logs permission to deliver events to Kinesis
+	// streams and Firehose delivery streams
+	//+optional
+	RoleARN string `json:"roleARN,omitempty"`
 }
 
 // SupportedCloudWatchClusterLogTypes returns all supported logging facilities
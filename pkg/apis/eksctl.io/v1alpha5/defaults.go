@@ -76,8 +76,20 @@ func SetClusterConfigDefaults(cfg *ClusterConfig) {
 		cfg.VPC.ManageSharedNodeSecurityGroupRules = Enabled()
 	}
 
-	if cfg.Karpenter != nil && cfg.Karpenter.CreateServiceAccount == nil {
-		cfg.Karpenter.CreateServiceAccount = Disabled()
+	if cfg.VPC != nil && cfg.VPC.Capacity != nil && cfg.VPC.Capacity.MaxPodsPerNode == 0 {
+		cfg.VPC.Capacity.MaxPodsPerNode = DefaultVPCMaxPodsPerNode
+	}
+
+	if cfg.HasCustomNetworking() && cfg.VPC.PodSubnets.CIDR == nil && len(cfg.VPC.PodSubnets.Subnets) == 0 {
+		cidr := DefaultPodSubnetsCIDR()
+		cfg.VPC.PodSubnets.CIDR = &cidr
+	}
+
+	if cfg.Karpenter != nil {
+		if cfg.Karpenter.CreateServiceAccount == nil {
+			cfg.Karpenter.CreateServiceAccount = Disabled()
+		}
+		setKarpenterNodePoolDefaults(cfg.Karpenter)
 	}
 
 	if cfg.RemoteNetworkConfig != nil {
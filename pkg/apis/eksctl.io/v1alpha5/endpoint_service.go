@@ -2,6 +2,7 @@ package v1alpha5
 
 import (
 	"fmt"
+	"slices"
 )
 
 // EndpointService represents a VPC endpoint service.
@@ -113,6 +114,21 @@ func MapOptionalEndpointServices(endpointServiceNames []string, cloudWatchLoggin
 	return mapped, nil
 }
 
+// MapEndpointServices maps a list of endpoint service names to []EndpointService.
+func MapEndpointServices(endpointServiceNames []string) ([]EndpointService, error) {
+	var mapped []EndpointService
+	for _, name := range endpointServiceNames {
+		idx := slices.IndexFunc(EndpointServices, func(es EndpointService) bool {
+			return es.Name == name
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("unsupported endpoint service %q", name)
+		}
+		mapped = append(mapped, EndpointServices[idx])
+	}
+	return mapped, nil
+}
+
 func getOptionalEndpointServices() map[string]EndpointService {
 	ret := map[string]EndpointService{}
 	for _, es := range EndpointServices {
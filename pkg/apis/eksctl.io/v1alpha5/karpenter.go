@@ -0,0 +1,330 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/go-version"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Values for `KarpenterNodeClass.AMIFamily`.
+const (
+	KarpenterAMIFamilyAL2023       = "AL2023"
+	KarpenterAMIFamilyAL2          = "AL2"
+	KarpenterAMIFamilyBottlerocket = "Bottlerocket"
+)
+
+// Values for `KarpenterDisruption.ConsolidationPolicy`.
+const (
+	KarpenterConsolidationPolicyWhenEmpty                = "WhenEmpty"
+	KarpenterConsolidationPolicyWhenEmptyOrUnderutilized = "WhenEmptyOrUnderutilized"
+	// KarpenterConsolidationPolicyWhenUnderutilized is only supported by Karpenter versions prior to v1.
+	KarpenterConsolidationPolicyWhenUnderutilized = "WhenUnderutilized"
+)
+
+const (
+	// KarpenterCapacityTypeLabel is the well-known label used by Karpenter to select the capacity type.
+	KarpenterCapacityTypeLabel = "karpenter.sh/capacity-type"
+
+	// DefaultKarpenterNodeClassName is the name of the EC2NodeClass created when
+	// NodePools are configured without any NodeClasses.
+	DefaultKarpenterNodeClassName = "default"
+
+	// minimum Karpenter version that supports the NodePool and EC2NodeClass APIs
+	minKarpenterNodePoolVersion = "v0.32.0"
+	// first Karpenter version serving the v1 NodePool and EC2NodeClass APIs
+	karpenterV1Version = "v1.0.0"
+)
+
+var (
+	karpenterAMIFamilies = []string{KarpenterAMIFamilyAL2023, KarpenterAMIFamilyAL2, KarpenterAMIFamilyBottlerocket}
+
+	karpenterRequirementOperators = []string{"In", "NotIn", "Exists", "DoesNotExist", "Gt", "Lt"}
+)
+
+// KarpenterNodePool holds the configuration for a Karpenter NodePool.
+type KarpenterNodePool struct {
+	// Name of the NodePool
+	// +required
+	Name string `json:"name"`
+	// NodeClass is the name of the EC2NodeClass used by this NodePool.
+	// Defaults to the only NodeClass in `karpenter.nodeClasses`, or `default`
+	// +optional
+	NodeClass string `json:"nodeClass,omitempty"`
+	// Requirements constrain the instances that can be launched by this NodePool.
+	// Defaults to on-demand capacity
+	// +optional
+	Requirements []KarpenterRequirement `json:"requirements,omitempty"`
+	// Labels are applied to every node launched by this NodePool
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are applied to every node launched by this NodePool
+	// +optional
+	Taints []NodeGroupTaint `json:"taints,omitempty"`
+	// Limits constrain the total resources provisioned by this NodePool,
+	// e.g. `cpu: "1000"`
+	// +optional
+	Limits map[string]string `json:"limits,omitempty"`
+	// Disruption configures how Karpenter consolidates and expires nodes
+	// +optional
+	Disruption *KarpenterDisruption `json:"disruption,omitempty"`
+	// Weight sets the priority of this NodePool relative to other NodePools
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// KarpenterRequirement is a node selector requirement for a Karpenter NodePool.
+type KarpenterRequirement struct {
+	// +required
+	Key string `json:"key"`
+	// Valid variants are:
+	// `"In"`
+	// `"NotIn"`
+	// `"Exists"`
+	// `"DoesNotExist"`
+	// `"Gt"`
+	// `"Lt"`
+	// +required
+	Operator string `json:"operator"`
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// KarpenterDisruption holds the disruption settings of a Karpenter NodePool.
+type KarpenterDisruption struct {
+	// ConsolidationPolicy is one of `WhenEmpty` or `WhenEmptyOrUnderutilized`
+	// (`WhenUnderutilized` for Karpenter versions prior to v1)
+	// +optional
+	ConsolidationPolicy string `json:"consolidationPolicy,omitempty"`
+	// ConsolidateAfter is the duration Karpenter waits before consolidating a node, e.g. `30s`
+	// +optional
+	ConsolidateAfter string `json:"consolidateAfter,omitempty"`
+	// ExpireAfter is the duration after which nodes are replaced, e.g. `720h`
+	// +optional
+	ExpireAfter string `json:"expireAfter,omitempty"`
+}
+
+// KarpenterNodeClass holds the configuration for a Karpenter EC2NodeClass.
+type KarpenterNodeClass struct {
+	// Name of the EC2NodeClass
+	// +required
+	Name string `json:"name"`
+	// AMIFamily is one of `AL2023`, `AL2` or `Bottlerocket`.
+	// Defaults to `"AL2023"`
+	// +optional
+	AMIFamily string `json:"amiFamily,omitempty"`
+	// AMISelectorTerms select the AMIs used by nodes. Defaults to the latest
+	// EKS optimized AMI of the AMI family
+	// +optional
+	AMISelectorTerms []KarpenterSelectorTerm `json:"amiSelectorTerms,omitempty"`
+	// Role is the name of the IAM role assumed by nodes. Defaults to the
+	// node role created by eksctl for Karpenter
+	// +optional
+	Role string `json:"role,omitempty"`
+	// InstanceProfile is the name of the IAM instance profile used by nodes;
+	// cannot be set together with `role`
+	// +optional
+	InstanceProfile string `json:"instanceProfile,omitempty"`
+	// SubnetSelectorTerms select the subnets nodes are launched in.
+	// Defaults to the private subnets of the cluster's VPC
+	// +optional
+	SubnetSelectorTerms []KarpenterSelectorTerm `json:"subnetSelectorTerms,omitempty"`
+	// SecurityGroupSelectorTerms select the security groups attached to nodes.
+	// Defaults to the cluster security group
+	// +optional
+	SecurityGroupSelectorTerms []KarpenterSelectorTerm `json:"securityGroupSelectorTerms,omitempty"`
+	// VolumeSize is the size of the root volume in GiB. Defaults to the default nodegroup volume size
+	// +optional
+	VolumeSize *int `json:"volumeSize,omitempty"`
+	// VolumeType is the type of the root volume. Defaults to `"gp3"`
+	// +optional
+	VolumeType *string `json:"volumeType,omitempty"`
+	// Tags are applied to the EC2 resources launched for this NodeClass
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// KarpenterSelectorTerm selects AWS resources by ID, name or tags.
+type KarpenterSelectorTerm struct {
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// HasNodePools reports whether any Karpenter NodePools or NodeClasses are configured.
+func (k *Karpenter) HasNodePools() bool {
+	return k != nil && (len(k.NodePools) > 0 || len(k.NodeClasses) > 0)
+}
+
+// FindNodeClass returns the NodeClass with the given name, or nil if it does not exist.
+func (k *Karpenter) FindNodeClass(name string) *KarpenterNodeClass {
+	for _, nc := range k.NodeClasses {
+		if nc.Name == name {
+			return nc
+		}
+	}
+	return nil
+}
+
+// IsKarpenterV1 reports whether the given Karpenter version serves the v1 NodePool and EC2NodeClass APIs.
+func IsKarpenterV1(karpenterVersion string) (bool, error) {
+	v, err := version.NewVersion(karpenterVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse Karpenter version %q: %w", karpenterVersion, err)
+	}
+	return !v.LessThan(version.Must(version.NewVersion(karpenterV1Version))), nil
+}
+
+func setKarpenterNodePoolDefaults(k *Karpenter) {
+	if len(k.NodePools) > 0 && len(k.NodeClasses) == 0 {
+		k.NodeClasses = []*KarpenterNodeClass{{Name: DefaultKarpenterNodeClassName}}
+	}
+	for _, nc := range k.NodeClasses {
+		if nc.AMIFamily == "" {
+			nc.AMIFamily = KarpenterAMIFamilyAL2023
+		}
+		if nc.VolumeSize == nil {
+			nc.VolumeSize = aws.Int(DefaultNodeVolumeSize)
+		}
+		if nc.VolumeType == nil {
+			nc.VolumeType = aws.String(DefaultNodeVolumeType)
+		}
+	}
+	for _, np := range k.NodePools {
+		if np.NodeClass == "" {
+			if len(k.NodeClasses) == 1 {
+				np.NodeClass = k.NodeClasses[0].Name
+			} else {
+				np.NodeClass = DefaultKarpenterNodeClassName
+			}
+		}
+		if len(np.Requirements) == 0 {
+			np.Requirements = []KarpenterRequirement{
+				{
+					Key:      KarpenterCapacityTypeLabel,
+					Operator: "In",
+					Values:   []string{"on-demand"},
+				},
+			}
+		}
+	}
+}
+
+// ValidateKarpenterNodePools validates the NodePools and NodeClasses of the Karpenter config.
+func ValidateKarpenterNodePools(k *Karpenter) error {
+	if !k.HasNodePools() {
+		return nil
+	}
+	v, err := version.NewVersion(k.Version)
+	if err != nil {
+		return fmt.Errorf("failed to parse Karpenter version %q: %w", k.Version, err)
+	}
+	if v.LessThan(version.Must(version.NewVersion(minKarpenterNodePoolVersion))) {
+		return fmt.Errorf("karpenter.nodePools and karpenter.nodeClasses require Karpenter version %s or newer", minKarpenterNodePoolVersion)
+	}
+	isV1, err := IsKarpenterV1(k.Version)
+	if err != nil {
+		return err
+	}
+
+	seenNodeClasses := map[string]struct{}{}
+	for i, nc := range k.NodeClasses {
+		path := fmt.Sprintf("karpenter.nodeClasses[%d]", i)
+		if err := validateKarpenterResourceName(nc.Name, path); err != nil {
+			return err
+		}
+		if _, ok := seenNodeClasses[nc.Name]; ok {
+			return fmt.Errorf("found duplicate NodeClass %q", nc.Name)
+		}
+		seenNodeClasses[nc.Name] = struct{}{}
+		if nc.AMIFamily != "" && !slices.Contains(karpenterAMIFamilies, nc.AMIFamily) {
+			return fmt.Errorf("%s.amiFamily: invalid value %q, supported values: %v", path, nc.AMIFamily, karpenterAMIFamilies)
+		}
+		if nc.Role != "" && nc.InstanceProfile != "" {
+			return fmt.Errorf("%s: cannot set both role and instanceProfile", path)
+		}
+		if nc.VolumeSize != nil && *nc.VolumeSize <= 0 {
+			return fmt.Errorf("%s.volumeSize must be greater than 0", path)
+		}
+		for _, terms := range [][]KarpenterSelectorTerm{nc.AMISelectorTerms, nc.SubnetSelectorTerms, nc.SecurityGroupSelectorTerms} {
+			for _, term := range terms {
+				if term.ID == "" && term.Name == "" && len(term.Tags) == 0 {
+					return fmt.Errorf("%s: selector terms must specify at least one of id, name or tags", path)
+				}
+			}
+		}
+	}
+
+	seenNodePools := map[string]struct{}{}
+	for i, np := range k.NodePools {
+		path := fmt.Sprintf("karpenter.nodePools[%d]", i)
+		if err := validateKarpenterResourceName(np.Name, path); err != nil {
+			return err
+		}
+		if _, ok := seenNodePools[np.Name]; ok {
+			return fmt.Errorf("found duplicate NodePool %q", np.Name)
+		}
+		seenNodePools[np.Name] = struct{}{}
+		if np.NodeClass != "" {
+			if _, ok := seenNodeClasses[np.NodeClass]; !ok {
+				return fmt.Errorf("%s.nodeClass: NodeClass %q is not defined in karpenter.nodeClasses", path, np.NodeClass)
+			}
+		}
+		for _, r := range np.Requirements {
+			if r.Key == "" {
+				return fmt.Errorf("%s.requirements: key must be set", path)
+			}
+			if !slices.Contains(karpenterRequirementOperators, r.Operator) {
+				return fmt.Errorf("%s.requirements: invalid operator %q for key %q, supported values: %v", path, r.Operator, r.Key, karpenterRequirementOperators)
+			}
+		}
+		if err := validateLabels(np.Labels); err != nil {
+			return fmt.Errorf("%s.labels: %w", path, err)
+		}
+		if err := validateTaints(np.Taints); err != nil {
+			return fmt.Errorf("%s.taints: %w", path, err)
+		}
+		if np.Disruption != nil {
+			if err := validateKarpenterDisruption(np.Disruption, isV1); err != nil {
+				return fmt.Errorf("%s.disruption: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateKarpenterDisruption(d *KarpenterDisruption, isV1 bool) error {
+	switch d.ConsolidationPolicy {
+	case "", KarpenterConsolidationPolicyWhenEmpty:
+	case KarpenterConsolidationPolicyWhenEmptyOrUnderutilized:
+		if !isV1 {
+			return fmt.Errorf("consolidationPolicy %q requires Karpenter %s or newer", d.ConsolidationPolicy, karpenterV1Version)
+		}
+	case KarpenterConsolidationPolicyWhenUnderutilized:
+		if isV1 {
+			return fmt.Errorf("consolidationPolicy %q is not supported by Karpenter %s or newer, use %q instead", d.ConsolidationPolicy, karpenterV1Version, KarpenterConsolidationPolicyWhenEmptyOrUnderutilized)
+		}
+		if d.ConsolidateAfter != "" {
+			return errors.New("consolidateAfter cannot be set when consolidationPolicy is WhenUnderutilized")
+		}
+	default:
+		return fmt.Errorf("invalid consolidationPolicy %q", d.ConsolidationPolicy)
+	}
+	return nil
+}
+
+func validateKarpenterResourceName(name, path string) error {
+	if name == "" {
+		return fmt.Errorf("%s.name must be set", path)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("%s.name %q is invalid: %v", path, name, errs)
+	}
+	return nil
+}
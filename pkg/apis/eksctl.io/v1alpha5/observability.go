@@ -0,0 +1,116 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Observability holds the metrics and logs collection stacks to install as addons, which use the pod identity
+// associations recommended by the EKS API, and therefore require the eks-pod-identity-agent addon
+type Observability struct {
+	// CloudWatch installs the Amazon CloudWatch Observability addon, which collects Container Insights metrics
+	// and container logs with Fluent Bit
+	// +optional
+	CloudWatch *CloudWatchObservability `json:"cloudWatch,omitempty"`
+	// ADOT installs the AWS Distro for OpenTelemetry addon, which requires cert-manager to be installed
+	// +optional
+	ADOT *ADOTObservability `json:"adot,omitempty"`
+}
+
+// CloudWatchObservability configures the Amazon CloudWatch Observability addon
+type CloudWatchObservability struct {
+	// Version of the addon, defaults to the latest version
+	// +optional
+	Version string `json:"version,omitempty"`
+	// ContainerLogs enables the collection of container logs with Fluent Bit. Defaults to `true`
+	// +optional
+	ContainerLogs *bool `json:"containerLogs,omitempty"`
+	// LogRetentionInDays sets the number of days to retain Container Insights logs for.
+	// Valid values are the same as for `cloudWatch.clusterLogging.logRetentionInDays`
+	// +optional
+	LogRetentionInDays int `json:"logRetentionInDays,omitempty"`
+}
+
+// ADOTObservability configures the AWS Distro for OpenTelemetry addon
+type ADOTObservability struct {
+	// Version of the addon, defaults to the latest version
+	// +optional
+	Version string `json:"version,omitempty"`
+	// ConfigurationValues configures the collectors of the addon, in JSON or YAML
+	// (see `eksctl utils describe-addon-configuration --name adot`)
+	// +optional
+	ConfigurationValues string `json:"configurationValues,omitempty"`
+}
+
+// Container Insights log groups, /aws/containerinsights/<cluster>/<name>
+const (
+	containerInsightsPerformanceLogGroup = "performance"
+	containerInsightsApplicationLogGroup = "application"
+	containerInsightsDataplaneLogGroup   = "dataplane"
+	containerInsightsHostLogGroup        = "host"
+)
+
+// HasObservability returns true if any observability stack is configured
+func (c *ClusterConfig) HasObservability() bool {
+	return c.Observability != nil && (c.Observability.CloudWatch != nil || c.Observability.ADOT != nil)
+}
+
+// ObservabilityAddons returns the addons that install the configured observability stacks
+func (c *ClusterConfig) ObservabilityAddons() []*Addon {
+	if !c.HasObservability() {
+		return nil
+	}
+	var addons []*Addon
+	if cw := c.Observability.CloudWatch; cw != nil {
+		addon := &Addon{
+			Name:                              CloudWatchObservabilityAddon,
+			Version:                           cw.Version,
+			UseDefaultPodIdentityAssociations: true,
+		}
+		if IsDisabled(cw.ContainerLogs) {
+			addon.ConfigurationValues = `{"containerLogs":{"enabled":false}}`
+		}
+		addons = append(addons, addon)
+	}
+	if adot := c.Observability.ADOT; adot != nil {
+		addons = append(addons, &Addon{
+			Name:                              ADOTAddon,
+			Version:                           adot.Version,
+			ConfigurationValues:               adot.ConfigurationValues,
+			UseDefaultPodIdentityAssociations: true,
+		})
+	}
+	return addons
+}
+
+// AppendObservabilityAddons appends the addons that install the configured observability stacks to the addons
+// of the config. Addons already set in the config take precedence, which allows configuring them further.
+func (c *ClusterConfig) AppendObservabilityAddons() error {
+	if err := validateObservability(c); err != nil {
+		return err
+	}
+	for _, addon := range c.ObservabilityAddons() {
+		if !slices.ContainsFunc(c.Addons, func(a *Addon) bool {
+			return a.CanonicalName() == addon.Name
+		}) {
+			c.Addons = append(c.Addons, addon)
+		}
+	}
+	return nil
+}
+
+// ContainerInsightsLogGroups returns the names of the log groups written to by the Amazon CloudWatch Observability addon
+func (c *ClusterConfig) ContainerInsightsLogGroups() []string {
+	if !c.HasObservability() || c.Observability.CloudWatch == nil {
+		return nil
+	}
+	names := []string{containerInsightsPerformanceLogGroup}
+	if !IsDisabled(c.Observability.CloudWatch.ContainerLogs) {
+		names = append(names, containerInsightsApplicationLogGroup, containerInsightsDataplaneLogGroup, containerInsightsHostLogGroup)
+	}
+	logGroups := make([]string, len(names))
+	for i, name := range names {
+		logGroups[i] = fmt.Sprintf("/aws/containerinsights/%s/%s", c.Metadata.Name, name)
+	}
+	return logGroups
+}
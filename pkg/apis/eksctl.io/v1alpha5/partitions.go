@@ -17,6 +17,7 @@ type partition struct {
 	serviceMappings             map[string]string
 	regions                     []string
 	endpointServiceDomainPrefix string
+	dnsSuffix                   string
 }
 
 type partitions []partition
@@ -29,12 +30,16 @@ var standardServiceMappings = map[string]string{
 	"EKSFargatePods": "eks-fargate-pods.amazonaws.com",
 }
 
-const standardPartitionServiceDomainPrefix = "com.amazonaws"
+const (
+	standardPartitionServiceDomainPrefix = "com.amazonaws"
+	standardPartitionDNSSuffix           = "amazonaws.com"
+)
 
 var awsPartition = partition{
 	name:                        PartitionAWS,
 	serviceMappings:             standardServiceMappings,
 	endpointServiceDomainPrefix: standardPartitionServiceDomainPrefix,
+	dnsSuffix:                   standardPartitionDNSSuffix,
 }
 
 // Partitions is a list of supported AWS partitions.
@@ -45,6 +50,7 @@ var Partitions = partitions{
 		serviceMappings:             standardServiceMappings,
 		regions:                     []string{RegionUSGovEast1, RegionUSGovWest1},
 		endpointServiceDomainPrefix: standardPartitionServiceDomainPrefix,
+		dnsSuffix:                   standardPartitionDNSSuffix,
 	},
 	{
 		name: PartitionChina,
@@ -55,6 +61,7 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionCNNorth1, RegionCNNorthwest1},
 		endpointServiceDomainPrefix: fmt.Sprintf("cn.%s", standardPartitionServiceDomainPrefix),
+		dnsSuffix:                   "amazonaws.com.cn",
 	},
 	{
 		name: PartitionISO,
@@ -65,6 +72,7 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionUSISOEast1, RegionUSISOWest1},
 		endpointServiceDomainPrefix: "gov.ic.c2s",
+		dnsSuffix:                   "c2s.ic.gov",
 	},
 	{
 		name: PartitionISOB,
@@ -75,6 +83,7 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionUSISOBEast1},
 		endpointServiceDomainPrefix: "gov.sgov.sc2s",
+		dnsSuffix:                   "sc2s.sgov.gov",
 	},
 }
 
@@ -90,6 +99,18 @@ func (p partitions) ForRegion(region string) string {
 	return PartitionAWS
 }
 
+// DNSSuffixForRegion returns the DNS suffix of the service endpoints of the partition a region belongs to.
+func (p partitions) DNSSuffixForRegion(region string) string {
+	for _, pt := range p {
+		for _, r := range pt.regions {
+			if r == region {
+				return pt.dnsSuffix
+			}
+		}
+	}
+	return awsPartition.dnsSuffix
+}
+
 // GetEndpointServiceDomainPrefix returns the domain prefix for the endpoint service.
 func (p partitions) GetEndpointServiceDomainPrefix(endpointService EndpointService, region string) string {
 	for _, pt := range p {
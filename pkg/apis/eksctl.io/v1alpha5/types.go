@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -309,6 +310,9 @@ const (
 	// AddonNameTag defines the tag of the IAM service account name
 	AddonNameTag = "alpha.eksctl.io/addon-name"
 
+	// FargateProfileNameTag defines the tag of the configured name of a Fargate profile replaced by `eksctl update fargateprofile`
+	FargateProfileNameTag = "alpha.eksctl.io/fargate-profile-name"
+
 	// ClusterNameLabel defines the tag of the cluster name
 	ClusterNameLabel = "alpha.eksctl.io/cluster-name"
 
@@ -704,6 +708,11 @@ type ClusterMeta struct {
 	// Annotations are arbitrary metadata ignored by `eksctl`.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// DeletionProtection enables CloudFormation termination protection on the cluster stack
+	// created by eksctl, and makes `eksctl delete cluster` refuse to delete the cluster
+	// unless `--disable-deletion-protection` is set
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
 	// Internal fields
 	// AccountID the ID of the account hosting this cluster
 	AccountID string `json:"-"`
@@ -956,6 +965,25 @@ type ProviderConfig struct {
 type Profile struct {
 	Name           string
 	SourceIsEnvVar bool
+	// AssumeRole holds the roles to assume with the credentials of the profile
+	AssumeRole AssumeRole
+}
+
+// AssumeRole holds a chain of roles to assume, as in a chain of AWS profiles with source_profile and role_arn.
+type AssumeRole struct {
+	// RoleARNs are assumed in order, each with the credentials of the previous role
+	RoleARNs []string
+	// ExternalID is passed when assuming the last role, as required by some cross-account roles
+	ExternalID string
+	// MFASerial is the MFA device used when assuming the first role; the token code is read from stdin
+	MFASerial string
+	// RoleSessionName is the session name of all roles, defaulting to one generated by the AWS SDK
+	RoleSessionName string
+}
+
+// IsSet returns true if there are roles to assume
+func (a AssumeRole) IsSet() bool {
+	return len(a.RoleARNs) > 0
 }
 
 // +genclient
@@ -1034,6 +1062,10 @@ type ClusterConfig struct {
 	// +optional
 	SecretsEncryption *SecretsEncryption `json:"secretsEncryption,omitempty"`
 
+	// Observability installs metrics and logs collection stacks as addons.
+	// +optional
+	Observability *Observability `json:"observability,omitempty"`
+
 	Status *ClusterStatus `json:"-"`
 
 	// future gitops plans, replacing the Git configuration above
@@ -1109,6 +1141,12 @@ type Karpenter struct {
 	// WithSpotInterruptionQueue if true, adds all required policies and rules
 	// for supporting Spot Interruption Queue on Karpenter deployments
 	WithSpotInterruptionQueue *bool `json:"withSpotInterruptionQueue,omitempty"`
+	// NodePools defines the Karpenter NodePools to create in the cluster
+	// +optional
+	NodePools []*KarpenterNodePool `json:"nodePools,omitempty"`
+	// NodeClasses defines the Karpenter EC2NodeClasses referenced by NodePools
+	// +optional
+	NodeClasses []*KarpenterNodeClass `json:"nodeClasses,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1992,6 +2030,38 @@ type FargateProfileSelector struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// Matches returns true if the selector selects pods with the given namespace and labels, the same way EKS does
+// when scheduling pods: the namespace must match and all labels of the selector must be set on the pod. The `*`
+// and `?` wildcards are supported in the namespace and in label keys and values.
+func (fps FargateProfileSelector) Matches(namespace string, labels map[string]string) bool {
+	if !wildcardMatches(fps.Namespace, namespace) {
+		return false
+	}
+	for selectorKey, selectorValue := range fps.Labels {
+		found := false
+		for key, value := range labels {
+			if wildcardMatches(selectorKey, key) && wildcardMatches(selectorValue, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func wildcardMatches(pattern, s string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == s
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
+
 // SecretsEncryption defines the configuration for KMS encryption provider
 type SecretsEncryption struct {
 	// +required
@@ -219,6 +219,10 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		return err
 	}
 
+	if err := validateObservability(cfg); err != nil {
+		return err
+	}
+
 	if err := validateAvailabilityZones(cfg.AvailabilityZones); err != nil {
 		return err
 	}
@@ -352,15 +356,19 @@ func validateKarpenterConfig(cfg *ClusterConfig) error {
 	if IsDisabled(cfg.IAM.WithOIDC) {
 		return errors.New("iam.withOIDC must be enabled with Karpenter")
 	}
-	return nil
+	return ValidateKarpenterNodePools(cfg.Karpenter)
 }
 
 func validateCloudWatchLogging(clusterConfig *ClusterConfig) error {
 	if !clusterConfig.HasClusterCloudWatchLogging() {
-		if clusterConfig.CloudWatch != nil &&
-			clusterConfig.CloudWatch.ClusterLogging != nil &&
-			clusterConfig.CloudWatch.ClusterLogging.LogRetentionInDays != 0 {
-			return errors.New("cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types")
+		if clusterConfig.CloudWatch != nil && clusterConfig.CloudWatch.ClusterLogging != nil {
+			logging := clusterConfig.CloudWatch.ClusterLogging
+			if logging.LogRetentionInDays != 0 {
+				return errors.New("cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types")
+			}
+			if logging.LogGroupKMSKeyARN != "" || len(logging.SubscriptionFilters) > 0 {
+				return errors.New("cannot set cloudWatch.clusterLogging.logGroupKMSKeyARN or cloudWatch.clusterLogging.subscriptionFilters without enabling log types")
+			}
 		}
 		return nil
 	}
@@ -376,15 +384,68 @@ func validateCloudWatchLogging(clusterConfig *ClusterConfig) error {
 			return fmt.Errorf("log type %q (cloudWatch.clusterLogging.enableTypes[%d]) is unknown", logType, i)
 		}
 	}
-	if logRetentionDays := clusterConfig.CloudWatch.ClusterLogging.LogRetentionInDays; logRetentionDays != 0 {
-		for _, v := range LogRetentionInDaysValues {
-			if v == logRetentionDays {
-				return nil
+	if err := validateLogRetentionInDays(clusterConfig.CloudWatch.ClusterLogging.LogRetentionInDays, "logRetentionInDays"); err != nil {
+		return err
+	}
+
+	if keyARN := clusterConfig.CloudWatch.ClusterLogging.LogGroupKMSKeyARN; keyARN != "" {
+		if _, err := arn.Parse(keyARN); err != nil {
+			return fmt.Errorf("invalid value %q for cloudWatch.clusterLogging.logGroupKMSKeyARN: %w", keyARN, err)
+		}
+	}
+
+	filters := clusterConfig.CloudWatch.ClusterLogging.SubscriptionFilters
+	if len(filters) > maxLogSubscriptionFilters {
+		return fmt.Errorf("at most %d subscription filters can be set in cloudWatch.clusterLogging.subscriptionFilters", maxLogSubscriptionFilters)
+	}
+	names := map[string]bool{}
+	for i, f := range filters {
+		path := fmt.Sprintf("cloudWatch.clusterLogging.subscriptionFilters[%d]", i)
+		if f.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("%s.name %q is not unique", path, f.Name)
+		}
+		names[f.Name] = true
+		if f.DestinationARN == "" {
+			return fmt.Errorf("%s.destinationARN must be set", path)
+		}
+		for field, value := range map[string]string{"destinationARN": f.DestinationARN, "roleARN": f.RoleARN} {
+			if value == "" {
+				continue
 			}
+			if _, err := arn.Parse(value); err != nil {
+				return fmt.Errorf("invalid value %q for %s.%s: %w", value, path, field, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxLogSubscriptionFilters is the maximum number of subscription filters of a log group
+const maxLogSubscriptionFilters = 2
+
+func validateLogRetentionInDays(logRetentionDays int, field string) error {
+	if logRetentionDays == 0 {
+		return nil
+	}
+	for _, v := range LogRetentionInDaysValues {
+		if v == logRetentionDays {
+			return nil
 		}
-		return fmt.Errorf("invalid value %d for logRetentionInDays; supported values are %v", logRetentionDays, LogRetentionInDaysValues)
 	}
+	return fmt.Errorf("invalid value %d for %s; supported values are %v", logRetentionDays, field, LogRetentionInDaysValues)
+}
 
+func validateObservability(clusterConfig *ClusterConfig) error {
+	if !clusterConfig.HasObservability() {
+		return nil
+	}
+	if cw := clusterConfig.Observability.CloudWatch; cw != nil {
+		return validateLogRetentionInDays(cw.LogRetentionInDays, "observability.cloudWatch.logRetentionInDays")
+	}
 	return nil
 }
 
@@ -427,6 +488,26 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		c.VPC.ExtraIPv6CIDRs = cidrs
 	}
 
+	if err := c.validateIPAM(); err != nil {
+		return err
+	}
+
+	if err := c.validatePodSubnets(); err != nil {
+		return err
+	}
+
+	if err := c.validatePrivateSubnetRoutes(); err != nil {
+		return err
+	}
+
+	if err := c.validateVPCEndpoints(); err != nil {
+		return err
+	}
+
+	if err := c.validateSecurityGroupPolicy(); err != nil {
+		return err
+	}
+
 	if c.VPC.SecurityGroup != "" && len(c.VPC.ControlPlaneSecurityGroupIDs) > 0 {
 		return errors.New("only one of vpc.securityGroup and vpc.controlPlaneSecurityGroupIDs can be specified")
 	}
@@ -435,6 +516,15 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		return fmt.Errorf("Ipv6Cidr and Ipv6CidrPool are only supported when IPFamily is set to IPv6")
 	}
 
+	if c.VPC.IPv6 != nil {
+		if !c.IPv6Enabled() {
+			return errors.New("vpc.ipv6 is only supported when ipFamily is set to IPv6")
+		}
+		if IsEnabled(c.VPC.IPv6.NAT64) && c.IsFullyPrivate() {
+			return errors.New("vpc.ipv6.nat64 is not supported with fully-private clusters, as they have no NAT gateway")
+		}
+	}
+
 	if c.IPv6Enabled() {
 		if IsEnabled(c.VPC.AutoAllocateIPv6) {
 			return fmt.Errorf("auto allocate ipv6 is not supported with IPv6")
@@ -1790,3 +1880,185 @@ func validateAddonPodIdentityAssociations(addons []*Addon) error {
 	}
 	return nil
 }
+
+func (c *ClusterConfig) validateIPAM() error {
+	vpc := c.VPC
+	if vpc.IPAMPoolID == "" {
+		if vpc.IPAMNetmaskLength != nil || len(vpc.IPAMSecondaryNetmaskLengths) > 0 || vpc.Capacity != nil {
+			return errors.New("vpc.ipamNetmaskLength, vpc.ipamSecondaryNetmaskLengths and vpc.capacity require vpc.ipamPoolID to be set")
+		}
+		return nil
+	}
+	if vpc.ID != "" || c.HasAnySubnets() {
+		return errors.New("vpc.ipamPoolID cannot be used with an existing VPC or subnets")
+	}
+	if c.IPv6Enabled() {
+		return errors.New("vpc.ipamPoolID is not supported with IPv6 clusters")
+	}
+	defaultCIDR := DefaultCIDR()
+	if vpc.CIDR != nil && vpc.CIDR.String() != defaultCIDR.String() {
+		return errors.New("vpc.cidr cannot be set when vpc.ipamPoolID is set, the VPC CIDR is allocated from the IPAM pool")
+	}
+	if vpc.IPAMNetmaskLength != nil && (*vpc.IPAMNetmaskLength < 16 || *vpc.IPAMNetmaskLength > 24) {
+		return fmt.Errorf("vpc.ipamNetmaskLength must be between 16 and 24, got %d", *vpc.IPAMNetmaskLength)
+	}
+	for _, length := range vpc.IPAMSecondaryNetmaskLengths {
+		if length < 16 || length > 28 {
+			return fmt.Errorf("vpc.ipamSecondaryNetmaskLengths must be between 16 and 28, got %d", length)
+		}
+	}
+	if vpc.Capacity != nil {
+		if vpc.IPAMNetmaskLength != nil {
+			return errors.New("only one of vpc.ipamNetmaskLength and vpc.capacity can be specified")
+		}
+		if vpc.Capacity.MaxNodes <= 0 {
+			return errors.New("vpc.capacity.maxNodes must be greater than 0")
+		}
+		if vpc.Capacity.MaxPodsPerNode < 0 {
+			return errors.New("vpc.capacity.maxPodsPerNode cannot be negative")
+		}
+	}
+	return nil
+}
+
+func (c *ClusterConfig) validatePodSubnets() error {
+	if !c.HasCustomNetworking() {
+		return nil
+	}
+	podSubnets := c.VPC.PodSubnets
+	if c.IPv6Enabled() {
+		return errors.New("vpc.podSubnets is not supported with IPv6 clusters")
+	}
+	if len(c.LocalZones) > 0 {
+		return errors.New("vpc.podSubnets is not supported with local zones")
+	}
+	if podSubnets.CIDR != nil && len(podSubnets.Subnets) > 0 {
+		return errors.New("only one of vpc.podSubnets.cidr and vpc.podSubnets.subnets can be specified")
+	}
+	if podSubnets.CIDR != nil {
+		if prefix, _ := podSubnets.CIDR.Mask.Size(); prefix < 16 || prefix > 28 {
+			return fmt.Errorf("vpc.podSubnets.cidr prefix must be between /16 and /28, got /%d", prefix)
+		}
+	}
+	if len(podSubnets.Subnets) > 0 {
+		if c.VPC.ID == "" && !c.HasAnySubnets() {
+			return errors.New("vpc.podSubnets.subnets can only be used with a pre-existing VPC, use vpc.podSubnets.cidr instead")
+		}
+		for name, subnet := range podSubnets.Subnets {
+			if subnet.ID == "" {
+				return fmt.Errorf("vpc.podSubnets.subnets[%s].id must be set", name)
+			}
+		}
+	}
+	if c.AddonsConfig.DisableDefaultAddons && len(c.addonContainsManagedAddons([]string{VPCCNIAddon})) > 0 {
+		return fmt.Errorf("vpc.podSubnets requires the %s addon", VPCCNIAddon)
+	}
+	return nil
+}
+
+func (c *ClusterConfig) validatePrivateSubnetRoutes() error {
+	// each destination can only be routed to one target
+	destinations := map[string]bool{}
+	if tgw := c.VPC.TransitGateway; tgw != nil {
+		if tgw.ID == "" && tgw.AttachmentID == "" {
+			return errors.New("one of vpc.transitGateway.id and vpc.transitGateway.attachmentID must be set")
+		}
+		if tgw.AttachmentID != "" {
+			if c.VPC.ID == "" {
+				return errors.New("vpc.transitGateway.attachmentID can only be used with a pre-existing VPC")
+			}
+			if len(tgw.Subnets) > 0 {
+				return errors.New("vpc.transitGateway.subnets cannot be set when using a pre-existing attachment")
+			}
+		}
+		if c.RemoteNetworkConfig != nil && c.RemoteNetworkConfig.VPCGatewayID.IsTransitGateway() {
+			return errors.New("vpc.transitGateway cannot be used with a transit gateway in remoteNetworkConfig.vpcGatewayID")
+		}
+		cidrs, err := validateDestinationCIDRs("vpc.transitGateway", tgw.DestinationCIDRs, destinations)
+		if err != nil {
+			return err
+		}
+		tgw.DestinationCIDRs = cidrs
+	}
+
+	for i := range c.VPC.Peerings {
+		peering := &c.VPC.Peerings[i]
+		field := fmt.Sprintf("vpc.peerings[%d]", i)
+		if (peering.PeeringConnectionID == "") == (peering.PeerVPCID == "") {
+			return fmt.Errorf("exactly one of %[1]s.peeringConnectionID and %[1]s.peerVPCID must be set", field)
+		}
+		if peering.PeeringConnectionID != "" && (peering.PeerOwnerID != "" || peering.PeerRegion != "" || peering.PeerRoleARN != "") {
+			return fmt.Errorf("%[1]s.peerOwnerID, %[1]s.peerRegion and %[1]s.peerRoleARN cannot be set with %[1]s.peeringConnectionID", field)
+		}
+		cidrs, err := validateDestinationCIDRs(field, peering.DestinationCIDRs, destinations)
+		if err != nil {
+			return err
+		}
+		peering.DestinationCIDRs = cidrs
+	}
+	return nil
+}
+
+func validateDestinationCIDRs(field string, destinationCIDRs []string, destinations map[string]bool) ([]string, error) {
+	if len(destinationCIDRs) == 0 {
+		return nil, fmt.Errorf("%s.destinationCIDRs must be set", field)
+	}
+	cidrs, err := validateCIDRs(destinationCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s.destinationCIDRs: %w", field, err)
+	}
+	for _, cidr := range cidrs {
+		if destinations[cidr] {
+			return nil, fmt.Errorf("%s.destinationCIDRs: CIDR %s is routed more than once", field, cidr)
+		}
+		destinations[cidr] = true
+	}
+	return cidrs, nil
+}
+
+func (c *ClusterConfig) validateSecurityGroupPolicy() error {
+	switch c.VPC.SecurityGroupPolicy {
+	case "", SecurityGroupPolicyDefault:
+		if len(c.VPC.WebhookPorts) > 0 {
+			return fmt.Errorf("vpc.webhookPorts can only be set when vpc.securityGroupPolicy is %q", SecurityGroupPolicyStrict)
+		}
+	case SecurityGroupPolicyStrict:
+		seen := map[int]bool{}
+		for i, port := range c.VPC.WebhookPorts {
+			if port < 1 || port > 65535 {
+				return fmt.Errorf("vpc.webhookPorts[%d]: invalid port %d", i, port)
+			}
+			if seen[port] {
+				return fmt.Errorf("found duplicate port in vpc.webhookPorts: %d", port)
+			}
+			seen[port] = true
+		}
+	default:
+		return fmt.Errorf("invalid value %q for vpc.securityGroupPolicy, must be one of %q or %q", c.VPC.SecurityGroupPolicy, SecurityGroupPolicyDefault, SecurityGroupPolicyStrict)
+	}
+	return nil
+}
+
+func (c *ClusterConfig) validateVPCEndpoints() error {
+	endpoints := c.VPC.Endpoints
+	if endpoints == nil {
+		return nil
+	}
+	if c.IsFullyPrivate() {
+		return errors.New("vpc.endpoints is not supported with fully-private clusters, use privateCluster.additionalEndpointServices instead")
+	}
+	if len(endpoints.Services) == 0 {
+		return errors.New("vpc.endpoints.services must be set")
+	}
+	seen := map[string]bool{}
+	for i, service := range endpoints.Services {
+		if _, err := MapEndpointServices([]string{service.Name}); err != nil {
+			return fmt.Errorf("vpc.endpoints.services[%d]: %w", i, err)
+		}
+		if seen[service.Name] {
+			return fmt.Errorf("found duplicate endpoint service in vpc.endpoints.services: %q", service.Name)
+		}
+		seen[service.Name] = true
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("ClusterConfig validation", func() {
@@ -692,8 +693,94 @@ var _ = Describe("ClusterConfig validation", func() {
 			},
 			expectedErr: "cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types",
 		}),
+
+		Entry("valid KMS key and subscription filters", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes:       []string{"audit"},
+				LogGroupKMSKeyARN: "arn:aws:kms:us-west-2:123456789012:key/abcd",
+				SubscriptionFilters: []api.LogSubscriptionFilter{
+					{Name: "to-firehose", DestinationARN: "arn:aws:firehose:us-west-2:123456789012:deliverystream/audit", RoleARN: "arn:aws:iam::123456789012:role/cwl-to-firehose"},
+					{Name: "to-lambda", FilterPattern: `{ $.verb = "delete" }`, DestinationARN: "arn:aws:lambda:us-west-2:123456789012:function:alert"},
+				},
+			},
+		}),
+
+		Entry("invalid KMS key", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes:       []string{"audit"},
+				LogGroupKMSKeyARN: "abcd",
+			},
+			expectedErr: `invalid value "abcd" for cloudWatch.clusterLogging.logGroupKMSKeyARN`,
+		}),
+
+		Entry("subscription filter without destination", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes:         []string{"audit"},
+				SubscriptionFilters: []api.LogSubscriptionFilter{{Name: "to-firehose"}},
+			},
+			expectedErr: "cloudWatch.clusterLogging.subscriptionFilters[0].destinationARN must be set",
+		}),
+
+		Entry("too many subscription filters", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes: []string{"audit"},
+				SubscriptionFilters: []api.LogSubscriptionFilter{
+					{Name: "a", DestinationARN: "arn:aws:lambda:us-west-2:123456789012:function:a"},
+					{Name: "b", DestinationARN: "arn:aws:lambda:us-west-2:123456789012:function:b"},
+					{Name: "c", DestinationARN: "arn:aws:lambda:us-west-2:123456789012:function:c"},
+				},
+			},
+			expectedErr: "at most 2 subscription filters can be set",
+		}),
+
+		Entry("subscription filters without enableTypes", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				SubscriptionFilters: []api.LogSubscriptionFilter{{Name: "a", DestinationARN: "arn:aws:lambda:us-west-2:123456789012:function:a"}},
+			},
+			expectedErr: "cannot set cloudWatch.clusterLogging.logGroupKMSKeyARN or cloudWatch.clusterLogging.subscriptionFilters without enabling log types",
+		}),
 	)
 
+	Describe("observability", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "dev"
+		})
+
+		It("appends the addons of the observability stacks", func() {
+			cfg.Observability = &api.Observability{
+				CloudWatch: &api.CloudWatchObservability{ContainerLogs: api.Disabled(), LogRetentionInDays: 14},
+				ADOT:       &api.ADOTObservability{Version: "latest"},
+			}
+			cfg.Addons = []*api.Addon{{Name: api.PodIdentityAgentAddon}}
+
+			Expect(cfg.AppendObservabilityAddons()).To(Succeed())
+			Expect(cfg.Addons).To(Equal([]*api.Addon{
+				{Name: api.PodIdentityAgentAddon},
+				{Name: api.CloudWatchObservabilityAddon, ConfigurationValues: `{"containerLogs":{"enabled":false}}`, UseDefaultPodIdentityAssociations: true},
+				{Name: api.ADOTAddon, Version: "latest", UseDefaultPodIdentityAssociations: true},
+			}))
+			Expect(cfg.ContainerInsightsLogGroups()).To(Equal([]string{"/aws/containerinsights/dev/performance"}))
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		})
+
+		It("keeps addons already set in the config", func() {
+			cfg.Observability = &api.Observability{CloudWatch: &api.CloudWatchObservability{}}
+			cfg.Addons = []*api.Addon{{Name: api.CloudWatchObservabilityAddon, Version: "v1.5.0-eksbuild.1"}}
+
+			Expect(cfg.AppendObservabilityAddons()).To(Succeed())
+			Expect(cfg.Addons).To(Equal([]*api.Addon{{Name: api.CloudWatchObservabilityAddon, Version: "v1.5.0-eksbuild.1"}}))
+			Expect(cfg.ContainerInsightsLogGroups()).To(HaveLen(4))
+		})
+
+		It("rejects invalid log retention", func() {
+			cfg.Observability = &api.Observability{CloudWatch: &api.CloudWatchObservability{LogRetentionInDays: 42}}
+			Expect(cfg.AppendObservabilityAddons()).To(MatchError(ContainSubstring("invalid value 42 for observability.cloudWatch.logRetentionInDays")))
+		})
+	})
+
 	type vpcHostnameTypeEntry struct {
 		vpc         *api.ClusterVPC
 		expectedErr string
@@ -1290,6 +1377,34 @@ var _ = Describe("ClusterConfig validation", func() {
 					})
 				})
 
+				When("ipFamily is set to IPv6 and vpc.ipv6.nat64 is enabled in a fully-private cluster", func() {
+					It("returns an error", func() {
+						cfg.Metadata.Version = api.Version1_22
+						cfg.IAM = &api.ClusterIAM{
+							WithOIDC: api.Enabled(),
+						}
+						cfg.Addons = append(cfg.Addons,
+							&api.Addon{Name: api.KubeProxyAddon},
+							&api.Addon{Name: api.CoreDNSAddon},
+							&api.Addon{Name: api.VPCCNIAddon},
+						)
+						cfg.VPC.NAT = nil
+						cfg.VPC.IPv6 = &api.VPCIPv6{NAT64: api.Enabled()}
+						cfg.PrivateCluster = &api.PrivateCluster{Enabled: true}
+						err = cfg.ValidateVPCConfig()
+						Expect(err).To(MatchError("vpc.ipv6.nat64 is not supported with fully-private clusters, as they have no NAT gateway"))
+					})
+				})
+
+				When("ipFamily is set to IPv4 and vpc.ipv6 is defined", func() {
+					It("returns an error", func() {
+						cfg.KubernetesNetworkConfig.IPFamily = api.IPV4Family
+						cfg.VPC.IPv6 = &api.VPCIPv6{EgressOnlyInternetGateway: api.Disabled()}
+						err = cfg.ValidateVPCConfig()
+						Expect(err).To(MatchError("vpc.ipv6 is only supported when ipFamily is set to IPv6"))
+					})
+				})
+
 				When("ipFamily is set to IPv6 and serviceIPv4CIDR is not empty", func() {
 					It("returns an error", func() {
 						cfg.Metadata.Version = api.Version1_22
@@ -1490,6 +1605,293 @@ var _ = Describe("ClusterConfig validation", func() {
 				updateVPC: func(v *api.ClusterVPC) {},
 			}),
 		)
+
+		DescribeTable("vpc.ipamPoolID", func(e vpcSecurityGroupEntry) {
+			e.updateVPC(cfg.VPC)
+			err := cfg.ValidateVPCConfig()
+			if e.expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+			Entry("pool with netmask length and secondary CIDRs", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.IPAMNetmaskLength = aws.Int(20)
+					v.IPAMSecondaryNetmaskLengths = []int{18}
+				},
+			}),
+			Entry("pool with capacity", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.Capacity = &api.VPCCapacity{MaxNodes: 50}
+				},
+			}),
+			Entry("netmask length without pool", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMNetmaskLength = aws.Int(20)
+				},
+				expectedErr: "require vpc.ipamPoolID to be set",
+			}),
+			Entry("pool with an existing VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.ID = "vpc-1234"
+				},
+				expectedErr: "vpc.ipamPoolID cannot be used with an existing VPC or subnets",
+			}),
+			Entry("pool with a custom CIDR", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.CIDR = ipnet.MustParseCIDR("10.0.0.0/16")
+				},
+				expectedErr: "vpc.cidr cannot be set when vpc.ipamPoolID is set",
+			}),
+			Entry("netmask length out of range", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.IPAMNetmaskLength = aws.Int(12)
+				},
+				expectedErr: "vpc.ipamNetmaskLength must be between 16 and 24, got 12",
+			}),
+			Entry("secondary netmask length out of range", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.IPAMSecondaryNetmaskLengths = []int{29}
+				},
+				expectedErr: "vpc.ipamSecondaryNetmaskLengths must be between 16 and 28, got 29",
+			}),
+			Entry("both netmask length and capacity", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.IPAMNetmaskLength = aws.Int(20)
+					v.Capacity = &api.VPCCapacity{MaxNodes: 50}
+				},
+				expectedErr: "only one of vpc.ipamNetmaskLength and vpc.capacity can be specified",
+			}),
+			Entry("capacity without nodes", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.IPAMPoolID = "ipam-pool-1234"
+					v.Capacity = &api.VPCCapacity{}
+				},
+				expectedErr: "vpc.capacity.maxNodes must be greater than 0",
+			}),
+		)
+
+		DescribeTable("vpc.podSubnets", func(e vpcSecurityGroupEntry) {
+			e.updateVPC(cfg.VPC)
+			err := cfg.ValidateVPCConfig()
+			if e.expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+			Entry("CIDR", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.PodSubnets = &api.PodSubnets{CIDR: ipnet.MustParseCIDR("100.64.0.0/16")}
+				},
+			}),
+			Entry("pre-existing subnets in a pre-existing VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.ID = "vpc-1234"
+					v.PodSubnets = &api.PodSubnets{Subnets: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						"us-west-2a": {ID: "subnet-1234"},
+					})}
+				},
+			}),
+			Entry("both CIDR and subnets", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.ID = "vpc-1234"
+					v.PodSubnets = &api.PodSubnets{
+						CIDR:    ipnet.MustParseCIDR("100.64.0.0/16"),
+						Subnets: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{"us-west-2a": {ID: "subnet-1234"}}),
+					}
+				},
+				expectedErr: "only one of vpc.podSubnets.cidr and vpc.podSubnets.subnets can be specified",
+			}),
+			Entry("CIDR too large", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.PodSubnets = &api.PodSubnets{CIDR: ipnet.MustParseCIDR("100.64.0.0/10")}
+				},
+				expectedErr: "vpc.podSubnets.cidr prefix must be between /16 and /28, got /10",
+			}),
+			Entry("subnets in a new VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.PodSubnets = &api.PodSubnets{Subnets: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						"us-west-2a": {ID: "subnet-1234"},
+					})}
+				},
+				expectedErr: "vpc.podSubnets.subnets can only be used with a pre-existing VPC",
+			}),
+			Entry("subnets without IDs", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.ID = "vpc-1234"
+					v.PodSubnets = &api.PodSubnets{Subnets: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						"us-west-2a": {AZ: "us-west-2a"},
+					})}
+				},
+				expectedErr: "vpc.podSubnets.subnets[us-west-2a].id must be set",
+			}),
+		)
+
+		DescribeTable("vpc.transitGateway and vpc.peerings", func(e vpcSecurityGroupEntry) {
+			e.updateVPC(cfg.VPC)
+			err := cfg.ValidateVPCConfig()
+			if e.expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+			Entry("transit gateway and peerings", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{ID: "tgw-1234", DestinationCIDRs: []string{"10.0.0.0/8"}}
+					v.Peerings = []api.VPCPeering{
+						{PeerVPCID: "vpc-peer", DestinationCIDRs: []string{"172.16.0.0/16"}},
+						{PeeringConnectionID: "pcx-1234", DestinationCIDRs: []string{"172.17.0.0/16"}},
+					}
+				},
+			}),
+			Entry("transit gateway attachment in a pre-existing VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.ID = "vpc-1234"
+					v.TransitGateway = &api.VPCTransitGateway{AttachmentID: "tgw-attach-1234", DestinationCIDRs: []string{"10.0.0.0/8"}}
+				},
+			}),
+			Entry("transit gateway without ID or attachment", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{DestinationCIDRs: []string{"10.0.0.0/8"}}
+				},
+				expectedErr: "one of vpc.transitGateway.id and vpc.transitGateway.attachmentID must be set",
+			}),
+			Entry("transit gateway attachment in a new VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{AttachmentID: "tgw-attach-1234", DestinationCIDRs: []string{"10.0.0.0/8"}}
+				},
+				expectedErr: "vpc.transitGateway.attachmentID can only be used with a pre-existing VPC",
+			}),
+			Entry("transit gateway without destination CIDRs", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{ID: "tgw-1234"}
+				},
+				expectedErr: "vpc.transitGateway.destinationCIDRs must be set",
+			}),
+			Entry("invalid destination CIDR", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{ID: "tgw-1234", DestinationCIDRs: []string{"10.0.0.0"}}
+				},
+				expectedErr: "invalid vpc.transitGateway.destinationCIDRs",
+			}),
+			Entry("destination CIDR routed twice", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.TransitGateway = &api.VPCTransitGateway{ID: "tgw-1234", DestinationCIDRs: []string{"10.0.0.0/8"}}
+					v.Peerings = []api.VPCPeering{{PeerVPCID: "vpc-peer", DestinationCIDRs: []string{"10.0.0.0/8"}}}
+				},
+				expectedErr: "vpc.peerings[0].destinationCIDRs: CIDR 10.0.0.0/8 is routed more than once",
+			}),
+			Entry("peering with both a peering connection and a peer VPC", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.Peerings = []api.VPCPeering{{PeeringConnectionID: "pcx-1234", PeerVPCID: "vpc-peer", DestinationCIDRs: []string{"172.16.0.0/16"}}}
+				},
+				expectedErr: "exactly one of vpc.peerings[0].peeringConnectionID and vpc.peerings[0].peerVPCID must be set",
+			}),
+		)
+
+		DescribeTable("vpc.endpoints", func(e vpcSecurityGroupEntry) {
+			e.updateVPC(cfg.VPC)
+			err := cfg.ValidateVPCConfig()
+			if e.expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+			Entry("supported services", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.Endpoints = &api.VPCEndpoints{
+						Services: []api.VPCEndpointService{
+							{Name: "s3", PolicyDocument: api.InlineDocument{"Version": "2012-10-17"}},
+							{Name: "ecr.api"},
+							{Name: "ecr.dkr"},
+						},
+					}
+				},
+			}),
+			Entry("no services", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.Endpoints = &api.VPCEndpoints{}
+				},
+				expectedErr: "vpc.endpoints.services must be set",
+			}),
+			Entry("unsupported service", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.Endpoints = &api.VPCEndpoints{Services: []api.VPCEndpointService{{Name: "s3"}, {Name: "dynamodb"}}}
+				},
+				expectedErr: `vpc.endpoints.services[1]: unsupported endpoint service "dynamodb"`,
+			}),
+			Entry("duplicate service", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.Endpoints = &api.VPCEndpoints{Services: []api.VPCEndpointService{{Name: "sts"}, {Name: "sts"}}}
+				},
+				expectedErr: `found duplicate endpoint service in vpc.endpoints.services: "sts"`,
+			}),
+		)
+
+		DescribeTable("vpc.securityGroupPolicy", func(e vpcSecurityGroupEntry) {
+			e.updateVPC(cfg.VPC)
+			err := cfg.ValidateVPCConfig()
+			if e.expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+			Entry("default policy", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.SecurityGroupPolicy = api.SecurityGroupPolicyDefault
+				},
+			}),
+			Entry("strict policy with webhook ports", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+					v.WebhookPorts = []int{8443, 9443}
+				},
+			}),
+			Entry("unknown policy", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.SecurityGroupPolicy = "permissive"
+				},
+				expectedErr: `invalid value "permissive" for vpc.securityGroupPolicy`,
+			}),
+			Entry("webhook ports without the strict policy", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.WebhookPorts = []int{9443}
+				},
+				expectedErr: `vpc.webhookPorts can only be set when vpc.securityGroupPolicy is "strict"`,
+			}),
+			Entry("invalid webhook port", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+					v.WebhookPorts = []int{9443, 70000}
+				},
+				expectedErr: "vpc.webhookPorts[1]: invalid port 70000",
+			}),
+			Entry("duplicate webhook port", vpcSecurityGroupEntry{
+				updateVPC: func(v *api.ClusterVPC) {
+					v.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+					v.WebhookPorts = []int{9443, 9443}
+				},
+				expectedErr: "found duplicate port in vpc.webhookPorts: 9443",
+			}),
+		)
+
+		It("rejects vpc.endpoints in fully-private clusters", func() {
+			cfg.PrivateCluster = &api.PrivateCluster{Enabled: true}
+			cfg.VPC.Endpoints = &api.VPCEndpoints{Services: []api.VPCEndpointService{{Name: "s3"}}}
+			Expect(cfg.ValidateVPCConfig()).To(MatchError(ContainSubstring("vpc.endpoints is not supported with fully-private clusters")))
+		})
 	})
 
 	Describe("ValidatePrivateCluster", func() {
@@ -2289,6 +2691,100 @@ var _ = Describe("ClusterConfig validation", func() {
 			}
 			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("failed to validate Karpenter config: minimum supported version is v0.20.0")))
 		})
+
+		Context("NodePools", func() {
+			var cfg *api.ClusterConfig
+
+			BeforeEach(func() {
+				cfg = api.NewClusterConfig()
+				cfg.IAM.WithOIDC = aws.Bool(true)
+				cfg.Karpenter = &api.Karpenter{
+					Version: "1.0.6",
+					NodePools: []*api.KarpenterNodePool{
+						{
+							Name: "general",
+						},
+					},
+				}
+			})
+
+			It("defaults the NodeClass and requirements", func() {
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+				Expect(cfg.Karpenter.NodeClasses).To(HaveLen(1))
+				nodeClass := cfg.Karpenter.NodeClasses[0]
+				Expect(nodeClass.Name).To(Equal(api.DefaultKarpenterNodeClassName))
+				Expect(nodeClass.AMIFamily).To(Equal(api.KarpenterAMIFamilyAL2023))
+				nodePool := cfg.Karpenter.NodePools[0]
+				Expect(nodePool.NodeClass).To(Equal(api.DefaultKarpenterNodeClassName))
+				Expect(nodePool.Requirements).To(ConsistOf(api.KarpenterRequirement{
+					Key:      api.KarpenterCapacityTypeLabel,
+					Operator: "In",
+					Values:   []string{"on-demand"},
+				}))
+			})
+
+			It("returns an error when the Karpenter version does not support NodePools", func() {
+				cfg.Karpenter.Version = "v0.31.0"
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("require Karpenter version v0.32.0 or newer")))
+			})
+
+			It("returns an error when a NodePool references an undefined NodeClass", func() {
+				cfg.Karpenter.NodePools[0].NodeClass = "missing"
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring(`NodeClass "missing" is not defined in karpenter.nodeClasses`)))
+			})
+
+			It("returns an error for duplicate NodePools", func() {
+				cfg.Karpenter.NodePools = append(cfg.Karpenter.NodePools, &api.KarpenterNodePool{Name: "general"})
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring(`found duplicate NodePool "general"`)))
+			})
+
+			It("returns an error for an invalid requirement operator", func() {
+				cfg.Karpenter.NodePools[0].Requirements = []api.KarpenterRequirement{
+					{
+						Key:      "karpenter.k8s.aws/instance-family",
+						Operator: "Equals",
+					},
+				}
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring(`invalid operator "Equals"`)))
+			})
+
+			It("returns an error when both role and instanceProfile are set", func() {
+				cfg.Karpenter.NodeClasses = []*api.KarpenterNodeClass{
+					{
+						Name:            "default",
+						Role:            "role",
+						InstanceProfile: "profile",
+					},
+				}
+				api.SetClusterConfigDefaults(cfg)
+				Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("cannot set both role and instanceProfile")))
+			})
+
+			DescribeTable("consolidation policy", func(version, policy, expectedErr string) {
+				cfg.Karpenter.Version = version
+				cfg.Karpenter.NodePools[0].Disruption = &api.KarpenterDisruption{
+					ConsolidationPolicy: policy,
+				}
+				api.SetClusterConfigDefaults(cfg)
+				err := api.ValidateClusterConfig(cfg)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+				Entry("WhenEmptyOrUnderutilized with v1", "1.0.6", api.KarpenterConsolidationPolicyWhenEmptyOrUnderutilized, ""),
+				Entry("WhenUnderutilized with v1", "1.0.6", api.KarpenterConsolidationPolicyWhenUnderutilized, "is not supported by Karpenter v1.0.0 or newer"),
+				Entry("WhenUnderutilized before v1", "0.37.0", api.KarpenterConsolidationPolicyWhenUnderutilized, ""),
+				Entry("WhenEmptyOrUnderutilized before v1", "0.37.0", api.KarpenterConsolidationPolicyWhenEmptyOrUnderutilized, "requires Karpenter v1.0.0 or newer"),
+				Entry("unknown policy", "1.0.6", "Never", `invalid consolidationPolicy "Never"`),
+			)
+		})
 	})
 
 	type labelsTaintsEntry struct {
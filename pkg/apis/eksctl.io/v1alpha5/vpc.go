@@ -29,6 +29,17 @@ const (
 	ClusterNATDefault = ClusterSingleNAT
 )
 
+// Values for `SecurityGroupPolicy`
+const (
+	// SecurityGroupPolicyDefault allows traffic on all ports between nodes, and on all non-privileged ports
+	// from the control plane to nodes
+	SecurityGroupPolicyDefault = "default"
+
+	// SecurityGroupPolicyStrict only allows the traffic required by Kubernetes between nodes and from the
+	// control plane to nodes
+	SecurityGroupPolicyStrict = "strict"
+)
+
 // AZSubnetMapping holds subnet to AZ mappings.
 // If the key is an AZ, that also becomes the name of the subnet
 // otherwise use the key to refer to this subnet.
@@ -153,6 +164,39 @@ type (
 		// private subnets or any ad-hoc subnets
 		// +optional
 		ExtraIPv6CIDRs []string `json:"extraIPv6CIDRs,omitempty"`
+		// IPAMPoolID is the ID of an Amazon VPC IP Address Manager (IPAM) IPv4 pool
+		// to allocate the VPC CIDR from, instead of using `cidr`.
+		// Subnets are carved from the allocated CIDR
+		// +optional
+		IPAMPoolID string `json:"ipamPoolID,omitempty"`
+		// IPAMNetmaskLength is the netmask length of the VPC CIDR allocated from `ipamPoolID`.
+		// Defaults to the smallest VPC whose subnets fit `capacity`, or to `16` when `capacity` is not set
+		// +optional
+		IPAMNetmaskLength *int `json:"ipamNetmaskLength,omitempty"`
+		// IPAMSecondaryNetmaskLengths are the netmask lengths of secondary CIDRs
+		// to allocate from `ipamPoolID` and associate with the VPC
+		// +optional
+		IPAMSecondaryNetmaskLengths []int `json:"ipamSecondaryNetmaskLengths,omitempty"`
+		// Capacity is the expected number of nodes and pods, used to size the
+		// VPC allocated from `ipamPoolID`
+		// +optional
+		Capacity *VPCCapacity `json:"capacity,omitempty"`
+		// PodSubnets enables VPC CNI custom networking, where pods are assigned IPs
+		// from dedicated subnets instead of the subnets of their nodes
+		// +optional
+		PodSubnets *PodSubnets `json:"podSubnets,omitempty"`
+		// TransitGateway attaches the VPC to a transit gateway, and routes traffic
+		// from the private subnets to the transit gateway
+		// +optional
+		TransitGateway *VPCTransitGateway `json:"transitGateway,omitempty"`
+		// Peerings are VPC peering connections that traffic from the private subnets
+		// is routed through
+		// +optional
+		Peerings []VPCPeering `json:"peerings,omitempty"`
+		// Endpoints creates VPC endpoints for AWS services in clusters that are not fully private,
+		// so that traffic from the private subnets to these services does not go through the NAT gateway
+		// +optional
+		Endpoints *VPCEndpoints `json:"endpoints,omitempty"`
 		// for pre-defined shared node SG
 		SharedNodeSecurityGroup string `json:"sharedNodeSecurityGroup,omitempty"`
 		// Automatically add security group rules to and from the default
@@ -163,11 +207,25 @@ type (
 		// Defaults to `true`
 		// +optional
 		ManageSharedNodeSecurityGroupRules *bool `json:"manageSharedNodeSecurityGroupRules,omitempty"`
+		// SecurityGroupPolicy sets how permissive the rules of the security groups created by eksctl are.
+		// With `strict`, nodes only accept kubelet, DNS and VPC CNI metrics traffic from other nodes, and kubelet,
+		// HTTPS and `webhookPorts` traffic from the control plane, instead of traffic on all ports.
+		// Valid variants are `SecurityGroupPolicy` constants.
+		// Defaults to `"default"`
+		// +optional
+		SecurityGroupPolicy string `json:"securityGroupPolicy,omitempty"`
+		// WebhookPorts are the TCP ports of admission webhooks and extension API servers running on nodes,
+		// which the control plane is allowed to reach when `securityGroupPolicy` is `strict`
+		// +optional
+		WebhookPorts []int `json:"webhookPorts,omitempty"`
 		// AutoAllocateIPV6 requests an IPv6 CIDR block with /56 prefix for the VPC
 		// +optional
 		AutoAllocateIPv6 *bool `json:"autoAllocateIPv6,omitempty"`
 		// +optional
 		NAT *ClusterNAT `json:"nat,omitempty"`
+		// IPv6 configures how the subnets of IPv6 clusters reach the internet
+		// +optional
+		IPv6 *VPCIPv6 `json:"ipv6,omitempty"`
 		// See [managing access to API](/usage/vpc-networking/#managing-access-to-the-kubernetes-api-server-endpoints)
 		// +optional
 		ClusterEndpoints *ClusterEndpoints `json:"clusterEndpoints,omitempty"`
@@ -182,6 +240,106 @@ type (
 		// +optional
 		ControlPlaneSecurityGroupIDs []string `json:"controlPlaneSecurityGroupIDs,omitempty"`
 	}
+	// VPCCapacity holds the expected number of nodes and pods of the cluster
+	VPCCapacity struct {
+		// MaxNodes is the maximum number of nodes of the cluster
+		MaxNodes int `json:"maxNodes"`
+		// MaxPodsPerNode is the maximum number of pods per node, each of which
+		// is assigned an IP from the private subnets.
+		// Defaults to `110`
+		// +optional
+		MaxPodsPerNode int `json:"maxPodsPerNode,omitempty"`
+	}
+	// PodSubnets holds the subnets pods are assigned IPs from when using VPC CNI custom networking
+	PodSubnets struct {
+		// CIDR is a secondary CIDR associated with the VPC, from which a pod subnet is
+		// created in each availability zone of the cluster.
+		// Defaults to `100.64.0.0/16` unless `subnets` is set
+		// +optional
+		CIDR *ipnet.IPNet `json:"cidr,omitempty"`
+		// Subnets are pre-existing pod subnets of the VPC, keyed by availability zone
+		// +optional
+		Subnets AZSubnetMapping `json:"subnets,omitempty"`
+		// SecurityGroups are attached to the network interfaces of pods.
+		// Defaults to the cluster security group
+		// +optional
+		SecurityGroups []string `json:"securityGroups,omitempty"`
+	}
+	// VPCTransitGateway holds the transit gateway attachment of the VPC
+	VPCTransitGateway struct {
+		// ID is the ID of the transit gateway to attach the VPC to
+		// +optional
+		ID string `json:"id,omitempty"`
+		// AttachmentID is the ID of a pre-existing attachment of the VPC to the transit gateway.
+		// When set, eksctl only adds routes to the transit gateway, and `id` can be omitted
+		// +optional
+		AttachmentID string `json:"attachmentID,omitempty"`
+		// DestinationCIDRs are routed from the private subnets to the transit gateway
+		DestinationCIDRs []string `json:"destinationCIDRs"`
+		// Subnets are the availability zones, or the IDs of pre-existing private subnets,
+		// of the subnets to attach, with at most one subnet per availability zone.
+		// Defaults to a private subnet in each availability zone
+		// +optional
+		Subnets []string `json:"subnets,omitempty"`
+	}
+	// VPCPeering holds a VPC peering connection of the VPC
+	VPCPeering struct {
+		// PeeringConnectionID is the ID of a pre-existing VPC peering connection.
+		// When set, eksctl only adds routes to the peering connection
+		// +optional
+		PeeringConnectionID string `json:"peeringConnectionID,omitempty"`
+		// PeerVPCID is the ID of the VPC to request a peering connection with
+		// +optional
+		PeerVPCID string `json:"peerVPCID,omitempty"`
+		// PeerOwnerID is the AWS account ID of the owner of the peer VPC.
+		// Defaults to the account of the cluster
+		// +optional
+		PeerOwnerID string `json:"peerOwnerID,omitempty"`
+		// PeerRegion is the region of the peer VPC.
+		// Defaults to the region of the cluster
+		// +optional
+		PeerRegion string `json:"peerRegion,omitempty"`
+		// PeerRoleARN is the ARN of a role in the peer account that accepts the peering connection,
+		// required when the peer VPC is owned by another account
+		// +optional
+		PeerRoleARN string `json:"peerRoleARN,omitempty"`
+		// DestinationCIDRs are routed from the private subnets to the peering connection
+		DestinationCIDRs []string `json:"destinationCIDRs"`
+	}
+	// VPCEndpoints holds the VPC endpoints of a cluster that is not fully private
+	VPCEndpoints struct {
+		// Services are the endpoint services to create VPC endpoints for
+		Services []VPCEndpointService `json:"services"`
+		// ReuseExisting skips creating an endpoint for a service that already has one
+		// in a pre-existing VPC.
+		// Defaults to `true`
+		// +optional
+		ReuseExisting *bool `json:"reuseExisting,omitempty"`
+	}
+	// VPCEndpointService holds a VPC endpoint to create
+	VPCEndpointService struct {
+		// Name is the name of the endpoint service.
+		// Valid entries are `ec2`, `ecr.api`, `ecr.dkr`, `s3`, `sts`, `ssm`, `ssmmessages`,
+		// `ec2messages`, `secretsmanager`, `cloudformation`, `autoscaling` and `logs`
+		Name string `json:"name"`
+		// PolicyDocument is the endpoint policy that controls access to the service.
+		// Defaults to full access
+		// +optional
+		PolicyDocument InlineDocument `json:"policyDocument,omitempty"`
+	}
+	// VPCIPv6 holds the IPv6 egress options of the private subnets of IPv6 clusters
+	VPCIPv6 struct {
+		// EgressOnlyInternetGateway routes outbound IPv6 traffic from private subnets
+		// through an egress-only internet gateway.
+		// Defaults to `true`
+		// +optional
+		EgressOnlyInternetGateway *bool `json:"egressOnlyInternetGateway,omitempty"`
+		// NAT64 enables DNS64 on private subnets and routes the NAT64 prefix `64:ff9b::/96`
+		// through the NAT gateway, so that IPv6-only workloads can reach IPv4-only endpoints.
+		// Defaults to `false`
+		// +optional
+		NAT64 *bool `json:"nat64,omitempty"`
+	}
 	// ClusterSubnets holds private and public subnets
 	ClusterSubnets struct {
 		Private AZSubnetMapping `json:"private,omitempty"`
@@ -254,6 +412,19 @@ func SubnetTopologies() []SubnetTopology {
 	}
 }
 
+// DefaultVPCMaxPodsPerNode is the default value of VPCCapacity.MaxPodsPerNode
+const DefaultVPCMaxPodsPerNode = 110
+
+// DefaultPodSubnetsCIDR returns the default secondary CIDR of pod subnets
+func DefaultPodSubnetsCIDR() ipnet.IPNet {
+	return ipnet.IPNet{
+		IPNet: net.IPNet{
+			IP:   []byte{100, 64, 0, 0},
+			Mask: []byte{255, 255, 0, 0},
+		},
+	}
+}
+
 // DefaultCIDR returns default global CIDR for VPC
 func DefaultCIDR() ipnet.IPNet {
 	return ipnet.IPNet{
@@ -385,6 +556,12 @@ func (m AZSubnetMapping) getOutpostARN() (outpostARN string, found bool) {
 	return "", false
 }
 
+// HasStrictSecurityGroupPolicy returns true if the security groups created by eksctl only allow the traffic
+// required by Kubernetes
+func (v *ClusterVPC) HasStrictSecurityGroupPolicy() bool {
+	return v != nil && v.SecurityGroupPolicy == SecurityGroupPolicyStrict
+}
+
 // FindOutpostSubnetsARN finds all subnets that are on Outposts and returns the Outpost ARN.
 func (v *ClusterVPC) FindOutpostSubnetsARN() (outpostARN string, found bool) {
 	outpostARN, found = v.Subnets.Private.getOutpostARN()
@@ -407,6 +584,33 @@ func (c *ClusterConfig) HasAnySubnets() bool {
 	return c.VPC.Subnets != nil && (len(c.VPC.Subnets.Private) > 0 || len(c.VPC.Subnets.Public) > 0)
 }
 
+// HasEgressOnlyInternetGateway returns true if IPv6 traffic from private subnets is routed through an
+// egress-only internet gateway
+func (c *ClusterConfig) HasEgressOnlyInternetGateway() bool {
+	return c.IPv6Enabled() && (c.VPC.IPv6 == nil || !IsDisabled(c.VPC.IPv6.EgressOnlyInternetGateway))
+}
+
+// HasNAT64 returns true if private subnets use DNS64 and route the NAT64 prefix through the NAT gateway
+func (c *ClusterConfig) HasNAT64() bool {
+	return c.IPv6Enabled() && c.VPC.IPv6 != nil && IsEnabled(c.VPC.IPv6.NAT64)
+}
+
+// HasPrivateSubnetRoutes returns true if routes to a transit gateway or VPC peering connections are added to the
+// route tables of private subnets
+func (c *ClusterConfig) HasPrivateSubnetRoutes() bool {
+	return c.VPC != nil && (c.VPC.TransitGateway != nil || len(c.VPC.Peerings) > 0)
+}
+
+// HasVPCEndpoints returns true if VPC endpoints are created for a cluster that is not fully private
+func (c *ClusterConfig) HasVPCEndpoints() bool {
+	return c.VPC != nil && c.VPC.Endpoints != nil && len(c.VPC.Endpoints.Services) > 0
+}
+
+// HasCustomNetworking returns true if pods are assigned IPs from dedicated pod subnets
+func (c *ClusterConfig) HasCustomNetworking() bool {
+	return c.VPC != nil && c.VPC.PodSubnets != nil
+}
+
 // HasSufficientPrivateSubnets validates if there is a sufficient
 // number of private subnets available to create a cluster
 func (c *ClusterConfig) HasSufficientPrivateSubnets() bool {
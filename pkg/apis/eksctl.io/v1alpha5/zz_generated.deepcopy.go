@@ -29,6 +29,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ADOTObservability) DeepCopyInto(out *ADOTObservability) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ADOTObservability.
+func (in *ADOTObservability) DeepCopy() *ADOTObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(ADOTObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ARN) DeepCopyInto(out *ARN) {
 	*out = *in
@@ -255,6 +271,27 @@ func (in *AddonsConfig) DeepCopy() *AddonsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssumeRole) DeepCopyInto(out *AssumeRole) {
+	*out = *in
+	if in.RoleARNs != nil {
+		in, out := &in.RoleARNs, &out.RoleARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssumeRole.
+func (in *AssumeRole) DeepCopy() *AssumeRole {
+	if in == nil {
+		return nil
+	}
+	out := new(AssumeRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoModeConfig) DeepCopyInto(out *AutoModeConfig) {
 	*out = *in
@@ -338,6 +375,27 @@ func (in *CapacityReservationTarget) DeepCopy() *CapacityReservationTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudWatchObservability) DeepCopyInto(out *CloudWatchObservability) {
+	*out = *in
+	if in.ContainerLogs != nil {
+		in, out := &in.ContainerLogs, &out.ContainerLogs
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudWatchObservability.
+func (in *CloudWatchObservability) DeepCopy() *CloudWatchObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudWatchObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCloudWatch) DeepCopyInto(out *ClusterCloudWatch) {
 	*out = *in
@@ -367,6 +425,11 @@ func (in *ClusterCloudWatchLogging) DeepCopyInto(out *ClusterCloudWatchLogging)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubscriptionFilters != nil {
+		in, out := &in.SubscriptionFilters, &out.SubscriptionFilters
+		*out = make([]LogSubscriptionFilter, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -394,16 +457,16 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(KubernetesNetworkConfig)
 		**out = **in
 	}
-	if in.RemoteNetworkConfig != nil {
-		in, out := &in.RemoteNetworkConfig, &out.RemoteNetworkConfig
-		*out = new(RemoteNetworkConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.AutoModeConfig != nil {
 		in, out := &in.AutoModeConfig, &out.AutoModeConfig
 		*out = new(AutoModeConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RemoteNetworkConfig != nil {
+		in, out := &in.RemoteNetworkConfig, &out.RemoteNetworkConfig
+		*out = new(RemoteNetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IAM != nil {
 		in, out := &in.IAM, &out.IAM
 		*out = new(ClusterIAM)
@@ -507,6 +570,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(SecretsEncryption)
 		**out = **in
 	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(Observability)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(ClusterStatus)
@@ -802,6 +870,11 @@ func (in *ClusterMeta) DeepCopyInto(out *ClusterMeta) {
 			(*out)[key] = val
 		}
 	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -916,11 +989,53 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPAMNetmaskLength != nil {
+		in, out := &in.IPAMNetmaskLength, &out.IPAMNetmaskLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.IPAMSecondaryNetmaskLengths != nil {
+		in, out := &in.IPAMSecondaryNetmaskLengths, &out.IPAMSecondaryNetmaskLengths
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(VPCCapacity)
+		**out = **in
+	}
+	if in.PodSubnets != nil {
+		in, out := &in.PodSubnets, &out.PodSubnets
+		*out = new(PodSubnets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(VPCTransitGateway)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Peerings != nil {
+		in, out := &in.Peerings, &out.Peerings
+		*out = make([]VPCPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(VPCEndpoints)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ManageSharedNodeSecurityGroupRules != nil {
 		in, out := &in.ManageSharedNodeSecurityGroupRules, &out.ManageSharedNodeSecurityGroupRules
 		*out = new(bool)
 		**out = **in
 	}
+	if in.WebhookPorts != nil {
+		in, out := &in.WebhookPorts, &out.WebhookPorts
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 	if in.AutoAllocateIPv6 != nil {
 		in, out := &in.AutoAllocateIPv6, &out.AutoAllocateIPv6
 		*out = new(bool)
@@ -931,6 +1046,11 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = new(ClusterNAT)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(VPCIPv6)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterEndpoints != nil {
 		in, out := &in.ClusterEndpoints, &out.ClusterEndpoints
 		*out = new(ClusterEndpoints)
@@ -1307,6 +1427,28 @@ func (in *Karpenter) DeepCopyInto(out *Karpenter) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]*KarpenterNodePool, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(KarpenterNodePool)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.NodeClasses != nil {
+		in, out := &in.NodeClasses, &out.NodeClasses
+		*out = make([]*KarpenterNodeClass, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(KarpenterNodeClass)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -1320,6 +1462,172 @@ func (in *Karpenter) DeepCopy() *Karpenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterDisruption) DeepCopyInto(out *KarpenterDisruption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterDisruption.
+func (in *KarpenterDisruption) DeepCopy() *KarpenterDisruption {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterDisruption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterNodeClass) DeepCopyInto(out *KarpenterNodeClass) {
+	*out = *in
+	if in.AMISelectorTerms != nil {
+		in, out := &in.AMISelectorTerms, &out.AMISelectorTerms
+		*out = make([]KarpenterSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SubnetSelectorTerms != nil {
+		in, out := &in.SubnetSelectorTerms, &out.SubnetSelectorTerms
+		*out = make([]KarpenterSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroupSelectorTerms != nil {
+		in, out := &in.SecurityGroupSelectorTerms, &out.SecurityGroupSelectorTerms
+		*out = make([]KarpenterSelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeSize != nil {
+		in, out := &in.VolumeSize, &out.VolumeSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.VolumeType != nil {
+		in, out := &in.VolumeType, &out.VolumeType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterNodeClass.
+func (in *KarpenterNodeClass) DeepCopy() *KarpenterNodeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterNodeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterNodePool) DeepCopyInto(out *KarpenterNodePool) {
+	*out = *in
+	if in.Requirements != nil {
+		in, out := &in.Requirements, &out.Requirements
+		*out = make([]KarpenterRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]NodeGroupTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Disruption != nil {
+		in, out := &in.Disruption, &out.Disruption
+		*out = new(KarpenterDisruption)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterNodePool.
+func (in *KarpenterNodePool) DeepCopy() *KarpenterNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterRequirement) DeepCopyInto(out *KarpenterRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterRequirement.
+func (in *KarpenterRequirement) DeepCopy() *KarpenterRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterSelectorTerm) DeepCopyInto(out *KarpenterSelectorTerm) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterSelectorTerm.
+func (in *KarpenterSelectorTerm) DeepCopy() *KarpenterSelectorTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterSelectorTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesNetworkConfig) DeepCopyInto(out *KubernetesNetworkConfig) {
 	*out = *in
@@ -1357,6 +1665,22 @@ func (in *LaunchTemplate) DeepCopy() *LaunchTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSubscriptionFilter) DeepCopyInto(out *LogSubscriptionFilter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSubscriptionFilter.
+func (in *LogSubscriptionFilter) DeepCopy() *LogSubscriptionFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSubscriptionFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedNodeGroup) DeepCopyInto(out *ManagedNodeGroup) {
 	*out = *in
@@ -2011,6 +2335,32 @@ func (in *OIDCIdentityProvider) DeepCopy() *OIDCIdentityProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Observability) DeepCopyInto(out *Observability) {
+	*out = *in
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(CloudWatchObservability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ADOT != nil {
+		in, out := &in.ADOT, &out.ADOT
+		*out = new(ADOTObservability)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Observability.
+func (in *Observability) DeepCopy() *Observability {
+	if in == nil {
+		return nil
+	}
+	out := new(Observability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Outpost) DeepCopyInto(out *Outpost) {
 	*out = *in
@@ -2078,6 +2428,38 @@ func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSubnets) DeepCopyInto(out *PodSubnets) {
+	*out = *in
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = (*in).DeepCopy()
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(AZSubnetMapping, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSubnets.
+func (in *PodSubnets) DeepCopy() *PodSubnets {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSubnets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateCluster) DeepCopyInto(out *PrivateCluster) {
 	*out = *in
@@ -2102,6 +2484,7 @@ func (in *PrivateCluster) DeepCopy() *PrivateCluster {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Profile) DeepCopyInto(out *Profile) {
 	*out = *in
+	in.AssumeRole.DeepCopyInto(&out.AssumeRole)
 	return
 }
 
@@ -2118,7 +2501,7 @@ func (in *Profile) DeepCopy() *Profile {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
-	out.Profile = in.Profile
+	in.Profile.DeepCopyInto(&out.Profile)
 	return
 }
 
@@ -2279,6 +2662,140 @@ func (in *SecretsEncryption) DeepCopy() *SecretsEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCCapacity) DeepCopyInto(out *VPCCapacity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCCapacity.
+func (in *VPCCapacity) DeepCopy() *VPCCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCEndpointService) DeepCopyInto(out *VPCEndpointService) {
+	*out = *in
+	in.PolicyDocument.DeepCopyInto(&out.PolicyDocument)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCEndpointService.
+func (in *VPCEndpointService) DeepCopy() *VPCEndpointService {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCEndpointService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCEndpoints) DeepCopyInto(out *VPCEndpoints) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]VPCEndpointService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReuseExisting != nil {
+		in, out := &in.ReuseExisting, &out.ReuseExisting
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCEndpoints.
+func (in *VPCEndpoints) DeepCopy() *VPCEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCIPv6) DeepCopyInto(out *VPCIPv6) {
+	*out = *in
+	if in.EgressOnlyInternetGateway != nil {
+		in, out := &in.EgressOnlyInternetGateway, &out.EgressOnlyInternetGateway
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NAT64 != nil {
+		in, out := &in.NAT64, &out.NAT64
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCIPv6.
+func (in *VPCIPv6) DeepCopy() *VPCIPv6 {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCIPv6)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeering) DeepCopyInto(out *VPCPeering) {
+	*out = *in
+	if in.DestinationCIDRs != nil {
+		in, out := &in.DestinationCIDRs, &out.DestinationCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeering.
+func (in *VPCPeering) DeepCopy() *VPCPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCTransitGateway) DeepCopyInto(out *VPCTransitGateway) {
+	*out = *in
+	if in.DestinationCIDRs != nil {
+		in, out := &in.DestinationCIDRs, &out.DestinationCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCTransitGateway.
+func (in *VPCTransitGateway) DeepCopy() *VPCTransitGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCTransitGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMapping) DeepCopyInto(out *VolumeMapping) {
 	*out = *in
@@ -3,6 +3,7 @@ package builder
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -77,7 +78,10 @@ func (c *ClusterResourceSet) AddAllResources(ctx context.Context) error {
 
 	clusterSG := c.addResourcesForSecurityGroups(vpcID)
 
-	if privateCluster := c.spec.PrivateCluster; privateCluster.Enabled && !privateCluster.SkipEndpointCreation {
+	if privateCluster := c.spec.PrivateCluster; (privateCluster.Enabled && !privateCluster.SkipEndpointCreation) || c.spec.HasVPCEndpoints() {
+		if c.spec.HasVPCEndpoints() && len(subnetDetails.Private) == 0 {
+			return errors.New("vpc.endpoints requires private subnets")
+		}
 		vpcEndpointResourceSet := NewVPCEndpointResourceSet(c.ec2API, c.region, c.rs, c.spec, vpcID, subnetDetails.Private, clusterSG.ClusterSharedNode)
 
 		if err := vpcEndpointResourceSet.AddResources(ctx); err != nil {
@@ -179,14 +183,8 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups(vpcID *gfnt.Value) *c
 			GroupDescription: gfnt.NewString("Communication between all nodes in the cluster"),
 			VpcId:            vpcID,
 		})
-		c.newResource("IngressInterNodeGroupSG", &gfnec2.SecurityGroupIngress{
-			GroupId:               refClusterSharedNodeSG,
-			SourceSecurityGroupId: refClusterSharedNodeSG,
-			Description:           gfnt.NewString("Allow nodes to communicate with each other (all ports)"),
-			IpProtocol:            gfnt.NewString("-1"),
-			FromPort:              sgPortZero,
-			ToPort:                sgMaxNodePort,
-		})
+		c.addSecurityGroupIngressRules("IngressInterNodeGroupSG", refClusterSharedNodeSG, refClusterSharedNodeSG,
+			"Allow nodes to communicate with each other", NodeToNodeRules(c.spec.VPC))
 	} else {
 		refClusterSharedNodeSG = gfnt.NewString(c.spec.VPC.SharedNodeSecurityGroup)
 	}
@@ -195,14 +193,8 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups(vpcID *gfnt.Value) *c
 		// To enable communication between both managed and unmanaged nodegroups, this allows ingress traffic from
 		// the default cluster security group ID that EKS creates by default
 		// EKS attaches this to Managed Nodegroups by default, but we need to handle this for unmanaged nodegroups
-		c.newResource(cfnIngressClusterToNodeSGResource, &gfnec2.SecurityGroupIngress{
-			GroupId:               refClusterSharedNodeSG,
-			SourceSecurityGroupId: gfnt.MakeFnGetAttString("ControlPlane", outputs.ClusterDefaultSecurityGroup),
-			Description:           gfnt.NewString("Allow managed and unmanaged nodes to communicate with each other (all ports)"),
-			IpProtocol:            gfnt.NewString("-1"),
-			FromPort:              sgPortZero,
-			ToPort:                sgMaxNodePort,
-		})
+		c.addSecurityGroupIngressRules(cfnIngressClusterToNodeSGResource, refClusterSharedNodeSG, gfnt.MakeFnGetAttString("ControlPlane", outputs.ClusterDefaultSecurityGroup),
+			"Allow managed and unmanaged nodes to communicate with each other", ClusterToNodeRules(c.spec.VPC))
 		if c.spec.IsControlPlaneOnOutposts() && c.spec.IsFullyPrivate() {
 			if subnets := c.spec.VPC.Subnets; subnets != nil && subnets.Private != nil {
 				for az, subnet := range subnets.Private {
@@ -218,14 +210,8 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups(vpcID *gfnt.Value) *c
 			}
 
 		}
-		c.newResource("IngressNodeToDefaultClusterSG", &gfnec2.SecurityGroupIngress{
-			GroupId:               gfnt.MakeFnGetAttString("ControlPlane", outputs.ClusterDefaultSecurityGroup),
-			SourceSecurityGroupId: refClusterSharedNodeSG,
-			Description:           gfnt.NewString("Allow unmanaged nodes to communicate with control plane (all ports)"),
-			IpProtocol:            gfnt.NewString("-1"),
-			FromPort:              sgPortZero,
-			ToPort:                sgMaxNodePort,
-		})
+		c.addSecurityGroupIngressRules("IngressNodeToDefaultClusterSG", gfnt.MakeFnGetAttString("ControlPlane", outputs.ClusterDefaultSecurityGroup), refClusterSharedNodeSG,
+			"Allow unmanaged nodes to communicate with control plane", NodeToClusterRules(c.spec.VPC))
 	}
 
 	if c.spec.VPC == nil {
@@ -246,6 +232,25 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups(vpcID *gfnt.Value) *c
 	}
 }
 
+// addSecurityGroupIngressRules adds an ingress resource per rule. With the default security group policy, the single
+// rule allowing all traffic keeps the resource name used by existing stacks.
+func (c *ClusterResourceSet) addSecurityGroupIngressRules(name string, groupID, sourceGroupID *gfnt.Value, description string, rules []PartialEgressRule) {
+	for _, rule := range rules {
+		resourceName, ruleDescription := name, description+" (all ports)"
+		if c.spec.VPC.HasStrictSecurityGroupPolicy() {
+			resourceName, ruleDescription = name+rule.resourceSuffix(), fmt.Sprintf("%s (%s)", description, rule)
+		}
+		c.newResource(resourceName, &gfnec2.SecurityGroupIngress{
+			GroupId:               groupID,
+			SourceSecurityGroupId: sourceGroupID,
+			Description:           gfnt.NewString(ruleDescription),
+			IpProtocol:            gfnt.NewString(rule.IPProtocol),
+			FromPort:              gfnt.NewInteger(rule.FromPort),
+			ToPort:                gfnt.NewInteger(rule.ToPort),
+		})
+	}
+}
+
 // RenderJSON returns the rendered JSON
 func (c *ClusterResourceSet) RenderJSON() ([]byte, error) {
 	return c.rs.renderJSON()
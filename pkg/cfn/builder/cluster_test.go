@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -179,6 +180,53 @@ var _ = Describe("Cluster Template Builder", func() {
 			Expect(clusterTemplate.Resources).To(HaveKey("ClusterSharedNodeSecurityGroup"))
 		})
 
+		Context("when the strict security group policy is used", func() {
+			BeforeEach(func() {
+				cfg.VPC.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+				cfg.VPC.ManageSharedNodeSecurityGroupRules = api.Enabled()
+			})
+
+			It("should only allow kubelet, DNS and CNI traffic between nodes", func() {
+				Expect(clusterTemplate.Resources).NotTo(HaveKey("IngressInterNodeGroupSG"))
+				for name, rule := range map[string]struct {
+					protocol string
+					port     int
+				}{
+					"IngressInterNodeGroupSGTCP10250": {"tcp", 10250},
+					"IngressInterNodeGroupSGTCP53":    {"tcp", 53},
+					"IngressInterNodeGroupSGUDP53":    {"udp", 53},
+					"IngressInterNodeGroupSGTCP61678": {"tcp", 61678},
+				} {
+					Expect(clusterTemplate.Resources).To(HaveKey(name))
+					properties := clusterTemplate.Resources[name].Properties
+					Expect(properties.IPProtocol).To(Equal(rule.protocol))
+					Expect(properties.FromPort).To(Equal(rule.port))
+					Expect(properties.ToPort).To(Equal(rule.port))
+				}
+				Expect(clusterTemplate.Resources["IngressInterNodeGroupSGTCP10250"].Properties.Description).To(Equal("Allow nodes to communicate with each other (TCP port 10250)"))
+			})
+
+			It("should only allow control plane, DNS and CNI traffic from the default cluster security group", func() {
+				Expect(clusterTemplate.Resources).NotTo(HaveKey("IngressDefaultClusterToNodeSG"))
+				var names []string
+				for name, resource := range clusterTemplate.Resources {
+					if !strings.HasPrefix(name, "IngressDefaultClusterToNodeSG") {
+						continue
+					}
+					names = append(names, name)
+					Expect(resource.Properties.IPProtocol).NotTo(Equal("-1"))
+					Expect(resource.Properties.ToPort - resource.Properties.FromPort).To(BeNumerically("<", 65535))
+				}
+				Expect(names).To(ConsistOf(
+					"IngressDefaultClusterToNodeSGTCP10250",
+					"IngressDefaultClusterToNodeSGTCP443",
+					"IngressDefaultClusterToNodeSGTCP53",
+					"IngressDefaultClusterToNodeSGUDP53",
+					"IngressDefaultClusterToNodeSGTCP61678",
+				))
+			})
+		})
+
 		Context("when extraCIDRs are defined", func() {
 			BeforeEach(func() {
 				cfg.VPC.ExtraCIDRs = []string{"192.168.0.0/24", "192.168.1.0/24"}
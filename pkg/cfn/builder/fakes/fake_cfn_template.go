@@ -68,12 +68,17 @@ type Properties struct {
 	EgressOnlyInternetGatewayID, RouteTableID, AllocationID                    interface{}
 	GatewayID, InternetGatewayID, NatGatewayID, VpnGatewayId, TransitGatewayId interface{}
 	DestinationCidrBlock, DestinationIpv6CidrBlock                             interface{}
+	VpcPeeringConnectionID, SubnetIDs                                          interface{}
+	PeerVpcID, PeerOwnerID, PeerRegion, PeerRoleArn                            string
 	MapPublicIPOnLaunch                                                        bool
 	AssignIpv6AddressOnCreation                                                *bool
+	EnableDns64                                                                *bool
 
 	Ipv6CidrBlock           interface{}
 	Ipv6Pool                string
 	CidrBlock               interface{}
+	Ipv4IpamPoolID          string
+	Ipv4NetmaskLength       int
 	KubernetesNetworkConfig KubernetesNetworkConfig
 
 	AmazonProvidedIpv6CidrBlock bool
@@ -13,6 +13,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
 const (
@@ -56,6 +57,11 @@ const (
 	iamDeleteInstanceProfile    = "iam:DeleteInstanceProfile"
 	iamTagInstanceProfile       = "iam:TagInstanceProfile"
 	iamAddRoleToInstanceProfile = "iam:AddRoleToInstanceProfile"
+	// actions required by Karpenter v0.32.0 and above, which manages instance profiles
+	// for EC2NodeClasses and discovers the cluster endpoint itself
+	iamRemoveRoleFromInstanceProfile = "iam:RemoveRoleFromInstanceProfile"
+	iamListInstanceProfiles          = "iam:ListInstanceProfiles"
+	eksDescribeCluster               = "eks:DescribeCluster"
 	// SSM
 	ssmGetParameter = "ssm:GetParameter"
 	// Pricing
@@ -182,6 +188,15 @@ func (k *KarpenterResourceSet) addResourcesForKarpenter() error {
 		},
 	}
 
+	if compare, err := utils.CompareVersions(k.clusterSpec.Karpenter.Version, "0.32.0"); err == nil && compare >= 0 {
+		actions := rolePolicyStatements[0]["Action"].([]string)
+		rolePolicyStatements[0]["Action"] = append(actions,
+			iamRemoveRoleFromInstanceProfile,
+			iamListInstanceProfiles,
+			eksDescribeCluster,
+		)
+	}
+
 	if api.IsEnabled(k.clusterSpec.Karpenter.WithSpotInterruptionQueue) {
 		rolePolicyStatements = append(rolePolicyStatements, cft.MapOfInterfaces{
 			"Effect":   effectAllow,
@@ -208,7 +208,7 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 			Key:   gfnt.NewString("kubernetes.io/cluster/" + n.options.ClusterConfig.Metadata.Name),
 			Value: gfnt.NewString("owned"),
 		}},
-		SecurityGroupIngress: makeNodeIngressRules(ng.NodeGroupBase, refControlPlaneSG, n.options.ClusterConfig.VPC.CIDR.String(), desc),
+		SecurityGroupIngress: makeNodeIngressRules(ng.NodeGroupBase, n.options.ClusterConfig.VPC, refControlPlaneSG, desc),
 	})
 
 	n.securityGroups = append(n.securityGroups, refNodeGroupLocalSG)
@@ -219,22 +219,16 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 	}
 
 	if !n.options.SkipEgressRules {
-		n.newResource("EgressInterCluster", &gfnec2.SecurityGroupEgress{
-			GroupId:                    refControlPlaneSG,
-			DestinationSecurityGroupId: refNodeGroupLocalSG,
-			Description:                gfnt.NewString(ControlPlaneEgressRuleDescriptionPrefix + desc + " (kubelet and workload TCP ports)"),
-			IpProtocol:                 gfnt.NewString(controlPlaneEgressInterCluster.IPProtocol),
-			FromPort:                   gfnt.NewInteger(controlPlaneEgressInterCluster.FromPort),
-			ToPort:                     gfnt.NewInteger(controlPlaneEgressInterCluster.ToPort),
-		})
-		n.newResource("EgressInterClusterAPI", &gfnec2.SecurityGroupEgress{
-			GroupId:                    refControlPlaneSG,
-			DestinationSecurityGroupId: refNodeGroupLocalSG,
-			Description:                gfnt.NewString(ControlPlaneEgressRuleDescriptionPrefix + desc + " (workloads using HTTPS port, commonly used with extension API servers)"),
-			IpProtocol:                 gfnt.NewString(controlPlaneEgressInterClusterAPI.IPProtocol),
-			FromPort:                   gfnt.NewInteger(controlPlaneEgressInterClusterAPI.FromPort),
-			ToPort:                     gfnt.NewInteger(controlPlaneEgressInterClusterAPI.ToPort),
-		})
+		for _, rule := range controlPlaneToNodeRules(n.options.ClusterConfig.VPC) {
+			n.newResource("Egress"+rule.name, &gfnec2.SecurityGroupEgress{
+				GroupId:                    refControlPlaneSG,
+				DestinationSecurityGroupId: refNodeGroupLocalSG,
+				Description:                gfnt.NewString(ControlPlaneEgressRuleDescriptionPrefix + desc + " (" + rule.description + ")"),
+				IpProtocol:                 gfnt.NewString(rule.IPProtocol),
+				FromPort:                   gfnt.NewInteger(rule.FromPort),
+				ToPort:                     gfnt.NewInteger(rule.ToPort),
+			})
+		}
 	}
 	n.newResource("IngressInterClusterCP", &gfnec2.SecurityGroupIngress{
 		GroupId:               refControlPlaneSG,
@@ -246,25 +240,41 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 	})
 }
 
-func makeNodeIngressRules(ng *api.NodeGroupBase, controlPlaneSG *gfnt.Value, vpcCIDR, description string) []gfnec2.SecurityGroup_Ingress {
-	ingressRules := []gfnec2.SecurityGroup_Ingress{
-		{
+func makeNodeIngressRules(ng *api.NodeGroupBase, vpc *api.ClusterVPC, controlPlaneSG *gfnt.Value, description string) []gfnec2.SecurityGroup_Ingress {
+	var ingressRules []gfnec2.SecurityGroup_Ingress
+	for _, rule := range controlPlaneToNodeRules(vpc) {
+		ingressRules = append(ingressRules, gfnec2.SecurityGroup_Ingress{
 			SourceSecurityGroupId: controlPlaneSG,
-			Description:           gfnt.NewString(fmt.Sprintf("[IngressInterCluster] Allow %s to communicate with control plane (kubelet and workload TCP ports)", description)),
-			IpProtocol:            gfnt.NewString(controlPlaneEgressInterCluster.IPProtocol),
-			FromPort:              gfnt.NewInteger(controlPlaneEgressInterCluster.FromPort),
-			ToPort:                gfnt.NewInteger(controlPlaneEgressInterCluster.ToPort),
-		},
-		{
-			SourceSecurityGroupId: controlPlaneSG,
-			Description:           gfnt.NewString(fmt.Sprintf("[IngressInterClusterAPI] Allow %s to communicate with control plane (workloads using HTTPS port, commonly used with extension API servers)", description)),
-			IpProtocol:            gfnt.NewString(controlPlaneEgressInterClusterAPI.IPProtocol),
-			FromPort:              gfnt.NewInteger(controlPlaneEgressInterClusterAPI.FromPort),
-			ToPort:                gfnt.NewInteger(controlPlaneEgressInterClusterAPI.ToPort),
-		},
+			Description:           gfnt.NewString(fmt.Sprintf("[Ingress%s] Allow %s to communicate with control plane (%s)", rule.name, description, rule.description)),
+			IpProtocol:            gfnt.NewString(rule.IPProtocol),
+			FromPort:              gfnt.NewInteger(rule.FromPort),
+			ToPort:                gfnt.NewInteger(rule.ToPort),
+		})
 	}
 
-	return append(ingressRules, makeSSHIngressRules(ng, vpcCIDR, description)...)
+	return append(ingressRules, makeSSHIngressRules(ng, vpc.CIDR.String(), description)...)
+}
+
+type namedRule struct {
+	PartialEgressRule
+	name        string
+	description string
+}
+
+// controlPlaneToNodeRules returns the rules allowing traffic from the control plane to a nodegroup, named after
+// the resources of the default security group policy
+func controlPlaneToNodeRules(vpc *api.ClusterVPC) []namedRule {
+	if !vpc.HasStrictSecurityGroupPolicy() {
+		return []namedRule{
+			{PartialEgressRule: controlPlaneEgressInterCluster, name: "InterCluster", description: "kubelet and workload TCP ports"},
+			{PartialEgressRule: controlPlaneEgressInterClusterAPI, name: "InterClusterAPI", description: "workloads using HTTPS port, commonly used with extension API servers"},
+		}
+	}
+	var rules []namedRule
+	for _, rule := range ControlPlaneToNodeRules(vpc) {
+		rules = append(rules, namedRule{PartialEgressRule: rule, name: "InterCluster" + rule.resourceSuffix(), description: rule.String()})
+	}
+	return rules
 }
 
 // RenderJSON returns the rendered JSON
@@ -1331,6 +1331,33 @@ var _ = Describe("Unmanaged NodeGroup Template Builder", func() {
 				})
 			})
 
+			Context("the strict security group policy is used", func() {
+				BeforeEach(func() {
+					cfg.VPC.SecurityGroupPolicy = api.SecurityGroupPolicyStrict
+					cfg.VPC.WebhookPorts = []int{9443}
+				})
+
+				It("should only allow kubelet, HTTPS and webhook traffic from the control plane", func() {
+					Expect(ngTemplate.Resources).NotTo(HaveKey("EgressInterCluster"))
+					for name, port := range map[string]int{
+						"EgressInterClusterTCP10250": 10250,
+						"EgressInterClusterTCP443":   443,
+						"EgressInterClusterTCP9443":  9443,
+					} {
+						Expect(ngTemplate.Resources).To(HaveKey(name))
+						properties := ngTemplate.Resources[name].Properties
+						Expect(properties.FromPort).To(Equal(port))
+						Expect(properties.ToPort).To(Equal(port))
+					}
+					Expect(ngTemplate.Resources["EgressInterClusterTCP9443"].Properties.Description).To(HaveSuffix("(TCP port 9443)"))
+
+					ingress := ngTemplate.Resources["SG"].Properties.SecurityGroupIngress
+					Expect(ingress).To(HaveLen(3))
+					Expect(ingress[0].Description).To(HavePrefix("[IngressInterClusterTCP10250]"))
+					Expect(ingress[2].FromPort).To(Equal(float64(9443)))
+				})
+			})
+
 			Context("skipEgressRules is true", func() {
 				BeforeEach(func() {
 					skipEgressRules = true
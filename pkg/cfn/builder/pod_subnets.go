@@ -0,0 +1,91 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gfnec2 "github.com/weaveworks/goformation/v4/cloudformation/ec2"
+	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+const cfnPodSubnetsCIDRResource = "PodSubnetsCIDR"
+
+// PodSubnetRefs returns the pod subnets used by VPC CNI custom networking.
+func (s *SubnetDetails) PodSubnetRefs() []*gfnt.Value {
+	return collectSubnetRefs(s.Pod)
+}
+
+// addPodSubnets associates the pod subnets CIDR with the VPC, and creates a pod subnet in each availability zone
+// which shares the route table of the private subnet in the same zone. Pre-existing pod subnets are used as is.
+func addPodSubnets(rs *resourceSet, vpcID *gfnt.Value, podSubnets *api.PodSubnets, routeTableForAZ func(az string) (*gfnt.Value, error)) ([]SubnetResource, error) {
+	var subnetResources []SubnetResource
+	if podSubnets.CIDR == nil {
+		for _, s := range podSubnets.Subnets {
+			subnetResources = append(subnetResources, SubnetResource{
+				AvailabilityZone: s.AZ,
+				Subnet:           gfnt.NewString(s.ID),
+			})
+		}
+		return subnetResources, nil
+	}
+
+	rs.newResource(cfnPodSubnetsCIDRResource, &gfnec2.VPCCidrBlock{
+		VpcId:     vpcID,
+		CidrBlock: gfnt.NewString(podSubnets.CIDR.String()),
+	})
+	for name, s := range podSubnets.Subnets {
+		refRT, err := routeTableForAZ(s.AZ)
+		if err != nil {
+			return nil, err
+		}
+		subnetAlias := "Pod" + makeAZResourceName(name)
+		refSubnet := rs.newResource("Subnet"+subnetAlias, &gfnec2.Subnet{
+			AvailabilityZone:           gfnt.NewString(s.AZ),
+			CidrBlock:                  gfnt.NewString(s.CIDR.String()),
+			VpcId:                      vpcID,
+			AWSCloudFormationDependsOn: []string{cfnPodSubnetsCIDRResource},
+		})
+		rs.newResource("RouteTableAssociation"+subnetAlias, &gfnec2.SubnetRouteTableAssociation{
+			SubnetId:     refSubnet,
+			RouteTableId: refRT,
+		})
+		subnetResources = append(subnetResources, SubnetResource{
+			AvailabilityZone: s.AZ,
+			RouteTable:       refRT,
+			Subnet:           refSubnet,
+		})
+	}
+	return subnetResources, nil
+}
+
+// addPodSubnetsOutput adds the pod subnets output, which populates vpc.podSubnets.subnets.
+func addPodSubnetsOutput(ctx context.Context, rs *resourceSet, ec2API awsapi.EC2, clusterConfig *api.ClusterConfig, subnetDetails *SubnetDetails) {
+	subnetRefs := subnetDetails.PodSubnetRefs()
+	if len(subnetRefs) == 0 {
+		return
+	}
+	rs.defineJoinedOutput(outputs.ClusterSubnetsPod, subnetRefs, true, func(value string) error {
+		return vpc.ImportSubnetsFromIDList(ctx, ec2API, clusterConfig, clusterConfig.VPC.PodSubnets.Subnets, strings.Split(value, ","))
+	})
+}
+
+// routeTablesByAZ maps the availability zones of private subnets to their route tables.
+func routeTablesByAZ(privateSubnets api.AZSubnetMapping, routeTableForSubnet func(name string, subnet api.AZSubnetSpec) (*gfnt.Value, bool)) func(az string) (*gfnt.Value, error) {
+	return func(az string) (*gfnt.Value, error) {
+		for name, subnet := range privateSubnets {
+			if subnet.AZ != az {
+				continue
+			}
+			if refRT, ok := routeTableForSubnet(name, subnet); ok {
+				return refRT, nil
+			}
+		}
+		return nil, fmt.Errorf("no route table found for a private subnet in availability zone %q to route pod subnets through", az)
+	}
+}
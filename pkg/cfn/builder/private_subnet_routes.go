@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	gfnec2 "github.com/weaveworks/goformation/v4/cloudformation/ec2"
+	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	TransitGatewayAttachmentKey = "VPCTransitGatewayAttachment"
+	TransitGatewayRouteKey      = "TransitGatewayRoute"
+	VPCPeeringConnectionKey     = "VPCPeeringConnection"
+	VPCPeeringRouteKey          = "VPCPeeringRoute"
+)
+
+// addPrivateSubnetRoutes attaches the VPC to the transit gateway of vpc.transitGateway and creates the peering
+// connections of vpc.peerings, and routes their destination CIDRs from the route tables of the private subnets.
+// transitGatewayID is the ID of the transit gateway of a pre-existing attachment.
+func addPrivateSubnetRoutes(rs *resourceSet, vpcID *gfnt.Value, clusterVPC *api.ClusterVPC, privateSubnets []SubnetResource, transitGatewayID string) error {
+	privateSubnets = sortSubnetResources(privateSubnets)
+	routeTables, err := privateRouteTables(privateSubnets)
+	if err != nil {
+		return err
+	}
+
+	if tgw := clusterVPC.TransitGateway; tgw != nil {
+		var dependsOn []string
+		if tgw.AttachmentID == "" {
+			subnetRefs, err := transitGatewaySubnets(privateSubnets, tgw.Subnets)
+			if err != nil {
+				return err
+			}
+			rs.newResource(TransitGatewayAttachmentKey, &gfnec2.TransitGatewayAttachment{
+				TransitGatewayId: gfnt.NewString(tgw.ID),
+				VpcId:            vpcID,
+				SubnetIds:        gfnt.NewSlice(subnetRefs...),
+			})
+			dependsOn = []string{TransitGatewayAttachmentKey}
+			transitGatewayID = tgw.ID
+		}
+		addRoutes(rs, TransitGatewayRouteKey, routeTables, tgw.DestinationCIDRs, func(route *gfnec2.Route) {
+			route.TransitGatewayId = gfnt.NewString(transitGatewayID)
+			route.AWSCloudFormationDependsOn = dependsOn
+		})
+	}
+
+	for i, peering := range clusterVPC.Peerings {
+		peeringConnectionID := gfnt.NewString(peering.PeeringConnectionID)
+		if peering.PeeringConnectionID == "" {
+			peeringConnection := &gfnec2.VPCPeeringConnection{
+				VpcId:     vpcID,
+				PeerVpcId: gfnt.NewString(peering.PeerVPCID),
+			}
+			if peering.PeerOwnerID != "" {
+				peeringConnection.PeerOwnerId = gfnt.NewString(peering.PeerOwnerID)
+			}
+			if peering.PeerRegion != "" {
+				peeringConnection.PeerRegion = gfnt.NewString(peering.PeerRegion)
+			}
+			if peering.PeerRoleARN != "" {
+				peeringConnection.PeerRoleArn = gfnt.NewString(peering.PeerRoleARN)
+			}
+			peeringConnectionID = rs.newResource(VPCPeeringConnectionKey+strconv.Itoa(i), peeringConnection)
+		}
+		addRoutes(rs, VPCPeeringRouteKey+strconv.Itoa(i)+"CIDR", routeTables, peering.DestinationCIDRs, func(route *gfnec2.Route) {
+			route.VpcPeeringConnectionId = peeringConnectionID
+		})
+	}
+	return nil
+}
+
+// routeTableResource is a route table of private subnets, along with the name used for the resources of its routes
+type routeTableResource struct {
+	name       string
+	routeTable *gfnt.Value
+}
+
+// privateRouteTables returns the distinct route tables of the private subnets, named after their availability zone
+func privateRouteTables(privateSubnets []SubnetResource) ([]routeTableResource, error) {
+	var (
+		routeTables []routeTableResource
+		seen        = map[string]bool{}
+		names       = map[string]int{}
+	)
+	for _, subnet := range privateSubnets {
+		if subnet.RouteTable == nil {
+			return nil, fmt.Errorf("no route table found for private subnet in availability zone %q", subnet.AvailabilityZone)
+		}
+		key := valueKey(subnet.RouteTable)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		name := formatAZ(subnet.AvailabilityZone)
+		if n := names[name]; n > 0 {
+			name += strconv.Itoa(n)
+		}
+		names[formatAZ(subnet.AvailabilityZone)]++
+		routeTables = append(routeTables, routeTableResource{name: name, routeTable: subnet.RouteTable})
+	}
+	return routeTables, nil
+}
+
+func addRoutes(rs *resourceSet, keyPrefix string, routeTables []routeTableResource, destinationCIDRs []string, setTarget func(*gfnec2.Route)) {
+	for i, cidr := range destinationCIDRs {
+		for _, rt := range routeTables {
+			route := &gfnec2.Route{
+				RouteTableId: rt.routeTable,
+			}
+			if strings.Contains(cidr, ":") {
+				route.DestinationIpv6CidrBlock = gfnt.NewString(cidr)
+			} else {
+				route.DestinationCidrBlock = gfnt.NewString(cidr)
+			}
+			setTarget(route)
+			rs.newResource(keyPrefix+strconv.Itoa(i)+rt.name, route)
+		}
+	}
+}
+
+// transitGatewaySubnets returns the private subnets to attach to the transit gateway, which are selected by
+// availability zone or subnet ID, or else a private subnet in each availability zone
+func transitGatewaySubnets(privateSubnets []SubnetResource, selectors []string) ([]*gfnt.Value, error) {
+	var selected []SubnetResource
+	if len(selectors) == 0 {
+		for _, subnet := range privateSubnets {
+			if !slices.ContainsFunc(selected, func(s SubnetResource) bool { return s.AvailabilityZone == subnet.AvailabilityZone }) {
+				selected = append(selected, subnet)
+			}
+		}
+	}
+	for _, selector := range selectors {
+		idx := slices.IndexFunc(privateSubnets, func(s SubnetResource) bool {
+			return s.AvailabilityZone == selector || valueKey(s.Subnet) == valueKey(gfnt.NewString(selector))
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("no private subnet found for %q in vpc.transitGateway.subnets", selector)
+		}
+		subnet := privateSubnets[idx]
+		if slices.ContainsFunc(selected, func(s SubnetResource) bool { return s.AvailabilityZone == subnet.AvailabilityZone }) {
+			return nil, fmt.Errorf("vpc.transitGateway.subnets can only contain one subnet per availability zone; found multiple subnets in %q", subnet.AvailabilityZone)
+		}
+		selected = append(selected, subnet)
+	}
+	return collectSubnetRefs(selected), nil
+}
+
+// sortSubnetResources sorts subnets by availability zone, so that resources derived from them do not change across
+// template renders
+func sortSubnetResources(subnets []SubnetResource) []SubnetResource {
+	sorted := slices.Clone(subnets)
+	slices.SortStableFunc(sorted, func(a, b SubnetResource) int {
+		if a.AvailabilityZone != b.AvailabilityZone {
+			return strings.Compare(a.AvailabilityZone, b.AvailabilityZone)
+		}
+		return strings.Compare(valueKey(a.Subnet), valueKey(b.Subnet))
+	})
+	return sorted
+}
+
+func valueKey(v *gfnt.Value) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,199 @@
+package builder_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+)
+
+var _ = Describe("Private subnet routes", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.VPC = vpcConfig()
+		cfg.AvailabilityZones = []string{azA, azB}
+		cfg.VPC.TransitGateway = &api.VPCTransitGateway{
+			ID:               "tgw-1234",
+			DestinationCIDRs: []string{"10.0.0.0/8", "fd00::/8"},
+		}
+		cfg.VPC.Peerings = []api.VPCPeering{
+			{
+				PeerVPCID:        "vpc-peer",
+				PeerOwnerID:      "123456789012",
+				DestinationCIDRs: []string{"172.16.0.0/16"},
+			},
+			{
+				PeeringConnectionID: "pcx-1234",
+				DestinationCIDRs:    []string{"172.17.0.0/16"},
+			},
+		}
+	})
+
+	Context("when eksctl creates the VPC", func() {
+		var (
+			vpcTemplate *fakes.FakeTemplate
+			createErr   error
+		)
+
+		JustBeforeEach(func() {
+			vpcRs := builder.NewIPv4VPCResourceSet(builder.NewRS(), cfg, &mocksv2.EC2{}, false)
+			_, _, createErr = vpcRs.CreateTemplate(context.Background())
+			if createErr == nil {
+				vpcTemplate = renderVPCTemplate(vpcRs)
+			}
+		})
+
+		It("attaches the VPC to the transit gateway and routes the private subnets to it", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(vpcTemplate.Resources).To(HaveKey(builder.TransitGatewayAttachmentKey))
+			attachment := vpcTemplate.Resources[builder.TransitGatewayAttachmentKey]
+			Expect(attachment.Type).To(Equal("AWS::EC2::TransitGatewayAttachment"))
+			Expect(attachment.Properties.TransitGatewayId).To(Equal("tgw-1234"))
+			Expect(attachment.Properties.VpcID).To(Equal(makeRef(vpcResourceKey)))
+			Expect(attachment.Properties.SubnetIDs).To(Equal([]interface{}{makeRef(privateSubnetRef1), makeRef(privateSubnetRef2)}))
+
+			for az, routeTable := range map[string]string{azAFormatted: privRouteTableA, azBFormatted: privRouteTableB} {
+				ipv4Route := vpcTemplate.Resources[builder.TransitGatewayRouteKey+"0"+az]
+				Expect(ipv4Route.DependsOn).To(ConsistOf(builder.TransitGatewayAttachmentKey))
+				Expect(ipv4Route.Properties.RouteTableID).To(Equal(makeRef(routeTable)))
+				Expect(ipv4Route.Properties.DestinationCidrBlock).To(Equal("10.0.0.0/8"))
+				Expect(ipv4Route.Properties.TransitGatewayId).To(Equal("tgw-1234"))
+
+				ipv6Route := vpcTemplate.Resources[builder.TransitGatewayRouteKey+"1"+az]
+				Expect(ipv6Route.Properties.RouteTableID).To(Equal(makeRef(routeTable)))
+				Expect(ipv6Route.Properties.DestinationIpv6CidrBlock).To(Equal("fd00::/8"))
+				Expect(ipv6Route.Properties.DestinationCidrBlock).To(BeNil())
+			}
+		})
+
+		It("creates VPC peering connections and routes the private subnets to them", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(vpcTemplate.Resources).To(HaveKey(builder.VPCPeeringConnectionKey + "0"))
+			Expect(vpcTemplate.Resources).NotTo(HaveKey(builder.VPCPeeringConnectionKey + "1"))
+			peeringConnection := vpcTemplate.Resources[builder.VPCPeeringConnectionKey+"0"]
+			Expect(peeringConnection.Type).To(Equal("AWS::EC2::VPCPeeringConnection"))
+			Expect(peeringConnection.Properties.VpcID).To(Equal(makeRef(vpcResourceKey)))
+			Expect(peeringConnection.Properties.PeerVpcID).To(Equal("vpc-peer"))
+			Expect(peeringConnection.Properties.PeerOwnerID).To(Equal("123456789012"))
+
+			for az, routeTable := range map[string]string{azAFormatted: privRouteTableA, azBFormatted: privRouteTableB} {
+				newPeeringRoute := vpcTemplate.Resources[builder.VPCPeeringRouteKey+"0CIDR0"+az]
+				Expect(newPeeringRoute.Properties.RouteTableID).To(Equal(makeRef(routeTable)))
+				Expect(newPeeringRoute.Properties.DestinationCidrBlock).To(Equal("172.16.0.0/16"))
+				Expect(newPeeringRoute.Properties.VpcPeeringConnectionID).To(Equal(makeRef(builder.VPCPeeringConnectionKey + "0")))
+
+				existingPeeringRoute := vpcTemplate.Resources[builder.VPCPeeringRouteKey+"1CIDR0"+az]
+				Expect(existingPeeringRoute.Properties.DestinationCidrBlock).To(Equal("172.17.0.0/16"))
+				Expect(existingPeeringRoute.Properties.VpcPeeringConnectionID).To(Equal("pcx-1234"))
+			}
+		})
+
+		When("subnets to attach are specified", func() {
+			BeforeEach(func() {
+				cfg.VPC.TransitGateway.Subnets = []string{azB}
+			})
+
+			It("only attaches the subnets of the specified availability zones", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				attachment := vpcTemplate.Resources[builder.TransitGatewayAttachmentKey]
+				Expect(attachment.Properties.SubnetIDs).To(Equal([]interface{}{makeRef(privateSubnetRef2)}))
+			})
+		})
+
+		When("a subnet to attach does not exist", func() {
+			BeforeEach(func() {
+				cfg.VPC.TransitGateway.Subnets = []string{azC}
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(MatchError(`no private subnet found for "us-west-2c" in vpc.transitGateway.subnets`))
+			})
+		})
+	})
+
+	Context("when the VPC is pre-existing and attached to the transit gateway", func() {
+		var (
+			mockEC2     *mocksv2.EC2
+			vpcTemplate *fakes.FakeTemplate
+			createErr   error
+			attachedVPC string
+		)
+
+		BeforeEach(func() {
+			cfg.VPC.ID = "custom-vpc"
+			cfg.VPC.TransitGateway = &api.VPCTransitGateway{
+				AttachmentID:     "tgw-attach-1234",
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}
+			cfg.VPC.Peerings = nil
+			attachedVPC = "custom-vpc"
+			mockEC2 = &mocksv2.EC2{}
+		})
+
+		JustBeforeEach(func() {
+			mockEC2.On("DescribeVpcs", mock.Anything, mock.Anything).Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []ec2types.Vpc{{VpcId: aws.String("custom-vpc")}},
+			}, nil)
+			mockEC2.On("DescribeRouteTables", mock.Anything, mock.Anything, mock.Anything).Return(makeRTOutput([]string{privateSubnet2, privateSubnet1}, false), nil)
+			mockEC2.On("DescribeTransitGatewayVpcAttachments", mock.Anything, &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+				TransitGatewayAttachmentIds: []string{"tgw-attach-1234"},
+			}).Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+				TransitGatewayVpcAttachments: []ec2types.TransitGatewayVpcAttachment{
+					{
+						TransitGatewayAttachmentId: aws.String("tgw-attach-1234"),
+						TransitGatewayId:           aws.String("tgw-5678"),
+						VpcId:                      aws.String(attachedVPC),
+					},
+				},
+			}, nil)
+
+			vpcRs := builder.NewExistingVPCResourceSet(builder.NewRS(), cfg, mockEC2)
+			_, _, createErr = vpcRs.CreateTemplate(context.Background())
+			vpcTemplate = renderVPCTemplate(vpcRs)
+		})
+
+		It("routes the private route tables to the transit gateway of the attachment", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(vpcTemplate.Resources).NotTo(HaveKey(builder.TransitGatewayAttachmentKey))
+
+			By("adding a single route to the route table shared by the private subnets")
+			Expect(vpcTemplate.Resources).To(HaveLen(1))
+			route := vpcTemplate.Resources[builder.TransitGatewayRouteKey+"0"+azAFormatted]
+			Expect(route.DependsOn).To(BeEmpty())
+			Expect(route.Properties.RouteTableID).To(Equal("this-is-a-route-table"))
+			Expect(route.Properties.DestinationCidrBlock).To(Equal("10.0.0.0/8"))
+			Expect(route.Properties.TransitGatewayId).To(Equal("tgw-5678"))
+		})
+
+		When("the attachment belongs to another VPC", func() {
+			BeforeEach(func() {
+				attachedVPC = "other-vpc"
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(MatchError(ContainSubstring(`transit gateway attachment "tgw-attach-1234" attaches VPC "other-vpc", not "custom-vpc"`)))
+			})
+		})
+	})
+})
+
+func renderVPCTemplate(vpcRs interface{ RenderJSON() ([]byte, error) }) *fakes.FakeTemplate {
+	templateBody, err := vpcRs.RenderJSON()
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	vpcTemplate := &fakes.FakeTemplate{}
+	ExpectWithOffset(1, json.Unmarshal(templateBody, vpcTemplate)).To(Succeed())
+	return vpcTemplate
+}
@@ -0,0 +1,105 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	sgPortKubelet    = 10250
+	sgPortDNS        = 53
+	sgPortCNIMetrics = 61678
+)
+
+var (
+	sgRuleAllTraffic = PartialEgressRule{FromPort: 0, ToPort: 65535, IPProtocol: "-1"}
+	sgRuleKubelet    = PartialEgressRule{FromPort: sgPortKubelet, ToPort: sgPortKubelet, IPProtocol: "tcp"}
+	sgRuleDNSTCP     = PartialEgressRule{FromPort: sgPortDNS, ToPort: sgPortDNS, IPProtocol: "tcp"}
+	sgRuleDNSUDP     = PartialEgressRule{FromPort: sgPortDNS, ToPort: sgPortDNS, IPProtocol: "udp"}
+	sgRuleCNIMetrics = PartialEgressRule{FromPort: sgPortCNIMetrics, ToPort: sgPortCNIMetrics, IPProtocol: "tcp"}
+)
+
+// NodeToNodeRules returns the rules allowing traffic between the nodes of the cluster. With the strict security
+// group policy, only kubelet, DNS and VPC CNI metrics traffic is allowed; the VPC CNI routes pod traffic natively and
+// does not require any other port.
+func NodeToNodeRules(vpc *api.ClusterVPC) []PartialEgressRule {
+	if !vpc.HasStrictSecurityGroupPolicy() {
+		return []PartialEgressRule{sgRuleAllTraffic}
+	}
+	return []PartialEgressRule{sgRuleKubelet, sgRuleDNSTCP, sgRuleDNSUDP, sgRuleCNIMetrics}
+}
+
+// ControlPlaneToNodeRules returns the rules allowing traffic from the control plane to nodes. With the strict
+// security group policy, only kubelet traffic, HTTPS and vpc.webhookPorts are allowed.
+func ControlPlaneToNodeRules(vpc *api.ClusterVPC) []PartialEgressRule {
+	if !vpc.HasStrictSecurityGroupPolicy() {
+		return ControlPlaneNodeGroupEgressRules
+	}
+	rules := []PartialEgressRule{sgRuleKubelet, controlPlaneEgressInterClusterAPI}
+	for _, port := range vpc.WebhookPorts {
+		rule := PartialEgressRule{FromPort: port, ToPort: port, IPProtocol: "tcp"}
+		if !containsRule(rules, rule) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ClusterToNodeRules returns the rules allowing traffic from the default cluster security group, which is attached
+// to the control plane and to managed nodes, to the shared node security group. With the strict security group
+// policy, these are the rules from the control plane to nodes, and the DNS and VPC CNI rules between nodes.
+func ClusterToNodeRules(vpc *api.ClusterVPC) []PartialEgressRule {
+	return mergeRules(NodeToNodeRules(vpc), ControlPlaneToNodeRules(vpc))
+}
+
+// NodeToClusterRules returns the rules allowing traffic from unmanaged nodes to the default cluster security group,
+// which is attached to the control plane and to managed nodes
+func NodeToClusterRules(vpc *api.ClusterVPC) []PartialEgressRule {
+	return mergeRules(NodeToNodeRules(vpc), []PartialEgressRule{controlPlaneEgressInterClusterAPI})
+}
+
+// Covers returns true if the rule allows all the traffic allowed by other
+func (r PartialEgressRule) Covers(other PartialEgressRule) bool {
+	if r.IPProtocol == "-1" {
+		return true
+	}
+	return r.IPProtocol == other.IPProtocol && r.FromPort <= other.FromPort && r.ToPort >= other.ToPort
+}
+
+// String returns a description of the traffic allowed by the rule
+func (r PartialEgressRule) String() string {
+	if r.IPProtocol == "-1" {
+		return "all traffic"
+	}
+	if r.FromPort == r.ToPort {
+		return fmt.Sprintf("%s port %d", strings.ToUpper(r.IPProtocol), r.FromPort)
+	}
+	return fmt.Sprintf("%s ports %d-%d", strings.ToUpper(r.IPProtocol), r.FromPort, r.ToPort)
+}
+
+// resourceSuffix returns the suffix of the name of the resource of a rule generated by the strict security group
+// policy, e.g. TCP10250
+func (r PartialEgressRule) resourceSuffix() string {
+	return fmt.Sprintf("%s%d", strings.ToUpper(r.IPProtocol), r.FromPort)
+}
+
+func mergeRules(rules, others []PartialEgressRule) []PartialEgressRule {
+	merged := append([]PartialEgressRule{}, rules...)
+	for _, rule := range others {
+		if !containsRule(merged, rule) {
+			merged = append(merged, rule)
+		}
+	}
+	return merged
+}
+
+func containsRule(rules []PartialEgressRule, rule PartialEgressRule) bool {
+	for _, r := range rules {
+		if r.Covers(rule) {
+			return true
+		}
+	}
+	return false
+}
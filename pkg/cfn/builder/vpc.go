@@ -26,6 +26,7 @@ const (
 	IPv6CIDRBlockKey = "IPv6CidrBlock"
 	InternetCIDR     = "0.0.0.0/0"
 	InternetIPv6CIDR = "::/0"
+	NAT64CIDR        = "64:ff9b::/96"
 
 	// Routing
 	PubRouteTableKey             = "PublicRouteTable"
@@ -36,6 +37,7 @@ const (
 	PubSubIPv6RouteKey           = "PublicSubnetIPv6DefaultRoute"
 	PrivateSubnetRouteKey        = "PrivateSubnetDefaultRoute"
 	PrivateSubnetIpv6RouteKey    = "PrivateSubnetDefaultIpv6Route"
+	PrivateSubnetNAT64RouteKey   = "PrivateSubnetNAT64Route"
 
 	// Subnets
 	PublicSubnetKey        = "PublicSubnet"
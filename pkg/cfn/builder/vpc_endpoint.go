@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aws/smithy-go"
+	"github.com/kris-nova/logger"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -53,22 +54,32 @@ type VPCEndpointServiceDetails struct {
 
 // AddResources adds resources for VPC endpoints.
 func (e *VPCEndpointResourceSet) AddResources(ctx context.Context) error {
-	additionalServices, err := api.MapOptionalEndpointServices(e.clusterConfig.PrivateCluster.AdditionalEndpointServices, e.clusterConfig.HasClusterCloudWatchLogging())
+	endpointServices, policyDocuments, err := e.endpointServices()
 	if err != nil {
 		return err
 	}
-	endpointServices := append(api.RequiredEndpointServices(e.clusterConfig.IsControlPlaneOnOutposts()), additionalServices...)
 	endpointServiceDetails, err := e.buildVPCEndpointServices(ctx, endpointServices)
 	if err != nil {
 		return fmt.Errorf("error building endpoint service details: %w", err)
 	}
+	existingEndpoints, err := e.existingEndpointServices(ctx, endpointServiceDetails)
+	if err != nil {
+		return err
+	}
 
 	for _, endpointDetail := range endpointServiceDetails {
+		if existingEndpoints[endpointDetail.ServiceName] {
+			logger.Info("reusing existing VPC endpoint for service %q", endpointDetail.ServiceName)
+			continue
+		}
 		endpoint := &gfnec2.VPCEndpoint{
 			ServiceName:     gfnt.NewString(endpointDetail.ServiceName),
 			VpcId:           e.vpc,
 			VpcEndpointType: gfnt.NewString(endpointDetail.EndpointType),
 		}
+		if policyDocument, ok := policyDocuments[strings.TrimPrefix(endpointDetail.ServiceReadableName, ".")]; ok {
+			endpoint.PolicyDocument = policyDocument
+		}
 
 		if endpointDetail.EndpointType == string(ec2types.VpcEndpointTypeGateway) {
 			endpoint.RouteTableIds = gfnt.NewSlice(e.routeTableIDs()...)
@@ -82,12 +93,79 @@ func (e *VPCEndpointResourceSet) AddResources(ctx context.Context) error {
 			strings.ReplaceAll(endpointDetail.ServiceReadableName, ".", ""),
 		))
 
-		// TODO attach policy document
 		e.rs.newResource(resourceName, endpoint)
 	}
 	return nil
 }
 
+// endpointServices returns the endpoint services required by a fully-private cluster, or else the services of
+// vpc.endpoints along with their policy documents keyed by service name
+func (e *VPCEndpointResourceSet) endpointServices() ([]api.EndpointService, map[string]api.InlineDocument, error) {
+	if e.clusterConfig.IsFullyPrivate() {
+		additionalServices, err := api.MapOptionalEndpointServices(e.clusterConfig.PrivateCluster.AdditionalEndpointServices, e.clusterConfig.HasClusterCloudWatchLogging())
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(api.RequiredEndpointServices(e.clusterConfig.IsControlPlaneOnOutposts()), additionalServices...), nil, nil
+	}
+
+	var (
+		serviceNames    []string
+		policyDocuments = map[string]api.InlineDocument{}
+	)
+	for _, service := range e.clusterConfig.VPC.Endpoints.Services {
+		serviceNames = append(serviceNames, service.Name)
+		if service.PolicyDocument != nil {
+			policyDocuments[service.Name] = service.PolicyDocument
+		}
+	}
+	endpointServices, err := api.MapEndpointServices(serviceNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	return endpointServices, policyDocuments, nil
+}
+
+// existingEndpointServices returns the services that already have a VPC endpoint in a pre-existing VPC, unless
+// vpc.endpoints.reuseExisting is disabled
+func (e *VPCEndpointResourceSet) existingEndpointServices(ctx context.Context, endpointServiceDetails []VPCEndpointServiceDetails) (map[string]bool, error) {
+	vpc := e.clusterConfig.VPC
+	if e.clusterConfig.IsFullyPrivate() || vpc.ID == "" || api.IsDisabled(vpc.Endpoints.ReuseExisting) || len(endpointServiceDetails) == 0 {
+		return nil, nil
+	}
+
+	var serviceNames []string
+	for _, sd := range endpointServiceDetails {
+		serviceNames = append(serviceNames, sd.ServiceName)
+	}
+	existing := map[string]bool{}
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(e.ec2API, &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpc.ID},
+			},
+			{
+				Name:   aws.String("service-name"),
+				Values: serviceNames,
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error describing VPC endpoints: %w", err)
+		}
+		for _, endpoint := range output.VpcEndpoints {
+			switch endpoint.State {
+			case ec2types.StateAvailable, ec2types.StatePending, ec2types.StatePendingAcceptance:
+				existing[aws.ToString(endpoint.ServiceName)] = true
+			}
+		}
+	}
+	return existing, nil
+}
+
 func (e *VPCEndpointResourceSet) subnetsForAZs(azs []string) []*gfnt.Value {
 	var subnetRefs []*gfnt.Value
 	for _, az := range azs {
@@ -142,8 +220,12 @@ func (e *VPCEndpointResourceSet) buildVPCEndpointServices(ctx context.Context, e
 		if err != nil {
 			var ae smithy.APIError
 			if errors.As(err, &ae) && ae.ErrorCode() == "InvalidServiceName" {
+				feature := "VPC endpoints are"
+				if e.clusterConfig.IsFullyPrivate() {
+					feature = "fully-private clusters are"
+				}
 				return nil, &api.UnsupportedFeatureError{
-					Message: fmt.Sprintf("fully-private clusters are not supported in region %q, please retry with a different region", e.region),
+					Message: fmt.Sprintf("%s not supported in region %q, please retry with a different region", feature, e.region),
 					Err:     err,
 				}
 			}
@@ -357,6 +357,129 @@ var _ = Describe("VPC Endpoint Builder", func() {
 	)
 })
 
+var _ = Describe("VPC endpoints of clusters that are not fully private", func() {
+	var (
+		provider *mockprovider.MockProvider
+		cfg      *api.ClusterConfig
+		rs       = builder.NewRS()
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		provider.MockEC2().On("DescribeVpcEndpointServices", mock.Anything, mock.Anything).Return(&ec2.DescribeVpcEndpointServicesOutput{
+			ServiceDetails: []ec2types.ServiceDetail{
+				{
+					ServiceName:       aws.String("com.amazonaws.us-west-2.s3"),
+					ServiceType:       []ec2types.ServiceTypeDetail{{ServiceType: ec2types.ServiceTypeGateway}},
+					AvailabilityZones: []string{"us-west-2a", "us-west-2b"},
+				},
+				{
+					ServiceName:       aws.String("com.amazonaws.us-west-2.ecr.api"),
+					ServiceType:       []ec2types.ServiceTypeDetail{{ServiceType: ec2types.ServiceTypeInterface}},
+					AvailabilityZones: []string{"us-west-2a", "us-west-2b"},
+				},
+			},
+		}, nil)
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Region = "us-west-2"
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b"}
+		cfg.VPC.Endpoints = &api.VPCEndpoints{
+			Services: []api.VPCEndpointService{
+				{
+					Name: "s3",
+					PolicyDocument: api.InlineDocument{
+						"Version": "2012-10-17",
+						"Statement": []interface{}{
+							map[string]interface{}{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*"},
+						},
+					},
+				},
+				{Name: "ecr.api"},
+			},
+		}
+		rs = builder.NewRS()
+	})
+
+	addEndpoints := func(vpcResourceSet builder.VPCResourceSet) map[string]*gfnec2.VPCEndpoint {
+		vpcID, subnetDetails, err := vpcResourceSet.CreateTemplate(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(builder.NewVPCEndpointResourceSet(provider.EC2(), "us-west-2", rs, cfg, vpcID, subnetDetails.Private, gfnt.NewString("sg-test")).AddResources(context.Background())).To(Succeed())
+
+		endpoints := map[string]*gfnec2.VPCEndpoint{}
+		for name, resource := range builder.GetTemplate(rs).Resources {
+			if endpoint, ok := resource.(*gfnec2.VPCEndpoint); ok {
+				endpoints[name] = endpoint
+			}
+		}
+		return endpoints
+	}
+
+	It("creates the endpoints of vpc.endpoints with their policies", func() {
+		Expect(vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, nil)).To(Succeed())
+		endpoints := addEndpoints(builder.NewIPv4VPCResourceSet(rs, cfg, provider.EC2(), false))
+		Expect(endpoints).To(HaveLen(2))
+
+		s3Endpoint := endpoints["VPCEndpointS3"]
+		Expect(s3Endpoint.VpcEndpointType).To(Equal(gfnt.NewString("Gateway")))
+		Expect(s3Endpoint.PolicyDocument).To(Equal(cfg.VPC.Endpoints.Services[0].PolicyDocument))
+		Expect(s3Endpoint.RouteTableIds.Raw()).To(ConsistOf(gfnt.MakeRef("PrivateRouteTableUSWEST2A"), gfnt.MakeRef("PrivateRouteTableUSWEST2B")))
+
+		ecrEndpoint := endpoints["VPCEndpointECRAPI"]
+		Expect(ecrEndpoint.VpcEndpointType).To(Equal(gfnt.NewString("Interface")))
+		Expect(ecrEndpoint.PolicyDocument).To(BeNil())
+		Expect(ecrEndpoint.SubnetIds.Raw()).To(ConsistOf(gfnt.MakeRef("SubnetPrivateUSWEST2A"), gfnt.MakeRef("SubnetPrivateUSWEST2B")))
+	})
+
+	Context("with a pre-existing VPC", func() {
+		BeforeEach(func() {
+			cfg.VPC = &api.ClusterVPC{
+				Network: api.Network{ID: "vpc-custom"},
+				Subnets: &api.ClusterSubnets{
+					Private: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						"us-west-2a": {ID: "subnet-custom1"},
+						"us-west-2b": {ID: "subnet-custom2"},
+					}),
+				},
+				Endpoints: cfg.VPC.Endpoints,
+			}
+			mockDescribeVPC(provider)
+			mockDescribeRouteTables(provider, []string{"subnet-custom1", "subnet-custom2"})
+			provider.MockEC2().On("DescribeVpcEndpoints", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []ec2types.VpcEndpoint{
+					{
+						ServiceName: aws.String("com.amazonaws.us-west-2.ecr.api"),
+						State:       ec2types.StateAvailable,
+					},
+					{
+						ServiceName: aws.String("com.amazonaws.us-west-2.s3"),
+						State:       ec2types.StateDeleted,
+					},
+				},
+			}, nil)
+		})
+
+		It("reuses existing endpoints and routes gateway endpoints through the route tables of the private subnets", func() {
+			endpoints := addEndpoints(builder.NewExistingVPCResourceSet(rs, cfg, provider.EC2()))
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints).To(HaveKey("VPCEndpointS3"))
+			Expect(endpoints["VPCEndpointS3"].RouteTableIds.Raw()).To(ConsistOf(gfnt.NewString("rtb-custom-1"), gfnt.NewString("rtb-custom-2")))
+		})
+
+		When("reusing existing endpoints is disabled", func() {
+			BeforeEach(func() {
+				cfg.VPC.Endpoints.ReuseExisting = api.Disabled()
+			})
+
+			It("creates all endpoints", func() {
+				endpoints := addEndpoints(builder.NewExistingVPCResourceSet(rs, cfg, provider.EC2()))
+				Expect(endpoints).To(HaveLen(2))
+				provider.MockEC2().AssertNotCalled(GinkgoT(), "DescribeVpcEndpoints", mock.Anything, mock.Anything, mock.Anything)
+			})
+		})
+	})
+})
+
 //go:embed testdata/service_details.json
 var serviceDetailsJSON []byte
 
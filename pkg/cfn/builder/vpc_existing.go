@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -56,6 +57,9 @@ func (v *ExistingVPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Valu
 		if err := v.checkIPv6CidrBlockAssociated(out); err != nil {
 			return nil, nil, err
 		}
+		if err := v.checkIPv6Subnets(ctx); err != nil {
+			return nil, nil, err
+		}
 	}
 	if err := v.importExistingResources(ctx); err != nil {
 		return nil, nil, fmt.Errorf("error importing VPC resources: %w", err)
@@ -90,6 +94,8 @@ func (v *ExistingVPCResourceSet) addOutputs(ctx context.Context) {
 		addSubnetOutput(subnetAZs, v.clusterConfig.VPC.Subnets.Public, outputs.ClusterSubnetsPublic)
 	}
 
+	addPodSubnetsOutput(ctx, v.rs, v.ec2API, v.clusterConfig, v.subnetDetails)
+
 	if v.clusterConfig.IsFullyPrivate() {
 		v.rs.defineOutputWithoutCollector(outputs.ClusterFullyPrivate, true, true)
 	}
@@ -102,13 +108,139 @@ func (v *ExistingVPCResourceSet) checkIPv6CidrBlockAssociated(describeVPCOutput
 	return nil
 }
 
+// checkIPv6Subnets checks that the subnets of the dual-stack VPC have IPv6 CIDR blocks, and that their route tables
+// route IPv6 traffic as configured in vpc.ipv6
+func (v *ExistingVPCResourceSet) checkIPv6Subnets(ctx context.Context) error {
+	var (
+		privateSubnetIDs = v.clusterConfig.VPC.Subnets.Private.WithIDs()
+		publicSubnetIDs  = v.clusterConfig.VPC.Subnets.Public.WithIDs()
+		subnetIDs        = append(slices.Clone(privateSubnetIDs), publicSubnetIDs...)
+	)
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+
+	output, err := v.ec2API.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing subnets: %w", err)
+	}
+	for _, subnet := range output.Subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+		if !hasAssociatedIPv6CidrBlock(subnet) {
+			return fmt.Errorf("subnet %q does not have an associated IPv6 CIDR block", subnetID)
+		}
+		if v.clusterConfig.HasNAT64() && slices.Contains(privateSubnetIDs, subnetID) && !aws.ToBool(subnet.EnableDns64) {
+			return fmt.Errorf("DNS64 must be enabled on private subnet %q to use vpc.ipv6.nat64", subnetID)
+		}
+	}
+
+	routeTables, err := describeSubnetRouteTables(ctx, v.ec2API, v.clusterConfig.VPC.ID)
+	if err != nil {
+		return err
+	}
+	for _, subnetID := range publicSubnetIDs {
+		if !hasIPv6Route(routeTables.forSubnet(subnetID), InternetIPv6CIDR, func(r ec2types.Route) bool {
+			return strings.HasPrefix(aws.ToString(r.GatewayId), "igw-")
+		}) {
+			return fmt.Errorf("route table of public subnet %q does not have a %s route to an internet gateway", subnetID, InternetIPv6CIDR)
+		}
+	}
+	if v.clusterConfig.IsFullyPrivate() {
+		return nil
+	}
+	for _, subnetID := range privateSubnetIDs {
+		routeTable := routeTables.forSubnet(subnetID)
+		if v.clusterConfig.HasEgressOnlyInternetGateway() && !hasIPv6Route(routeTable, InternetIPv6CIDR, func(r ec2types.Route) bool {
+			return r.EgressOnlyInternetGatewayId != nil
+		}) {
+			return fmt.Errorf("route table of private subnet %q does not have a %s route to an egress-only internet gateway; "+
+				"set vpc.ipv6.egressOnlyInternetGateway to false if the subnet routes IPv6 traffic differently", subnetID, InternetIPv6CIDR)
+		}
+		if v.clusterConfig.HasNAT64() && !hasIPv6Route(routeTable, NAT64CIDR, func(r ec2types.Route) bool {
+			return r.NatGatewayId != nil
+		}) {
+			return fmt.Errorf("route table of private subnet %q does not have a %s route to a NAT gateway, which is required by vpc.ipv6.nat64", subnetID, NAT64CIDR)
+		}
+	}
+	return nil
+}
+
+func hasAssociatedIPv6CidrBlock(subnet ec2types.Subnet) bool {
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState == nil || association.Ipv6CidrBlockState.State == ec2types.SubnetCidrBlockStateCodeAssociated {
+			return true
+		}
+	}
+	return false
+}
+
+func hasIPv6Route(routeTable *ec2types.RouteTable, destination string, isTarget func(ec2types.Route) bool) bool {
+	if routeTable == nil {
+		return false
+	}
+	for _, route := range routeTable.Routes {
+		if aws.ToString(route.DestinationIpv6CidrBlock) == destination && route.State != ec2types.RouteStateBlackhole && isTarget(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcRouteTables holds the route tables of a VPC
+type vpcRouteTables struct {
+	bySubnet map[string]*ec2types.RouteTable
+	main     *ec2types.RouteTable
+}
+
+// forSubnet returns the route table associated with the subnet, or the main route table of the VPC
+// if the subnet is not explicitly associated with a route table
+func (r vpcRouteTables) forSubnet(subnetID string) *ec2types.RouteTable {
+	if rt, ok := r.bySubnet[subnetID]; ok {
+		return rt
+	}
+	return r.main
+}
+
+func describeSubnetRouteTables(ctx context.Context, ec2API awsapi.EC2, vpcID string) (vpcRouteTables, error) {
+	routeTables := vpcRouteTables{
+		bySubnet: map[string]*ec2types.RouteTable{},
+	}
+	paginator := ec2.NewDescribeRouteTablesPaginator(ec2API, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return routeTables, fmt.Errorf("error describing route tables: %w", err)
+		}
+		for i := range output.RouteTables {
+			rt := &output.RouteTables[i]
+			for _, rta := range rt.Associations {
+				if aws.ToBool(rta.Main) {
+					routeTables.main = rt
+				} else if rta.SubnetId != nil {
+					routeTables.bySubnet[*rta.SubnetId] = rt
+				}
+			}
+		}
+	}
+	return routeTables, nil
+}
+
 func (v *ExistingVPCResourceSet) importExistingResources(ctx context.Context) error {
 	if subnets := v.clusterConfig.VPC.Subnets.Private; subnets != nil {
 		var (
 			subnetRoutes map[string]string
 			err          error
 		)
-		if v.clusterConfig.IsFullyPrivate() {
+		if v.clusterConfig.IsFullyPrivate() || v.clusterConfig.HasVPCEndpoints() {
 			subnetRoutes, err = importRouteTables(ctx, v.ec2API, v.clusterConfig.VPC.Subnets.Private)
 			if err != nil {
 				return err
@@ -130,9 +262,74 @@ func (v *ExistingVPCResourceSet) importExistingResources(ctx context.Context) er
 		v.subnetDetails.Public = subnetResources
 	}
 
+	if v.clusterConfig.HasPrivateSubnetRoutes() {
+		if err := v.addPrivateSubnetRoutes(ctx); err != nil {
+			return err
+		}
+	}
+
+	if podSubnets := v.clusterConfig.VPC.PodSubnets; podSubnets != nil {
+		var subnetRoutes map[string]string
+		if podSubnets.CIDR != nil {
+			var err error
+			if subnetRoutes, err = importRouteTables(ctx, v.ec2API, v.clusterConfig.VPC.Subnets.Private); err != nil {
+				return err
+			}
+		}
+		subnetResources, err := addPodSubnets(v.rs, v.vpcID, podSubnets, routeTablesByAZ(v.clusterConfig.VPC.Subnets.Private, func(_ string, subnet api.AZSubnetSpec) (*gfnt.Value, bool) {
+			rt, ok := subnetRoutes[subnet.ID]
+			return gfnt.NewString(rt), ok
+		}))
+		if err != nil {
+			return err
+		}
+		v.subnetDetails.Pod = subnetResources
+	}
+
 	return nil
 }
 
+// addPrivateSubnetRoutes adds the routes of vpc.transitGateway and vpc.peerings to the explicit route tables of the
+// private subnets
+func (v *ExistingVPCResourceSet) addPrivateSubnetRoutes(ctx context.Context) error {
+	subnetRoutes, err := importRouteTables(ctx, v.ec2API, v.clusterConfig.VPC.Subnets.Private)
+	if err != nil {
+		return err
+	}
+	privateSubnets, err := makeSubnetResources(v.clusterConfig.VPC.Subnets.Private, subnetRoutes)
+	if err != nil {
+		return err
+	}
+
+	var transitGatewayID string
+	if tgw := v.clusterConfig.VPC.TransitGateway; tgw != nil && tgw.AttachmentID != "" {
+		if transitGatewayID, err = v.transitGatewayIDForAttachment(ctx, tgw.AttachmentID); err != nil {
+			return err
+		}
+		if tgw.ID != "" && tgw.ID != transitGatewayID {
+			return fmt.Errorf("transit gateway attachment %q belongs to transit gateway %q, not %q", tgw.AttachmentID, transitGatewayID, tgw.ID)
+		}
+	}
+	return addPrivateSubnetRoutes(v.rs, v.vpcID, v.clusterConfig.VPC, privateSubnets, transitGatewayID)
+}
+
+func (v *ExistingVPCResourceSet) transitGatewayIDForAttachment(ctx context.Context, attachmentID string) (string, error) {
+	output, err := v.ec2API.DescribeTransitGatewayVpcAttachments(ctx, &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		TransitGatewayAttachmentIds: []string{attachmentID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error describing transit gateway attachment %q: %w", attachmentID, err)
+	}
+	if len(output.TransitGatewayVpcAttachments) == 0 {
+		return "", fmt.Errorf("transit gateway attachment %q does not exist", attachmentID)
+	}
+	attachment := output.TransitGatewayVpcAttachments[0]
+	if vpcID := aws.ToString(attachment.VpcId); vpcID != v.clusterConfig.VPC.ID {
+		return "", fmt.Errorf("transit gateway attachment %q attaches VPC %q, not %q", attachmentID, vpcID, v.clusterConfig.VPC.ID)
+	}
+	return aws.ToString(attachment.TransitGatewayId), nil
+}
+
 func makeSubnetResources(subnets map[string]api.AZSubnetSpec, subnetRoutes map[string]string) ([]SubnetResource, error) {
 	var subnetResources []SubnetResource
 	for _, network := range subnets {
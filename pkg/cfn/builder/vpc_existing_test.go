@@ -179,14 +179,161 @@ var _ = Describe("Existing VPC", func() {
 		})
 
 		Context("when ipv6 is true", func() {
+			var (
+				subnetsOutput *ec2.DescribeSubnetsOutput
+				rtOutput      *ec2.DescribeRouteTablesOutput
+			)
+
 			BeforeEach(func() {
 				cfg.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+
+				subnetsOutput = &ec2.DescribeSubnetsOutput{}
+				for _, subnetID := range []string{privateSubnet1, privateSubnet2, publicSubnet1, publicSubnet2} {
+					subnetsOutput.Subnets = append(subnetsOutput.Subnets, ec2types.Subnet{
+						SubnetId: aws.String(subnetID),
+						Ipv6CidrBlockAssociationSet: []ec2types.SubnetIpv6CidrBlockAssociation{
+							{
+								Ipv6CidrBlock: aws.String("2600:1f14::/64"),
+								Ipv6CidrBlockState: &ec2types.SubnetCidrBlockState{
+									State: ec2types.SubnetCidrBlockStateCodeAssociated,
+								},
+							},
+						},
+					})
+				}
+				rtOutput = &ec2.DescribeRouteTablesOutput{
+					RouteTables: []ec2types.RouteTable{
+						{
+							RouteTableId: aws.String("rtb-main"),
+							Associations: []ec2types.RouteTableAssociation{{Main: aws.Bool(true)}},
+						},
+						{
+							RouteTableId: aws.String("rtb-public"),
+							Associations: []ec2types.RouteTableAssociation{
+								{SubnetId: aws.String(publicSubnet1)},
+								{SubnetId: aws.String(publicSubnet2)},
+							},
+							Routes: []ec2types.Route{
+								{
+									DestinationIpv6CidrBlock: aws.String("::/0"),
+									GatewayId:                aws.String("igw-1234"),
+								},
+							},
+						},
+						{
+							RouteTableId: aws.String("rtb-private"),
+							Associations: []ec2types.RouteTableAssociation{
+								{SubnetId: aws.String(privateSubnet1)},
+								{SubnetId: aws.String(privateSubnet2)},
+							},
+							Routes: []ec2types.Route{
+								{
+									DestinationIpv6CidrBlock:    aws.String("::/0"),
+									EgressOnlyInternetGatewayId: aws.String("eigw-1234"),
+								},
+							},
+						},
+					},
+				}
+
+				mockEC2.On("DescribeSubnets", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeSubnetsInput) bool {
+					return len(input.SubnetIds) == 4
+				})).Return(func(_ context.Context, _ *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) *ec2.DescribeSubnetsOutput {
+					return subnetsOutput
+				}, nil)
+				mockEC2.On("DescribeRouteTables", mock.Anything, &ec2.DescribeRouteTablesInput{
+					Filters: []ec2types.Filter{
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []string{"custom-vpc"},
+						},
+					},
+				}, mock.Anything).Return(func(_ context.Context, _ *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) *ec2.DescribeRouteTablesOutput {
+					return rtOutput
+				}, nil)
 			})
 
 			It("succeeds", func() {
 				Expect(addErr).NotTo(HaveOccurred())
 			})
 
+			When("a subnet does not have an IPv6 CIDR block", func() {
+				BeforeEach(func() {
+					subnetsOutput.Subnets[1].Ipv6CidrBlockAssociationSet = nil
+				})
+
+				It("errors", func() {
+					Expect(addErr).To(MatchError(fmt.Sprintf("subnet %q does not have an associated IPv6 CIDR block", privateSubnet2)))
+				})
+			})
+
+			When("a public subnet does not route IPv6 traffic to an internet gateway", func() {
+				BeforeEach(func() {
+					rtOutput.RouteTables[1].Associations = rtOutput.RouteTables[1].Associations[:1]
+				})
+
+				It("errors", func() {
+					Expect(addErr).To(MatchError(fmt.Sprintf("route table of public subnet %q does not have a ::/0 route to an internet gateway", publicSubnet2)))
+				})
+			})
+
+			When("a private subnet does not route IPv6 traffic to an egress-only internet gateway", func() {
+				BeforeEach(func() {
+					rtOutput.RouteTables[2].Routes = nil
+				})
+
+				It("errors", func() {
+					Expect(addErr).To(MatchError(ContainSubstring("does not have a ::/0 route to an egress-only internet gateway")))
+				})
+
+				When("the egress-only internet gateway is disabled", func() {
+					BeforeEach(func() {
+						cfg.VPC.IPv6 = &api.VPCIPv6{EgressOnlyInternetGateway: api.Disabled()}
+					})
+
+					It("succeeds", func() {
+						Expect(addErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("NAT64 is enabled", func() {
+				BeforeEach(func() {
+					cfg.VPC.IPv6 = &api.VPCIPv6{NAT64: api.Enabled()}
+					for i := range subnetsOutput.Subnets {
+						subnetsOutput.Subnets[i].EnableDns64 = aws.Bool(true)
+					}
+					rtOutput.RouteTables[2].Routes = append(rtOutput.RouteTables[2].Routes, ec2types.Route{
+						DestinationIpv6CidrBlock: aws.String("64:ff9b::/96"),
+						NatGatewayId:             aws.String("nat-1234"),
+					})
+				})
+
+				It("succeeds", func() {
+					Expect(addErr).NotTo(HaveOccurred())
+				})
+
+				When("DNS64 is not enabled on a private subnet", func() {
+					BeforeEach(func() {
+						subnetsOutput.Subnets[0].EnableDns64 = aws.Bool(false)
+					})
+
+					It("errors", func() {
+						Expect(addErr).To(MatchError(fmt.Sprintf("DNS64 must be enabled on private subnet %q to use vpc.ipv6.nat64", privateSubnet1)))
+					})
+				})
+
+				When("a private subnet does not route the NAT64 prefix to a NAT gateway", func() {
+					BeforeEach(func() {
+						rtOutput.RouteTables[2].Routes = rtOutput.RouteTables[2].Routes[:1]
+					})
+
+					It("errors", func() {
+						Expect(addErr).To(MatchError(ContainSubstring("does not have a 64:ff9b::/96 route to a NAT gateway")))
+					})
+				})
+			})
+
 			When("and the VPC does not have ipv6 enabled", func() {
 				BeforeEach(func() {
 					mockEC2.On("DescribeVpcs", mock.Anything, &ec2.DescribeVpcsInput{
@@ -47,6 +47,7 @@ type SubnetDetails struct {
 	Public           []SubnetResource
 	PrivateLocalZone []SubnetResource
 	PublicLocalZone  []SubnetResource
+	Pod              []SubnetResource
 
 	controlPlaneOnOutposts bool
 	autoMode               bool
@@ -79,17 +80,36 @@ func (v *IPv4VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *
 func (v *IPv4VPCResourceSet) addResources() error {
 	vpc := v.clusterConfig.VPC
 
-	v.vpcID = v.rs.newResource(cfnVPCResource, &gfnec2.VPC{
-		CidrBlock:          gfnt.NewString(vpc.CIDR.String()),
-		EnableDnsSupport:   gfnt.True(),
-		EnableDnsHostnames: gfnt.True(),
-	})
+	if vpc.IPAMPoolID != "" {
+		v.addIPAMVPC()
+	} else {
+		v.vpcID = v.rs.newResource(cfnVPCResource, &gfnec2.VPC{
+			CidrBlock:          gfnt.NewString(vpc.CIDR.String()),
+			EnableDnsSupport:   gfnt.True(),
+			EnableDnsHostnames: gfnt.True(),
+		})
+	}
 
 	// Add base private networking config, which is common to all eksctl created VPCs i.e.
 	// - Private Subnets
 	// - Private Route Tables
 	v.addPrivateRouteTables()
 	v.subnetDetails.Private = v.addSubnets(nil, api.SubnetTopologyPrivate, vpc.Subnets.Private)
+	if vpc.PodSubnets != nil {
+		podSubnets, err := addPodSubnets(v.rs, v.vpcID, vpc.PodSubnets, routeTablesByAZ(vpc.Subnets.Private, func(name string, _ api.AZSubnetSpec) (*gfnt.Value, bool) {
+			refRT, ok := v.azToRTMap[makeAZResourceName(name)]
+			return refRT, ok
+		}))
+		if err != nil {
+			return err
+		}
+		v.subnetDetails.Pod = podSubnets
+	}
+	if v.clusterConfig.HasPrivateSubnetRoutes() {
+		if err := addPrivateSubnetRoutes(v.rs, v.vpcID, vpc, v.subnetDetails.Private, ""); err != nil {
+			return err
+		}
+	}
 
 	if v.clusterConfig.IsFullyPrivate() {
 		// if the cluster if fully private, we have already added all required resources
@@ -206,6 +226,33 @@ func collectSubnetRefs(subnetResources []SubnetResource) []*gfnt.Value {
 	})
 }
 
+// addIPAMVPC adds a VPC whose CIDR, which was previewed by vpc.AllocateFromIPAM, is allocated from the IPAM pool,
+// along with the secondary CIDRs allocated from the same pool.
+// goformation does not support the IPAM properties of VPCs and VPC CIDR blocks yet.
+func (v *IPv4VPCResourceSet) addIPAMVPC() {
+	vpc := v.clusterConfig.VPC
+	v.vpcID = v.rs.newResource(cfnVPCResource, &awsCloudFormationResource{
+		Type: "AWS::EC2::VPC",
+		Properties: map[string]interface{}{
+			"CidrBlock":          vpc.CIDR.String(),
+			"Ipv4IpamPoolId":     vpc.IPAMPoolID,
+			"EnableDnsSupport":   true,
+			"EnableDnsHostnames": true,
+			"Tags":               []gfncfn.Tag{makeAutoNameTag(cfnVPCResource)},
+		},
+	})
+	for i, netmaskLength := range vpc.IPAMSecondaryNetmaskLengths {
+		v.rs.newResource(fmt.Sprintf("IPAMSecondaryCIDR%d", i), &awsCloudFormationResource{
+			Type: "AWS::EC2::VPCCidrBlock",
+			Properties: map[string]interface{}{
+				"VpcId":             v.vpcID,
+				"Ipv4IpamPoolId":    vpc.IPAMPoolID,
+				"Ipv4NetmaskLength": netmaskLength,
+			},
+		})
+	}
+}
+
 // addOutputs adds VPC resource outputs
 func (v *IPv4VPCResourceSet) addOutputs(ctx context.Context) {
 	v.rs.defineOutput(outputs.ClusterVPC, v.vpcID, true, func(val string) error {
@@ -250,6 +297,8 @@ func (v *IPv4VPCResourceSet) addOutputs(ctx context.Context) {
 		addSubnetOutput(subnetAZs, clusterVPC.LocalZoneSubnets.Public, outputs.ClusterSubnetsPublicLocal)
 	}
 
+	addPodSubnetsOutput(ctx, v.rs, v.ec2API, v.clusterConfig, v.subnetDetails)
+
 	if v.extendForOutposts {
 		if subnetAZs := v.subnetDetails.PrivateOutpostSubnetRefs(); len(subnetAZs) > 0 {
 			addSubnetOutputWithAlias(subnetAZs, clusterVPC.Subnets.Private, outputs.ClusterSubnetsPrivateExtended, vpc.MakeExtendedSubnetAliasFunc())
@@ -3,6 +3,7 @@ package builder_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -16,6 +17,8 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder/fakes"
 	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 var _ = Describe("VPC Template Builder", func() {
@@ -302,6 +305,56 @@ var _ = Describe("VPC Template Builder", func() {
 			})
 		})
 
+		Context("when the VPC CIDR is allocated from an IPAM pool", func() {
+			BeforeEach(func() {
+				cfg.VPC.CIDR = ipnet.MustParseCIDR("10.4.0.0/16")
+				cfg.VPC.IPAMPoolID = "ipam-pool-1234"
+				cfg.VPC.IPAMSecondaryNetmaskLengths = []int{18, 20}
+			})
+
+			It("creates the VPC from the pool", func() {
+				Expect(vpcTemplate.Resources[vpcResourceKey].Type).To(Equal("AWS::EC2::VPC"))
+				Expect(vpcTemplate.Resources[vpcResourceKey].Properties.Ipv4IpamPoolID).To(Equal("ipam-pool-1234"))
+				Expect(vpcTemplate.Resources[vpcResourceKey].Properties.CidrBlock).To(Equal("10.4.0.0/16"))
+				Expect(vpcTemplate.Resources[vpcResourceKey].Properties.EnableDNSHostnames).To(BeTrue())
+			})
+
+			It("associates the secondary CIDRs allocated from the pool", func() {
+				for i, length := range []int{18, 20} {
+					key := fmt.Sprintf("IPAMSecondaryCIDR%d", i)
+					Expect(vpcTemplate.Resources).To(HaveKey(key))
+					Expect(vpcTemplate.Resources[key].Type).To(Equal("AWS::EC2::VPCCidrBlock"))
+					Expect(vpcTemplate.Resources[key].Properties.VpcID).To(Equal(makeRef(vpcResourceKey)))
+					Expect(vpcTemplate.Resources[key].Properties.Ipv4IpamPoolID).To(Equal("ipam-pool-1234"))
+					Expect(vpcTemplate.Resources[key].Properties.Ipv4NetmaskLength).To(Equal(length))
+				}
+			})
+		})
+
+		Context("when pod subnets are configured", func() {
+			BeforeEach(func() {
+				cfg.VPC.PodSubnets = &api.PodSubnets{CIDR: ipnet.MustParseCIDR("100.64.0.0/16")}
+				Expect(vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones)).To(Succeed())
+			})
+
+			It("associates the pod subnets CIDR with the VPC", func() {
+				Expect(vpcTemplate.Resources).To(HaveKey("PodSubnetsCIDR"))
+				Expect(vpcTemplate.Resources["PodSubnetsCIDR"].Properties.VpcID).To(Equal(makeRef(vpcResourceKey)))
+				Expect(vpcTemplate.Resources["PodSubnetsCIDR"].Properties.CidrBlock).To(Equal("100.64.0.0/16"))
+			})
+
+			It("adds a pod subnet per availability zone routed through the private route table of the zone", func() {
+				Expect(vpcTemplate.Resources).To(HaveKey("SubnetPodUSWEST2A"))
+				Expect(vpcTemplate.Resources["SubnetPodUSWEST2A"].Properties.CidrBlock).To(Equal("100.64.0.0/17"))
+				Expect(vpcTemplate.Resources["SubnetPodUSWEST2A"].Properties.AvailabilityZone).To(Equal(azA))
+				Expect(vpcTemplate.Resources["SubnetPodUSWEST2A"].DependsOn).To(ConsistOf("PodSubnetsCIDR"))
+				Expect(vpcTemplate.Resources["RouteTableAssociationPodUSWEST2A"].Properties.RouteTableID).To(Equal(makeRef(privRouteTableA)))
+				Expect(vpcTemplate.Resources["SubnetPodUSWEST2B"].Properties.CidrBlock).To(Equal("100.64.128.0/17"))
+				Expect(vpcTemplate.Resources["RouteTableAssociationPodUSWEST2B"].Properties.RouteTableID).To(Equal(makeRef(privRouteTableB)))
+				Expect(subnetDetails.PodSubnetRefs()).To(HaveLen(2))
+			})
+		})
+
 		Context("when the vpc is fully private", func() {
 			BeforeEach(func() {
 				cfg.PrivateCluster.Enabled = true
@@ -74,6 +74,12 @@ func (v *IPv6VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *
 	}
 	addSubnetOutput(privateSubnetResourceRefs, v.clusterConfig.VPC.Subnets.Private, outputs.ClusterSubnetsPrivate)
 
+	if v.clusterConfig.HasPrivateSubnetRoutes() {
+		if err := addPrivateSubnetRoutes(v.rs, vpcResourceRef, v.clusterConfig.VPC, privateSubnets, ""); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if v.clusterConfig.IsFullyPrivate() {
 		return vpcResourceRef, &SubnetDetails{
 			Private: privateSubnets,
@@ -122,9 +128,11 @@ func (v *IPv6VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *
 		RouteTableId:               gfnt.MakeRef(PubRouteTableKey),
 	})
 
-	v.rs.newResource(EgressOnlyInternetGatewayKey, &gfnec2.EgressOnlyInternetGateway{
-		VpcId: gfnt.MakeRef(VPCResourceKey),
-	})
+	if v.clusterConfig.HasEgressOnlyInternetGateway() {
+		v.rs.newResource(EgressOnlyInternetGatewayKey, &gfnec2.EgressOnlyInternetGateway{
+			VpcId: gfnt.MakeRef(VPCResourceKey),
+		})
+	}
 	var publicSubnets []SubnetResource
 	for i, az := range v.clusterConfig.AvailabilityZones {
 		azFormatted := formatAZ(az)
@@ -138,11 +146,22 @@ func (v *IPv6VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *
 			SubnetId:     gfnt.MakeRef(PublicSubnetKey + azFormatted),
 		})
 
-		v.rs.newResource(PrivateSubnetIpv6RouteKey+azFormatted, &gfnec2.Route{
-			DestinationIpv6CidrBlock:    gfnt.NewString(InternetIPv6CIDR),
-			EgressOnlyInternetGatewayId: gfnt.MakeRef(EgressOnlyInternetGatewayKey),
-			RouteTableId:                gfnt.MakeRef(PrivateRouteTableKey + azFormatted),
-		})
+		if v.clusterConfig.HasEgressOnlyInternetGateway() {
+			v.rs.newResource(PrivateSubnetIpv6RouteKey+azFormatted, &gfnec2.Route{
+				DestinationIpv6CidrBlock:    gfnt.NewString(InternetIPv6CIDR),
+				EgressOnlyInternetGatewayId: gfnt.MakeRef(EgressOnlyInternetGatewayKey),
+				RouteTableId:                gfnt.MakeRef(PrivateRouteTableKey + azFormatted),
+			})
+		}
+
+		if v.clusterConfig.HasNAT64() {
+			v.rs.newResource(PrivateSubnetNAT64RouteKey+azFormatted, &gfnec2.Route{
+				AWSCloudFormationDependsOn: []string{NATGatewayKey},
+				DestinationIpv6CidrBlock:   gfnt.NewString(NAT64CIDR),
+				NatGatewayId:               gfnt.MakeRef(NATGatewayKey),
+				RouteTableId:               gfnt.MakeRef(PrivateRouteTableKey + azFormatted),
+			})
+		}
 
 		v.rs.newResource(PrivateSubnetRouteKey+azFormatted, &gfnec2.Route{
 			AWSCloudFormationDependsOn: []string{NATGatewayKey, GAKey},
@@ -205,6 +224,9 @@ func (v *IPv6VPCResourceSet) createSubnet(az, azFormatted string, i, cidrPartiti
 			Value: gfnt.NewString("1"),
 		}},
 	}
+	if private && v.clusterConfig.HasNAT64() {
+		subnet.EnableDns64 = gfnt.True()
+	}
 	maybeSetHostnameType(v.clusterConfig.VPC, subnet)
 	return v.rs.newResource(subnetKey, subnet)
 
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -633,6 +635,45 @@ var _ = Describe("IPv6 VPC builder", func() {
 		})
 	})
 
+	When("the egress-only internet gateway is disabled and NAT64 is enabled", func() {
+		BeforeEach(func() {
+			cfg.VPC.IPv6 = &api.VPCIPv6{
+				EgressOnlyInternetGateway: api.Disabled(),
+				NAT64:                     api.Enabled(),
+			}
+		})
+
+		It("routes the NAT64 prefix of private subnets through the NAT gateway", func() {
+			vpcRs := builder.NewIPv6VPCResourceSet(builder.NewRS(), cfg, nil)
+			vpcTemplate, err := createAndRenderTemplate(vpcRs)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("not creating the egress-only internet gateway")
+			Expect(vpcTemplate.Resources).NotTo(HaveKey(builder.EgressOnlyInternetGatewayKey))
+			Expect(vpcTemplate.Resources).NotTo(HaveKey(builder.PrivateSubnetIpv6RouteKey + azAFormatted))
+			Expect(vpcTemplate.Resources).NotTo(HaveKey(builder.PrivateSubnetIpv6RouteKey + azBFormatted))
+
+			By("enabling DNS64 on private subnets only")
+			for _, az := range []string{azAFormatted, azBFormatted} {
+				Expect(vpcTemplate.Resources[builder.PrivateSubnetKey+az].Properties.EnableDns64).To(Equal(aws.Bool(true)))
+				Expect(vpcTemplate.Resources[builder.PublicSubnetKey+az].Properties.EnableDns64).To(BeNil())
+			}
+
+			By("adding NAT64 routes to the private route tables")
+			for _, az := range []string{azAFormatted, azBFormatted} {
+				routeKey := builder.PrivateSubnetNAT64RouteKey + az
+				Expect(vpcTemplate.Resources).To(HaveKey(routeKey))
+				Expect(vpcTemplate.Resources[routeKey].Type).To(Equal("AWS::EC2::Route"))
+				Expect(vpcTemplate.Resources[routeKey].DependsOn).To(ConsistOf(builder.NATGatewayKey))
+				Expect(vpcTemplate.Resources[routeKey].Properties).To(Equal(fakes.Properties{
+					DestinationIpv6CidrBlock: builder.NAT64CIDR,
+					NatGatewayID:             map[string]interface{}{"Ref": builder.NATGatewayKey},
+					RouteTableID:             map[string]interface{}{"Ref": builder.PrivateRouteTableKey + az},
+				}))
+			}
+		})
+	})
+
 	When("a user provides a custom ipv6 block", func() {
 		BeforeEach(func() {
 			cfg.VPC.IPv6Cidr = "my-cidr"
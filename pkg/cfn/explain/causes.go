@@ -0,0 +1,105 @@
+package explain
+
+import (
+	"regexp"
+)
+
+// Cause is a common cause of failures, recognised from error messages.
+type Cause struct {
+	// Kind identifies the cause
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Suggestion  string `json:"suggestion"`
+}
+
+type causeMatcher struct {
+	pattern *regexp.Regexp
+	cause   Cause
+}
+
+// causeMatchers are tried in order, so that specific causes are recognised before more general ones matching the
+// same messages, e.g. denials by service control policies before missing IAM permissions
+var causeMatchers = []causeMatcher{
+	{
+		pattern: regexp.MustCompile(`(?i)explicit deny in a service control policy`),
+		cause: Cause{
+			Kind:        "ServiceControlPolicy",
+			Description: "the action is denied by a service control policy of the AWS Organization",
+			Suggestion: "ask the administrators of the AWS Organization to allow the action for this account and region, " +
+				"or use an account or region the policy does not restrict",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)could not be assumed|cannot be assumed|trusted entity is not correct|invalid iaminstanceprofile|iaminstanceprofile\.\w+ is invalid|instance profile .*(not found|does not exist)`),
+		cause: Cause{
+			Kind:        "IAMPropagation",
+			Description: "an IAM role or instance profile could not be used yet",
+			Suggestion: "IAM changes take a few seconds to propagate, retry the command; if it fails again, check that the role exists " +
+				"and that its trust policy allows the AWS service to assume it",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)not authorized to perform|accessdenied|access denied|unauthorizedoperation`),
+		cause: Cause{
+			Kind:        "AccessDenied",
+			Description: "an IAM identity is missing permissions",
+			Suggestion: "grant the missing permissions to the IAM identity used by eksctl, or to the CloudFormation service role " +
+				"set with --cfn-role-arn (see https://eksctl.io/usage/minimum-iam-policies/)",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)insufficientfreeaddressesinsubnet|insufficient free (ip )?addresses|not enough (free )?ip|not enough ip space`),
+		cause: Cause{
+			Kind:        "SubnetIPs",
+			Description: "the subnets do not have enough free IP addresses",
+			Suggestion: "use subnets with more free IP addresses: a larger vpc.cidr for VPCs created by eksctl, or other subnets in " +
+				"vpc.subnets or the subnets of the nodegroup; for pods, consider VPC CNI prefix delegation or custom networking",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)unsupportedavailabilityzoneexception|because .* does not currently have sufficient capacity to support the cluster`),
+		cause: Cause{
+			Kind:        "UnsupportedAvailabilityZone",
+			Description: "EKS does not support creating clusters in one of the availability zones",
+			Suggestion:  "set availabilityZones to zones supported by EKS, as listed in the error message",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)insufficientinstancecapacity|insufficient capacity|sufficient .* capacity|not supported in your requested availability zone|unsupported: your requested instance type`),
+		cause: Cause{
+			Kind:        "Capacity",
+			Description: "the availability zones do not have capacity for the instance type",
+			Suggestion: "use several instance types (instanceTypes for managed nodegroups, instancesDistribution for nodegroups), " +
+				"other instance types, or other availabilityZones",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)limitexceeded|limit exceeded|quota|maximum number of|exceeded .*limit`),
+		cause: Cause{
+			Kind:        "ServiceQuota",
+			Description: "a service quota of the account is exceeded",
+			Suggestion: "request a quota increase in the Service Quotas console (for instances, the Running On-Demand instances vCPU quota), " +
+				"or delete unused resources such as Elastic IPs, VPCs or NAT gateways",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)already exists`),
+		cause: Cause{
+			Kind:        "AlreadyExists",
+			Description: "a resource with the same name already exists",
+			Suggestion: "delete the existing resource or choose another name; resources left behind by deleted clusters are listed " +
+				"by `eksctl utils find-orphans`",
+		},
+	},
+}
+
+// RecogniseCause returns the common cause of the error message, or nil if it is not recognised.
+func RecogniseCause(message string) *Cause {
+	for _, m := range causeMatchers {
+		if m.pattern.MatchString(message) {
+			cause := m.cause
+			return &cause
+		}
+	}
+	return nil
+}
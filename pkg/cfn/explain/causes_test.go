@@ -0,0 +1,32 @@
+package explain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/explain"
+)
+
+var _ = DescribeTable("RecogniseCause", func(message, expectedKind string) {
+	cause := explain.RecogniseCause(message)
+	if expectedKind == "" {
+		Expect(cause).To(BeNil())
+		return
+	}
+	Expect(cause).NotTo(BeNil())
+	Expect(cause.Kind).To(Equal(expectedKind))
+	Expect(cause.Suggestion).NotTo(BeEmpty())
+},
+	Entry("service quota", "The maximum number of addresses has been reached. (Service: Ec2, Status Code: 400, Error Code: AddressLimitExceeded)", "ServiceQuota"),
+	Entry("vCPU quota", "You have requested more vCPU capacity than your current vCPU limit of 32 allows. (Error Code: VcpuLimitExceeded)", "ServiceQuota"),
+	Entry("instance capacity", "We currently do not have sufficient m5.large capacity in the Availability Zone you requested (us-west-2d). (Error Code: InsufficientInstanceCapacity)", "Capacity"),
+	Entry("instance type not offered", "Your requested instance type (m7g.large) is not supported in your requested Availability Zone (us-west-2d).", "Capacity"),
+	Entry("unsupported EKS zone", "Cannot create cluster 'dev' because us-east-1e, the targeted availability zone, does not currently have sufficient capacity to support the cluster. (Error Code: UnsupportedAvailabilityZoneException)", "UnsupportedAvailabilityZone"),
+	Entry("SCP denial", "User: arn:aws:sts::123:assumed-role/admin/x is not authorized to perform: ec2:CreateVpc with an explicit deny in a service control policy", "ServiceControlPolicy"),
+	Entry("missing permission", "User: arn:aws:sts::123:assumed-role/admin/x is not authorized to perform: iam:CreateRole on resource: role eksctl-dev-cluster-ServiceRole", "AccessDenied"),
+	Entry("IAM propagation", "Value (eksctl-dev-nodegroup-ng-1-NodeInstanceProfile) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", "IAMPropagation"),
+	Entry("role not assumable", "Role with arn: arn:aws:iam::123:role/eksctl-dev-cluster-ServiceRole, could not be assumed because it does not exist or the trusted entity is not correct", "IAMPropagation"),
+	Entry("subnet IPs", "[InsufficientFreeAddressesInSubnet] Insufficient free IP addresses in subnet subnet-1", "SubnetIPs"),
+	Entry("existing resource", "eksctl-dev-cluster-ServiceRole already exists in stack arn:aws:cloudformation:us-west-2:123:stack/other", "AlreadyExists"),
+	Entry("unknown", "Internal Failure", ""),
+)
@@ -0,0 +1,115 @@
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/kris-nova/logger"
+)
+
+const (
+	// cloudTrailWindow is how long before the first failure CloudTrail events are looked up from
+	cloudTrailWindow = 5 * time.Minute
+	// maxCloudTrailPages limits the number of events looked up, as all events of the account are returned
+	maxCloudTrailPages  = 10
+	maxCloudTrailErrors = 20
+)
+
+// cloudTrailInvokers are the services whose errors relate to stack failures, when calling other services
+// on behalf of CloudFormation
+var cloudTrailInvokers = map[string]bool{
+	"cloudformation.amazonaws.com": true,
+	"eks.amazonaws.com":            true,
+	"eks-nodegroup.amazonaws.com":  true,
+	"autoscaling.amazonaws.com":    true,
+}
+
+// CloudTrailError is an error returned to an AWS service acting for CloudFormation.
+type CloudTrailError struct {
+	Time         time.Time `json:"time"`
+	EventSource  string    `json:"eventSource"`
+	EventName    string    `json:"eventName"`
+	InvokedBy    string    `json:"invokedBy"`
+	ErrorCode    string    `json:"errorCode"`
+	ErrorMessage string    `json:"errorMessage,omitempty"`
+	Cause        *Cause    `json:"cause,omitempty"`
+}
+
+type cloudTrailEvent struct {
+	ErrorCode       string `json:"errorCode"`
+	ErrorMessage    string `json:"errorMessage"`
+	SourceIPAddress string `json:"sourceIPAddress"`
+	UserAgent       string `json:"userAgent"`
+	UserIdentity    struct {
+		InvokedBy string `json:"invokedBy"`
+	} `json:"userIdentity"`
+}
+
+func (e *cloudTrailEvent) invokedBy() string {
+	for _, invoker := range []string{e.UserIdentity.InvokedBy, e.SourceIPAddress, e.UserAgent} {
+		if cloudTrailInvokers[invoker] {
+			return invoker
+		}
+	}
+	return ""
+}
+
+// cloudTrailErrors returns the distinct errors returned to AWS services from shortly before the first failure
+// until the last failure
+func (e *Explainer) cloudTrailErrors(ctx context.Context, failures []Failure) ([]CloudTrailError, error) {
+	start, end := failures[0].Timestamp, failures[0].Timestamp
+	for _, f := range failures[1:] {
+		if f.Timestamp.Before(start) {
+			start = f.Timestamp
+		}
+		if f.Timestamp.After(end) {
+			end = f.Timestamp
+		}
+	}
+
+	paginator := cloudtrail.NewLookupEventsPaginator(e.cloudTrail, &cloudtrail.LookupEventsInput{
+		StartTime: aws.Time(start.Add(-cloudTrailWindow)),
+		EndTime:   aws.Time(end.Add(time.Minute)),
+	})
+	var errors []CloudTrailError
+	seen := map[string]bool{}
+	for page := 0; paginator.HasMorePages() && page < maxCloudTrailPages; page++ {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("looking up CloudTrail events: %w", err)
+		}
+		for _, event := range output.Events {
+			var parsed cloudTrailEvent
+			if err := json.Unmarshal([]byte(aws.ToString(event.CloudTrailEvent)), &parsed); err != nil {
+				logger.Debug("ignoring CloudTrail event %q: %v", aws.ToString(event.EventId), err)
+				continue
+			}
+			invokedBy := parsed.invokedBy()
+			if parsed.ErrorCode == "" || invokedBy == "" {
+				continue
+			}
+			key := aws.ToString(event.EventName) + parsed.ErrorCode + parsed.ErrorMessage
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			errors = append(errors, CloudTrailError{
+				Time:         aws.ToTime(event.EventTime),
+				EventSource:  aws.ToString(event.EventSource),
+				EventName:    aws.ToString(event.EventName),
+				InvokedBy:    invokedBy,
+				ErrorCode:    parsed.ErrorCode,
+				ErrorMessage: parsed.ErrorMessage,
+				Cause:        RecogniseCause(parsed.ErrorCode + ": " + parsed.ErrorMessage),
+			})
+			if len(errors) == maxCloudTrailErrors {
+				return errors, nil
+			}
+		}
+	}
+	return errors, nil
+}
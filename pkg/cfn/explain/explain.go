@@ -0,0 +1,215 @@
+// Package explain reports the root causes of CloudFormation stack failures.
+package explain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+const nestedStackType = "AWS::CloudFormation::Stack"
+
+// consequentialReasons are the reasons of failures caused by other failures, which are not reported
+var consequentialReasons = []string{
+	"Resource creation cancelled",
+	"Resource update cancelled",
+	"The following resource(s) failed to",
+}
+
+// Report is the root cause report of a stack failure.
+type Report struct {
+	StackName    string `json:"stackName"`
+	StackStatus  string `json:"stackStatus"`
+	StatusReason string `json:"statusReason,omitempty"`
+	// Failures are the failed resources of the last operation on the stack and its nested stacks,
+	// excluding failures caused by other failures
+	Failures []Failure `json:"failures"`
+	// CloudTrailErrors are the errors returned to AWS services around the time of the failures
+	CloudTrailErrors []CloudTrailError `json:"cloudTrailErrors,omitempty"`
+}
+
+// Failure is the failure of a stack resource.
+type Failure struct {
+	StackName         string    `json:"stackName"`
+	LogicalResourceID string    `json:"logicalResourceID"`
+	ResourceType      string    `json:"resourceType"`
+	Status            string    `json:"status"`
+	Reason            string    `json:"reason"`
+	Timestamp         time.Time `json:"timestamp"`
+	// ConfigField is the ClusterConfig field the resource originates from, if known
+	ConfigField string `json:"configField,omitempty"`
+	Cause       *Cause `json:"cause,omitempty"`
+}
+
+// Causes returns the distinct causes recognised in the failures and CloudTrail errors of the report.
+func (r *Report) Causes() []Cause {
+	var causes []Cause
+	seen := map[string]bool{}
+	add := func(cause *Cause) {
+		if cause != nil && !seen[cause.Kind] {
+			seen[cause.Kind] = true
+			causes = append(causes, *cause)
+		}
+	}
+	for _, f := range r.Failures {
+		add(f.Cause)
+	}
+	for _, e := range r.CloudTrailErrors {
+		add(e.Cause)
+	}
+	return causes
+}
+
+// Explainer explains stack failures.
+type Explainer struct {
+	cfn        awsapi.CloudFormation
+	cloudTrail awsapi.CloudTrail
+}
+
+// New creates an Explainer. CloudTrail events are not looked up if cloudTrail is nil, as they take several minutes
+// to be delivered after the failure.
+func New(cfn awsapi.CloudFormation, cloudTrail awsapi.CloudTrail) *Explainer {
+	return &Explainer{
+		cfn:        cfn,
+		cloudTrail: cloudTrail,
+	}
+}
+
+// Explain returns the root cause report of the last operation on the stack, which can be a stack name or ID.
+func (e *Explainer) Explain(ctx context.Context, stackName string) (*Report, error) {
+	stack, err := e.describeStack(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		StackName:    aws.ToString(stack.StackName),
+		StackStatus:  string(stack.StackStatus),
+		StatusReason: aws.ToString(stack.StackStatusReason),
+	}
+	if report.Failures, err = e.failures(ctx, stack); err != nil {
+		return nil, err
+	}
+	if e.cloudTrail != nil && len(report.Failures) > 0 {
+		if report.CloudTrailErrors, err = e.cloudTrailErrors(ctx, report.Failures); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (e *Explainer) describeStack(ctx context.Context, stackName string) (*cfntypes.Stack, error) {
+	output, err := e.cfn.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing stack %q: %w", stackName, err)
+	}
+	if len(output.Stacks) == 0 {
+		return nil, fmt.Errorf("stack %q not found", stackName)
+	}
+	return &output.Stacks[0], nil
+}
+
+// failures returns the root failures of the last operation on the stack, walking into the nested stacks that failed
+func (e *Explainer) failures(ctx context.Context, stack *cfntypes.Stack) ([]Failure, error) {
+	events, err := e.lastOperationEvents(ctx, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []Failure
+	for _, event := range events {
+		if !strings.HasSuffix(string(event.ResourceStatus), "FAILED") || isStackEvent(stack, event) {
+			continue
+		}
+		if aws.ToString(event.ResourceType) == nestedStackType && aws.ToString(event.PhysicalResourceId) != "" {
+			nested, err := e.describeStack(ctx, aws.ToString(event.PhysicalResourceId))
+			if err != nil {
+				return nil, err
+			}
+			nestedFailures, err := e.failures(ctx, nested)
+			if err != nil {
+				return nil, err
+			}
+			if len(nestedFailures) > 0 {
+				failures = append(failures, nestedFailures...)
+				continue
+			}
+		}
+		reason := aws.ToString(event.ResourceStatusReason)
+		if isConsequential(reason) {
+			continue
+		}
+		failures = append(failures, Failure{
+			StackName:         aws.ToString(stack.StackName),
+			LogicalResourceID: aws.ToString(event.LogicalResourceId),
+			ResourceType:      aws.ToString(event.ResourceType),
+			Status:            string(event.ResourceStatus),
+			Reason:            reason,
+			Timestamp:         aws.ToTime(event.Timestamp),
+			ConfigField:       configField(stack, aws.ToString(event.ResourceType)),
+			Cause:             RecogniseCause(reason),
+		})
+	}
+	return failures, nil
+}
+
+// lastOperationEvents returns the events of the last operation on the stack, oldest first
+func (e *Explainer) lastOperationEvents(ctx context.Context, stack *cfntypes.Stack) ([]cfntypes.StackEvent, error) {
+	var events []cfntypes.StackEvent
+	paginator := cloudformation.NewDescribeStackEventsPaginator(e.cfn, &cloudformation.DescribeStackEventsInput{
+		StackName: stack.StackId,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing events of stack %q: %w", aws.ToString(stack.StackName), err)
+		}
+		// events are returned newest first
+		for _, event := range output.StackEvents {
+			events = append(events, event)
+			if isStackEvent(stack, event) && isOperationStart(event.ResourceStatus) {
+				return sortByTime(events), nil
+			}
+		}
+	}
+	return sortByTime(events), nil
+}
+
+func isStackEvent(stack *cfntypes.Stack, event cfntypes.StackEvent) bool {
+	return aws.ToString(event.PhysicalResourceId) == aws.ToString(stack.StackId) ||
+		aws.ToString(event.ResourceType) == nestedStackType && aws.ToString(event.LogicalResourceId) == aws.ToString(stack.StackName)
+}
+
+func isOperationStart(status cfntypes.ResourceStatus) bool {
+	switch status {
+	case cfntypes.ResourceStatusCreateInProgress, cfntypes.ResourceStatusUpdateInProgress, cfntypes.ResourceStatusDeleteInProgress:
+		return true
+	default:
+		return false
+	}
+}
+
+func isConsequential(reason string) bool {
+	for _, r := range consequentialReasons {
+		if strings.HasPrefix(reason, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortByTime(events []cfntypes.StackEvent) []cfntypes.StackEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		return aws.ToTime(events[i].Timestamp).Before(aws.ToTime(events[j].Timestamp))
+	})
+	return events
+}
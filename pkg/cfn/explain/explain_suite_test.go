@@ -0,0 +1,11 @@
+package explain_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestExplain(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,174 @@
+package explain_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	cttypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/explain"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type stackEvent struct {
+	logicalID, physicalID, resourceType string
+	status                              cfntypes.ResourceStatus
+	reason                              string
+	minute                              int
+}
+
+var startTime = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func stackEvents(events ...stackEvent) []cfntypes.StackEvent {
+	var stackEvents []cfntypes.StackEvent
+	for _, e := range events {
+		event := cfntypes.StackEvent{
+			LogicalResourceId:  aws.String(e.logicalID),
+			PhysicalResourceId: aws.String(e.physicalID),
+			ResourceType:       aws.String(e.resourceType),
+			ResourceStatus:     e.status,
+			Timestamp:          aws.Time(startTime.Add(time.Duration(e.minute) * time.Minute)),
+		}
+		if e.reason != "" {
+			event.ResourceStatusReason = aws.String(e.reason)
+		}
+		stackEvents = append(stackEvents, event)
+	}
+	return stackEvents
+}
+
+func mockStack(provider *mockprovider.MockProvider, name, id string, tags map[string]string, events []cfntypes.StackEvent) {
+	stack := cfntypes.Stack{
+		StackName:         aws.String(name),
+		StackId:           aws.String(id),
+		StackStatus:       cfntypes.StackStatusRollbackComplete,
+		StackStatusReason: aws.String("The following resource(s) failed to create: [ManagedNodeGroup]."),
+	}
+	for k, v := range tags {
+		stack.Tags = append(stack.Tags, cfntypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	for _, stackName := range []string{name, id} {
+		provider.MockCloudFormation().On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{
+			StackName: aws.String(stackName),
+		}).Return(&cloudformation.DescribeStacksOutput{Stacks: []cfntypes.Stack{stack}}, nil)
+	}
+	provider.MockCloudFormation().On("DescribeStackEvents", mock.Anything, &cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(id),
+	}, mock.Anything).Return(&cloudformation.DescribeStackEventsOutput{StackEvents: events}, nil)
+}
+
+var _ = Describe("Explain", func() {
+	const (
+		nodeGroupStack   = "eksctl-dev-nodegroup-ng-1"
+		nodeGroupStackID = "arn:aws:cloudformation:us-west-2:123:stack/eksctl-dev-nodegroup-ng-1/1"
+		clusterStack     = "eksctl-dev-cluster"
+		clusterStackID   = "arn:aws:cloudformation:us-west-2:123:stack/eksctl-dev-cluster/1"
+		nestedStackID    = "arn:aws:cloudformation:us-west-2:123:stack/eksctl-dev-cluster-VPC-1/1"
+	)
+
+	var provider *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+	})
+
+	It("reports the root failures of the last operation with their config fields and causes", func() {
+		mockStack(provider, nodeGroupStack, nodeGroupStackID, map[string]string{
+			api.ClusterNameTag:   "dev",
+			api.NodeGroupNameTag: "ng-1",
+			api.NodeGroupTypeTag: string(api.NodeGroupTypeManaged),
+		}, stackEvents(
+			stackEvent{nodeGroupStack, nodeGroupStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusRollbackComplete, "", 20},
+			stackEvent{"SecurityGroup", "", "AWS::EC2::SecurityGroup", cfntypes.ResourceStatusCreateFailed, "Resource creation cancelled", 19},
+			stackEvent{"ManagedNodeGroup", "", "AWS::EKS::Nodegroup", cfntypes.ResourceStatusCreateFailed,
+				"Resource handler returned message: \"[InsufficientFreeAddressesInSubnet] Insufficient free IP addresses in subnet subnet-1\"", 18},
+			stackEvent{"ManagedNodeGroup", "", "AWS::EKS::Nodegroup", cfntypes.ResourceStatusCreateInProgress, "", 1},
+			stackEvent{nodeGroupStack, nodeGroupStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusCreateInProgress, "User Initiated", 0},
+			stackEvent{"ManagedNodeGroup", "", "AWS::EKS::Nodegroup", cfntypes.ResourceStatusDeleteFailed, "failure of a previous operation", -10},
+		))
+
+		report, err := explain.New(provider.CloudFormation(), nil).Explain(context.Background(), nodeGroupStack)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.StackName).To(Equal(nodeGroupStack))
+		Expect(report.StackStatus).To(Equal(string(cfntypes.StackStatusRollbackComplete)))
+		Expect(report.Failures).To(HaveLen(1))
+		failure := report.Failures[0]
+		Expect(failure.LogicalResourceID).To(Equal("ManagedNodeGroup"))
+		Expect(failure.Timestamp).To(Equal(startTime.Add(18 * time.Minute)))
+		Expect(failure.ConfigField).To(Equal("managedNodeGroups[name=ng-1]"))
+		Expect(failure.Cause.Kind).To(Equal("SubnetIPs"))
+		Expect(report.CloudTrailErrors).To(BeEmpty())
+		provider.MockCloudTrail().AssertNotCalled(GinkgoT(), "LookupEvents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("walks nested stacks and adds related CloudTrail errors", func() {
+		mockStack(provider, clusterStack, clusterStackID, map[string]string{
+			api.ClusterNameTag: "dev",
+		}, stackEvents(
+			stackEvent{clusterStack, clusterStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusRollbackInProgress, "", 6},
+			stackEvent{"VPC", nestedStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusCreateFailed,
+				"Embedded stack " + nestedStackID + " was not successfully created: The following resource(s) failed to create: [NATIP].", 5},
+			stackEvent{clusterStack, clusterStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusCreateInProgress, "User Initiated", 0},
+		))
+		mockStack(provider, "eksctl-dev-cluster-VPC-1", nestedStackID, map[string]string{
+			api.ClusterNameTag: "dev",
+		}, stackEvents(
+			stackEvent{"NATIP", "", "AWS::EC2::EIP", cfntypes.ResourceStatusCreateFailed,
+				"The maximum number of addresses has been reached. (Service: Ec2, Status Code: 400, Error Code: AddressLimitExceeded)", 4},
+			stackEvent{"eksctl-dev-cluster-VPC-1", nestedStackID, "AWS::CloudFormation::Stack", cfntypes.ResourceStatusCreateInProgress, "", 1},
+		))
+
+		cloudTrailEvent := func(id, name, errorCode, invokedBy string) cttypes.Event {
+			return cttypes.Event{
+				EventId:     aws.String(id),
+				EventName:   aws.String(name),
+				EventSource: aws.String("ec2.amazonaws.com"),
+				EventTime:   aws.Time(startTime.Add(4 * time.Minute)),
+				CloudTrailEvent: aws.String(`{"errorCode":"` + errorCode + `","errorMessage":"The maximum number of addresses has been reached.",` +
+					`"sourceIPAddress":"` + invokedBy + `","userIdentity":{"invokedBy":"` + invokedBy + `"}}`),
+			}
+		}
+		provider.MockCloudTrail().On("LookupEvents", mock.Anything, &cloudtrail.LookupEventsInput{
+			StartTime: aws.Time(startTime.Add(-time.Minute)),
+			EndTime:   aws.Time(startTime.Add(5 * time.Minute)),
+		}, mock.Anything).Return(&cloudtrail.LookupEventsOutput{
+			Events: []cttypes.Event{
+				cloudTrailEvent("1", "AllocateAddress", "AddressLimitExceeded", "cloudformation.amazonaws.com"),
+				cloudTrailEvent("2", "AllocateAddress", "AddressLimitExceeded", "cloudformation.amazonaws.com"),
+				cloudTrailEvent("3", "AllocateAddress", "AddressLimitExceeded", "203.0.113.1"),
+				cloudTrailEvent("4", "DescribeAddresses", "", "cloudformation.amazonaws.com"),
+			},
+		}, nil)
+
+		report, err := explain.New(provider.CloudFormation(), provider.CloudTrail()).Explain(context.Background(), clusterStack)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Failures).To(HaveLen(1))
+		failure := report.Failures[0]
+		Expect(failure.StackName).To(Equal("eksctl-dev-cluster-VPC-1"))
+		Expect(failure.LogicalResourceID).To(Equal("NATIP"))
+		Expect(failure.ConfigField).To(Equal("vpc"))
+		Expect(failure.Cause.Kind).To(Equal("ServiceQuota"))
+
+		Expect(report.CloudTrailErrors).To(HaveLen(1))
+		Expect(report.CloudTrailErrors[0].EventName).To(Equal("AllocateAddress"))
+		Expect(report.CloudTrailErrors[0].InvokedBy).To(Equal("cloudformation.amazonaws.com"))
+		Expect(report.CloudTrailErrors[0].Cause.Kind).To(Equal("ServiceQuota"))
+		Expect(report.Causes()).To(HaveLen(1))
+	})
+
+	It("returns an error when the stack cannot be described", func() {
+		provider.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything).Return(nil, errors.New("stack does not exist"))
+
+		_, err := explain.New(provider.CloudFormation(), nil).Explain(context.Background(), nodeGroupStack)
+		Expect(err).To(MatchError(ContainSubstring(`describing stack "eksctl-dev-nodegroup-ng-1": stack does not exist`)))
+	})
+})
@@ -0,0 +1,85 @@
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// clusterResourceFields are the ClusterConfig fields that resources of the cluster stack originate from, by resource type
+var clusterResourceFields = map[string]string{
+	"AWS::EKS::Cluster":        "metadata",
+	"AWS::EKS::AccessEntry":    "accessConfig.accessEntries",
+	"AWS::EKS::FargateProfile": "fargateProfiles",
+	"AWS::KMS::Key":            "secretsEncryption",
+	"AWS::IAM::Role":           "iam",
+	"AWS::IAM::Policy":         "iam",
+	"AWS::IAM::ManagedPolicy":  "iam",
+}
+
+// nodeGroupResourceSuffixes are the fields of nodegroups that resources of nodegroup stacks originate from, by resource type
+var nodeGroupResourceSuffixes = map[string]string{
+	"AWS::IAM::Role":                 ".iam",
+	"AWS::IAM::Policy":               ".iam",
+	"AWS::IAM::ManagedPolicy":        ".iam",
+	"AWS::IAM::InstanceProfile":      ".iam",
+	"AWS::EC2::SecurityGroup":        ".securityGroups",
+	"AWS::EC2::SecurityGroupIngress": ".securityGroups",
+	"AWS::EC2::SecurityGroupEgress":  ".securityGroups",
+}
+
+// configField returns the ClusterConfig field that the resource of the stack originates from, or an empty string
+// if the stack was not created by eksctl
+func configField(stack *cfntypes.Stack, resourceType string) string {
+	tags := map[string]string{}
+	for _, tag := range stack.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	if name := nodeGroupName(tags); name != "" {
+		field := fmt.Sprintf("nodeGroups[name=%s]", name)
+		if tags[api.NodeGroupTypeTag] == string(api.NodeGroupTypeManaged) {
+			field = fmt.Sprintf("managedNodeGroups[name=%s]", name)
+		}
+		return field + nodeGroupResourceSuffixes[resourceType]
+	}
+	if name := tags[api.IAMServiceAccountNameTag]; name != "" {
+		return fmt.Sprintf("iam.serviceAccounts[%s]", name)
+	}
+	if name := tags[api.AddonPodIdentityAssociationNameTag]; name != "" {
+		return fmt.Sprintf("addons[].podIdentityAssociations[%s]", name)
+	}
+	if name := tags[api.PodIdentityAssociationNameTag]; name != "" {
+		return fmt.Sprintf("iam.podIdentityAssociations[%s]", name)
+	}
+	if name := tags[api.AddonNameTag]; name != "" {
+		return fmt.Sprintf("addons[name=%s]", name)
+	}
+	if name := tags[api.FargateProfileNameTag]; name != "" {
+		return fmt.Sprintf("fargateProfiles[name=%s]", name)
+	}
+	if tags[api.KarpenterNameTag] != "" {
+		return "karpenter"
+	}
+	if tags[api.ClusterNameTag] == "" && tags[api.OldClusterNameTag] == "" {
+		return ""
+	}
+	if field, ok := clusterResourceFields[resourceType]; ok {
+		return field
+	}
+	if strings.HasPrefix(resourceType, "AWS::EC2::") {
+		return "vpc"
+	}
+	return ""
+}
+
+func nodeGroupName(tags map[string]string) string {
+	if name := tags[api.NodeGroupNameTag]; name != "" {
+		return name
+	}
+	return tags[api.OldNodeGroupNameTag]
+}
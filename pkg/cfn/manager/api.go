@@ -121,6 +121,10 @@ func (c *StackCollection) DoCreateStackRequest(ctx context.Context, i *Stack, te
 		StackName:       i.StackName,
 		DisableRollback: aws.Bool(c.disableRollback),
 	}
+	// only the cluster stack is protected, as protecting the other stacks would prevent deleting them on their own
+	if api.IsEnabled(c.spec.Metadata.DeletionProtection) && aws.ToString(i.StackName) == c.MakeClusterStackName() {
+		input.EnableTerminationProtection = aws.Bool(true)
+	}
 	input.Tags = append(input.Tags, c.sharedTags...)
 	for k, v := range tags {
 		input.Tags = append(input.Tags, newTag(k, v))
@@ -632,6 +636,17 @@ func (c *StackCollection) DeleteStackSync(ctx context.Context, s *Stack) error {
 	return c.doWaitUntilStackIsDeleted(ctx, s)
 }
 
+// SetTerminationProtection enables or disables CloudFormation termination protection on the stack
+func (c *StackCollection) SetTerminationProtection(ctx context.Context, stackName string, enabled bool) error {
+	if _, err := c.cloudformationAPI.UpdateTerminationProtection(ctx, &cloudformation.UpdateTerminationProtectionInput{
+		StackName:                   aws.String(stackName),
+		EnableTerminationProtection: aws.Bool(enabled),
+	}); err != nil {
+		return fmt.Errorf("updating termination protection of stack %q: %w", stackName, err)
+	}
+	return nil
+}
+
 func fmtStacksRegexForCluster(name string) string {
 	return fmt.Sprintf(ourStackRegexFmt, name)
 }
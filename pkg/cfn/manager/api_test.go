@@ -436,4 +436,48 @@ var _ = Describe("StackCollection", func() {
 			})
 		})
 	})
+
+	Context("DoCreateStackRequest", func() {
+		createStack := func(stackName string, deletionProtection *bool) *cfn.CreateStackInput {
+			p := mockprovider.NewMockProvider()
+			var input *cfn.CreateStackInput
+			p.MockCloudFormation().On("CreateStack", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				input = args[1].(*cfn.CreateStackInput)
+			}).Return(&cfn.CreateStackOutput{StackId: aws.String("stack-id")}, nil)
+
+			cfg := api.NewClusterConfig()
+			cfg.Metadata.Name = "my-cluster"
+			cfg.Metadata.DeletionProtection = deletionProtection
+			sm := NewStackCollection(p, cfg)
+			err := sm.DoCreateStackRequest(context.Background(), &Stack{StackName: aws.String(stackName)}, TemplateBody("{}"), nil, nil, false, false)
+			Expect(err).NotTo(HaveOccurred())
+			return input
+		}
+
+		It("enables termination protection on the cluster stack when deletion protection is enabled", func() {
+			Expect(createStack("eksctl-my-cluster-cluster", api.Enabled()).EnableTerminationProtection).To(Equal(aws.Bool(true)))
+		})
+
+		It("does not enable termination protection on other stacks", func() {
+			Expect(createStack("eksctl-my-cluster-nodegroup-ng-1", api.Enabled()).EnableTerminationProtection).To(BeNil())
+		})
+
+		It("does not enable termination protection by default", func() {
+			Expect(createStack("eksctl-my-cluster-cluster", nil).EnableTerminationProtection).To(BeNil())
+		})
+	})
+
+	Context("SetTerminationProtection", func() {
+		It("updates the termination protection of the stack", func() {
+			p := mockprovider.NewMockProvider()
+			p.MockCloudFormation().On("UpdateTerminationProtection", mock.Anything, &cfn.UpdateTerminationProtectionInput{
+				StackName:                   aws.String("eksctl-my-cluster-cluster"),
+				EnableTerminationProtection: aws.Bool(false),
+			}).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+
+			sm := NewStackCollection(p, api.NewClusterConfig())
+			Expect(sm.SetTerminationProtection(context.Background(), "eksctl-my-cluster-cluster", false)).To(Succeed())
+			p.MockCloudFormation().AssertExpectations(GinkgoT())
+		})
+	})
 })
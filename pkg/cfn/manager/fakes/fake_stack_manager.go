@@ -809,6 +809,19 @@ type FakeStackManager struct {
 	refreshFargatePodExecutionRoleARNReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetTerminationProtectionStub        func(context.Context, string, bool) error
+	setTerminationProtectionMutex       sync.RWMutex
+	setTerminationProtectionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	setTerminationProtectionReturns struct {
+		result1 error
+	}
+	setTerminationProtectionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StackStatusIsNotTransitionalStub        func(*types.Stack) bool
 	stackStatusIsNotTransitionalMutex       sync.RWMutex
 	stackStatusIsNotTransitionalArgsForCall []struct {
@@ -4577,6 +4590,69 @@ func (fake *FakeStackManager) RefreshFargatePodExecutionRoleARNReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *FakeStackManager) SetTerminationProtection(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.setTerminationProtectionMutex.Lock()
+	ret, specificReturn := fake.setTerminationProtectionReturnsOnCall[len(fake.setTerminationProtectionArgsForCall)]
+	fake.setTerminationProtectionArgsForCall = append(fake.setTerminationProtectionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.SetTerminationProtectionStub
+	fakeReturns := fake.setTerminationProtectionReturns
+	fake.recordInvocation("SetTerminationProtection", []interface{}{arg1, arg2, arg3})
+	fake.setTerminationProtectionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStackManager) SetTerminationProtectionCallCount() int {
+	fake.setTerminationProtectionMutex.RLock()
+	defer fake.setTerminationProtectionMutex.RUnlock()
+	return len(fake.setTerminationProtectionArgsForCall)
+}
+
+func (fake *FakeStackManager) SetTerminationProtectionCalls(stub func(context.Context, string, bool) error) {
+	fake.setTerminationProtectionMutex.Lock()
+	defer fake.setTerminationProtectionMutex.Unlock()
+	fake.SetTerminationProtectionStub = stub
+}
+
+func (fake *FakeStackManager) SetTerminationProtectionArgsForCall(i int) (context.Context, string, bool) {
+	fake.setTerminationProtectionMutex.RLock()
+	defer fake.setTerminationProtectionMutex.RUnlock()
+	argsForCall := fake.setTerminationProtectionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStackManager) SetTerminationProtectionReturns(result1 error) {
+	fake.setTerminationProtectionMutex.Lock()
+	defer fake.setTerminationProtectionMutex.Unlock()
+	fake.SetTerminationProtectionStub = nil
+	fake.setTerminationProtectionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStackManager) SetTerminationProtectionReturnsOnCall(i int, result1 error) {
+	fake.setTerminationProtectionMutex.Lock()
+	defer fake.setTerminationProtectionMutex.Unlock()
+	fake.SetTerminationProtectionStub = nil
+	if fake.setTerminationProtectionReturnsOnCall == nil {
+		fake.setTerminationProtectionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTerminationProtectionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStackManager) StackStatusIsNotTransitional(arg1 *types.Stack) bool {
 	fake.stackStatusIsNotTransitionalMutex.Lock()
 	ret, specificReturn := fake.stackStatusIsNotTransitionalReturnsOnCall[len(fake.stackStatusIsNotTransitionalArgsForCall)]
@@ -4915,6 +4991,8 @@ func (fake *FakeStackManager) Invocations() map[string][][]interface{} {
 	defer fake.propagateManagedNodeGroupTagsToASGMutex.RUnlock()
 	fake.refreshFargatePodExecutionRoleARNMutex.RLock()
 	defer fake.refreshFargatePodExecutionRoleARNMutex.RUnlock()
+	fake.setTerminationProtectionMutex.RLock()
+	defer fake.setTerminationProtectionMutex.RUnlock()
 	fake.stackStatusIsNotTransitionalMutex.RLock()
 	defer fake.stackStatusIsNotTransitionalMutex.RUnlock()
 	fake.troubleshootStackFailureCauseMutex.RLock()
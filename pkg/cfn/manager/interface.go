@@ -95,6 +95,7 @@ type StackManager interface {
 	NewUnmanagedNodeGroupTask(ctx context.Context, nodeGroups []*api.NodeGroup, forceAddCNIPolicy, skipEgressRules, disableAccessEntryCreation bool, importer vpc.Importer, nodeGroupParallelism int) *tasks.TaskTree
 	PropagateManagedNodeGroupTagsToASG(ngName string, ngTags map[string]string, asgNames []string, errCh chan error) error
 	RefreshFargatePodExecutionRoleARN(ctx context.Context) error
+	SetTerminationProtection(ctx context.Context, stackName string, enabled bool) error
 	StackStatusIsNotTransitional(s *Stack) bool
 	TroubleshootStackFailureCause(ctx context.Context, s *cfntypes.Stack, desiredStatus cfntypes.StackStatus)
 	UpdateNodeGroupStack(ctx context.Context, nodeGroupName, template string, wait bool) error
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/explain"
 )
 
 // TroubleshootStackFailureCause identifies the cause of the stack's failure and prints the stack events
@@ -59,6 +61,30 @@ func (c *StackCollection) TroubleshootStackFailureCause(ctx context.Context, s *
 			logger.Info(msg)
 		}
 	}
+	c.explainStackFailure(ctx, stack)
+}
+
+// explainStackFailure logs the recognised causes of the stack's failure, with the ClusterConfig fields they
+// originate from and suggested fixes. CloudTrail errors are left to `eksctl utils explain-failure`, as they are
+// delivered several minutes after the failure.
+func (c *StackCollection) explainStackFailure(ctx context.Context, stack *Stack) {
+	report, err := explain.New(c.cloudformationAPI, nil).Explain(ctx, aws.ToString(stack.StackId))
+	if err != nil {
+		logger.Debug("cannot explain the failure of stack %q: %v", *stack.StackName, err)
+		return
+	}
+	for _, f := range report.Failures {
+		if f.Cause == nil {
+			continue
+		}
+		if f.ConfigField != "" {
+			logger.Critical("%s/%s (%s) failed because %s", f.ResourceType, f.LogicalResourceID, f.ConfigField, f.Cause.Description)
+		} else {
+			logger.Critical("%s/%s failed because %s", f.ResourceType, f.LogicalResourceID, f.Cause.Description)
+		}
+		logger.Info("suggestion: %s", f.Cause.Suggestion)
+	}
+	logger.Info("to explain the failure with related CloudTrail errors, run `eksctl utils explain-failure --stack %s`", *stack.StackName)
 }
 
 // NoChangeError represents an error for when a CloudFormation changeset contains no changes.
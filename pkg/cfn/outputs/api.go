@@ -20,6 +20,7 @@ const (
 	ClusterSubnetsPublic          = string("Subnets" + api.SubnetTopologyPublic)
 	ClusterSubnetsPrivateLocal    = string("SubnetsLocalZone" + api.SubnetTopologyPrivate)
 	ClusterSubnetsPublicLocal     = string("SubnetsLocalZone" + api.SubnetTopologyPublic)
+	ClusterSubnetsPod             = "SubnetsPod"
 	ClusterSubnetsPrivateExtended = ClusterSubnetsPrivate + "Extended"
 	ClusterSubnetsPublicExtended  = ClusterSubnetsPublic + "Extended"
 	ClusterFullyPrivate           = "ClusterFullyPrivate"
@@ -16,6 +16,9 @@ func NewCreateOrUpgradeAddonLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
 	l.flagsIncompatibleWithConfigFile.Insert(addonFlagsIncompatibleWithConfigFile...)
 	l.validateWithConfigFile = func() error {
+		if err := cmd.ClusterConfig.AppendObservabilityAddons(); err != nil {
+			return err
+		}
 		if len(cmd.ClusterConfig.Addons) == 0 {
 			return fmt.Errorf("no addons specified")
 		}
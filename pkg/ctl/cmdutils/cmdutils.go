@@ -108,6 +108,10 @@ func GetNameArg(args []string) string {
 func AddCommonFlagsForAWS(cmd *Cmd, p *api.ProviderConfig, addCfnOptions bool) {
 	cmd.FlagSetGroup.InFlagSet("AWS client", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&p.Profile.Name, "profile", "p", "", "AWS credentials profile to use (defaults to the value of the AWS_PROFILE environment variable)")
+		fs.StringArrayVar(&p.Profile.AssumeRole.RoleARNs, "assume-role-arn", nil, "IAM role to assume with the profile credentials; repeat to assume each role with the credentials of the previous one")
+		fs.StringVar(&p.Profile.AssumeRole.ExternalID, "external-id", "", "external ID to pass when assuming the last role of --assume-role-arn")
+		fs.StringVar(&p.Profile.AssumeRole.MFASerial, "mfa-serial", "", "serial number or ARN of the MFA device to use when assuming the first role of --assume-role-arn, prompting for a token code")
+		fs.StringVar(&p.Profile.AssumeRole.RoleSessionName, "role-session-name", "", "session name of the roles assumed with --assume-role-arn")
 		if addCfnOptions {
 			fs.StringVar(&p.CloudFormationRoleARN, "cfn-role-arn", "", "IAM role used by CloudFormation to call AWS API on your behalf")
 			fs.BoolVar(&p.CloudFormationDisableRollback, "cfn-disable-rollback", false, "for debugging: If a stack fails, do not roll it back. Be careful, this may lead to unintentional resource consumption!")
@@ -117,10 +121,8 @@ func AddCommonFlagsForAWS(cmd *Cmd, p *api.ProviderConfig, addCfnOptions bool) {
 	AddPreRun(cmd.CobraCommand, func(c *cobra.Command, args []string) {
 		if !c.Flag("profile").Changed {
 			if val, ok := os.LookupEnv("AWS_PROFILE"); ok {
-				p.Profile = api.Profile{
-					Name:           val,
-					SourceIsEnvVar: true,
-				}
+				p.Profile.Name = val
+				p.Profile.SourceIsEnvVar = true
 			}
 		}
 	})
@@ -205,7 +207,7 @@ func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath, authenticatorRol
 // AddCommonFlagsForGetCmd adds common flags for get commands.
 func AddCommonFlagsForGetCmd(fs *pflag.FlagSet, chunkSize *int, outputMode *printers.Type) {
 	fs.IntVar(chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
-	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
 }
 
 // AddStringToStringVarPFlag is a wrapper that prefixes the description of the flag for consistency
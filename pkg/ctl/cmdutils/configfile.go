@@ -307,6 +307,9 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 			}
 		}
 
+		if err := clusterConfig.AppendObservabilityAddons(); err != nil {
+			return err
+		}
 		for _, addon := range clusterConfig.Addons {
 			if err := addon.Validate(); err != nil {
 				return err
@@ -731,6 +734,37 @@ func NewUtilsEnableLoggingLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewUtilsUpdateDeletionProtectionLoader will load config or use flags for 'eksctl utils update-deletion-protection'
+func NewUtilsUpdateDeletionProtectionLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.flagsIncompatibleWithConfigFile.Insert("enabled")
+
+	l.validateWithoutConfigFile = l.validateMetadataWithoutConfigFile
+
+	return l
+}
+
+// NewUtilsAuditSecurityGroupsLoader will load config or use flags for 'eksctl utils audit-security-groups'.
+func NewUtilsAuditSecurityGroupsLoader(cmd *Cmd, securityGroupPolicy string, webhookPorts []int) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.flagsIncompatibleWithConfigFile.Insert(
+		"security-group-policy",
+		"webhook-ports",
+	)
+	l.validateWithoutConfigFile = func() error {
+		if err := l.validateMetadataWithoutConfigFile(); err != nil {
+			return err
+		}
+		cmd.ClusterConfig.VPC.SecurityGroupPolicy = securityGroupPolicy
+		cmd.ClusterConfig.VPC.WebhookPorts = webhookPorts
+		return cmd.ClusterConfig.ValidateVPCConfig()
+	}
+
+	return l
+}
+
 // NewUtilsEnableEndpointAccessLoader will load config or use flags for 'eksctl utils update-cluster-endpoints'.
 func NewUtilsEnableEndpointAccessLoader(cmd *Cmd, privateAccess, publicAccess bool) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -820,6 +854,26 @@ func NewUtilsPublicAccessCIDRsLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewUtilsSubnetCapacityLoader loads config for `eksctl utils subnet-capacity`
+func NewUtilsSubnetCapacityLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		if err := validateUnsetNodeGroups(l.ClusterConfig); err != nil {
+			return err
+		}
+		if len(l.ClusterConfig.NodeGroups) == 0 && len(l.ClusterConfig.ManagedNodeGroups) == 0 {
+			return errors.New("no nodegroups defined in the config file")
+		}
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file/-f <file>")
+	}
+	return l
+}
+
 func parseList(arg string) ([]string, error) {
 	reader := strings.NewReader(arg)
 	csvReader := csv.NewReader(reader)
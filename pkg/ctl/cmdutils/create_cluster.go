@@ -34,6 +34,7 @@ type NodeGroupOptions struct {
 	CreateManagedNGOptions
 	UpdateAuthConfigMap     *bool
 	SkipOutdatedAddonsCheck bool
+	SkipSubnetCapacityCheck bool
 	SubnetIDs               []string
 }
 
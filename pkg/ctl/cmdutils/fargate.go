@@ -1,6 +1,7 @@
 package cmdutils
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/pflag"
@@ -167,3 +168,57 @@ func NewDeleteFargateProfileLoader(cmd *Cmd, options *fargate.Options) ClusterCo
 	}
 	return l
 }
+
+// NewFargateMatchLoader will load config or use flags for
+// 'eksctl utils fargate-match'
+func NewFargateMatchLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		if len(cmd.ClusterConfig.FargateProfiles) == 0 {
+			return errors.New("no Fargate profiles defined in the config file")
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		if cmd.NameArg != "" {
+			return ErrUnsupportedNameArg()
+		}
+		return validateCluster(cmd)
+	}
+	return l
+}
+
+// NewUpdateFargateProfileLoader will load config or use flags for
+// 'eksctl update fargateprofile'
+func NewUpdateFargateProfileLoader(cmd *Cmd, options *fargate.CreateOptions) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// The name of the profile limits the update to this profile when a config file is used:
+	l.flagsIncompatibleWithConfigFile = flagsIncompatibleWithConfigFileExcept(fargateProfileName)
+	l.flagsIncompatibleWithConfigFile.Insert(fargateProfileTags)
+	l.flagsIncompatibleWithoutConfigFile.Insert(fargateProfileFlagsIncompatibleWithoutConfigFile...)
+	l.validateWithConfigFile = func() error {
+		if err := validateNameFlagAndArgCreate(cmd, options); err != nil {
+			return err
+		}
+		return validateFargateProfiles(l)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		if err := validateNameFlagAndArgCreate(cmd, options); err != nil {
+			return err
+		}
+		if options.ProfileName == "" {
+			return ErrMustBeSet(fmt.Sprintf("--%s", fargateProfileName))
+		}
+		if err := options.Validate(); err != nil {
+			return err
+		}
+		cmd.ClusterConfig.FargateProfiles = []*api.FargateProfile{
+			options.ToFargateProfile(),
+		}
+		return validateFargateProfiles(l)
+	}
+	return l
+}
@@ -0,0 +1,89 @@
+package cmdutils
+
+// NewCreateOrUpdateKarpenterNodePoolLoader loads config file and validates command for
+// `eksctl create karpenter-nodepool` and `eksctl update karpenter-nodepool`.
+func NewCreateOrUpdateKarpenterNodePoolLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		if cmd.NameArg != "" {
+			return ErrUnsupportedNameArg()
+		}
+		if !cmd.ClusterConfig.Karpenter.HasNodePools() {
+			return ErrMustBeSet("karpenter.nodePools or karpenter.nodeClasses")
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+	return l
+}
+
+// NewDeleteKarpenterNodePoolLoader loads config file and validates command for `eksctl delete karpenter-nodepool`.
+func NewDeleteKarpenterNodePoolLoader(cmd *Cmd, nodePoolName *string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		if cmd.NameArg != "" {
+			return ErrUnsupportedNameArg()
+		}
+		if !cmd.ClusterConfig.Karpenter.HasNodePools() {
+			return ErrMustBeSet("karpenter.nodePools or karpenter.nodeClasses")
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		if *nodePoolName != "" && cmd.NameArg != "" {
+			return ErrFlagAndArg("--name", *nodePoolName, cmd.NameArg)
+		}
+		if cmd.NameArg != "" {
+			*nodePoolName = cmd.NameArg
+		}
+		if *nodePoolName == "" {
+			return ErrMustBeSet("--name")
+		}
+		return nil
+	}
+	return l
+}
+
+// NewUpgradeKarpenterLoader loads config file and validates command for `eksctl upgrade karpenter`.
+func NewUpgradeKarpenterLoader(cmd *Cmd, version string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		if cmd.NameArg != "" {
+			return ErrUnsupportedNameArg()
+		}
+		if cmd.ClusterConfig.Karpenter == nil || cmd.ClusterConfig.Karpenter.Version == "" {
+			return ErrMustBeSet("karpenter.version")
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		if version == "" {
+			return ErrMustBeSet("--version")
+		}
+		return nil
+	}
+	return l
+}
+
+// NewDeleteKarpenterLoader loads config file and validates command for `eksctl delete karpenter`.
+func NewDeleteKarpenterLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		if cmd.NameArg != "" {
+			return ErrUnsupportedNameArg()
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		return validateCluster(cmd)
+	}
+	return l
+}
@@ -0,0 +1,46 @@
+package cmdutils
+
+import (
+	"fmt"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// NewMigrateNodeGroupLoader will load config or use flags for 'eksctl migrate nodegroup'.
+func NewMigrateNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, target string, batchSize int) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	validate := func() error {
+		switch target {
+		case "karpenter", "automode":
+		case "":
+			return ErrMustBeSet("--to")
+		default:
+			return fmt.Errorf("invalid value %q for --to, must be one of karpenter or automode", target)
+		}
+		if batchSize < 1 || batchSize > 25 {
+			return fmt.Errorf("--batch-size value must be of range 1-25")
+		}
+		if ng.Name != "" && l.NameArg != "" {
+			return ErrFlagAndArg("--name", ng.Name, l.NameArg)
+		}
+		if l.NameArg != "" {
+			ng.Name = l.NameArg
+		}
+		if ng.Name == "" {
+			return ErrMustBeSet("--name")
+		}
+		return nil
+	}
+
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+	l.validateWithConfigFile = validate
+	l.validateWithoutConfigFile = func() error {
+		if l.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet(ClusterNameFlag(cmd))
+		}
+		return validate()
+	}
+
+	return l
+}
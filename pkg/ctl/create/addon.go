@@ -95,6 +95,10 @@ func createAddonCmd(cmd *cmdutils.Cmd) {
 			return err
 		}
 
+		if err := clusterProvider.ConfigureObservabilityLogGroups(ctx, cmd.ClusterConfig); err != nil {
+			return err
+		}
+
 		iamRoleCreator := &podidentityassociation.IAMRoleCreator{
 			ClusterName:  cmd.ClusterConfig.Metadata.Name,
 			StackCreator: stackManager,
@@ -408,7 +408,7 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		var kubeconfigContextName string
 
 		if params.WriteKubeconfig {
-			kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+			kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile())
 			kubeconfigContextName = kubectlConfig.CurrentContext
 
 			params.KubeconfigPath, err = kubeconfig.Write(params.KubeconfigPath, *kubectlConfig, params.SetContext)
@@ -505,7 +505,7 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 
 		// After we have the cluster config and all the nodes are done, we install Karpenter if necessary.
 		if cfg.Karpenter != nil {
-			config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+			config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile())
 			kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, config)
 			if err != nil {
 				return fmt.Errorf("generating kubeconfig: %w", err)
@@ -517,6 +517,15 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			if err := installKarpenter(ctx, ctl, cfg, stackManager, clientSet, kubernetes.NewRESTClientGetter("karpenter", string(kubeConfigBytes))); err != nil {
 				return err
 			}
+			if cfg.Karpenter.HasNodePools() {
+				nodePoolManager, err := karpenter.NewNodePoolManager(ctl, cfg)
+				if err != nil {
+					return fmt.Errorf("error creating Karpenter NodePools: %w", err)
+				}
+				if err := nodePoolManager.Create(ctx); err != nil {
+					return fmt.Errorf("error creating Karpenter NodePools: %w", err)
+				}
+			}
 		}
 
 		if cfg.HasGitOpsFluxConfigured() {
@@ -612,7 +621,15 @@ func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterC
 				return nil
 			}
 		}
-		return vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones)
+		if cfg.VPC.IPAMPoolID != "" {
+			if err := vpc.AllocateFromIPAM(ctx, ctl.AWSProvider.EC2(), cfg.VPC, len(cfg.AvailabilityZones)+len(cfg.LocalZones)); err != nil {
+				return err
+			}
+		}
+		if err := vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones); err != nil {
+			return err
+		}
+		return vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones)
 	}
 
 	if params.KopsClusterNameForVPC != "" {
@@ -648,6 +665,10 @@ func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterC
 			return err
 		}
 
+		if err := vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones); err != nil {
+			return err
+		}
+
 		logger.Success("using %s from kops cluster %q", cfg.SubnetInfo(), params.KopsClusterNameForVPC)
 		logger.Warning(customNetworkingNotice)
 		return nil
@@ -685,6 +706,10 @@ func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterC
 		return err
 	}
 
+	if err := vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones); err != nil {
+		return err
+	}
+
 	logger.Success("using existing %s", cfg.SubnetInfo())
 	logger.Warning(customNetworkingNotice)
 	return nil
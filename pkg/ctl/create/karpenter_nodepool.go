@@ -0,0 +1,57 @@
+package create
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func createKarpenterNodePoolCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter-nodepool",
+		"Create Karpenter NodePools and EC2NodeClasses",
+		"Create the Karpenter NodePools and EC2NodeClasses defined in karpenter.nodePools and karpenter.nodeClasses; existing objects are left unchanged",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewCreateOrUpdateKarpenterNodePoolLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd)
+	}
+}
+
+func createKarpenterNodePoolCmd(cmd *cmdutils.Cmd) {
+	createKarpenterNodePoolCmdWithRunFunc(cmd, doCreateKarpenterNodePool)
+}
+
+func doCreateKarpenterNodePool(cmd *cmdutils.Cmd) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if err := karpenter.LoadClusterVPC(ctx, ctl, cmd.ClusterConfig, ctl.NewStackManager(cmd.ClusterConfig)); err != nil {
+		return err
+	}
+	nodePoolManager, err := karpenter.NewNodePoolManager(ctl, cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	return nodePoolManager.Create(ctx)
+}
@@ -0,0 +1,27 @@
+package create
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("create karpenter-nodepool", func() {
+	type createKarpenterNodePoolEntry struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("unsupported arguments", func(e createKarpenterNodePoolEntry) {
+		cmd := newDefaultCmd(append([]string{"karpenter-nodepool"}, e.args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing --config-file", createKarpenterNodePoolEntry{
+			expectedErr: "--config-file must be set",
+		}),
+		Entry("config file without NodePools", createKarpenterNodePoolEntry{
+			args:        []string{"--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "karpenter.nodePools or karpenter.nodeClasses must be set",
+		}),
+	)
+})
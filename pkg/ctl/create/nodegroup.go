@@ -83,6 +83,7 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			SkipOutdatedAddonsCheck: options.SkipOutdatedAddonsCheck,
 			ConfigFileProvided:      cmd.ClusterConfigFile != "",
 			Parallelism:             options.NodeGroupParallelism,
+			SkipSubnetCapacityCheck: options.SkipSubnetCapacityCheck,
 		}, ngFilter)
 	})
 }
@@ -117,6 +118,7 @@ func createNodeGroupCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc runFn) {
 		cmdutils.AddSubnetIDs(fs, &options.SubnetIDs, "Define an optional list of subnet IDs to create the nodegroup in")
 		fs.BoolVarP(&options.DryRun, "dry-run", "", false, "Dry-run mode that skips nodegroup creation and outputs a ClusterConfig")
 		fs.BoolVarP(&options.SkipOutdatedAddonsCheck, "skip-outdated-addons-check", "", false, "whether the creation of ARM nodegroups should proceed when the cluster addons are outdated")
+		fs.BoolVar(&options.SkipSubnetCapacityCheck, "skip-subnet-capacity-check", false, "whether nodegroup creation should proceed when the subnets do not have enough free IPs for the desired number of nodes")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("New nodegroup", func(fs *pflag.FlagSet) {
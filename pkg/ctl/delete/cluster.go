@@ -16,26 +16,27 @@ import (
 )
 
 func deleteClusterCmd(cmd *cmdutils.Cmd) {
-	deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error {
-		return doDeleteCluster(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel)
+	deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force, disableNodegroupEviction, disableDeletionProtection bool, podEvictionWaitPeriod time.Duration, parallel int) error {
+		return doDeleteCluster(cmd, force, disableNodegroupEviction, disableDeletionProtection, podEvictionWaitPeriod, parallel)
 	})
 }
 
-func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error) {
+func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, force, disableNodegroupEviction, disableDeletionProtection bool, podEvictionWaitPeriod time.Duration, parallel int) error) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
 	cmd.SetDescription("cluster", "Delete a cluster", "")
 
 	var (
-		force                    bool
-		disableNodegroupEviction bool
-		podEvictionWaitPeriod    time.Duration
-		parallel                 int
+		force                     bool
+		disableNodegroupEviction  bool
+		disableDeletionProtection bool
+		podEvictionWaitPeriod     time.Duration
+		parallel                  int
 	)
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return runFunc(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel)
+		return runFunc(cmd, force, disableNodegroupEviction, disableDeletionProtection, podEvictionWaitPeriod, parallel)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -49,6 +50,7 @@ func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd,
 		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
 		fs.DurationVar(&podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
 		fs.IntVar(&parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		fs.BoolVar(&disableDeletionProtection, "disable-deletion-protection", false, "Disable the deletion protection of the cluster, set by metadata.deletionProtection, and delete it")
 
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
@@ -57,7 +59,7 @@ func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd,
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
-func doDeleteCluster(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error {
+func doDeleteCluster(cmd *cmdutils.Cmd, force, disableNodegroupEviction, disableDeletionProtection bool, podEvictionWaitPeriod time.Duration, parallel int) error {
 	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -85,6 +87,12 @@ func doDeleteCluster(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction boo
 		return err
 	}
 
+	stackManager := ctl.NewStackManager(cfg)
+	cluster.ReportDeletion(ctx, cfg, ctl, stackManager)
+	if err := cluster.CheckDeletionProtection(ctx, cfg, stackManager, disableDeletionProtection); err != nil {
+		return err
+	}
+
 	cluster, err := cluster.New(ctx, cfg, ctl)
 	if err != nil {
 		return err
@@ -0,0 +1,95 @@
+package delete
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func deleteKarpenterCmd(cmd *cmdutils.Cmd) {
+	deleteKarpenterWithRunFunc(cmd, doDeleteKarpenter)
+}
+
+func deleteKarpenterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, nodeTerminationTimeout time.Duration) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter",
+		"Delete Karpenter",
+		"Delete a Karpenter installation created by eksctl. The NodePools and EC2NodeClasses created by eksctl are deleted and "+
+			"the nodes launched by Karpenter are terminated before Karpenter is uninstalled and its instance profiles and stacks "+
+			"are removed. Karpenter is not deleted while NodePools or EC2NodeClasses not created by eksctl exist",
+	)
+
+	var nodeTerminationTimeout time.Duration
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.DurationVar(&nodeTerminationTimeout, "node-termination-timeout", 10*time.Minute, "Time to wait for Karpenter to terminate its nodes before terminating the remaining instances")
+		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of all resources")
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewDeleteKarpenterLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, nodeTerminationTimeout)
+	}
+}
+
+func doDeleteKarpenter(cmd *cmdutils.Cmd, nodeTerminationTimeout time.Duration) error {
+	cfg := cmd.ClusterConfig
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := ctl.NewDynamicClient(cfg)
+	if err != nil {
+		return err
+	}
+	restClientGetter, err := karpenter.NewRESTClientGetter(ctl, cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	version, err := karpenter.GetInstalledVersion(ctx, stackManager)
+	if err != nil {
+		return err
+	}
+	cfg.Karpenter = &api.Karpenter{Version: version}
+
+	deleter, err := karpenter.NewDeleter(cfg, ctl.AWSProvider.EC2(), ctl.AWSProvider.IAM(), stackManager, clientSet, dynamicClient, restClientGetter)
+	if err != nil {
+		return err
+	}
+	deleter.NodeTerminationTimeout = nodeTerminationTimeout
+
+	cmdutils.LogIntendedAction(cmd.Plan, "delete Karpenter from cluster %q", cfg.Metadata.Name)
+	if err := deleter.Delete(ctx, cmd.Wait, cmd.Plan); err != nil {
+		return err
+	}
+	cmdutils.LogCompletedAction(cmd.Plan, "deleted Karpenter from cluster %q", cfg.Metadata.Name)
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
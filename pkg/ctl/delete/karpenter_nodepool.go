@@ -0,0 +1,73 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func deleteKarpenterNodePoolCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter-nodepool",
+		"Delete Karpenter NodePools",
+		"Delete a Karpenter NodePool by name, or all NodePools and EC2NodeClasses defined in a config file. "+
+			"Karpenter drains and terminates the nodes launched by deleted NodePools",
+	)
+
+	var nodePoolName string
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&nodePoolName, "name", "", "name of the NodePool to delete")
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewDeleteKarpenterNodePoolLoader(cmd, &nodePoolName).Load(); err != nil {
+			return err
+		}
+		return doDeleteKarpenterNodePool(cmd, nodePoolName)
+	}
+}
+
+func doDeleteKarpenterNodePool(cmd *cmdutils.Cmd, nodePoolName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	var nodePoolNames, nodeClassNames []string
+	if cmd.ClusterConfigFile != "" {
+		for _, np := range cmd.ClusterConfig.Karpenter.NodePools {
+			nodePoolNames = append(nodePoolNames, np.Name)
+		}
+		for _, nc := range cmd.ClusterConfig.Karpenter.NodeClasses {
+			nodeClassNames = append(nodeClassNames, nc.Name)
+		}
+	} else {
+		version, err := karpenter.GetInstalledVersion(ctx, ctl.NewStackManager(cmd.ClusterConfig))
+		if err != nil {
+			return err
+		}
+		cmd.ClusterConfig.Karpenter = &api.Karpenter{Version: version}
+		nodePoolNames = []string{nodePoolName}
+	}
+
+	nodePoolManager, err := karpenter.NewNodePoolManager(ctl, cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	return nodePoolManager.Delete(ctx, nodePoolNames, nodeClassNames)
+}
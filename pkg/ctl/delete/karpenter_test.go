@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("delete karpenter", func() {
+	DescribeTable("plan mode", func(expectedPlan bool, args ...string) {
+		cmd := newMockEmptyCmd(append([]string{"karpenter", "--cluster", "clus-1"}, args...)...)
+		count := 0
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			deleteKarpenterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _ time.Duration) error {
+				Expect(cmd.Plan).To(Equal(expectedPlan))
+				count++
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	},
+		Entry("without --approve", true),
+		Entry("with --approve", false, "--approve"),
+	)
+
+	DescribeTable("invalid flags", func(expectedErr string, args ...string) {
+		cmd := newDefaultCmd(append([]string{"karpenter"}, args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing --cluster", "Error: --cluster must be set"),
+		Entry("name argument with a config file", "Error: cannot use name argument when --config-file/-f is set", "--config-file", "../../../examples/01-simple-cluster.yaml", "foo"),
+		Entry("--cluster with a config file", "Error: cannot use --cluster when --config-file/-f is set",
+			"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"),
+	)
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
@@ -51,7 +52,7 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 		return err
 	}
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		//log warnings and errors to stdout
 		logger.Writer = os.Stderr
 	}
@@ -81,8 +82,8 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addAccessEntrySummaryTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addAccessEntrySummaryTableColumns(tablePrinter)
 		logger.Info("to get a detailed view of Kubernetes groups or policies associated with each access entry, use --output yaml or json")
 	}
 
@@ -99,4 +100,14 @@ func addAccessEntrySummaryTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("ACCESS POLICIES", func(s accessentryactions.Summary) int {
 		return len(s.AccessPolicies)
 	})
+	printer.AddWideColumn("KUBERNETES GROUP NAMES", func(s accessentryactions.Summary) string {
+		return strings.Join(s.KubernetesGroups, ",")
+	})
+	printer.AddWideColumn("ACCESS POLICY ARNS", func(s accessentryactions.Summary) string {
+		var policyARNs []string
+		for _, p := range s.AccessPolicies {
+			policyARNs = append(policyARNs, p.PolicyARN.String())
+		}
+		return strings.Join(policyARNs, ",")
+	})
 }
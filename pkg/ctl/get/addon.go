@@ -3,6 +3,7 @@ package get
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
@@ -22,6 +23,7 @@ import (
 func getAddonCmd(cmd *cmdutils.Cmd) {
 	cmd.ClusterConfig = api.NewClusterConfig()
 	params := &getCmdParams{}
+	var watch watchParams
 
 	cmd.SetDescription(
 		"addon",
@@ -40,21 +42,22 @@ func getAddonCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		addWatchFlags(fs, &watch)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return getAddon(cmd, &a, params)
+		return getAddon(cmd, &a, params, watch)
 	}
 }
 
-func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
+func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams, watch watchParams) error {
 	if err := cmdutils.NewGetAddonsLoader(cmd).Load(); err != nil {
 		return err
 	}
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		//log warnings and errors to stdout
 		logger.Writer = os.Stderr
 	}
@@ -84,18 +87,19 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		return err
 	}
 
-	var summaries []addon.Summary
-	if a.Name == "" {
-		summaries, err = addonManager.GetAll(ctx)
-		if err != nil {
-			return err
+	getSummaries := func(ctx context.Context) ([]addon.Summary, error) {
+		if a.Name == "" {
+			return addonManager.GetAll(ctx)
 		}
-	} else {
 		summary, err := addonManager.Get(ctx, a)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		summaries = []addon.Summary{summary}
+		return []addon.Summary{summary}, nil
+	}
+	summaries, err := getSummaries(ctx)
+	if err != nil {
+		return err
 	}
 
 	if len(summaries) > 0 {
@@ -116,7 +120,33 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		addAddonSummaryTableColumns(tablePrinter)
 	}
 
-	if err := printer.PrintObjWithKind("addons", summaries, cmd.CobraCommand.OutOrStdout()); err != nil {
+	out := cmd.CobraCommand.OutOrStdout()
+	if watch.enabled() {
+		ctx, cancel := watchContext(ctx, watch, cmd.ProviderConfig.WaitTimeout)
+		defer cancel()
+		w := &watcher[addon.Summary]{
+			kind:  "addons",
+			fetch: getSummaries,
+			key: func(s addon.Summary) string {
+				return s.Name
+			},
+			state: func(s addon.Summary) string {
+				return fmt.Sprintf("%s %s %v", s.Status, s.Version, s.Issues)
+			},
+			status: func(s addon.Summary) string {
+				return s.Status
+			},
+			print: func(summaries []addon.Summary, header bool) error {
+				return printWatchedRows(params.output, header, out, func(writer io.Writer) error {
+					return printer.PrintObjWithKind("addons", summaries, writer)
+				})
+			},
+			params: watch,
+		}
+		return w.run(ctx, summaries)
+	}
+
+	if err := printer.PrintObjWithKind("addons", summaries, out); err != nil {
 		return err
 	}
 
@@ -159,4 +189,18 @@ func addAddonSummaryTableColumns(printer *printers.TablePrinter) {
 		}
 		return strings.Join(roleARNs, ",")
 	})
+	printer.AddWideColumn("ISSUE CODES", func(s addon.Summary) string {
+		var codes []string
+		for _, issue := range s.Issues {
+			codes = append(codes, issue.Code)
+		}
+		return strings.Join(codes, ",")
+	})
+	printer.AddWideColumn("POD IDENTITY ASSOCIATIONS", func(s addon.Summary) string {
+		var serviceAccounts []string
+		for _, pia := range s.PodIdentityAssociations {
+			serviceAccounts = append(serviceAccounts, pia.Namespace+"/"+pia.ServiceAccount)
+		}
+		return strings.Join(serviceAccounts, ",")
+	})
 }
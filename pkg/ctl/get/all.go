@@ -0,0 +1,263 @@
+package get
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// csvType is only supported by `eksctl get all`, as it prints one row per cluster
+const csvType printers.Type = "csv"
+
+type getAllOptions struct {
+	regions           []string
+	allRegions        bool
+	profiles          []string
+	parallelism       int
+	requestsPerSecond float64
+}
+
+func getAllCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	params := &getCmdParams{}
+	var options getAllOptions
+
+	cmd.SetDescription("all", "Get an inventory of clusters across regions and accounts",
+		"Reports the version, nodegroups, addons, authentication mode and endpoint access of each cluster "+
+			"in the given regions of the accounts of the given profiles")
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doGetAll(cmd, params, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringSliceVar(&options.regions, "regions", nil, "regions to look up clusters in, defaults to --region")
+		fs.BoolVarP(&options.allRegions, "all-regions", "A", false, "look up clusters in all supported regions enabled in each account")
+		fs.StringSliceVar(&options.profiles, "profiles", nil, "AWS profiles of the accounts to look up clusters in, defaults to --profile")
+		fs.IntVar(&options.parallelism, "parallelism", 4, "maximum number of regions and clusters looked up concurrently")
+		fs.Float64Var(&options.requestsPerSecond, "rate", 10, "maximum number of AWS API calls per second across all lookups, 0 disables the limit")
+		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		fs.Lookup("output").Usage = "specifies the output format (valid option: " + printers.ValidTypesDescription + ", csv)"
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doGetAll(cmd *cmdutils.Cmd, params *getCmdParams, options getAllOptions) error {
+	if err := cmdutils.NewGetClusterLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if options.allRegions && len(options.regions) > 0 {
+		return fmt.Errorf("--regions and --all-regions cannot be used at the same time")
+	}
+	if options.parallelism < 1 {
+		return fmt.Errorf("--parallelism must be at least 1")
+	}
+	if options.requestsPerSecond < 0 {
+		return fmt.Errorf("--rate cannot be negative")
+	}
+
+	if !printers.IsTableType(params.output) {
+		logger.Writer = os.Stderr
+	}
+
+	var printer printers.OutputPrinter
+	if params.output != csvType {
+		var err error
+		if printer, err = printers.NewPrinter(params.output); err != nil {
+			return err
+		}
+	}
+
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	inventory, err := cluster.GetInventory(ctx, ctl.AWSProvider, cluster.InventoryOptions{
+		Regions:           options.regions,
+		AllRegions:        options.allRegions,
+		Profiles:          options.profiles,
+		Parallelism:       options.parallelism,
+		RequestsPerSecond: options.requestsPerSecond,
+		ChunkSize:         params.chunkSize,
+	})
+	if err != nil {
+		return err
+	}
+	if inventory == nil {
+		inventory = []cluster.Inventory{}
+	}
+
+	if printer == nil {
+		return printInventoryCSV(inventory, cmd.CobraCommand.OutOrStdout())
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addInventoryTableColumns(tablePrinter)
+	}
+	return printer.PrintObjWithKind("clusters", inventory, cmd.CobraCommand.OutOrStdout())
+}
+
+func addInventoryTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("ACCOUNT", func(c cluster.Inventory) string {
+		return c.Account
+	})
+	printer.AddColumn("REGION", func(c cluster.Inventory) string {
+		return c.Region
+	})
+	printer.AddColumn("NAME", func(c cluster.Inventory) string {
+		return c.Name
+	})
+	printer.AddColumn("VERSION", func(c cluster.Inventory) string {
+		return orDash(c.Version)
+	})
+	printer.AddColumn("STATUS", func(c cluster.Inventory) string {
+		if c.Error != "" {
+			return "ERROR"
+		}
+		return orDash(c.Status)
+	})
+	printer.AddColumn("AUTH MODE", func(c cluster.Inventory) string {
+		return orDash(c.AuthenticationMode)
+	})
+	printer.AddColumn("ENDPOINT ACCESS", inventoryEndpointAccess)
+	printer.AddColumn("NODEGROUPS", inventoryNodeGroups)
+	printer.AddColumn("ADDONS", inventoryAddons)
+	printer.AddColumn("EKSCTL CREATED", func(c cluster.Inventory) api.EKSCTLCreated {
+		return c.EksctlCreated
+	})
+	printer.AddWideColumn("PROFILE", func(c cluster.Inventory) string {
+		return orDash(c.Profile)
+	})
+	printer.AddWideColumn("PLATFORM VERSION", func(c cluster.Inventory) string {
+		return orDash(c.PlatformVersion)
+	})
+	printer.AddWideColumn("NODEGROUP IMAGES", inventoryNodeGroupImages)
+	printer.AddWideColumn("ERROR", func(c cluster.Inventory) string {
+		return orDash(c.Error)
+	})
+}
+
+var inventoryCSVHeader = []string{
+	"ACCOUNT", "PROFILE", "REGION", "NAME", "VERSION", "PLATFORM VERSION", "STATUS", "AUTH MODE",
+	"ENDPOINT PUBLIC ACCESS", "ENDPOINT PRIVATE ACCESS", "PUBLIC ACCESS CIDRS", "NODEGROUPS",
+	"NODEGROUP IMAGES", "ADDONS", "EKSCTL CREATED", "ERROR",
+}
+
+// printInventoryCSV prints a row per cluster, with nodegroups and addons joined by semicolons
+func printInventoryCSV(inventory []cluster.Inventory, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write(inventoryCSVHeader); err != nil {
+		return err
+	}
+	for _, c := range inventory {
+		if err := w.Write([]string{
+			c.Account,
+			c.Profile,
+			c.Region,
+			c.Name,
+			c.Version,
+			c.PlatformVersion,
+			c.Status,
+			c.AuthenticationMode,
+			strconv.FormatBool(c.EndpointPublicAccess),
+			strconv.FormatBool(c.EndpointPrivateAccess),
+			strings.Join(c.PublicAccessCIDRs, ";"),
+			inventoryNodeGroups(c),
+			inventoryNodeGroupImages(c),
+			inventoryAddons(c),
+			string(c.EksctlCreated),
+			c.Error,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func inventoryEndpointAccess(c cluster.Inventory) string {
+	var access []string
+	if c.EndpointPublicAccess {
+		access = append(access, "public")
+	}
+	if c.EndpointPrivateAccess {
+		access = append(access, "private")
+	}
+	if len(access) == 0 {
+		return "-"
+	}
+	return strings.Join(access, ",")
+}
+
+// inventoryNodeGroups returns the nodegroups as name=version, with the AMI release version of managed nodegroups
+func inventoryNodeGroups(c cluster.Inventory) string {
+	var nodeGroups []string
+	for _, ng := range c.NodeGroups {
+		switch {
+		case ng.ReleaseVersion != "":
+			nodeGroups = append(nodeGroups, fmt.Sprintf("%s=%s (%s)", ng.Name, ng.Version, ng.ReleaseVersion))
+		case ng.Version != "":
+			nodeGroups = append(nodeGroups, fmt.Sprintf("%s=%s", ng.Name, ng.Version))
+		default:
+			nodeGroups = append(nodeGroups, ng.Name)
+		}
+	}
+	if len(nodeGroups) == 0 {
+		return "-"
+	}
+	return strings.Join(nodeGroups, ";")
+}
+
+func inventoryNodeGroupImages(c cluster.Inventory) string {
+	var images []string
+	for _, ng := range c.NodeGroups {
+		switch {
+		case ng.ImageID != "":
+			images = append(images, fmt.Sprintf("%s=%s", ng.Name, ng.ImageID))
+		case ng.AMIType != "":
+			images = append(images, fmt.Sprintf("%s=%s", ng.Name, ng.AMIType))
+		}
+	}
+	if len(images) == 0 {
+		return "-"
+	}
+	return strings.Join(images, ";")
+}
+
+func inventoryAddons(c cluster.Inventory) string {
+	var addons []string
+	for _, addon := range c.Addons {
+		addons = append(addons, fmt.Sprintf("%s=%s", addon.Name, addon.Version))
+	}
+	if len(addons) == 0 {
+		return "-"
+	}
+	return strings.Join(addons, ";")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
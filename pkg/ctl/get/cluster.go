@@ -56,7 +56,7 @@ func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool)
 	cfg := cmd.ClusterConfig
 	regionGiven := cfg.Metadata.Region != "" // eks.New resets this field, so we need to check if it was set in the first place
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		logger.Writer = os.Stderr
 	}
 
@@ -81,7 +81,7 @@ func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool)
 		return fmt.Errorf("--all-regions is for listing all clusters, it must be used without cluster name flag/argument")
 	}
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		// log warnings and errors to stdout
 		logger.Writer = os.Stderr
 	}
@@ -100,8 +100,8 @@ func getAndPrinterClusters(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.Clus
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addGetClustersSummaryTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addGetClustersSummaryTableColumns(tablePrinter)
 	}
 
 	clusters, err := cluster.GetClusters(ctx, ctl.AWSProvider, listAllRegions, params.chunkSize)
@@ -130,8 +130,8 @@ func getAndPrintCluster(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.Cluster
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addGetClusterSummaryTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addGetClusterSummaryTableColumns(tablePrinter)
 	}
 
 	cluster, err := ctl.GetCluster(ctx, cfg.Metadata.Name)
@@ -200,4 +200,22 @@ func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
 		}
 		return "EKS"
 	})
+	printer.AddWideColumn("PLATFORM VERSION", func(c *ekstypes.Cluster) string {
+		if c.PlatformVersion == nil {
+			return "-"
+		}
+		return *c.PlatformVersion
+	})
+	printer.AddWideColumn("ENDPOINT", func(c *ekstypes.Cluster) string {
+		if c.Endpoint == nil {
+			return "-"
+		}
+		return *c.Endpoint
+	})
+	printer.AddWideColumn("ARN", func(c *ekstypes.Cluster) string {
+		if c.Arn == nil {
+			return "-"
+		}
+		return *c.Arn
+	})
 }
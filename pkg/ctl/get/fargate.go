@@ -2,6 +2,7 @@ package get
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"github.com/kris-nova/logger"
@@ -17,6 +18,7 @@ import (
 type options struct {
 	fargate.Options
 	getCmdParams
+	watch watchParams
 }
 
 func getFargateProfileWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options *options) error) {
@@ -54,13 +56,17 @@ func configureGetFargateProfileCmd(cmd *cmdutils.Cmd) *options {
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddCommonFlagsForGetCmd(fs, &options.chunkSize, &options.output)
+		addWatchFlags(fs, &options.watch)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 	return &options
 }
 
 func doGetFargateProfile(cmd *cmdutils.Cmd, options *options) error {
-	if options.output != printers.TableType {
+	if err := validateNoWideOutput(options.output); err != nil {
+		return err
+	}
+	if !printers.IsTableType(options.output) {
 		//log warnings and errors to stderr
 		logger.Writer = os.Stderr
 	}
@@ -79,7 +85,35 @@ func doGetFargateProfile(cmd *cmdutils.Cmd, options *options) error {
 	if err != nil {
 		return err
 	}
-	return fargate.PrintProfiles(profiles, cmd.CobraCommand.OutOrStdout(), options.output)
+	out := cmd.CobraCommand.OutOrStdout()
+	if !options.watch.enabled() {
+		return fargate.PrintProfiles(profiles, out, options.output)
+	}
+
+	ctx, cancel := watchContext(ctx, options.watch, cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+	w := &watcher[*api.FargateProfile]{
+		kind: "fargateprofiles",
+		fetch: func(ctx context.Context) ([]*api.FargateProfile, error) {
+			return getProfiles(ctx, &manager, options.ProfileName)
+		},
+		key: func(p *api.FargateProfile) string {
+			return p.Name
+		},
+		state: func(p *api.FargateProfile) string {
+			return p.Status
+		},
+		status: func(p *api.FargateProfile) string {
+			return p.Status
+		},
+		print: func(profiles []*api.FargateProfile, header bool) error {
+			return printWatchedRows(options.output, header, out, func(writer io.Writer) error {
+				return fargate.PrintProfiles(profiles, writer, options.output)
+			})
+		},
+		params: options.watch,
+	}
+	return w.run(ctx, profiles)
 }
 
 func getProfiles(ctx context.Context, manager *fargate.Client, name string) ([]*api.FargateProfile, error) {
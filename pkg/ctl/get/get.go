@@ -1,6 +1,8 @@
 package get
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
@@ -12,6 +14,14 @@ type getCmdParams struct {
 	output    printers.Type
 }
 
+// validateNoWideOutput rejects the wide output for resources that have no additional columns to print
+func validateNoWideOutput(output printers.Type) error {
+	if output == printers.WideType {
+		return fmt.Errorf("output %q is not supported for this resource, use %q instead", output, printers.TableType)
+	}
+	return nil
+}
+
 // Command will create the `get` commands
 func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	verbCmd := cmdutils.NewVerbCmd("get", "Get resource(s)", "")
@@ -26,6 +36,7 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAddonCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getPodIdentityAssociationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAccessEntryCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAllCmd)
 
 	return verbCmd
 }
@@ -51,7 +51,7 @@ func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string
 
 	cfg := cmd.ClusterConfig
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		logger.Writer = os.Stderr
 	}
 
@@ -100,8 +100,8 @@ func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string
 	if err != nil {
 		return err
 	}
-	if params.output == printers.TableType {
-		addIAMIdentityMappingTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addIAMIdentityMappingTableColumns(tablePrinter)
 	}
 
 	return printer.PrintObjWithKind("iamidentitymappings", identities, cmd.CobraCommand.OutOrStdout())
@@ -120,4 +120,7 @@ func addIAMIdentityMappingTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("ACCOUNT", func(r iam.Identity) string {
 		return r.Account()
 	})
+	printer.AddWideColumn("TYPE", func(r iam.Identity) string {
+		return r.Type()
+	})
 }
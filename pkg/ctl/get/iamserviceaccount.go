@@ -3,7 +3,9 @@ package get
 import (
 	"context"
 	"os"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -61,7 +63,7 @@ func doGetIAMServiceAccount(cmd *cmdutils.Cmd, options IAMServiceAccountOptions)
 		return err
 	}
 
-	if options.output != printers.TableType {
+	if !printers.IsTableType(options.output) {
 		logger.Writer = os.Stderr
 	}
 
@@ -89,8 +91,8 @@ func doGetIAMServiceAccount(cmd *cmdutils.Cmd, options IAMServiceAccountOptions)
 		return err
 	}
 
-	if options.output == printers.TableType {
-		addIAMServiceAccountSummaryTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addIAMServiceAccountSummaryTableColumns(tablePrinter)
 	}
 
 	return printer.PrintObjWithKind("iamserviceaccounts", serviceAccounts, cmd.CobraCommand.OutOrStdout())
@@ -106,4 +108,10 @@ func addIAMServiceAccountSummaryTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("ROLE ARN", func(sa *api.ClusterIAMServiceAccount) string {
 		return *sa.Status.RoleARN
 	})
+	printer.AddWideColumn("STACK NAME", func(sa *api.ClusterIAMServiceAccount) string {
+		return aws.ToString(sa.Status.StackName)
+	})
+	printer.AddWideColumn("CAPABILITIES", func(sa *api.ClusterIAMServiceAccount) string {
+		return strings.Join(sa.Status.Capabilities, ",")
+	})
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"os"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/weaveworks/eksctl/pkg/actions/identityproviders"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -46,7 +48,7 @@ func doGetIdentityProvider(cmd *cmdutils.Cmd, params getCmdParams, name string)
 		return err
 	}
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		//log warnings and errors to stderr
 		logger.Writer = os.Stderr
 	}
@@ -77,8 +79,8 @@ func doGetIdentityProvider(cmd *cmdutils.Cmd, params getCmdParams, name string)
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addIdentityProviderTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addIdentityProviderTableColumns(tablePrinter)
 	}
 
 	return printer.PrintObjWithKind("identity provider summary", summaries, cmd.CobraCommand.OutOrStdout())
@@ -103,4 +105,16 @@ func addIdentityProviderTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("STATUS", func(s identityproviders.Summary) string {
 		return s.Status
 	})
+	printer.AddWideColumn("USERNAME_CLAIM", func(s identityproviders.Summary) string {
+		return aws.ToString(s.UsernameClaim)
+	})
+	printer.AddWideColumn("GROUPS_CLAIM", func(s identityproviders.Summary) string {
+		return aws.ToString(s.GroupsClaim)
+	})
+	printer.AddWideColumn("REQUIRED_CLAIMS", func(s identityproviders.Summary) string {
+		return labels.FormatLabels(s.RequiredClaims)
+	})
+	printer.AddWideColumn("TAGS", func(s identityproviders.Summary) string {
+		return labels.FormatLabels(s.Tags)
+	})
 }
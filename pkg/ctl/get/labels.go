@@ -2,10 +2,12 @@ package get
 
 import (
 	"context"
+	"os"
 
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/managed"
 
+	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/labels"
@@ -20,13 +22,14 @@ import (
 func getLabelsCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
+	params := &getCmdParams{}
 
 	cmd.SetDescription("labels", "Get labels for managed nodegroup", "")
 
 	var nodeGroupName string
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return getLabels(cmd, nodeGroupName)
+		return getLabels(cmd, nodeGroupName, params)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -36,16 +39,21 @@ func getLabelsCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 }
 
-func getLabels(cmd *cmdutils.Cmd, nodeGroupName string) error {
+func getLabels(cmd *cmdutils.Cmd, nodeGroupName string, params *getCmdParams) error {
 	if err := cmdutils.NewGetLabelsLoader(cmd, nodeGroupName).Load(); err != nil {
 		return err
 	}
+	if !printers.IsTableType(params.output) {
+		//log warnings and errors to stdout
+		logger.Writer = os.Stderr
+	}
 	cfg := cmd.ClusterConfig
 
 	ctx := context.Background()
@@ -61,8 +69,13 @@ func getLabels(cmd *cmdutils.Cmd, nodeGroupName string) error {
 		return err
 	}
 
-	printer := printers.NewTablePrinter()
-	addColumns(printer.(*printers.TablePrinter))
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addColumns(tablePrinter)
+	}
 	return printer.PrintObjWithKind("labels", labels, cmd.CobraCommand.OutOrStdout())
 }
 
@@ -30,6 +30,12 @@ var _ = Describe("get", func() {
 			Expect(err.Error()).To(ContainSubstring("Error: --nodegroup must be set"))
 		})
 
+		It("accepts the --output flag", func() {
+			cmd := newMockCmd("labels", "--nodegroup", "dummyNodeGroup", "--output", "json")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("Error: --cluster must be set")))
+		})
+
 		It("fails when name argument is used", func() {
 			cmd := newMockCmd("labels", "--cluster", "dummy", "--nodegroup", "dummyNodeGroup", "dummyName")
 			_, err := cmd.execute()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"time"
@@ -25,12 +26,13 @@ func getNodeGroupCmd(cmd *cmdutils.Cmd) {
 	cmd.ClusterConfig = cfg
 
 	params := &getCmdParams{}
+	var watch watchParams
 
 	cmd.SetDescription("nodegroup", "Get nodegroup(s)", "", "ng", "nodegroups")
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doGetNodeGroup(cmd, ng, params)
+		return doGetNodeGroup(cmd, ng, params, watch)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -38,6 +40,7 @@ func getNodeGroupCmd(cmd *cmdutils.Cmd) {
 		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup")
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		addWatchFlags(fs, &watch)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 	})
@@ -45,12 +48,12 @@ func getNodeGroupCmd(cmd *cmdutils.Cmd) {
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams) error {
+func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams, watch watchParams) error {
 	if err := cmdutils.NewGetNodegroupLoader(cmd, ng).Load(); err != nil {
 		return err
 	}
 
-	if params.output != printers.TableType {
+	if !printers.IsTableType(params.output) {
 		//log warnings and errors to stderr
 		logger.Writer = os.Stderr
 	}
@@ -76,19 +79,20 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 		return err
 	}
 
-	var summaries []*nodegroup.Summary
 	manager := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
-	if ng.Name == "" {
-		summaries, err = manager.GetAll(ctx)
-		if err != nil {
-			return err
+	getSummaries := func(ctx context.Context) ([]*nodegroup.Summary, error) {
+		if ng.Name == "" {
+			return manager.GetAll(ctx)
 		}
-	} else {
 		summary, err := manager.Get(ctx, ng.Name)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		summaries = append(summaries, summary)
+		return []*nodegroup.Summary{summary}, nil
+	}
+	summaries, err := getSummaries(ctx)
+	if err != nil {
+		return err
 	}
 
 	printer, err := printers.NewPrinter(params.output)
@@ -96,7 +100,7 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 		return err
 	}
 
-	if params.output == printers.TableType {
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
 		// Empty summary implies no nodegroups
 		// We only error if the output is table, since if the output
 		// is yaml or json we should return an empty object.
@@ -106,10 +110,36 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 			}
 			return fmt.Errorf("nodegroup with name %v not found", ng.Name)
 		}
-		addSummaryTableColumns(printer.(*printers.TablePrinter))
+		addSummaryTableColumns(tablePrinter)
+	}
+
+	out := cmd.CobraCommand.OutOrStdout()
+	if !watch.enabled() {
+		return printer.PrintObjWithKind("nodegroups", summaries, out)
 	}
 
-	return printer.PrintObjWithKind("nodegroups", summaries, cmd.CobraCommand.OutOrStdout())
+	ctx, cancel := watchContext(ctx, watch, cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+	w := &watcher[*nodegroup.Summary]{
+		kind:  "nodegroups",
+		fetch: getSummaries,
+		key: func(s *nodegroup.Summary) string {
+			return s.Name
+		},
+		state: func(s *nodegroup.Summary) string {
+			return fmt.Sprintf("%s %d/%d/%d %s %v", s.Status, s.DesiredCapacity, s.MinSize, s.MaxSize, s.ReleaseVersion, s.HealthIssues)
+		},
+		status: func(s *nodegroup.Summary) string {
+			return s.Status
+		},
+		print: func(summaries []*nodegroup.Summary, header bool) error {
+			return printWatchedRows(params.output, header, out, func(writer io.Writer) error {
+				return printer.PrintObjWithKind("nodegroups", summaries, writer)
+			})
+		},
+		params: watch,
+	}
+	return w.run(ctx, summaries)
 }
 
 func addSummaryTableColumns(printer *printers.TablePrinter) {
@@ -146,4 +176,19 @@ func addSummaryTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("TYPE", func(s *nodegroup.Summary) api.NodeGroupType {
 		return s.NodeGroupType
 	})
+	printer.AddWideColumn("VERSION", func(s *nodegroup.Summary) string {
+		return s.Version
+	})
+	printer.AddWideColumn("NODE ROLE ARN", func(s *nodegroup.Summary) string {
+		return s.NodeInstanceRoleARN
+	})
+	printer.AddWideColumn("STACK NAME", func(s *nodegroup.Summary) string {
+		return s.StackName
+	})
+	printer.AddWideColumn("RELEASE VERSION", func(s *nodegroup.Summary) string {
+		return s.ReleaseVersion
+	})
+	printer.AddWideColumn("HEALTH ISSUES", func(s *nodegroup.Summary) int {
+		return len(s.HealthIssues)
+	})
 }
@@ -34,6 +34,9 @@ func getPodIdentityAssociationCmd(cmd *cmdutils.Cmd) {
 }
 
 func doGetPodIdentityAssociation(cmd *cmdutils.Cmd, namespace, serviceAccountName string, params *getCmdParams) error {
+	if err := validateNoWideOutput(params.output); err != nil {
+		return err
+	}
 	cfg := cmd.ClusterConfig
 	ctx := context.Background()
 
@@ -57,8 +60,8 @@ func doGetPodIdentityAssociation(cmd *cmdutils.Cmd, namespace, serviceAccountNam
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addPodIdentityAssociationSummaryTableColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addPodIdentityAssociationSummaryTableColumns(tablePrinter)
 	}
 
 	return printer.PrintObjWithKind("podidentityassociations", summaries, cmd.CobraCommand.OutOrStdout())
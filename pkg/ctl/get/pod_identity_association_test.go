@@ -24,5 +24,9 @@ var _ = Describe("get pod identity association", func() {
 			args:        []string{"--cluster", "test-cluster", "--service-account-name", "test-sa-name"},
 			expectedErr: "--namespace must be set in order to specify --service-account-name",
 		}),
+		Entry("using the wide output", getPodIdentityAssociationEntry{
+			args:        []string{"--cluster", "test-cluster", "--output", "wide"},
+			expectedErr: `output "wide" is not supported for this resource, use "table" instead`,
+		}),
 	)
 })
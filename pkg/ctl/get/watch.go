@@ -0,0 +1,207 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+	maxWatchInterval     = time.Minute
+	// maxWatchErrors is the number of consecutive failed polls after which watching stops
+	maxWatchErrors = 5
+)
+
+type watchParams struct {
+	watch    bool
+	until    string
+	interval time.Duration
+}
+
+func addWatchFlags(fs *pflag.FlagSet, params *watchParams) {
+	fs.BoolVarP(&params.watch, "watch", "w", false, "after listing, watch for changes and print the rows that changed")
+	fs.StringVar(&params.until, "until", "", "watch until all listed resources reach the given status, e.g. ACTIVE (implies --watch)")
+	fs.DurationVar(&params.interval, "watch-interval", defaultWatchInterval, fmt.Sprintf("interval between polls, which backs off up to %s while nothing changes", maxWatchInterval))
+}
+
+func (p watchParams) enabled() bool {
+	return p.watch || p.until != ""
+}
+
+// watcher polls resources and prints the ones that are new or whose state changed since the last poll
+type watcher[T any] struct {
+	kind  string
+	fetch func(ctx context.Context) ([]T, error)
+	// key identifies a resource across polls
+	key func(T) string
+	// state returns the fields of a resource whose changes are printed
+	state  func(T) string
+	status func(T) string
+	// print prints resources, with the header of the output format if header is true
+	print  func(items []T, header bool) error
+	params watchParams
+	// sleep waits between polls, defaults to sleeping for the given duration
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// run prints the initial resources, then polls until the resources reach the target status, or until
+// ctx is cancelled if there is no target status
+func (w *watcher[T]) run(ctx context.Context, initial []T) error {
+	if err := w.print(initial, true); err != nil {
+		return err
+	}
+	states := w.states(initial)
+	if w.reached(initial) {
+		return nil
+	}
+
+	initialInterval := w.params.interval
+	if initialInterval <= 0 {
+		initialInterval = defaultWatchInterval
+	}
+	if w.sleep == nil {
+		w.sleep = sleep
+	}
+	interval := initialInterval
+	failures := 0
+	for {
+		if err := w.sleep(ctx, interval); err != nil {
+			return w.stopped(err)
+		}
+
+		items, err := w.fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return w.stopped(ctx.Err())
+			}
+			failures++
+			if failures >= maxWatchErrors {
+				return fmt.Errorf("watching %s: %w", w.kind, err)
+			}
+			logger.Warning("error polling %s, retrying: %v", w.kind, err)
+			interval = backoff(interval)
+			continue
+		}
+		failures = 0
+
+		var changed []T
+		for _, item := range items {
+			if state, ok := states[w.key(item)]; !ok || state != w.state(item) {
+				changed = append(changed, item)
+			}
+		}
+		newStates := w.states(items)
+		for key := range states {
+			if _, ok := newStates[key]; !ok {
+				logger.Info("%s %q no longer exists", strings.TrimSuffix(w.kind, "s"), key)
+			}
+		}
+		states = newStates
+
+		if len(changed) > 0 {
+			if err := w.print(changed, false); err != nil {
+				return err
+			}
+			interval = initialInterval
+		} else {
+			interval = backoff(interval)
+		}
+
+		if w.reached(items) {
+			return nil
+		}
+	}
+}
+
+func (w *watcher[T]) states(items []T) map[string]string {
+	states := make(map[string]string, len(items))
+	for _, item := range items {
+		states[w.key(item)] = w.state(item)
+	}
+	return states
+}
+
+// reached returns true if all resources have the target status
+func (w *watcher[T]) reached(items []T) bool {
+	if w.params.until == "" || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !strings.EqualFold(w.status(item), w.params.until) {
+			return false
+		}
+	}
+	logger.Success("all %s reached status %s", w.kind, strings.ToUpper(w.params.until))
+	return true
+}
+
+func (w *watcher[T]) stopped(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for %s to reach status %s", w.kind, strings.ToUpper(w.params.until))
+	}
+	// interrupted by the user
+	return nil
+}
+
+// watchContext returns a context cancelled when the user interrupts the command and, when waiting for a
+// target status, when the timeout expires
+func watchContext(ctx context.Context, params watchParams, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	if params.until == "" || timeout <= 0 {
+		return ctx, stop
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// printWatchedRows prints rows with print, removing the header line of table outputs unless header is true,
+// so that rows printed by later polls follow the rows printed before
+func printWatchedRows(output printers.Type, header bool, writer io.Writer, print func(io.Writer) error) error {
+	if header || !hasHeader(output) {
+		return print(writer)
+	}
+	var b bytes.Buffer
+	if err := print(&b); err != nil {
+		return err
+	}
+	_, rows, _ := strings.Cut(b.String(), "\n")
+	_, err := io.WriteString(writer, rows)
+	return err
+}
+
+func hasHeader(output printers.Type) bool {
+	return printers.IsTableType(output) || strings.HasPrefix(output, printers.CustomColumnsType+"=")
+}
+
+func backoff(interval time.Duration) time.Duration {
+	if interval >= maxWatchInterval {
+		return interval
+	}
+	return min(interval*2, maxWatchInterval)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
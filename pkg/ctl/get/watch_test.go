@@ -0,0 +1,160 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type watchedResource struct {
+	Name   string
+	Status string
+	Size   int
+}
+
+var _ = Describe("watch", func() {
+	var (
+		polls   [][]watchedResource
+		printed [][]string
+		headers []bool
+		sleeps  []time.Duration
+		w       *watcher[watchedResource]
+	)
+
+	BeforeEach(func() {
+		polls = nil
+		printed = nil
+		headers = nil
+		sleeps = nil
+		w = &watcher[watchedResource]{
+			kind: "resources",
+			fetch: func(context.Context) ([]watchedResource, error) {
+				if len(polls) == 0 {
+					return nil, context.Canceled
+				}
+				items := polls[0]
+				polls = polls[1:]
+				return items, nil
+			},
+			key: func(r watchedResource) string {
+				return r.Name
+			},
+			state: func(r watchedResource) string {
+				return fmt.Sprintf("%s %d", r.Status, r.Size)
+			},
+			status: func(r watchedResource) string {
+				return r.Status
+			},
+			print: func(items []watchedResource, header bool) error {
+				var names []string
+				for _, item := range items {
+					names = append(names, item.Name)
+				}
+				printed = append(printed, names)
+				headers = append(headers, header)
+				return nil
+			},
+			params: watchParams{watch: true, interval: 10 * time.Second},
+			sleep: func(ctx context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				if len(polls) == 0 {
+					return context.Canceled
+				}
+				return nil
+			},
+		}
+	})
+
+	It("prints only the resources that are new or changed", func() {
+		initial := []watchedResource{{Name: "a", Status: "UPDATING", Size: 1}, {Name: "b", Status: "ACTIVE", Size: 1}}
+		polls = [][]watchedResource{
+			{{Name: "a", Status: "UPDATING", Size: 1}, {Name: "b", Status: "ACTIVE", Size: 2}},
+			{{Name: "a", Status: "UPDATING", Size: 1}, {Name: "b", Status: "ACTIVE", Size: 2}, {Name: "c", Status: "CREATING"}},
+		}
+
+		Expect(w.run(context.Background(), initial)).To(Succeed())
+		Expect(printed).To(Equal([][]string{{"a", "b"}, {"b"}, {"c"}}))
+		Expect(headers).To(Equal([]bool{true, false, false}))
+	})
+
+	It("backs off while nothing changes and resets the interval on changes", func() {
+		initial := []watchedResource{{Name: "a", Status: "UPDATING"}}
+		polls = [][]watchedResource{
+			initial,
+			initial,
+			initial,
+			initial,
+			{{Name: "a", Status: "ACTIVE"}},
+		}
+
+		Expect(w.run(context.Background(), initial)).To(Succeed())
+		Expect(sleeps).To(Equal([]time.Duration{
+			10 * time.Second,
+			20 * time.Second,
+			40 * time.Second,
+			time.Minute,
+			time.Minute,
+			10 * time.Second,
+		}))
+	})
+
+	It("stops when all resources reach the target status", func() {
+		w.params.until = "active"
+		initial := []watchedResource{{Name: "a", Status: "UPDATING"}, {Name: "b", Status: "ACTIVE"}}
+		polls = [][]watchedResource{
+			{{Name: "a", Status: "ACTIVE"}, {Name: "b", Status: "ACTIVE"}},
+			{{Name: "a", Status: "DEGRADED"}, {Name: "b", Status: "ACTIVE"}},
+		}
+
+		Expect(w.run(context.Background(), initial)).To(Succeed())
+		Expect(printed).To(Equal([][]string{{"a", "b"}, {"a"}}))
+		Expect(polls).To(HaveLen(1))
+	})
+
+	It("does not poll if the resources already have the target status", func() {
+		w.params.until = "ACTIVE"
+		Expect(w.run(context.Background(), []watchedResource{{Name: "a", Status: "ACTIVE"}})).To(Succeed())
+		Expect(sleeps).To(BeEmpty())
+	})
+
+	It("retries failed polls and errors after too many consecutive failures", func() {
+		polls = [][]watchedResource{{}}
+		w.fetch = func(context.Context) ([]watchedResource, error) {
+			return nil, fmt.Errorf("throttled")
+		}
+
+		err := w.run(context.Background(), []watchedResource{{Name: "a", Status: "UPDATING"}})
+		Expect(err).To(MatchError("watching resources: throttled"))
+		Expect(sleeps).To(HaveLen(maxWatchErrors))
+	})
+
+	It("errors when timing out waiting for the target status", func() {
+		w.params.until = "ACTIVE"
+		w.sleep = func(context.Context, time.Duration) error {
+			return context.DeadlineExceeded
+		}
+
+		err := w.run(context.Background(), []watchedResource{{Name: "a", Status: "UPDATING"}})
+		Expect(err).To(MatchError("timed out waiting for resources to reach status ACTIVE"))
+	})
+
+	DescribeTable("printing watched rows",
+		func(output string, header bool, expected string) {
+			var b bytes.Buffer
+			Expect(printWatchedRows(output, header, &b, func(writer io.Writer) error {
+				_, err := io.WriteString(writer, "NAME\tSTATUS\na\tACTIVE\n")
+				return err
+			})).To(Succeed())
+			Expect(b.String()).To(Equal(expected))
+		},
+		Entry("table with header", "table", true, "NAME\tSTATUS\na\tACTIVE\n"),
+		Entry("table without header", "table", false, "a\tACTIVE\n"),
+		Entry("custom columns without header", "custom-columns=NAME:.Name", false, "a\tACTIVE\n"),
+		Entry("json", "json", false, "NAME\tSTATUS\na\tACTIVE\n"),
+	)
+})
@@ -0,0 +1,16 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command will create the `migrate` commands
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("migrate", "Migrate resource(s)", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateNodeGroupCmd)
+
+	return verbCmd
+}
@@ -0,0 +1,11 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlMigrate(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
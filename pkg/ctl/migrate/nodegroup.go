@@ -0,0 +1,207 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+type migrateNodeGroupOptions struct {
+	target                string
+	batchSize             int
+	maxGracePeriod        time.Duration
+	nodeDrainWaitPeriod   time.Duration
+	podEvictionWaitPeriod time.Duration
+	disableEviction       bool
+}
+
+func migrateNodeGroupCmd(cmd *cmdutils.Cmd) {
+	migrateNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *api.NodeGroup, options migrateNodeGroupOptions) error {
+		return doMigrateNodeGroup(cmd, ng, options)
+	})
+}
+
+func migrateNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, ng *api.NodeGroup, options migrateNodeGroupOptions) error) {
+	cfg := api.NewClusterConfig()
+	ng := api.NewNodeGroup()
+	cmd.ClusterConfig = cfg
+
+	var options migrateNodeGroupOptions
+
+	cmd.SetDescription(
+		"nodegroup",
+		"Migrate a nodegroup to Karpenter or EKS Auto Mode",
+		"Create a NodePool equivalent to the nodegroup's instance types, labels, taints, AMI family, volume and subnets, "+
+			"wait for it to become ready, cordon and drain the nodegroup in batches and delete it",
+		"ng",
+	)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewMigrateNodeGroupLoader(cmd, ng, options.target, options.batchSize).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, ng, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup to migrate")
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&options.target, "to", "", "Node provisioner to migrate the nodegroup to, one of karpenter or automode")
+		cmdutils.AddApproveFlag(fs, cmd)
+
+		cmd.Wait = false
+		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of the nodegroup")
+		cmdutils.AddTimeoutFlagWithValue(fs, &cmd.ProviderConfig.WaitTimeout, time.Hour)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain", func(fs *pflag.FlagSet) {
+		fs.IntVar(&options.batchSize, "batch-size", 1, "Number of nodes to drain at the same time. Max 25")
+		defaultMaxGracePeriod, _ := time.ParseDuration("10m")
+		fs.DurationVar(&options.maxGracePeriod, "max-grace-period", defaultMaxGracePeriod, "Maximum pods termination grace period")
+		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
+		fs.DurationVar(&options.podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
+		fs.DurationVar(&options.nodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes")
+		fs.BoolVar(&options.disableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
+}
+
+type authConfigMapUpdater struct {
+	clientSet kubernetes.Interface
+}
+
+func (a *authConfigMapUpdater) RemoveNodeGroup(ng *api.NodeGroup) error {
+	return authconfigmap.RemoveNodeGroup(a.clientSet, ng)
+}
+
+func doMigrateNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options migrateNodeGroupOptions) error {
+	cfg := cmd.ClusterConfig
+
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := ctl.NewDynamicClient(cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	// only the nodegroup being migrated is deleted, regardless of the nodegroups in the config file
+	cfg.NodeGroups, cfg.ManagedNodeGroups = nil, nil
+	if err := cmdutils.PopulateNodegroup(ctx, stackManager, ng.Name, cfg, ctl.AWSProvider); err != nil {
+		return err
+	}
+	var nodeGroupType api.NodeGroupType
+	switch {
+	case len(cfg.NodeGroups) == 1:
+		nodeGroupType = api.NodeGroupTypeUnmanaged
+		if err := ctl.GetNodeGroupIAM(ctx, stackManager, cfg.NodeGroups[0]); err != nil {
+			logger.Warning("error getting instance role ARN for nodegroup %q: %v", ng.Name, err)
+		}
+	case len(cfg.ManagedNodeGroups) == 1 && cfg.ManagedNodeGroups[0].Unowned:
+		nodeGroupType = api.NodeGroupTypeUnowned
+	case len(cfg.ManagedNodeGroups) == 1:
+		nodeGroupType = api.NodeGroupTypeManaged
+	default:
+		return fmt.Errorf("nodegroup %q not found", ng.Name)
+	}
+	kubeNodeGroup := cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups)[0]
+
+	migrateOptions := nodegroup.MigrateOptions{
+		Target:                nodegroup.MigrationTarget(options.target),
+		BatchSize:             options.batchSize,
+		MaxGracePeriod:        options.maxGracePeriod,
+		NodeDrainWaitPeriod:   options.nodeDrainWaitPeriod,
+		PodEvictionWaitPeriod: options.podEvictionWaitPeriod,
+		DisableEviction:       options.disableEviction,
+		Plan:                  cmd.Plan,
+		Wait:                  cmd.Wait,
+	}
+	switch migrateOptions.Target {
+	case nodegroup.MigrationTargetKarpenter:
+		if migrateOptions.KarpenterVersion, err = karpenteractions.GetInstalledVersion(ctx, stackManager); err != nil {
+			return err
+		}
+		if err := karpenteractions.LoadClusterVPC(ctx, ctl, cfg, stackManager); err != nil {
+			return err
+		}
+	case nodegroup.MigrationTargetAutoMode:
+		if migrateOptions.AutoModeNodeRoleName, err = getAutoModeNodeRoleName(ctl); err != nil {
+			return err
+		}
+	}
+
+	spec, err := nodegroup.New(cfg, ctl, clientSet, nil).GetMigrationSpec(ctx, kubeNodeGroup, nodeGroupType)
+	if err != nil {
+		return err
+	}
+
+	cmdutils.LogIntendedAction(cmd.Plan, "migrate nodegroup %q in cluster %q to %s", ng.Name, cfg.Metadata.Name, options.target)
+	migrator := &nodegroup.Migrator{
+		ClusterConfig: cfg,
+		ClientSet:     clientSet,
+		DynamicClient: dynamicClient,
+		Deleter: &nodegroup.Deleter{
+			StackHelper:      stackManager,
+			NodeGroupDeleter: ctl.AWSProvider.EKS(),
+			ClusterName:      cfg.Metadata.Name,
+			AuthConfigMapUpdater: &authConfigMapUpdater{
+				clientSet: clientSet,
+			},
+		},
+		PollInterval: 10 * time.Second,
+	}
+	if err := migrator.Migrate(ctx, spec, kubeNodeGroup, cfg.NodeGroups, cfg.ManagedNodeGroups, migrateOptions); err != nil {
+		return err
+	}
+
+	cmdutils.LogCompletedAction(cmd.Plan, "migrated nodegroup %q in cluster %q to %s", ng.Name, cfg.Metadata.Name, options.target)
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
+
+func getAutoModeNodeRoleName(ctl *eks.ClusterProvider) (string, error) {
+	computeConfig := ctl.Status.ClusterInfo.Cluster.ComputeConfig
+	if computeConfig == nil || computeConfig.Enabled == nil || !*computeConfig.Enabled {
+		return "", fmt.Errorf("Auto Mode is not enabled in the cluster; enable it with `eksctl update auto-mode-config`")
+	}
+	if computeConfig.NodeRoleArn == nil {
+		return "", fmt.Errorf("Auto Mode node role is not set in the cluster")
+	}
+	roleARN, err := arn.Parse(*computeConfig.NodeRoleArn)
+	if err != nil {
+		return "", fmt.Errorf("parsing Auto Mode node role ARN %q: %w", *computeConfig.NodeRoleArn, err)
+	}
+	parts := strings.Split(roleARN.Resource, "/")
+	return parts[len(parts)-1], nil
+}
@@ -0,0 +1,82 @@
+package migrate
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("migrate nodegroup", func() {
+	DescribeTable("valid flags and arguments", func(target string, args ...string) {
+		cmd := newMockEmptyCmd(args...)
+		count := 0
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			migrateNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *api.NodeGroup, options migrateNodeGroupOptions) error {
+				Expect(cmd.ClusterConfig.Metadata.Name).To(Equal("clusterName"))
+				Expect(ng.Name).To(Equal("ng"))
+				Expect(options.target).To(Equal(target))
+				Expect(options.batchSize).To(Equal(1))
+				count++
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	},
+		Entry("to Karpenter", "karpenter", "nodegroup", "--cluster", "clusterName", "--name", "ng", "--to", "karpenter"),
+		Entry("to Auto Mode with the nodegroup name as argument", "automode", "nodegroup", "ng", "--cluster", "clusterName", "--to", "automode"),
+	)
+
+	DescribeTable("invalid flags or arguments", func(expectedErr string, args ...string) {
+		cmd := newDefaultCmd(args...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing --cluster", "--cluster must be set", "nodegroup", "--name", "ng", "--to", "karpenter"),
+		Entry("missing --to", "--to must be set", "nodegroup", "--cluster", "dummy", "--name", "ng"),
+		Entry("invalid --to", `invalid value "fargate" for --to`, "nodegroup", "--cluster", "dummy", "--name", "ng", "--to", "fargate"),
+		Entry("missing --name", "--name must be set", "nodegroup", "--cluster", "dummy", "--to", "karpenter"),
+		Entry("--name and argument", "--name=ng and argument ng cannot be used at the same time", "nodegroup", "ng", "--cluster", "dummy", "--name", "ng", "--to", "karpenter"),
+		Entry("--batch-size above 25", "--batch-size value must be of range 1-25", "nodegroup", "--cluster", "dummy", "--name", "ng", "--to", "karpenter", "--batch-size", "26"),
+	)
+})
+
+func newDefaultCmd(args ...string) *mockVerbCmd {
+	flagGrouping := cmdutils.NewGrouping()
+	cmd := Command(flagGrouping)
+	cmd.SetArgs(args)
+	return &mockVerbCmd{
+		parentCmd: cmd,
+	}
+}
+
+func newMockEmptyCmd(args ...string) *mockVerbCmd {
+	cmd := cmdutils.NewVerbCmd("migrate", "Migrate resource(s)", "")
+	cmd.SetArgs(args)
+	return &mockVerbCmd{
+		parentCmd: cmd,
+	}
+}
+
+type mockVerbCmd struct {
+	parentCmd *cobra.Command
+}
+
+func (c mockVerbCmd) execute() (string, error) {
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	c.parentCmd.SetOut(outBuf)
+	c.parentCmd.SetErr(errBuf)
+	err := c.parentCmd.Execute()
+	if err != nil {
+		err = errors.New(errBuf.String())
+	}
+	return outBuf.String(), err
+}
@@ -2,6 +2,7 @@ package scale
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
 
@@ -14,8 +15,13 @@ import (
 )
 
 func scaleNodeGroupCmd(cmd *cmdutils.Cmd) {
+	var skipSubnetCapacityCheck bool
 	scaleNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
-		return doScaleNodeGroup(cmd, ng)
+		return doScaleNodeGroup(cmd, ng, skipSubnetCapacityCheck)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&skipSubnetCapacityCheck, "skip-subnet-capacity-check", false, "whether scaling should proceed when the subnets do not have enough free IPs for the desired number of nodes")
 	})
 }
 
@@ -60,34 +66,34 @@ func scaleNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
-func doScaleNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
+func doScaleNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase, skipSubnetCapacityCheck bool) error {
 	if ng.Name == "" && cmd.NameArg == "" {
 		if err := cmdutils.NewScaleAllNodeGroupLoader(cmd).Load(); err != nil {
 			return err
 		}
-		return scaleAllNodegroups(cmd)
+		return scaleAllNodegroups(cmd, skipSubnetCapacityCheck)
 	}
 
 	if err := cmdutils.NewScaleNodeGroupLoader(cmd, ng).Load(); err != nil {
 		return err
 	}
-	return scaleNodegroup(cmd, ng)
+	return scaleNodegroup(cmd, ng, skipSubnetCapacityCheck)
 }
 
-func scaleAllNodegroups(cmd *cmdutils.Cmd) error {
+func scaleAllNodegroups(cmd *cmdutils.Cmd, skipSubnetCapacityCheck bool) error {
 	allNg := cmd.ClusterConfig.AllNodeGroups()
 	for _, ng := range allNg {
 		if err := cmdutils.ValidateNumberOfNodes(ng); err != nil {
 			return err
 		}
-		if err := scaleNodegroup(cmd, ng); err != nil {
+		if err := scaleNodegroup(cmd, ng, skipSubnetCapacityCheck); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func scaleNodegroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
+func scaleNodegroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase, skipSubnetCapacityCheck bool) error {
 	cfg := cmd.ClusterConfig
 	ctx := context.Background()
 	ctl, err := cmd.NewProviderForExistingCluster(ctx)
@@ -104,5 +110,11 @@ func scaleNodegroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
 		return err
 	}
 
-	return nodegroup.New(cfg, ctl, clientSet, instanceSelector).Scale(ctx, ng, cmd.Wait)
+	manager := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
+	if !skipSubnetCapacityCheck {
+		if err := manager.CheckScaleSubnetCapacity(ctx, ng); err != nil {
+			return fmt.Errorf("%w; use --skip-subnet-capacity-check to scale the nodegroup anyway", err)
+		}
+	}
+	return manager.Scale(ctx, ng, cmd.Wait)
 }
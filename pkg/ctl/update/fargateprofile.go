@@ -0,0 +1,74 @@
+package update
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	actionsfargate "github.com/weaveworks/eksctl/pkg/actions/fargate"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+type updateFargateProfileOptions struct {
+	fargate.CreateOptions
+	rolloutDeployments bool
+}
+
+func updateFargateProfileCmd(cmd *cmdutils.Cmd) {
+	updateFargateProfileWithRunFunc(cmd, doUpdateFargateProfile)
+}
+
+func updateFargateProfileWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options *updateFargateProfileOptions) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"fargateprofile",
+		"Update Fargate profile(s) by replacing them",
+		"Fargate profiles are immutable. Each profile which differs from the desired one is replaced by a temporary profile, "+
+			"which is then replaced by a profile with the configured name. Before each old profile is deleted, the new one "+
+			"becomes active and the matching deployments are optionally restarted",
+		"fargateprofiles",
+	)
+
+	var options updateFargateProfileOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpdateFargateProfileLoader(cmd, &options.CreateOptions).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, &options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("Fargate", func(fs *pflag.FlagSet) {
+		cmdutils.AddFlagsForFargateProfileCreation(fs, &options.CreateOptions)
+		fs.BoolVar(&options.rolloutDeployments, "rollout-deployments", false,
+			"Restart the deployments selected by the old or the new profile before deleting the old profile")
+	})
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpdateFargateProfile(cmd *cmdutils.Cmd, options *updateFargateProfileOptions) error {
+	ctx := cmd.CobraCommand.Context()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	manager := actionsfargate.New(cmd.ClusterConfig, ctl, ctl.NewStackManager(cmd.ClusterConfig))
+	if err := manager.Update(ctx, actionsfargate.UpdateOptions{
+		ProfileName:        options.ProfileName,
+		RolloutDeployments: options.rolloutDeployments,
+		Plan:               cmd.Plan,
+	}); err != nil {
+		return err
+	}
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
@@ -0,0 +1,81 @@
+package update
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+var _ = Describe("update fargateprofile", func() {
+	var options *updateFargateProfileOptions
+
+	newCmd := func(args ...string) *ctltest.MockCmd {
+		return ctltest.NewMockCmd(func(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+			updateFargateProfileWithRunFunc(cmd, func(cmd *cmdutils.Cmd, o *updateFargateProfileOptions) error {
+				options = o
+				return runFunc(cmd)
+			})
+		}, "update", append([]string{"fargateprofile"}, args...)...)
+	}
+
+	It("builds the desired profile from flags", func() {
+		cmd := newCmd("--cluster", "my-cluster", "fp-dev", "--namespace", "dev", "--labels", "app=web", "--rollout-deployments")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.FargateProfiles).To(Equal([]*api.FargateProfile{{
+			Name:      "fp-dev",
+			Selectors: []api.FargateProfileSelector{{Namespace: "dev", Labels: map[string]string{"app": "web"}}},
+			Tags:      map[string]string{},
+		}}))
+		Expect(options.rolloutDeployments).To(BeTrue())
+		Expect(cmd.Cmd.Plan).To(BeTrue())
+	})
+
+	It("limits the update to the named profile of the config file", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{Name: "fp-dev", Selectors: []api.FargateProfileSelector{{Namespace: "dev"}}},
+		}
+		cmd := newCmd("--config-file", ctltest.CreateConfigFile(cfg), "--name", "fp-dev", "--approve")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(options.ProfileName).To(Equal("fp-dev"))
+		Expect(cmd.Cmd.Plan).To(BeFalse())
+	})
+
+	type invalidArgsEntry struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("invalid arguments", func(e invalidArgsEntry) {
+		_, err := newCmd(e.args...).Execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing --cluster", invalidArgsEntry{
+			args:        []string{"--name", "fp-dev", "--namespace", "dev"},
+			expectedErr: "--cluster must be set",
+		}),
+		Entry("missing --name", invalidArgsEntry{
+			args:        []string{"--cluster", "my-cluster", "--namespace", "dev"},
+			expectedErr: "--name must be set",
+		}),
+		Entry("missing --namespace", invalidArgsEntry{
+			args:        []string{"--cluster", "my-cluster", "--name", "fp-dev"},
+			expectedErr: "invalid Fargate profile: empty selector namespace",
+		}),
+		Entry("--name and name argument", invalidArgsEntry{
+			args:        []string{"--cluster", "my-cluster", "--name", "fp-dev", "fp-prod", "--namespace", "dev"},
+			expectedErr: "--name=fp-dev and argument fp-prod cannot be used at the same time",
+		}),
+		Entry("--namespace with a config file", invalidArgsEntry{
+			args:        []string{"--config-file", "../../../examples/16-fargate-profile.yaml", "--namespace", "dev"},
+			expectedErr: "cannot use --namespace when --config-file/-f is set",
+		}),
+	)
+})
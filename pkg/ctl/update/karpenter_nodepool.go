@@ -0,0 +1,53 @@
+package update
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateKarpenterNodePoolCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter-nodepool",
+		"Update Karpenter NodePools and EC2NodeClasses",
+		"Reconcile the Karpenter NodePools and EC2NodeClasses in the cluster with karpenter.nodePools and karpenter.nodeClasses, creating any that are missing",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewCreateOrUpdateKarpenterNodePoolLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doUpdateKarpenterNodePool(cmd)
+	}
+}
+
+func doUpdateKarpenterNodePool(cmd *cmdutils.Cmd) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if err := karpenter.LoadClusterVPC(ctx, ctl, cmd.ClusterConfig, ctl.NewStackManager(cmd.ClusterConfig)); err != nil {
+		return err
+	}
+	nodePoolManager, err := karpenter.NewNodePoolManager(ctl, cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	return nodePoolManager.Update(ctx)
+}
@@ -15,7 +15,9 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateIAMServiceAccountCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateNodeGroupCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updatePodIdentityAssociation)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateFargateProfileCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAutoModeConfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateKarpenterNodePoolCmd)
 
 	return verbCmd
 }
@@ -0,0 +1,97 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func upgradeKarpenterCmd(cmd *cmdutils.Cmd) {
+	upgradeKarpenterWithRunFunc(cmd, doUpgradeKarpenter)
+}
+
+func upgradeKarpenterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter",
+		"Upgrade Karpenter",
+		"Upgrade a Karpenter installation created by eksctl. The Karpenter stack is updated, the Karpenter CRDs are upgraded "+
+			"before the Karpenter chart, and NodePools, NodeClaims and EC2NodeClasses are migrated to v1 when upgrading to Karpenter v1",
+	)
+
+	var version string
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&version, "version", "", "Karpenter version to upgrade to")
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpgradeKarpenterLoader(cmd, version).Load(); err != nil {
+			return err
+		}
+		if cmd.ClusterConfigFile == "" {
+			cmd.ClusterConfig.Karpenter = &api.Karpenter{Version: version}
+		}
+		return runFunc(cmd)
+	}
+}
+
+func doUpgradeKarpenter(cmd *cmdutils.Cmd) error {
+	cfg := cmd.ClusterConfig
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := ctl.NewDynamicClient(cfg)
+	if err != nil {
+		return err
+	}
+	restClientGetter, err := karpenter.NewRESTClientGetter(ctl, cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	currentVersion, err := karpenter.GetInstalledVersion(ctx, stackManager)
+	if err != nil {
+		return err
+	}
+	upgrader, err := karpenter.NewUpgrader(cfg, stackManager, clientSet, dynamicClient, restClientGetter)
+	if err != nil {
+		return err
+	}
+	if cmd.ClusterConfigFile == "" {
+		if err := upgrader.LoadInstalledSettings(ctx); err != nil {
+			return err
+		}
+	}
+
+	cmdutils.LogIntendedAction(cmd.Plan, "upgrade Karpenter in cluster %q from version %s to %s", cfg.Metadata.Name, currentVersion, cfg.Karpenter.Version)
+	if err := upgrader.Upgrade(ctx, currentVersion, cmd.Plan); err != nil {
+		return err
+	}
+	cmdutils.LogCompletedAction(cmd.Plan, "upgraded Karpenter in cluster %q to version %s", cfg.Metadata.Name, cfg.Karpenter.Version)
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
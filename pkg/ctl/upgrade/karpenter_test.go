@@ -0,0 +1,43 @@
+package upgrade
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+var _ = Describe("upgrade karpenter", func() {
+	newMockUpgradeKarpenterCmd := func(args ...string) *ctltest.MockCmd {
+		return ctltest.NewMockCmd(upgradeKarpenterWithRunFunc, "upgrade", append([]string{"karpenter"}, args...)...)
+	}
+
+	It("sets the Karpenter version from the --version flag", func() {
+		cmd := newMockUpgradeKarpenterCmd("--cluster", "clus-1", "--version", "1.0.6")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Metadata.Name).To(Equal("clus-1"))
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.Version).To(Equal("1.0.6"))
+		Expect(cmd.Cmd.Plan).To(BeTrue())
+	})
+
+	It("accepts the --approve flag", func() {
+		cmd := newMockUpgradeKarpenterCmd("--cluster", "clus-1", "--version", "1.0.6", "--approve")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.Plan).To(BeFalse())
+	})
+
+	DescribeTable("invalid flags", func(expectedErr string, args ...string) {
+		cmd := newMockUpgradeKarpenterCmd(args...)
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing --cluster", "--cluster must be set", "--version", "1.0.6"),
+		Entry("missing --version", "--version must be set", "--cluster", "clus-1"),
+		Entry("--version with a config file", "cannot use --version when --config-file/-f is set",
+			"--version", "1.0.6", "--config-file", "../../../examples/01-simple-cluster.yaml"),
+		Entry("config file without karpenter.version", "karpenter.version must be set",
+			"--config-file", "../../../examples/01-simple-cluster.yaml"),
+	)
+})
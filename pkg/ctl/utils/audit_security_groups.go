@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"context"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/securitygroups"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type auditSecurityGroupsOptions struct {
+	securityGroupPolicy string
+	webhookPorts        []int
+	output              printers.Type
+}
+
+func auditSecurityGroupsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("audit-security-groups", "Audit the rules of the security groups of a cluster",
+		"Compares the ingress rules of the security groups of a cluster with the rules eksctl would generate "+
+			"for the given security group policy, and reports rules that allow more traffic")
+
+	var options auditSecurityGroupsOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doAuditSecurityGroups(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&options.securityGroupPolicy, "security-group-policy", api.SecurityGroupPolicyStrict,
+			"security group policy to audit the rules against (valid options: default, strict); if a config file is used, vpc.securityGroupPolicy is used instead")
+		fs.IntSliceVar(&options.webhookPorts, "webhook-ports", nil, "TCP ports of admission webhooks the control plane is allowed to reach with the strict security group policy")
+		fs.StringVarP(&options.output, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doAuditSecurityGroups(cmd *cmdutils.Cmd, options auditSecurityGroupsOptions) error {
+	if err := cmdutils.NewUtilsAuditSecurityGroupsLoader(cmd, options.securityGroupPolicy, options.webhookPorts).Load(); err != nil {
+		return err
+	}
+	if !printers.IsTableType(options.output) {
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	findings, err := securitygroups.NewAuditor(ctl.AWSProvider, cfg).Audit(ctx)
+	if err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(options.output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addSecurityGroupFindingColumns(tablePrinter)
+	}
+	if findings == nil {
+		findings = []securitygroups.Finding{}
+	}
+	if err := printer.PrintObjWithKind("findings", findings, os.Stdout); err != nil {
+		return err
+	}
+
+	policy := cfg.VPC.SecurityGroupPolicy
+	if policy == "" {
+		policy = api.SecurityGroupPolicyDefault
+	}
+	if len(findings) == 0 {
+		logger.Success("all security group rules of cluster %q are allowed by the %s security group policy", cfg.Metadata.Name, policy)
+		return nil
+	}
+	logger.Warning("found %d security group rule(s) of cluster %q allowing more traffic than the %s security group policy", len(findings), cfg.Metadata.Name, policy)
+	return nil
+}
+
+func addSecurityGroupFindingColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("SECURITY GROUP", func(f securitygroups.Finding) string {
+		return f.SecurityGroupID
+	})
+	printer.AddColumn("ROLE", func(f securitygroups.Finding) string {
+		return f.Role
+	})
+	printer.AddColumn("RULE", func(f securitygroups.Finding) string {
+		return f.RuleID
+	})
+	printer.AddColumn("SOURCE", func(f securitygroups.Finding) string {
+		return f.Source
+	})
+	printer.AddColumn("TRAFFIC", func(f securitygroups.Finding) string {
+		return f.Traffic
+	})
+	printer.AddColumn("REASON", func(f securitygroups.Finding) string {
+		return f.Reason
+	})
+}
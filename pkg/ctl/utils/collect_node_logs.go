@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/node"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func collectNodeLogsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var (
+		nodeGroupName string
+		nodeNames     []string
+		options       = node.CollectLogsOptions{Timeout: 10 * time.Minute}
+	)
+
+	cmd.SetDescription("collect-node-logs", "Collect logs from nodes using the EKS log collector", "The log collector is run on the nodes using Run Command, which requires the SSM agent to run on the nodes, as set up by enableSsm. The log collector script is read from a local file, or downloaded and checked against its checksum, and sent to the nodes with the command. The nodes upload the log archives to the S3 bucket, from which they are downloaded and deleted")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doCollectNodeLogs(cmd, nodeGroupName, nodeNames, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		fs.StringVar(&nodeGroupName, "nodegroup", "", "name of the nodegroup to collect logs from")
+		fs.StringSliceVar(&nodeNames, "nodes", nil, "names or instance IDs of the nodes to collect logs from, by default all nodes of the nodegroup")
+		fs.StringVar(&options.Bucket, "s3-bucket", "", "S3 bucket in the region of the cluster that nodes upload the log archives to")
+		fs.StringVar(&options.OutputDir, "output-dir", ".", "directory to download the log archives to")
+		fs.StringVar(&options.CollectorScript, "log-collector-script", "", "path of a local copy of the EKS log collector script")
+		fs.StringVar(&options.CollectorURL, "log-collector-url", "", "URL to download the EKS log collector script from, e.g. from a release of awslabs/amazon-eks-ami")
+		fs.StringVar(&options.CollectorSHA256, "log-collector-sha256", "", "SHA-256 checksum the EKS log collector script must match, required with --log-collector-url")
+		fs.DurationVar(&options.Timeout, "timeout", options.Timeout, "maximum time to wait for nodes to collect logs")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doCollectNodeLogs(cmd *cmdutils.Cmd, nodeGroupName string, nodeNames []string, options node.CollectLogsOptions) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if nodeGroupName == "" && len(nodeNames) == 0 {
+		return cmdutils.ErrMustBeSet("--nodegroup or --nodes")
+	}
+	if options.Bucket == "" {
+		return cmdutils.ErrMustBeSet("--s3-bucket")
+	}
+	if options.CollectorScript == "" && options.CollectorURL == "" {
+		return cmdutils.ErrMustBeSet("--log-collector-script or --log-collector-url")
+	}
+	if options.CollectorScript != "" && options.CollectorURL != "" {
+		return errors.New("--log-collector-script and --log-collector-url cannot be used together")
+	}
+	if options.CollectorURL != "" && options.CollectorSHA256 == "" {
+		return cmdutils.ErrMustBeSet("--log-collector-sha256")
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	manager := node.New(ctl.AWSProvider, clientSet)
+	nodes, err := manager.ListNodes(ctx, nodeGroupName, nodeNames)
+	if err != nil {
+		return err
+	}
+	_, err = manager.CollectLogs(ctx, cfg.Metadata.Name, nodes, options)
+	return err
+}
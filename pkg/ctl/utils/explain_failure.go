@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/explain"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+const explainTextOutput = "text"
+
+func explainFailureCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var stackName string
+	withCloudTrail := true
+	output := explainTextOutput
+
+	cmd.SetDescription("explain-failure", "Explain the root causes of CloudFormation stack failures",
+		"Walks the failed events of the stack and its nested stacks, maps them to ClusterConfig fields, adds related CloudTrail errors and suggests fixes for common causes")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doExplainFailure(cmd, stackName, withCloudTrail, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&stackName, "stack", "", "name or ID of the stack to explain")
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		fs.BoolVar(&withCloudTrail, "cloudtrail", withCloudTrail, "look up related errors in CloudTrail, which are delivered several minutes after the failure")
+		fs.StringVarP(&output, "output", "o", output, "output format, one of text, json, yaml")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doExplainFailure(cmd *cmdutils.Cmd, stackName string, withCloudTrail bool, output string) error {
+	var printer printers.OutputPrinter
+	switch output {
+	case explainTextOutput:
+	case printers.JSONType, printers.YAMLType:
+		var err error
+		if printer, err = printers.NewPrinter(output); err != nil {
+			return err
+		}
+		logger.Writer = os.Stderr
+	default:
+		return fmt.Errorf("output format %q is not supported, must be one of text, json, yaml", output)
+	}
+
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name != "" && cmd.NameArg != "" {
+		return cmdutils.ErrFlagAndArg(cmdutils.ClusterNameFlag(cmd), cfg.Metadata.Name, cmd.NameArg)
+	}
+	if cmd.NameArg != "" {
+		cfg.Metadata.Name = cmd.NameArg
+	}
+	if stackName == "" && cfg.Metadata.Name == "" && cmd.ClusterConfigFile == "" {
+		return errors.New("--stack or --cluster must be set")
+	}
+	if cmd.ClusterConfigFile != "" {
+		if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.TODO()
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	stackNames := []string{stackName}
+	if stackName == "" {
+		stacks, err := ctl.NewStackManager(cfg).ListStacks(ctx)
+		if err != nil {
+			return err
+		}
+		stackNames = failedStackNames(stacks)
+		if len(stackNames) == 0 {
+			logger.Info("no failed stacks found for cluster %q", cfg.Metadata.Name)
+			return nil
+		}
+	}
+
+	var cloudTrail awsapi.CloudTrail
+	if withCloudTrail {
+		cloudTrail = ctl.AWSProvider.CloudTrail()
+	}
+	explainer := explain.New(ctl.AWSProvider.CloudFormation(), cloudTrail)
+	var reports []*explain.Report
+	for _, name := range stackNames {
+		report, err := explainer.Explain(ctx, name)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	if printer != nil {
+		return printer.PrintObj(reports, os.Stdout)
+	}
+	for _, report := range reports {
+		if err := printExplainReport(os.Stdout, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failedStackNames returns the names of the stacks whose last operation failed
+func failedStackNames(stacks []*cfntypes.Stack) []string {
+	var names []string
+	for _, s := range stacks {
+		status := string(s.StackStatus)
+		if strings.HasSuffix(status, "FAILED") || strings.HasSuffix(status, "ROLLBACK_COMPLETE") {
+			names = append(names, aws.ToString(s.StackName))
+		}
+	}
+	return names
+}
+
+func printExplainReport(w io.Writer, report *explain.Report) error {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "stack %q is %s", report.StackName, report.StackStatus)
+	if report.StatusReason != "" {
+		fmt.Fprintf(out, ": %s", report.StatusReason)
+	}
+	out.WriteString("\n")
+	if len(report.Failures) == 0 {
+		out.WriteString("\nno failed resources found in the last operation on the stack\n")
+	}
+	for i, f := range report.Failures {
+		fmt.Fprintf(out, "\n%d. %s (%s) in stack %q: %s at %s\n", i+1, f.LogicalResourceID, f.ResourceType, f.StackName, f.Status, f.Timestamp.Format(time.RFC3339))
+		fmt.Fprintf(out, "   reason:     %s\n", f.Reason)
+		if f.ConfigField != "" {
+			fmt.Fprintf(out, "   config:     %s\n", f.ConfigField)
+		}
+		if f.Cause != nil {
+			fmt.Fprintf(out, "   cause:      %s\n", f.Cause.Description)
+			fmt.Fprintf(out, "   suggestion: %s\n", f.Cause.Suggestion)
+		}
+	}
+	if len(report.CloudTrailErrors) > 0 {
+		out.WriteString("\nrelated CloudTrail errors:\n")
+		for _, e := range report.CloudTrailErrors {
+			fmt.Fprintf(out, "   %s %s:%s by %s: %s %s\n", e.Time.Format(time.RFC3339), e.EventSource, e.EventName, e.InvokedBy, e.ErrorCode, e.ErrorMessage)
+			if e.Cause != nil {
+				fmt.Fprintf(out, "      cause:      %s\n", e.Cause.Description)
+				fmt.Fprintf(out, "      suggestion: %s\n", e.Cause.Suggestion)
+			}
+		}
+	}
+	out.WriteString("\n")
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"context"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type fargateMatchOptions struct {
+	manifests []string
+	output    printers.Type
+}
+
+func fargateMatchCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("fargate-match", "Report which Fargate profile workloads would be scheduled on",
+		"Evaluates the pods, deployments and other workloads of the cluster, or those defined in manifests, "+
+			"against the Fargate profiles of the config file or of the cluster, and reports the profile each "+
+			"workload matches along with specs that cannot run on Fargate")
+
+	var options fargateMatchOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewFargateMatchLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doFargateMatch(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringSliceVar(&options.manifests, "manifests", nil, "Manifest files or directories to read workloads from instead of the cluster")
+		fs.StringVarP(&options.output, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doFargateMatch(cmd *cmdutils.Cmd, options fargateMatchOptions) error {
+	if !printers.IsTableType(options.output) {
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	profiles := cfg.FargateProfiles
+	needsCluster := len(profiles) == 0 || len(options.manifests) == 0
+
+	ctx := context.Background()
+	var ctl *eks.ClusterProvider
+	if needsCluster {
+		var err error
+		ctl, err = cmd.NewProviderForExistingCluster(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(profiles) == 0 {
+		manager := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, ctl.NewStackManager(cfg))
+		var err error
+		if profiles, err = manager.ReadProfiles(ctx); err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			logger.Warning("cluster %q has no Fargate profiles", cfg.Metadata.Name)
+		}
+	}
+
+	var (
+		workloads []fargate.Workload
+		err       error
+	)
+	if len(options.manifests) > 0 {
+		workloads, err = fargate.ReadWorkloads(options.manifests)
+	} else {
+		if ok, err := ctl.CanOperate(cfg); !ok {
+			return err
+		}
+		var clientSet kubernetes.Interface
+		clientSet, err = ctl.NewStdClientSet(cfg)
+		if err != nil {
+			return err
+		}
+		workloads, err = fargate.ListWorkloads(ctx, clientSet)
+	}
+	if err != nil {
+		return err
+	}
+
+	matches := fargate.MatchWorkloads(profiles, workloads)
+	if err := fargate.PrintMatches(matches, os.Stdout, options.output); err != nil {
+		return err
+	}
+
+	var unmatched, incompatible int
+	for _, m := range matches {
+		if !m.IsMatched() {
+			unmatched++
+		} else if len(m.Issues) > 0 {
+			incompatible++
+		}
+	}
+	logger.Info("%d workload(s) matched by a Fargate profile, %d unmatched, %d with issues", len(matches)-unmatched, unmatched, incompatible)
+	return nil
+}
@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("fargate-match", func() {
+	type fargateMatchEntry struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("invalid arguments", func(e fargateMatchEntry) {
+		cmd := newMockCmd(append([]string{"fargate-match"}, e.args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing required flag --cluster", fargateMatchEntry{
+			expectedErr: "Error: --cluster must be set",
+		}),
+		Entry("unsupported name argument", fargateMatchEntry{
+			args:        []string{"--cluster", "test", "name"},
+			expectedErr: "Error: name argument is not supported",
+		}),
+		Entry("setting --cluster and --config-file at the same time", fargateMatchEntry{
+			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
+		}),
+		Entry("config file without Fargate profiles", fargateMatchEntry{
+			args:        []string{"--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "Error: no Fargate profiles defined in the config file",
+		}),
+	)
+})
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/orphans"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type findOrphansOptions struct {
+	delete   bool
+	auditLog string
+	output   printers.Type
+}
+
+func findOrphansCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("find-orphans", "Find resources left behind by deleted clusters",
+		"Scans the region for load balancers, network interfaces, EBS volumes, security groups, log groups, "+
+			"OIDC providers and CloudFormation stacks that are tagged or named for clusters that no longer exist, "+
+			"and optionally deletes them")
+
+	var options findOrphansOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doFindOrphans(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.BoolVar(&options.delete, "delete", false, "delete the orphaned resources; only shows the deletion plan unless --approve is set")
+		cmdutils.AddApproveFlag(fs, cmd)
+		fs.StringVar(&options.auditLog, "audit-log", "", "file to append an audit record of each deletion to, as JSON lines (default eksctl-orphans-<region>-<time>.jsonl)")
+		fs.StringVarP(&options.output, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doFindOrphans(cmd *cmdutils.Cmd, options findOrphansOptions) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name != "" && cmd.NameArg != "" {
+		return cmdutils.ErrClusterFlagAndArg(cmd, cfg.Metadata.Name, cmd.NameArg)
+	}
+	if cmd.NameArg != "" {
+		cfg.Metadata.Name = cmd.NameArg
+	}
+	if options.auditLog != "" && !options.delete {
+		return fmt.Errorf("--audit-log can only be used with --delete")
+	}
+	if !printers.IsTableType(options.output) {
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	finder := orphans.New(ctl.AWSProvider, cfg.Metadata.Name)
+	resources, err := finder.Find(ctx)
+	if err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(options.output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addOrphanColumns(tablePrinter)
+	}
+	if resources == nil {
+		resources = []orphans.Resource{}
+	}
+	if err := printer.PrintObjWithKind("orphans", resources, os.Stdout); err != nil {
+		return err
+	}
+
+	if len(resources) == 0 {
+		logger.Info("no orphaned resources found in region %q", ctl.AWSProvider.Region())
+		return nil
+	}
+	if !options.delete {
+		logger.Info("found %d orphaned resource(s), run again with --delete to delete them", len(resources))
+		return nil
+	}
+
+	if cmd.Plan {
+		for _, r := range resources {
+			cmdutils.LogIntendedAction(true, "delete %s %q of cluster %q", r.Kind, r.ID, r.Cluster)
+		}
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	auditLog := options.auditLog
+	if auditLog == "" {
+		auditLog = fmt.Sprintf("eksctl-orphans-%s-%s.jsonl", ctl.AWSProvider.Region(), time.Now().UTC().Format("20060102T150405Z"))
+	}
+	auditFile, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	defer auditFile.Close()
+
+	logger.Info("deleting %d orphaned resource(s), recording an audit trail in %q", len(resources), auditLog)
+	return finder.Delete(ctx, resources, auditFile)
+}
+
+func addOrphanColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("CLUSTER", func(r orphans.Resource) string {
+		return r.Cluster
+	})
+	printer.AddColumn("KIND", func(r orphans.Resource) string {
+		return string(r.Kind)
+	})
+	printer.AddColumn("ID", func(r orphans.Resource) string {
+		return r.ID
+	})
+	printer.AddColumn("DETAILS", func(r orphans.Resource) string {
+		return r.Details
+	})
+}
@@ -0,0 +1,365 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+// Statuses of the cluster of a kubeconfig context
+const (
+	clusterStatusExists   = "Exists"
+	clusterStatusNotFound = "NotFound"
+	clusterStatusUnknown  = "Unknown"
+)
+
+type kubeconfigOptions struct {
+	kubeconfigPath string
+	kubeconfigDir  string
+}
+
+type kubeconfigContext struct {
+	kubeconfig.Context
+	ClusterStatus string `json:",omitempty"`
+}
+
+func kubeconfigCommand(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("kubeconfig", "Manage the kubeconfig contexts written by eksctl", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, listKubeconfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, pruneKubeconfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, renameKubeconfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, switchKubeconfigCmd)
+
+	return verbCmd
+}
+
+func addKubeconfigFlags(fs *pflag.FlagSet, options *kubeconfigOptions) {
+	fs.StringVar(&options.kubeconfigPath, "kubeconfig", kubeconfig.DefaultPath(), "path to the kubeconfig file, or a list of paths as in KUBECONFIG")
+	fs.StringVar(&options.kubeconfigDir, "kubeconfig-dir", kubeconfig.AutoDir(), "directory of kubeconfig files holding a cluster each, as written with --auto-kubeconfig; pass an empty string to skip")
+}
+
+func listKubeconfigCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("list", "List the kubeconfig contexts written by eksctl",
+		"Lists the kubeconfig contexts of clusters written by eksctl, and whether each cluster still exists")
+
+	var (
+		options       kubeconfigOptions
+		checkClusters bool
+		output        printers.Type
+	)
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doListKubeconfig(cmd, options, checkClusters, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		addKubeconfigFlags(fs, &options)
+		fs.BoolVar(&checkClusters, "check-clusters", true, "look up whether the cluster of each context still exists using the EKS API")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doListKubeconfig(cmd *cmdutils.Cmd, options kubeconfigOptions, checkClusters bool, output printers.Type) error {
+	if !printers.IsTableType(output) {
+		logger.Writer = os.Stderr
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	contexts, err := listKubeconfigContexts(options)
+	if err != nil {
+		return err
+	}
+	items := make([]kubeconfigContext, 0, len(contexts))
+	for _, c := range contexts {
+		items = append(items, kubeconfigContext{Context: c})
+	}
+	if checkClusters {
+		statuses := getClusterStatuses(context.Background(), cmd, contexts)
+		for i := range items {
+			items[i].ClusterStatus = statuses[statusKey(items[i].Context)]
+		}
+	}
+
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addKubeconfigContextColumns(tablePrinter, checkClusters)
+	}
+	return printer.PrintObjWithKind("contexts", items, cmd.CobraCommand.OutOrStdout())
+}
+
+func addKubeconfigContextColumns(printer *printers.TablePrinter, checkClusters bool) {
+	printer.AddColumn("CURRENT", func(c kubeconfigContext) string {
+		if c.Current {
+			return "*"
+		}
+		return ""
+	})
+	printer.AddColumn("CONTEXT", func(c kubeconfigContext) string {
+		return c.Name
+	})
+	printer.AddColumn("CLUSTER", func(c kubeconfigContext) string {
+		return c.ClusterName
+	})
+	printer.AddColumn("REGION", func(c kubeconfigContext) string {
+		return c.Region
+	})
+	if checkClusters {
+		printer.AddColumn("CLUSTER STATUS", func(c kubeconfigContext) string {
+			return c.ClusterStatus
+		})
+	}
+	printer.AddColumn("KUBECONFIG", func(c kubeconfigContext) string {
+		return c.Path
+	})
+}
+
+func pruneKubeconfigCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("prune", "Remove the kubeconfig contexts of clusters that no longer exist",
+		"Removes the kubeconfig contexts written by eksctl whose cluster is not found using the EKS API, along with "+
+			"their users and clusters, and deletes files in --kubeconfig-dir left without contexts. "+
+			"Clusters are looked up with the credentials of each context")
+
+	var options kubeconfigOptions
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doPruneKubeconfig(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		addKubeconfigFlags(fs, &options)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doPruneKubeconfig(cmd *cmdutils.Cmd, options kubeconfigOptions) error {
+	contexts, err := listKubeconfigContexts(options)
+	if err != nil {
+		return err
+	}
+
+	statuses := getClusterStatuses(context.Background(), cmd, contexts)
+	var stale []kubeconfig.Context
+	for _, c := range contexts {
+		switch statuses[statusKey(c)] {
+		case clusterStatusNotFound:
+			cmdutils.LogIntendedAction(cmd.Plan, "remove context %q in %q, as cluster %q in region %q no longer exists", c.Name, c.Path, c.ClusterName, c.Region)
+			stale = append(stale, c)
+		case clusterStatusUnknown:
+			logger.Warning("skipping context %q, as whether cluster %q exists is unknown", c.Name, c.ClusterName)
+		}
+	}
+
+	if len(stale) == 0 {
+		logger.Info("no contexts to prune")
+		return nil
+	}
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+	if err := kubeconfig.PruneContexts(stale, options.kubeconfigDir); err != nil {
+		return err
+	}
+	logger.Success("removed %d context(s)", len(stale))
+	return nil
+}
+
+func renameKubeconfigCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("rename OLD NEW", "Rename a kubeconfig context", "")
+
+	var options kubeconfigOptions
+	cmd.CobraCommand.Args = cobra.ExactArgs(2)
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		return doRenameKubeconfig(options, args[0], args[1])
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		addKubeconfigFlags(fs, &options)
+	})
+}
+
+func doRenameKubeconfig(options kubeconfigOptions, oldName, newName string) error {
+	paths, err := kubeconfig.ConfigPaths(options.kubeconfigPath, options.kubeconfigDir)
+	if err != nil {
+		return err
+	}
+	path, err := kubeconfig.RenameContext(paths, oldName, newName)
+	if err != nil {
+		return err
+	}
+	logger.Success("renamed context %q to %q in %q", oldName, newName, path)
+	return nil
+}
+
+func switchKubeconfigCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("switch CONTEXT|CLUSTER", "Set the current kubeconfig context",
+		"Sets the current context to the given context, or to the context written by eksctl for the given cluster")
+
+	var options kubeconfigOptions
+	cmd.CobraCommand.Args = cobra.ExactArgs(1)
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		return doSwitchKubeconfig(options, cmd.ProviderConfig.Region, args[0])
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		addKubeconfigFlags(fs, &options)
+		fs.StringVarP(&cmd.ProviderConfig.Region, "region", "r", "", "region of the cluster, when clusters with the same name exist in several regions")
+	})
+}
+
+func doSwitchKubeconfig(options kubeconfigOptions, region, name string) error {
+	contexts, err := listKubeconfigContexts(options)
+	if err != nil {
+		return err
+	}
+
+	var matches []kubeconfig.Context
+	for _, c := range contexts {
+		if c.Name == name {
+			matches = []kubeconfig.Context{c}
+			break
+		}
+		if c.ClusterName == name && (region == "" || c.Region == region) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no context written by eksctl found for %q", name)
+	case 1:
+	default:
+		var names []string
+		for _, c := range matches {
+			names = append(names, fmt.Sprintf("%s (%s)", c.Name, c.Path))
+		}
+		return fmt.Errorf("several contexts found for %q, use the name of the context or --region: %s", name, strings.Join(names, ", "))
+	}
+
+	match := matches[0]
+	if !isInKubeconfigPath(options.kubeconfigPath, match.Path) {
+		return fmt.Errorf("context %q is in %q, which is not in --kubeconfig; use it with KUBECONFIG=%s", match.Name, match.Path, match.Path)
+	}
+	if err := kubeconfig.UseContext(match.Path, match.Name); err != nil {
+		return err
+	}
+	logger.Success("switched to context %q in %q", match.Name, match.Path)
+	return nil
+}
+
+func listKubeconfigContexts(options kubeconfigOptions) ([]kubeconfig.Context, error) {
+	paths, err := kubeconfig.ConfigPaths(options.kubeconfigPath, options.kubeconfigDir)
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfig.ListContexts(paths)
+}
+
+func isInKubeconfigPath(kubeconfigPath, path string) bool {
+	for _, p := range filepath.SplitList(kubeconfigPath) {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// statusKey identifies the cluster of a context along with the credentials it is looked up with, as clusters
+// with the same name and region may exist in several accounts
+func statusKey(c kubeconfig.Context) string {
+	key := c.ClusterName + "." + c.Region
+	if c.Credentials != nil {
+		key += "|" + c.Credentials.Key()
+	}
+	return key
+}
+
+// getClusterStatuses looks up whether the cluster of each context exists, with the credentials the context
+// authenticates with, creating a provider per region and credentials. Clusters of contexts whose credentials
+// are not known, or require an MFA token, are reported as unknown, so that they are never pruned.
+func getClusterStatuses(ctx context.Context, cmd *cmdutils.Cmd, contexts []kubeconfig.Context) map[string]string {
+	statuses := map[string]string{}
+	providers := map[string]api.ClusterProvider{}
+	for _, c := range contexts {
+		key := statusKey(c)
+		if _, ok := statuses[key]; ok {
+			continue
+		}
+		credentials := c.Credentials
+		switch {
+		case credentials == nil:
+			logger.Debug("credentials of context %q are not known", c.Name)
+			statuses[key] = clusterStatusUnknown
+			continue
+		case credentials.MFASerial != "":
+			logger.Debug("credentials of context %q require an MFA token", c.Name)
+			statuses[key] = clusterStatusUnknown
+			continue
+		}
+
+		providerKey := c.Region + "|" + credentials.Key()
+		provider, ok := providers[providerKey]
+		if !ok {
+			providerConfig := api.ProviderConfig{
+				Region:      c.Region,
+				WaitTimeout: cmd.ProviderConfig.WaitTimeout,
+				Profile: api.Profile{
+					Name: credentials.Profile,
+					AssumeRole: api.AssumeRole{
+						RoleARNs:        credentials.RoleARNs,
+						ExternalID:      credentials.ExternalID,
+						RoleSessionName: credentials.RoleSessionName,
+					},
+				},
+			}
+			ctl, err := eks.New(ctx, &providerConfig, nil)
+			if err != nil {
+				logger.Warning("error creating provider in %q region for context %q: %v", c.Region, c.Name, err)
+				statuses[key] = clusterStatusUnknown
+				continue
+			}
+			provider = ctl.AWSProvider
+			providers[providerKey] = provider
+		}
+
+		_, err := provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{Name: &c.ClusterName})
+		var notFoundErr *ekstypes.ResourceNotFoundException
+		switch {
+		case err == nil:
+			statuses[key] = clusterStatusExists
+		case errors.As(err, &notFoundErr):
+			statuses[key] = clusterStatusNotFound
+		default:
+			logger.Warning("error describing cluster %q in region %q: %v", c.ClusterName, c.Region, err)
+			statuses[key] = clusterStatusUnknown
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/logs"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+const (
+	logsTextOutput = "text"
+	logsJSONOutput = "json"
+)
+
+func logsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	options := logs.Options{
+		Type:  "api",
+		Since: time.Hour,
+	}
+	output := logsTextOutput
+
+	cmd.SetDescription("logs", "Read the control plane logs of a cluster from CloudWatch Logs", "The log type must be enabled with cloudWatch.clusterLogging")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doLogs(cmd, options, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		fs.StringVar(&options.Type, "type", options.Type, fmt.Sprintf("control plane log type, one of %s", strings.Join(api.SupportedCloudWatchClusterLogTypes(), ", ")))
+		fs.DurationVar(&options.Since, "since", options.Since, "read logs newer than a relative duration, e.g. 30m")
+		fs.StringVar(&options.FilterPattern, "filter", "", "CloudWatch Logs filter pattern that events must match, e.g. '{ $.user.username = \"kubernetes-admin\" }' for audit logs")
+		fs.BoolVar(&options.Follow, "follow", false, "keep polling for new events until interrupted")
+		fs.DurationVar(&options.FollowInterval, "follow-interval", logs.DefaultFollowInterval, "interval between polls when following logs")
+		fs.StringVarP(&output, "output", "o", output, "output format, one of text, json")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doLogs(cmd *cmdutils.Cmd, options logs.Options, output string) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	var handle func(logs.Event) error
+	switch output {
+	case logsTextOutput:
+		handle = func(e logs.Event) error {
+			return printLogEvent(os.Stdout, e)
+		}
+	case logsJSONOutput:
+		// events are printed as they are read, one JSON object per line
+		logger.Writer = os.Stderr
+		encoder := json.NewEncoder(os.Stdout)
+		handle = func(e logs.Event) error {
+			return encoder.Encode(e)
+		}
+	default:
+		return fmt.Errorf("output format %q is not supported, must be one of %s, %s", output, logsTextOutput, logsJSONOutput)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	count := 0
+	err = logs.New(ctl.AWSProvider.CloudWatchLogs(), cfg.Metadata.Name).Read(ctx, options, func(e logs.Event) error {
+		count++
+		return handle(e)
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 && !options.Follow {
+		logger.Info("no %s log events found in the last %s, check that %s logging is enabled with cloudWatch.clusterLogging", options.Type, options.Since, options.Type)
+	}
+	return nil
+}
+
+func printLogEvent(w io.Writer, e logs.Event) error {
+	timestamp := e.Timestamp.Format(time.RFC3339)
+	if a := e.Audit; a != nil {
+		_, err := fmt.Fprintf(w, "%s %d %s %s %s\n", timestamp, a.Code, a.User, a.Verb, a.Object())
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %s %s\n", timestamp, e.Stream, strings.TrimRight(e.Message, "\n"))
+	return err
+}
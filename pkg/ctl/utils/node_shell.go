@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/node"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func nodeShellCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var nodeName string
+
+	cmd.SetDescription("node-shell", "Open a Session Manager shell on a node", "The node must run the SSM agent with the AmazonSSMManagedInstanceCore policy, as set up by enableSsm, and the session-manager-plugin must be installed locally")
+
+	cmd.CobraCommand.Args = cobra.MaximumNArgs(1)
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doNodeShell(cmd, nodeName)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		fs.StringVar(&nodeName, "node", "", "name or instance ID of the node")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doNodeShell(cmd *cmdutils.Cmd, nodeName string) error {
+	if nodeName != "" && cmd.NameArg != "" {
+		return cmdutils.ErrFlagAndArg("--node", nodeName, cmd.NameArg)
+	}
+	if cmd.NameArg != "" {
+		nodeName = cmd.NameArg
+	}
+	if nodeName == "" {
+		return cmdutils.ErrMustBeSet("--node")
+	}
+
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	manager := node.New(ctl.AWSProvider, clientSet)
+	n, err := manager.GetNode(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	return manager.Shell(ctx, n)
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/nodes"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+func subnetCapacityCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("subnet-capacity", "Report the free IPs of nodegroup subnets",
+		"Compares the free IPs of the subnets of the nodegroups in the config file with the IPs the nodegroups "+
+			"can consume at their desired and max sizes, accounting for the network interface limits of their "+
+			"instance types, prefix delegation and maxPodsPerNode")
+
+	var output printers.Type
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUtilsSubnetCapacityLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doSubnetCapacity(cmd, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: "+printers.ValidTypesDescription+")")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doSubnetCapacity(cmd *cmdutils.Cmd, output printers.Type) error {
+	if !printers.IsTableType(output) {
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	stack, err := ctl.NewStackManager(cfg).DescribeClusterStack(ctx)
+	if err != nil {
+		var stackNotFoundErr *manager.StackNotFoundErr
+		if !errors.As(err, &stackNotFoundErr) {
+			return fmt.Errorf("getting existing configuration for cluster %q: %w", cfg.Metadata.Name, err)
+		}
+		if err := vpc.ImportSubnetsFromSpec(ctx, ctl.AWSProvider.EC2(), cfg); err != nil {
+			return err
+		}
+	} else if err := ctl.LoadClusterVPC(ctx, cfg, stack, true); err != nil {
+		return err
+	}
+
+	capacities, err := nodegroup.New(cfg, ctl, nil, nil).SubnetCapacities(ctx, nodes.ToNodePools(cfg))
+	if err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addSubnetCapacityColumns(tablePrinter)
+	}
+	if capacities == nil {
+		capacities = []vpc.SubnetCapacity{}
+	}
+	if err := printer.PrintObjWithKind("subnetcapacities", capacities, os.Stdout); err != nil {
+		return err
+	}
+
+	var insufficient int
+	for _, c := range capacities {
+		if !c.FitsMax() {
+			insufficient++
+		}
+	}
+	if insufficient > 0 {
+		logger.Warning("%d of %d subnet(s) do not have enough free IPs for their nodegroups at max size", insufficient, len(capacities))
+	}
+	return nil
+}
+
+func addSubnetCapacityColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("SUBNET", func(c vpc.SubnetCapacity) string {
+		return c.SubnetID
+	})
+	printer.AddColumn("AVAILABILITY ZONE", func(c vpc.SubnetCapacity) string {
+		return c.AZ
+	})
+	printer.AddColumn("CIDR", func(c vpc.SubnetCapacity) string {
+		return c.CIDR
+	})
+	printer.AddColumn("FREE IPS", func(c vpc.SubnetCapacity) string {
+		return strconv.Itoa(c.AvailableIPs)
+	})
+	printer.AddColumn("DESIRED IPS", func(c vpc.SubnetCapacity) string {
+		return strconv.Itoa(c.DesiredIPs)
+	})
+	printer.AddColumn("MAX IPS", func(c vpc.SubnetCapacity) string {
+		return strconv.Itoa(c.MaxIPs)
+	})
+	printer.AddColumn("NODEGROUPS", func(c vpc.SubnetCapacity) string {
+		return strings.Join(c.NodeGroups, ",")
+	})
+	printer.AddColumn("STATUS", func(c vpc.SubnetCapacity) string {
+		switch {
+		case !c.FitsDesired():
+			return "insufficient"
+		case !c.FitsMax():
+			return "insufficient at max size"
+		default:
+			return "ok"
+		}
+	})
+}
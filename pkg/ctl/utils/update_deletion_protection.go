@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateDeletionProtectionCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("update-deletion-protection", "Update deletion protection of a cluster",
+		"Enables or disables CloudFormation termination protection on the cluster stack")
+
+	var enabled bool
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doUpdateDeletionProtection(cmd, enabled)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.BoolVar(&enabled, "enabled", true, "whether deletion protection is enabled; if a config file is used, metadata.deletionProtection is used instead")
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpdateDeletionProtection(cmd *cmdutils.Cmd, enabled bool) error {
+	if err := cmdutils.NewUtilsUpdateDeletionProtectionLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	meta := cfg.Metadata
+	if cmd.ClusterConfigFile != "" {
+		enabled = api.IsEnabled(meta.DeletionProtection)
+	}
+
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+	cmdutils.LogIntendedAction(cmd.Plan, "%s deletion protection of cluster %q in %q", action, meta.Name, meta.Region)
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	updated, err := cluster.SetDeletionProtection(ctx, meta.Name, ctl.NewStackManager(cfg), enabled)
+	if err != nil {
+		return err
+	}
+	if len(updated) == 0 {
+		logger.Success("deletion protection of cluster %q in %q is already up-to-date", meta.Name, meta.Region)
+		return nil
+	}
+	logger.Success("%sd termination protection on stack(s) %s", action, strings.Join(updated, ", "))
+	return nil
+}
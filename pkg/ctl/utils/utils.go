@@ -26,11 +26,22 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableSecretsEncryptionCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, schemaCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupHealthCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, fargateMatchCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, subnetCapacityCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, findOrphansCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateDeletionProtectionCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, auditSecurityGroupsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonVersionsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonConfigurationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToPodIdentityCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateAccessEntryCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateZonalShiftConfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeShellCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, collectNodeLogsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, logsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, explainFailureCmd)
+
+	verbCmd.AddCommand(kubeconfigCommand(flagGrouping))
 
 	return verbCmd
 }
@@ -21,6 +21,7 @@ func writeKubeconfigCmd(cmd *cmdutils.Cmd) {
 	var (
 		outputPath           string
 		authenticatorRoleARN string
+		authenticator        string
 		setContext, autoPath bool
 	)
 
@@ -28,7 +29,7 @@ func writeKubeconfigCmd(cmd *cmdutils.Cmd) {
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doWriteKubeconfigCmd(cmd, outputPath, authenticatorRoleARN, setContext, autoPath)
+		return doWriteKubeconfigCmd(cmd, outputPath, authenticatorRoleARN, authenticator, setContext, autoPath)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -40,12 +41,20 @@ func writeKubeconfigCmd(cmd *cmdutils.Cmd) {
 
 	cmd.FlagSetGroup.InFlagSet("Output kubeconfig", func(fs *pflag.FlagSet) {
 		cmdutils.AddCommonFlagsForKubeconfig(fs, &outputPath, &authenticatorRoleARN, &setContext, &autoPath, "<name>")
+		fs.StringVar(&authenticator, "authenticator", "", fmt.Sprintf("command used by kubectl to get a token, one of %q, %q or %q; %q needs no other binaries (defaults to %q or %q, whichever is installed)",
+			kubeconfig.EksctlAuthenticator, kubeconfig.AWSEKSAuthenticator, kubeconfig.AWSIAMAuthenticator, kubeconfig.EksctlAuthenticator, kubeconfig.AWSIAMAuthenticator, kubeconfig.AWSEKSAuthenticator))
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setContext, autoPath bool) error {
+func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN, authenticator string, setContext, autoPath bool) error {
+	if authenticator != "" {
+		if err := kubeconfig.ValidateAuthenticator(authenticator); err != nil {
+			return err
+		}
+	}
+
 	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -81,7 +90,7 @@ func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setCont
 		return err
 	}
 
-	kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile().Name)
+	kubectlConfig := kubeconfig.NewForKubectlWithAuthenticator(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile(), authenticator)
 	filename, err := kubeconfig.Write(outputPath, *kubectlConfig, setContext)
 	if err != nil {
 		return fmt.Errorf("writing kubeconfig: %w", err)
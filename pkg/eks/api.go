@@ -175,7 +175,9 @@ func newAWSProvider(spec *api.ProviderConfig, configurationLoader AWSConfigurati
 		spec: spec,
 	}
 
-	cacheCredentials := os.Getenv(credentials.EksctlGlobalEnableCachingEnvName) != ""
+	// credentials of sessions requiring an MFA token code are cached so that the code is not read again until they
+	// expire, e.g. on each call of `eksctl get-token` by kubectl
+	cacheCredentials := os.Getenv(credentials.EksctlGlobalEnableCachingEnvName) != "" || spec.Profile.AssumeRole.MFASerial != ""
 	if cacheCredentials {
 		credentialsCacheFilePath, err = credentials.GetCacheFilePath()
 		if err != nil {
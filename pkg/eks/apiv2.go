@@ -3,12 +3,15 @@ package eks
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	middlewarev2 "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go/middleware"
 	"github.com/gofrs/flock"
 	"github.com/kris-nova/logger"
@@ -55,7 +58,7 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 			return NewRetryerV2()
 		}),
 		config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
-			o.TokenProvider = stscreds.StdinTokenProvider
+			o.TokenProvider = stderrTokenProvider
 			o.Duration = 60 * time.Minute
 		}),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
@@ -75,8 +78,14 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 	if err != nil {
 		return cfg, err
 	}
+	if err := validateAssumeRole(pc.Profile.AssumeRole); err != nil {
+		return cfg, err
+	}
+	if pc.Profile.AssumeRole.IsSet() {
+		cfg.Credentials = assumeRoleChain(cfg, pc.Profile.AssumeRole)
+	}
 	if credentialsCacheFilePath != "" {
-		fileCache, err := credentials.NewFileCacheV2(cfg.Credentials, pc.Profile.Name, afero.NewOsFs(), func(path string) credentials.Flock {
+		fileCache, err := credentials.NewFileCacheV2(cfg.Credentials, credentialsCacheKey(pc.Profile), afero.NewOsFs(), func(path string) credentials.Flock {
 			return flock.New(path)
 		}, &credentials.RealClock{}, credentialsCacheFilePath)
 		if err != nil {
@@ -86,3 +95,72 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 	}
 	return cfg, nil
 }
+
+func validateAssumeRole(assumeRole api.AssumeRole) error {
+	if assumeRole.IsSet() {
+		return nil
+	}
+	for flag, value := range map[string]string{
+		"--external-id":       assumeRole.ExternalID,
+		"--mfa-serial":        assumeRole.MFASerial,
+		"--role-session-name": assumeRole.RoleSessionName,
+	} {
+		if value != "" {
+			return fmt.Errorf("%s can only be used with --assume-role-arn", flag)
+		}
+	}
+	return nil
+}
+
+// assumeRoleChain returns the credentials of the last role of the chain, assuming each role with the credentials
+// of the previous one, starting with the credentials of cfg
+func assumeRoleChain(cfg aws.Config, assumeRole api.AssumeRole) aws.CredentialsProvider {
+	credentialsProvider := cfg.Credentials
+	for i, roleARN := range assumeRole.RoleARNs {
+		logger.Debug("assuming role %q", roleARN)
+		stsConfig := cfg.Copy()
+		stsConfig.Credentials = credentialsProvider
+		first, last := i == 0, i == len(assumeRole.RoleARNs)-1
+		credentialsProvider = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(stsConfig), roleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.Duration = 60 * time.Minute
+			if assumeRole.RoleSessionName != "" {
+				o.RoleSessionName = assumeRole.RoleSessionName
+			}
+			if first && assumeRole.MFASerial != "" {
+				o.SerialNumber = aws.String(assumeRole.MFASerial)
+				o.TokenProvider = stderrTokenProvider
+			}
+			if last && assumeRole.ExternalID != "" {
+				o.ExternalID = aws.String(assumeRole.ExternalID)
+			}
+		}), func(o *aws.CredentialsCacheOptions) {
+			o.ExpiryWindow = 30 * time.Minute
+		})
+	}
+	return credentialsProvider
+}
+
+// stderrTokenProvider reads the MFA token code from stdin like stscreds.StdinTokenProvider, but prompts on stderr so
+// that the prompt does not corrupt the output of commands, such as the ExecCredential printed by `eksctl get-token`
+func stderrTokenProvider() (string, error) {
+	fmt.Fprint(os.Stderr, "Assume Role MFA token code: ")
+	var code string
+	_, err := fmt.Scanln(&code)
+	return code, err
+}
+
+// credentialsCacheKey returns the key of the credentials of the profile in the credentials cache, which includes
+// the roles assumed and the options they are assumed with, so that credentials of different sessions are cached
+// separately
+func credentialsCacheKey(profile api.Profile) string {
+	if !profile.AssumeRole.IsSet() {
+		return profile.Name
+	}
+	assumeRole := profile.AssumeRole
+	return strings.Join([]string{
+		strings.Join(append([]string{profile.Name}, assumeRole.RoleARNs...), "->"),
+		assumeRole.ExternalID,
+		assumeRole.MFASerial,
+		assumeRole.RoleSessionName,
+	}, "|")
+}
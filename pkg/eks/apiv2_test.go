@@ -0,0 +1,143 @@
+package eks_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/eks/fakes"
+)
+
+type assumeRoleRequest struct {
+	accessKeyID string
+	roleARN     string
+	externalID  string
+	sessionName string
+}
+
+var _ = Describe("assuming roles", func() {
+	var (
+		server                  *httptest.Server
+		requests                []assumeRoleRequest
+		fakeConfigurationLoader *fakes.FakeAWSConfigurationLoader
+	)
+
+	BeforeEach(func() {
+		var mu sync.Mutex
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.Form.Get("Action")).To(Equal("AssumeRole"))
+
+			mu.Lock()
+			defer mu.Unlock()
+			authorization := r.Header.Get("Authorization")
+			accessKeyID := strings.SplitN(strings.SplitN(authorization, "Credential=", 2)[1], "/", 2)[0]
+			requests = append(requests, assumeRoleRequest{
+				accessKeyID: accessKeyID,
+				roleARN:     r.Form.Get("RoleArn"),
+				externalID:  r.Form.Get("ExternalId"),
+				sessionName: r.Form.Get("RoleSessionName"),
+			})
+			fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult>
+<Credentials><AccessKeyId>role-%d</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken><Expiration>2100-01-01T00:00:00Z</Expiration></Credentials>
+<AssumedRoleUser><Arn>%s</Arn><AssumedRoleId>id</AssumedRoleId></AssumedRoleUser>
+</AssumeRoleResult></AssumeRoleResponse>`, len(requests), r.Form.Get("RoleArn"))
+		}))
+		DeferCleanup(server.Close)
+
+		fakeConfigurationLoader = &fakes.FakeAWSConfigurationLoader{}
+		fakeConfigurationLoader.LoadDefaultConfigReturns(aws.Config{
+			Region:       api.DefaultRegion,
+			Credentials:  credentials.NewStaticCredentialsProvider("profile", "secret", ""),
+			BaseEndpoint: aws.String(server.URL),
+		}, nil)
+	})
+
+	It("assumes each role with the credentials of the previous one", func() {
+		awsProvider, err := eks.NewAWSProvider(&api.ProviderConfig{
+			Profile: api.Profile{
+				AssumeRole: api.AssumeRole{
+					RoleARNs:        []string{"arn:aws:iam::111111111111:role/hop", "arn:aws:iam::222222222222:role/admin"},
+					ExternalID:      "external-id",
+					RoleSessionName: "session",
+				},
+			},
+		}, fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+
+		creds, err := awsProvider.CredentialsProvider().Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("role-2"))
+		Expect(requests).To(Equal([]assumeRoleRequest{
+			{accessKeyID: "profile", roleARN: "arn:aws:iam::111111111111:role/hop", sessionName: "session"},
+			{accessKeyID: "role-1", roleARN: "arn:aws:iam::222222222222:role/admin", externalID: "external-id", sessionName: "session"},
+		}))
+	})
+
+	It("uses the profile credentials when there are no roles to assume", func() {
+		awsProvider, err := eks.NewAWSProvider(&api.ProviderConfig{}, fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+
+		creds, err := awsProvider.CredentialsProvider().Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("profile"))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("resolves the access key ID of the profile credentials without assuming roles", func() {
+		accessKeyID, err := eks.SourceAccessKeyIDWithLoader(context.Background(), &api.ProviderConfig{
+			Profile: api.Profile{
+				AssumeRole: api.AssumeRole{
+					RoleARNs:  []string{"arn:aws:iam::111111111111:role/admin"},
+					MFASerial: "arn:aws:iam::111111111111:mfa/user",
+				},
+			},
+		}, fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accessKeyID).To(Equal("profile"))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("caches the credentials of sessions with different options separately", func() {
+		profile := api.Profile{
+			Name: "dev",
+			AssumeRole: api.AssumeRole{
+				RoleARNs:        []string{"arn:aws:iam::111111111111:role/admin"},
+				ExternalID:      "external-id",
+				RoleSessionName: "session",
+			},
+		}
+		key := eks.CredentialsCacheKey(profile)
+		for _, update := range []func(*api.Profile){
+			func(p *api.Profile) { p.AssumeRole.ExternalID = "other" },
+			func(p *api.Profile) { p.AssumeRole.RoleSessionName = "other" },
+			func(p *api.Profile) { p.AssumeRole.MFASerial = "arn:aws:iam::111111111111:mfa/user" },
+			func(p *api.Profile) { p.AssumeRole.RoleARNs = []string{"arn:aws:iam::111111111111:role/other"} },
+		} {
+			other := profile
+			update(&other)
+			Expect(eks.CredentialsCacheKey(other)).NotTo(Equal(key))
+		}
+	})
+
+	It("rejects role options without roles to assume", func() {
+		_, err := eks.NewAWSProvider(&api.ProviderConfig{
+			Profile: api.Profile{
+				AssumeRole: api.AssumeRole{MFASerial: "arn:aws:iam::111111111111:mfa/user"},
+			},
+		}, fakeConfigurationLoader)
+		Expect(err).To(MatchError("--mfa-serial can only be used with --assume-role-arn"))
+	})
+})
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/credentials"
+)
+
+// DefaultTokenCacheLeeway is how long before their expiry cached tokens stop being used.
+const DefaultTokenCacheLeeway = time.Minute
+
+// CachedGenerator is a TokenGenerator that stores tokens in files, so that a token can be reused until shortly
+// before it expires instead of being presigned on each call.
+type CachedGenerator struct {
+	// TokenGenerator generates the tokens that are not in the cache.
+	TokenGenerator TokenGenerator
+	// Dir is the directory of the cache files.
+	Dir string
+	// Scope identifies the credentials used to generate tokens, e.g. the region, profile and role,
+	// so that tokens generated with different credentials are cached separately.
+	Scope string
+	// Leeway is how long before their expiry cached tokens stop being used.
+	Leeway time.Duration
+	Clock  credentials.Clock
+}
+
+type cachedToken struct {
+	Token      string    `json:"token"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// DefaultTokenCacheDir returns the directory where tokens are cached by default.
+func DefaultTokenCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "cache", "tokens"), nil
+}
+
+// GetWithSTS returns a cached token valid for clusterID, or generates one and caches it.
+// Failing to read or write the cache is not an error, the token is then generated or returned uncached.
+func (g CachedGenerator) GetWithSTS(ctx context.Context, clusterID string) (Token, error) {
+	path := g.path(clusterID)
+	if token, ok := g.load(path); ok {
+		logger.Debug("using cached token from %q", path)
+		return token, nil
+	}
+
+	token, err := g.TokenGenerator.GetWithSTS(ctx, clusterID)
+	if err != nil {
+		return Token{}, err
+	}
+	if err := g.store(path, token); err != nil {
+		logger.Debug("failed to cache token in %q: %v", path, err)
+	}
+	return token, nil
+}
+
+func (g CachedGenerator) path(clusterID string) string {
+	sum := sha256.Sum256([]byte(g.Scope + "\n" + clusterID))
+	return filepath.Join(g.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (g CachedGenerator) load(path string) (Token, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Token{}, false
+	}
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		logger.Debug("ignoring invalid cached token in %q: %v", path, err)
+		return Token{}, false
+	}
+	if cached.Token == "" || !g.Clock.Now().Add(g.Leeway).Before(cached.Expiration) {
+		return Token{}, false
+	}
+	return Token{Token: cached.Token, Expiration: cached.Expiration}, true
+}
+
+func (g CachedGenerator) store(path string, token Token) error {
+	if err := os.MkdirAll(g.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedToken{Token: token.Token, Expiration: token.Expiration})
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so that concurrent calls never read a partially written token
+	f, err := os.CreateTemp(g.Dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("renaming %q: %w", f.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/credentials/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/auth"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("CachedGenerator", func() {
+	var (
+		provider  *mockprovider.MockProvider
+		clock     *fakes.FakeClock
+		generator auth.CachedGenerator
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		provider.MockSTSPresigner().PresignGetCallerIdentityReturns(&v4.PresignedHTTPRequest{
+			URL: "https://example.com",
+		}, nil)
+		now = time.Date(2022, 1, 1, 1, 1, 1, 0, time.UTC)
+		clock = &fakes.FakeClock{}
+		clock.NowStub = func() time.Time {
+			return now
+		}
+		generator = auth.CachedGenerator{
+			TokenGenerator: auth.NewGenerator(provider.MockSTSPresigner(), clock),
+			Dir:            filepath.Join(GinkgoT().TempDir(), "tokens"),
+			Scope:          "us-west-2",
+			Leeway:         time.Minute,
+			Clock:          clock,
+		}
+	})
+
+	It("reuses tokens until shortly before they expire", func() {
+		token, err := generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.Token).To(Equal("k8s-aws-v1.aHR0cHM6Ly9leGFtcGxlLmNvbQ"))
+
+		now = now.Add(8 * time.Minute)
+		cached, err := generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached.Token).To(Equal(token.Token))
+		Expect(cached.Expiration.Equal(token.Expiration)).To(BeTrue())
+		Expect(provider.MockSTSPresigner().PresignGetCallerIdentityCallCount()).To(Equal(1))
+
+		now = now.Add(time.Minute)
+		_, err = generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.MockSTSPresigner().PresignGetCallerIdentityCallCount()).To(Equal(2))
+	})
+
+	It("caches tokens separately for each cluster and scope", func() {
+		_, err := generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = generator.GetWithSTS(context.Background(), "other-cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		generator.Scope = "eu-west-1"
+		_, err = generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(provider.MockSTSPresigner().PresignGetCallerIdentityCallCount()).To(Equal(3))
+		entries, err := os.ReadDir(generator.Dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+	})
+
+	It("ignores invalid cache files", func() {
+		_, err := generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		entries, err := os.ReadDir(generator.Dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(generator.Dir, entries[0].Name()), []byte("{"), 0600)).To(Succeed())
+
+		_, err = generator.GetWithSTS(context.Background(), "cluster-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.MockSTSPresigner().PresignGetCallerIdentityCallCount()).To(Equal(2))
+	})
+})
+
+var _ = Describe("ExecCredential", func() {
+	token := auth.Token{
+		Token:      "k8s-aws-v1.token",
+		Expiration: time.Date(2022, 1, 1, 1, 11, 1, 0, time.UTC),
+	}
+
+	It("writes the token in the requested API version", func() {
+		data, err := auth.ExecCredential(token, "client.authentication.k8s.io/v1")
+		Expect(err).NotTo(HaveOccurred())
+		var execCredential map[string]interface{}
+		Expect(json.Unmarshal(data, &execCredential)).To(Succeed())
+		Expect(execCredential).To(HaveKeyWithValue("apiVersion", "client.authentication.k8s.io/v1"))
+		Expect(execCredential).To(HaveKeyWithValue("kind", "ExecCredential"))
+		Expect(execCredential).To(HaveKeyWithValue("status", map[string]interface{}{
+			"token":               "k8s-aws-v1.token",
+			"expirationTimestamp": "2022-01-01T01:11:01Z",
+		}))
+
+		_, err = auth.ExecCredential(token, "client.authentication.k8s.io/v2")
+		Expect(err).To(MatchError(`unsupported ExecCredential API version "client.authentication.k8s.io/v2"`))
+	})
+
+	It("reads the API version requested by client-go", func() {
+		GinkgoT().Setenv(auth.ExecInfoEnvVar, "")
+		Expect(auth.ExecCredentialAPIVersion()).To(Equal(auth.DefaultExecCredentialAPIVersion))
+
+		GinkgoT().Setenv(auth.ExecInfoEnvVar, `{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1","spec":{"interactive":false}}`)
+		Expect(auth.ExecCredentialAPIVersion()).To(Equal("client.authentication.k8s.io/v1"))
+	})
+})
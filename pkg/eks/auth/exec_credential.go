@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1beta1 "k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+)
+
+const (
+	// ExecInfoEnvVar is the environment variable client-go sets for exec plugins, holding the ExecCredential
+	// that describes the request.
+	ExecInfoEnvVar = "KUBERNETES_EXEC_INFO"
+
+	execCredentialKind = "ExecCredential"
+	// DefaultExecCredentialAPIVersion is the API version of ExecCredentials when client-go does not set one.
+	DefaultExecCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+)
+
+// supportedExecCredentialAPIVersions are the API versions whose ExecCredential status holds a token and
+// an expiration timestamp in the same fields.
+var supportedExecCredentialAPIVersions = map[string]bool{
+	"client.authentication.k8s.io/v1alpha1": true,
+	"client.authentication.k8s.io/v1beta1":  true,
+	"client.authentication.k8s.io/v1":       true,
+}
+
+// ExecCredentialAPIVersion returns the API version of the ExecCredential requested by client-go in
+// KUBERNETES_EXEC_INFO, or the default version if it is not set.
+func ExecCredentialAPIVersion() (string, error) {
+	execInfo := os.Getenv(ExecInfoEnvVar)
+	if execInfo == "" {
+		return DefaultExecCredentialAPIVersion, nil
+	}
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal([]byte(execInfo), &typeMeta); err != nil {
+		return "", fmt.Errorf("parsing %s: %w", ExecInfoEnvVar, err)
+	}
+	if typeMeta.APIVersion == "" {
+		return DefaultExecCredentialAPIVersion, nil
+	}
+	return typeMeta.APIVersion, nil
+}
+
+// ExecCredential returns the token as an ExecCredential of the given API version, as read by client-go from
+// exec credential plugins.
+func ExecCredential(token Token, apiVersion string) ([]byte, error) {
+	if !supportedExecCredentialAPIVersions[apiVersion] {
+		return nil, fmt.Errorf("unsupported ExecCredential API version %q", apiVersion)
+	}
+	expiration := metav1.NewTime(token.Expiration)
+	return json.Marshal(&clientauthv1beta1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiVersion,
+			Kind:       execCredentialKind,
+		},
+		Status: &clientauthv1beta1.ExecCredentialStatus{
+			ExpirationTimestamp: &expiration,
+			Token:               token.Token,
+		},
+	})
+}
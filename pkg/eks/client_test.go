@@ -56,7 +56,7 @@ var _ = Describe("eks auth helpers", func() {
 				}
 
 				testAuthenticatorConfig := func(roleARN string) {
-					clientConfig := kubeconfig.NewForKubectl(cfg, GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile().Name)
+					clientConfig := kubeconfig.NewForKubectl(cfg, GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile())
 					Expect(clientConfig).To(Not(BeNil()))
 					ctx := clientConfig.CurrentContext
 					cluster := strings.Split(ctx, "@")[1]
@@ -85,7 +85,7 @@ var _ = Describe("eks auth helpers", func() {
 					// TODO: This test depends on which authenticator(s) is(are) installed and
 					// the code deciding which one should be picked up. Ideally we'd like to
 					// test all combinations, probably best done with a unit test.
-					Expect(k.AuthInfos[ctx].Exec.Command).To(BeElementOf("aws-iam-authenticator", "aws"))
+					Expect(k.AuthInfos[ctx].Exec.Command).To(BeElementOf("aws-iam-authenticator", "aws", "eksctl"))
 
 					var expectedArgs, roleARNArg string
 					switch k.AuthInfos[ctx].Exec.Command {
@@ -95,6 +95,9 @@ var _ = Describe("eks auth helpers", func() {
 					case "aws-iam-authenticator":
 						expectedArgs = "token -i auth-test-cluster"
 						roleARNArg = "-r"
+					case "eksctl":
+						expectedArgs = "get-token --cluster-name auth-test-cluster --region eu-west-3"
+						roleARNArg = "--role-arn"
 					}
 					if roleARN != "" {
 						expectedArgs += fmt.Sprintf(" %s %s", roleARNArg, roleARN)
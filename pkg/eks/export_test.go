@@ -3,4 +3,7 @@ package eks
 var (
 	NewHelper      = newHelper
 	NewAWSProvider = newAWSProvider
+
+	SourceAccessKeyIDWithLoader = sourceAccessKeyID
+	CredentialsCacheKey         = credentialsCacheKey
 )
@@ -0,0 +1,97 @@
+package eks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// HasClusterLogGroupSettings returns true if the control plane log group has settings to configure
+func HasClusterLogGroupSettings(cfg *api.ClusterConfig) bool {
+	if cfg.CloudWatch == nil || cfg.CloudWatch.ClusterLogging == nil {
+		return false
+	}
+	logging := cfg.CloudWatch.ClusterLogging
+	return logging.LogRetentionInDays != 0 || logging.LogGroupKMSKeyARN != "" || len(logging.SubscriptionFilters) > 0
+}
+
+// ConfigureClusterLogGroup sets the retention, KMS key and subscription filters of the control plane log group,
+// which is created by EKS when logging is enabled
+func (c *ClusterProvider) ConfigureClusterLogGroup(ctx context.Context, cfg *api.ClusterConfig) error {
+	logging := cfg.CloudWatch.ClusterLogging
+	// The format for log group name is documented here: https://docs.aws.amazon.com/eks/latest/userguide/control-plane-logs.html
+	logGroupName := fmt.Sprintf("/aws/eks/%s/cluster", cfg.Metadata.Name)
+	cwl := c.AWSProvider.CloudWatchLogs()
+
+	if logRetentionInDays := logging.LogRetentionInDays; logRetentionInDays > 0 {
+		if _, err := cwl.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(logGroupName),
+			RetentionInDays: aws.Int32(int32(logRetentionInDays)),
+		}); err != nil {
+			return fmt.Errorf("error updating log retention settings: %w", err)
+		}
+		logger.Success("set log retention to %d days for CloudWatch logging", logRetentionInDays)
+	}
+
+	if keyARN := logging.LogGroupKMSKeyARN; keyARN != "" {
+		if _, err := cwl.AssociateKmsKey(ctx, &cloudwatchlogs.AssociateKmsKeyInput{
+			LogGroupName: aws.String(logGroupName),
+			KmsKeyId:     aws.String(keyARN),
+		}); err != nil {
+			return fmt.Errorf("error associating KMS key %q with log group %q: %w", keyARN, logGroupName, err)
+		}
+		logger.Success("set KMS key %q to encrypt CloudWatch logging", keyARN)
+	}
+
+	for _, filter := range logging.SubscriptionFilters {
+		input := &cloudwatchlogs.PutSubscriptionFilterInput{
+			LogGroupName:   aws.String(logGroupName),
+			FilterName:     aws.String(filter.Name),
+			FilterPattern:  aws.String(filter.FilterPattern),
+			DestinationArn: aws.String(filter.DestinationARN),
+		}
+		if filter.RoleARN != "" {
+			input.RoleArn = aws.String(filter.RoleARN)
+		}
+		if _, err := cwl.PutSubscriptionFilter(ctx, input); err != nil {
+			return fmt.Errorf("error putting subscription filter %q on log group %q: %w", filter.Name, logGroupName, err)
+		}
+		logger.Success("set subscription filter %q to stream CloudWatch logging to %q", filter.Name, filter.DestinationARN)
+	}
+	return nil
+}
+
+// ConfigureObservabilityLogGroups creates the Container Insights log groups with the configured retention, before
+// the Amazon CloudWatch Observability addon creates them without retention
+func (c *ClusterProvider) ConfigureObservabilityLogGroups(ctx context.Context, cfg *api.ClusterConfig) error {
+	if !cfg.HasObservability() || cfg.Observability.CloudWatch == nil || cfg.Observability.CloudWatch.LogRetentionInDays == 0 {
+		return nil
+	}
+	logRetentionInDays := cfg.Observability.CloudWatch.LogRetentionInDays
+	cwl := c.AWSProvider.CloudWatchLogs()
+	for _, logGroupName := range cfg.ContainerInsightsLogGroups() {
+		if _, err := cwl.CreateLogGroup(ctx, &cloudwatchlogs.CreateLogGroupInput{
+			LogGroupName: aws.String(logGroupName),
+		}); err != nil {
+			var exists *cwltypes.ResourceAlreadyExistsException
+			if !errors.As(err, &exists) {
+				return fmt.Errorf("error creating log group %q: %w", logGroupName, err)
+			}
+		}
+		if _, err := cwl.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(logGroupName),
+			RetentionInDays: aws.Int32(int32(logRetentionInDays)),
+		}); err != nil {
+			return fmt.Errorf("error updating log retention settings of log group %q: %w", logGroupName, err)
+		}
+	}
+	logger.Success("set log retention to %d days for Container Insights logs", logRetentionInDays)
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 
 	"github.com/weaveworks/eksctl/pkg/ami"
@@ -112,10 +113,70 @@ func (n *NodeGroupService) Normalize(ctx context.Context, nodePools []api.NodePo
 		if publicKeyName != "" {
 			ng.SSH.PublicKeyName = &publicKeyName
 		}
+
+		if clusterConfig.HasCustomNetworking() {
+			if err := n.setCustomNetworkingMaxPods(ctx, np); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setCustomNetworkingMaxPods sets the max pods of nodes using custom networking, where the primary network interface
+// is not used for pods, unless it is set explicitly.
+func (n *NodeGroupService) setCustomNetworkingMaxPods(ctx context.Context, np api.NodePool) error {
+	ng := np.BaseNodeGroup()
+	if ng.MaxPodsPerNode != 0 || api.IsWindowsImage(ng.AMIFamily) {
+		return nil
+	}
+	if mng, ok := np.(*api.ManagedNodeGroup); ok && (mng.AMIFamily == api.NodeImageFamilyAmazonLinux2023 || api.IsAMI(mng.AMI) || mng.LaunchTemplate != nil) {
+		logger.Warning("nodegroup %q uses custom networking; set maxPodsPerNode in the launch template or AMI to account for the primary network interface not being used for pods", ng.Name)
+		return nil
+	}
+
+	var instanceTypes []ec2types.InstanceType
+	for _, it := range np.InstanceTypeList() {
+		if it != "mixed" {
+			instanceTypes = append(instanceTypes, ec2types.InstanceType(it))
+		}
+	}
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+	output, err := n.provider.EC2().DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: instanceTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("describing instance types %v: %w", instanceTypes, err)
+	}
+	maxPods := 0
+	for _, it := range output.InstanceTypes {
+		if pods := CustomNetworkingMaxPods(it.NetworkInfo); maxPods == 0 || pods < maxPods {
+			maxPods = pods
+		}
+	}
+	if maxPods > 0 {
+		logger.Info("nodegroup %q will use %d max pods per node, as it uses custom networking", ng.Name, maxPods)
+		ng.MaxPodsPerNode = maxPods
 	}
 	return nil
 }
 
+// CustomNetworkingMaxPods returns the maximum number of pods of an instance type using custom networking, where the
+// primary network interface is not used for pods. The two extra pods account for pods using the host network.
+func CustomNetworkingMaxPods(networkInfo *ec2types.NetworkInfo) int {
+	if networkInfo == nil {
+		return 0
+	}
+	enis := int(aws.ToInt32(networkInfo.MaximumNetworkInterfaces))
+	ipsPerENI := int(aws.ToInt32(networkInfo.Ipv4AddressesPerInterface))
+	if enis < 2 || ipsPerENI < 2 {
+		return 0
+	}
+	return (enis-1)*(ipsPerENI-1) + 2
+}
+
 // ExpandInstanceSelectorOptions sets instance types to instances matched by the instance selector criteria.
 func (n *NodeGroupService) ExpandInstanceSelectorOptions(nodePools []api.NodePool, clusterAZs []string) error {
 	instanceTypesMatch := func(a, b []string) bool {
@@ -267,6 +267,20 @@ var _ = Describe("NodeGroupService", func() {
 			expectedInstanceTypes: []string{"", ""},
 		}),
 	)
+
+	DescribeTable("CustomNetworkingMaxPods", func(networkInfo *ec2types.NetworkInfo, expectedMaxPods int) {
+		Expect(eks.CustomNetworkingMaxPods(networkInfo)).To(Equal(expectedMaxPods))
+	},
+		Entry("excludes the primary ENI from pod IPs", &ec2types.NetworkInfo{
+			MaximumNetworkInterfaces:  aws.Int32(3),
+			Ipv4AddressesPerInterface: aws.Int32(10),
+		}, 20),
+		Entry("instance types with a single ENI", &ec2types.NetworkInfo{
+			MaximumNetworkInterfaces:  aws.Int32(1),
+			Ipv4AddressesPerInterface: aws.Int32(4),
+		}, 0),
+		Entry("missing network info", nil, 0),
+	)
 })
 
 func mockOutpostInstanceTypes(provider *mockprovider.MockProvider) {
@@ -5,9 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 
 	"github.com/kris-nova/logger"
 
@@ -203,6 +203,14 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 			return c.RefreshClusterStatus(ctx, cfg)
 		},
 	})
+	if cfg.HasCustomNetworking() {
+		newTasks.Append(&tasks.GenericTask{
+			Description: "create ENIConfigs for pod subnets",
+			Doer: func() error {
+				return c.applyENIConfigs(ctx, cfg)
+			},
+		})
+	}
 	if cfg.IsAutoModeEnabled() && cfg.VPC != nil && cfg.VPC.ID != "" {
 		logger.Info("subnets supplied in subnets.private and subnets.public will be used for nodes launched by Auto Mode; please create a new NodeClass " +
 			"resource if you do not want to use cluster subnets")
@@ -215,25 +223,24 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 		}
 	}
 
-	if cfg.HasClusterCloudWatchLogging() {
-		if logRetentionDays := cfg.CloudWatch.ClusterLogging.LogRetentionInDays; logRetentionDays != 0 {
-			newTasks.Append(&clusterConfigTask{
-				info: "update CloudWatch log retention",
-				spec: cfg,
-				call: func(clusterConfig *api.ClusterConfig) error {
-					_, err := c.AWSProvider.CloudWatchLogs().PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
-						// The format for log group name is documented here: https://docs.aws.amazon.com/eks/latest/userguide/control-plane-logs.html
-						LogGroupName:    aws.String(fmt.Sprintf("/aws/eks/%s/cluster", cfg.Metadata.Name)),
-						RetentionInDays: aws.Int32(int32(logRetentionDays)),
-					})
-					if err != nil {
-						return fmt.Errorf("error updating log retention settings: %w", err)
-					}
-					logger.Info("set log retention to %d days for CloudWatch logging", logRetentionDays)
-					return nil
-				},
-			})
-		}
+	if HasClusterLogGroupSettings(cfg) {
+		newTasks.Append(&clusterConfigTask{
+			info: "update CloudWatch log group settings",
+			spec: cfg,
+			call: func(clusterConfig *api.ClusterConfig) error {
+				return c.ConfigureClusterLogGroup(ctx, clusterConfig)
+			},
+		})
+	}
+
+	if cfg.HasObservability() && cfg.Observability.CloudWatch != nil && cfg.Observability.CloudWatch.LogRetentionInDays != 0 {
+		newTasks.Append(&clusterConfigTask{
+			info: "create Container Insights log groups",
+			spec: cfg,
+			call: func(clusterConfig *api.ClusterConfig) error {
+				return c.ConfigureObservabilityLogGroups(ctx, clusterConfig)
+			},
+		})
 	}
 
 	if cfg.IsFargateEnabled() {
@@ -291,6 +298,22 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 	return newTasks
 }
 
+func (c *ClusterProvider) applyENIConfigs(ctx context.Context, cfg *api.ClusterConfig) error {
+	if err := c.RefreshClusterStatusIfStale(ctx, cfg); err != nil {
+		return err
+	}
+	securityGroups := cfg.VPC.PodSubnets.SecurityGroups
+	if len(securityGroups) == 0 {
+		securityGroups = []string{aws.ToString(c.Status.ClusterInfo.Cluster.ResourcesVpcConfig.ClusterSecurityGroupId)}
+	}
+	dynamicClient, err := c.NewDynamicClient(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating dynamic client: %w", err)
+	}
+	eniConfigs := addons.NewENIConfigs(cfg.VPC.PodSubnets, securityGroups)
+	return addons.ApplyENIConfigs(ctx, dynamicClient, eniConfigs, 5*time.Second, c.AWSProvider.WaitTimeout())
+}
+
 // LogEnabledFeatures logs enabled features
 func LogEnabledFeatures(clusterConfig *api.ClusterConfig) {
 	if clusterConfig.HasClusterEndpointAccess() && api.EndpointsEqual(*clusterConfig.VPC.ClusterEndpoints, *api.ClusterEndpointAccessDefaults()) {
@@ -0,0 +1,58 @@
+package eks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/credentials"
+	"github.com/weaveworks/eksctl/pkg/eks/auth"
+)
+
+// NewTokenGenerator creates a generator of cluster authentication tokens using the credentials of the provider
+// config, assuming roleARN if set. Unlike New, it makes no AWS API calls, as tokens are presigned locally.
+func NewTokenGenerator(spec *api.ProviderConfig, roleARN string) (auth.Generator, error) {
+	provider, err := newAWSProvider(spec, &ConfigurationLoader{})
+	if err != nil {
+		return auth.Generator{}, err
+	}
+
+	presigner := provider.STSPresigner()
+	if roleARN != "" {
+		cfg := provider.AWSConfig()
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN))
+		presigner = sts.NewPresignClient(sts.NewFromConfig(cfg, func(o *sts.Options) {
+			// Disable retryer for STS
+			// (see https://github.com/eksctl-io/eksctl/issues/705)
+			o.Retryer = aws.NopRetryer{}
+		}))
+	}
+	return auth.NewGenerator(presigner, &credentials.RealClock{}), nil
+}
+
+// SourceAccessKeyID returns the access key ID of the credentials of the provider config before the roles of its
+// AssumeRole are assumed, so that no role is assumed and no MFA token code is read.
+func SourceAccessKeyID(ctx context.Context, spec *api.ProviderConfig) (string, error) {
+	return sourceAccessKeyID(ctx, spec, &ConfigurationLoader{})
+}
+
+func sourceAccessKeyID(ctx context.Context, spec *api.ProviderConfig, configurationLoader AWSConfigurationLoader) (string, error) {
+	sourceSpec := *spec
+	sourceSpec.Profile.AssumeRole = api.AssumeRole{}
+	cfg, err := newV2Config(&sourceSpec, "", configurationLoader)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Credentials == nil {
+		return "", nil
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+	return creds.AccessKeyID, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	. "github.com/onsi/ginkgo/v2"
@@ -179,5 +180,62 @@ var _ = Describe("EKS API wrapper", func() {
 			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
 			Expect(ctl.UpdateClusterConfigForLogging(context.Background(), cfg)).To(Succeed())
 		})
+
+		It("should configure the KMS key and subscription filters of the log group", func() {
+			logGroupName := aws.String(fmt.Sprintf("/aws/eks/%s/cluster", cfg.Metadata.Name))
+			p.MockCloudWatchLogs().On("AssociateKmsKey", mock.Anything, &cloudwatchlogs.AssociateKmsKeyInput{
+				LogGroupName: logGroupName,
+				KmsKeyId:     aws.String("arn:aws:kms:us-west-2:123456789012:key/abcd"),
+			}).Return(&cloudwatchlogs.AssociateKmsKeyOutput{}, nil)
+			p.MockCloudWatchLogs().On("PutSubscriptionFilter", mock.Anything, &cloudwatchlogs.PutSubscriptionFilterInput{
+				LogGroupName:   logGroupName,
+				FilterName:     aws.String("to-firehose"),
+				FilterPattern:  aws.String(""),
+				DestinationArn: aws.String("arn:aws:firehose:us-west-2:123456789012:deliverystream/audit"),
+				RoleArn:        aws.String("arn:aws:iam::123456789012:role/cwl-to-firehose"),
+			}).Return(&cloudwatchlogs.PutSubscriptionFilterOutput{}, nil)
+			cfg.CloudWatch.ClusterLogging.EnableTypes = []string{"audit"}
+			cfg.CloudWatch.ClusterLogging.LogGroupKMSKeyARN = "arn:aws:kms:us-west-2:123456789012:key/abcd"
+			cfg.CloudWatch.ClusterLogging.SubscriptionFilters = []api.LogSubscriptionFilter{{
+				Name:           "to-firehose",
+				DestinationARN: "arn:aws:firehose:us-west-2:123456789012:deliverystream/audit",
+				RoleARN:        "arn:aws:iam::123456789012:role/cwl-to-firehose",
+			}}
+
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+			Expect(ctl.UpdateClusterConfigForLogging(context.Background(), cfg)).To(Succeed())
+			p.MockCloudWatchLogs().AssertNotCalled(GinkgoT(), "PutRetentionPolicy", mock.Anything, mock.Anything)
+		})
+	})
+
+	Describe("configuring observability log groups", func() {
+		It("creates the Container Insights log groups with the log retention", func() {
+			p := mockprovider.NewMockProvider()
+			ctl := &ClusterProvider{AWSProvider: p, Status: &ProviderStatus{}}
+			cfg := api.NewClusterConfig()
+			cfg.Metadata.Name = "dev"
+			cfg.Observability = &api.Observability{
+				CloudWatch: &api.CloudWatchObservability{LogRetentionInDays: 14},
+			}
+
+			p.MockCloudWatchLogs().On("CreateLogGroup", mock.Anything, &cloudwatchlogs.CreateLogGroupInput{
+				LogGroupName: aws.String("/aws/containerinsights/dev/performance"),
+			}).Return(nil, &cwltypes.ResourceAlreadyExistsException{})
+			p.MockCloudWatchLogs().On("CreateLogGroup", mock.Anything, mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+			var logGroups []string
+			p.MockCloudWatchLogs().On("PutRetentionPolicy", mock.Anything, mock.MatchedBy(func(input *cloudwatchlogs.PutRetentionPolicyInput) bool {
+				return aws.ToInt32(input.RetentionInDays) == 14
+			})).Run(func(args mock.Arguments) {
+				logGroups = append(logGroups, *args.Get(1).(*cloudwatchlogs.PutRetentionPolicyInput).LogGroupName)
+			}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+
+			Expect(ctl.ConfigureObservabilityLogGroups(context.Background(), cfg)).To(Succeed())
+			Expect(logGroups).To(Equal([]string{
+				"/aws/containerinsights/dev/performance",
+				"/aws/containerinsights/dev/application",
+				"/aws/containerinsights/dev/dataplane",
+				"/aws/containerinsights/dev/host",
+			}))
+		})
 	})
 })
@@ -25,6 +25,7 @@ import (
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	cloudprovider "k8s.io/cloud-provider"
 
@@ -44,6 +45,10 @@ type loadBalancer struct {
 	name                  string
 	kind                  loadBalancerKind
 	ownedSecurityGroupIDs map[string]struct{}
+	// arn is only set for load balancers tagged by the AWS Load Balancer Controller, which are deleted by eksctl
+	// if the controller does not delete them
+	arn             string
+	deletedByEksctl bool
 }
 
 const (
@@ -64,9 +69,10 @@ type DescribeLoadBalancersAPIV2 interface {
 	DescribeLoadBalancers(ctx context.Context, params *elasticloadbalancingv2.DescribeLoadBalancersInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error)
 }
 
-// Cleanup finds and deletes any dangling ELBs associated to a Kubernetes Service
-func Cleanup(ctx context.Context, ec2API awsapi.EC2, elbAPI DescribeLoadBalancersAPI, elbv2API DescribeLoadBalancersAPIV2,
-	kubernetesCS kubernetes.Interface, clusterConfig *api.ClusterConfig) error {
+// Cleanup finds and deletes any dangling ELBs associated to a Kubernetes Service, Ingress, TargetGroupBinding or
+// Gateway, or tagged by the AWS Load Balancer Controller as belonging to the cluster
+func Cleanup(ctx context.Context, ec2API awsapi.EC2, elbAPI DescribeLoadBalancersAPI, elbv2API awsapi.ELBV2,
+	kubernetesCS kubernetes.Interface, dynamicClient dynamic.Interface, clusterConfig *api.ClusterConfig) error {
 
 	deadline, ok := ctx.Deadline()
 	if !ok {
@@ -145,10 +151,44 @@ func Cleanup(ctx context.Context, ec2API awsapi.EC2, elbAPI DescribeLoadBalancer
 		}
 	}
 
-	// Wait for all the load balancers backing the LoadBalancer services to disappear
+	// Delete TargetGroupBindings and Gateways, and track the load balancers tagged by the AWS Load Balancer
+	// Controller, including those whose Kubernetes objects no longer exist
+	if dynamicClient != nil {
+		if err := deleteControllerResources(ctx, dynamicClient); err != nil {
+			return err
+		}
+	}
+	controllerLoadBalancers, err := getControllerLoadBalancers(ctx, ec2API, elbv2API, clusterConfig.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	for _, lb := range controllerLoadBalancers {
+		if tracked, ok := awsLoadBalancers[lb.name]; ok {
+			for sgID := range tracked.ownedSecurityGroupIDs {
+				lb.ownedSecurityGroupIDs[sgID] = struct{}{}
+			}
+		}
+		logger.Debug(
+			"tracking deletion of AWS Load Balancer Controller load balancer %s of kind %d with security groups %v",
+			lb.name, lb.kind, convertStringSetToSlice(lb.ownedSecurityGroupIDs),
+		)
+		awsLoadBalancers[lb.name] = lb
+	}
+
+	// Wait for all the load balancers, their security groups and network interfaces to disappear, deleting the load
+	// balancers of the AWS Load Balancer Controller that it has not deleted after a grace period
 	pollInterval := 2 * time.Second
+	controllerDeadline := time.Now().Add(controllerDeletionGracePeriod)
 	for ; time.Now().Before(deadline) && len(awsLoadBalancers) > 0; time.Sleep(pollInterval) {
 		for name, lb := range awsLoadBalancers {
+			if lb.arn != "" && !lb.deletedByEksctl && time.Now().After(controllerDeadline) {
+				if err := deleteControllerLoadBalancer(ctx, elbv2API, lb); err != nil {
+					logger.Warning("error when deleting load balancer %s: %s", lb.name, err)
+				} else {
+					lb.deletedByEksctl = true
+					awsLoadBalancers[name] = lb
+				}
+			}
 			exists, err := loadBalancerExists(ctx, ec2API, elbAPI, elbv2API, lb)
 			if err != nil {
 				logger.Warning("error when checking existence of load balancer %s: %s", lb.name, err)
@@ -162,12 +202,11 @@ func Cleanup(ctx context.Context, ec2API awsapi.EC2, elbAPI DescribeLoadBalancer
 		}
 	}
 
-	if numLB := len(awsLoadBalancers); numLB > 0 {
-		lbs := make([]string, 0, numLB)
-		for name := range awsLoadBalancers {
-			lbs = append(lbs, name)
-		}
-		return fmt.Errorf("deadline surpassed waiting for AWS load balancers to be deleted: %s", strings.Join(lbs, ","))
+	if len(awsLoadBalancers) > 0 {
+		return fmt.Errorf("deadline surpassed waiting for AWS load balancers to be deleted: %s", loadBalancerNames(awsLoadBalancers))
+	}
+	if err := deleteControllerTargetGroups(ctx, elbv2API, clusterConfig.Metadata.Name); err != nil {
+		return fmt.Errorf("cannot delete AWS Load Balancer Controller target groups: %w", err)
 	}
 	logger.Debug("deleting load balancer Security Group orphans")
 	// Orphan security-group deletion is needed due to https://github.com/kubernetes/kubernetes/issues/79994`
@@ -185,6 +224,24 @@ func getServiceLoadBalancer(ctx context.Context, ec2API awsapi.EC2, elbAPI Descr
 	}
 	name := cloudprovider.DefaultLoadBalancerName(service)
 	kind := getLoadBalancerKind(service)
+	if isControllerService(service) {
+		// NLBs of the AWS Load Balancer Controller are not named after the Service, but their name
+		// can be obtained from the hostname
+		var hosts []string
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			hosts = append(hosts, ingress.Hostname)
+		}
+		if len(hosts) == 0 {
+			logger.Debug("load balancer of Service %s/%s is probably not provisioned, skip", service.Namespace, service.Name)
+			return nil, nil
+		}
+		var err error
+		if name, err = getIngressELBName(hosts); err != nil {
+			logger.Debug("cannot obtain load balancer name of Service %s/%s, skip: %s", service.Namespace, service.Name, err)
+			return nil, nil
+		}
+		kind = network
+	}
 	ctx, cleanup := context.WithTimeout(ctx, 30*time.Second)
 	securityGroupIDs, err := getSecurityGroupsOwnedByLoadBalancer(ctx, ec2API, elbAPI, elbv2API, clusterName, name, kind)
 	cleanup()
@@ -486,6 +543,23 @@ func loadBalancerExists(ctx context.Context, ec2API awsapi.EC2, elbAPI DescribeL
 		return true, nil
 	}
 
+	// The security groups of the load balancer can only be deleted once its network interfaces are released
+	hasNetworkInterfaces, err := loadBalancerHasNetworkInterfaces(ctx, ec2API, lb)
+	if err != nil {
+		return false, err
+	}
+	if hasNetworkInterfaces {
+		return true, nil
+	}
+	if len(lb.ownedSecurityGroupIDs) == 0 {
+		return false, nil
+	}
+	if lb.deletedByEksctl {
+		if err := deleteLoadBalancerSecurityGroups(ctx, ec2API, lb); err != nil {
+			return false, err
+		}
+	}
+
 	// Check whether all the security groups owned by the load balancer have also been deleted
 	// (they are deleted after the load balancer)
 	sgResponse, err := describeSecurityGroupsByID(ctx, ec2API, convertStringSetToSlice(lb.ownedSecurityGroupIDs))
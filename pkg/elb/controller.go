@@ -0,0 +1,346 @@
+package elb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/smithy-go"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// Resources reconciled by the AWS Load Balancer Controller
+var (
+	targetGroupBindingGVR = schema.GroupVersionResource{Group: "elbv2.k8s.aws", Version: "v1beta1", Resource: "targetgroupbindings"}
+	gatewayGVR            = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+	gatewayClassGVR       = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}
+)
+
+const (
+	// controllerServiceLoadBalancerClass is the load balancer class of Services reconciled by the AWS Load Balancer Controller
+	controllerServiceLoadBalancerClass = "service.k8s.aws/nlb"
+	// controllerResourceTagKey is set by the AWS Load Balancer Controller on the security groups it creates,
+	// with the value backend-sg on the security group shared by all load balancers of the cluster
+	controllerResourceTagKey = "elbv2.k8s.aws/resource"
+	controllerBackendSGValue = "backend-sg"
+
+	// controllerDeletionGracePeriod is how long the AWS Load Balancer Controller is given to delete the load balancers
+	// of deleted Kubernetes objects, before eksctl deletes them
+	controllerDeletionGracePeriod = 2 * time.Minute
+
+	// maxDescribeTagsResources is the maximum number of resources per ELBv2 DescribeTags request
+	maxDescribeTagsResources = 20
+)
+
+// controllerGatewayControllerNames are the GatewayClass controller names of the AWS Load Balancer Controller
+var controllerGatewayControllerNames = map[string]bool{
+	"gateway.k8s.aws/alb": true,
+	"gateway.k8s.aws/nlb": true,
+}
+
+// isControllerService returns true if the load balancer of the Service is provisioned by the AWS Load Balancer
+// Controller rather than by the in-tree cloud provider
+func isControllerService(service *corev1.Service) bool {
+	if class := service.Spec.LoadBalancerClass; class != nil {
+		return *class == controllerServiceLoadBalancerClass
+	}
+	switch service.Annotations[serviceAnnotationLoadBalancerType] {
+	case "external", "nlb-ip":
+		return true
+	}
+	return false
+}
+
+// deleteControllerResources deletes the TargetGroupBindings and the Gateways reconciled by the AWS Load Balancer
+// Controller, so that the controller deregisters their targets and deletes their load balancers. Resources whose
+// CRDs are not installed are skipped.
+func deleteControllerResources(ctx context.Context, dynamicClient dynamic.Interface) error {
+	if err := deleteResources(ctx, dynamicClient, targetGroupBindingGVR, "TargetGroupBinding", func(map[string]interface{}) bool {
+		return true
+	}); err != nil {
+		return err
+	}
+
+	gatewayClasses, err := dynamicClient.Resource(gatewayClassGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot list Kubernetes GatewayClasses: %w", err)
+	}
+	controllerClasses := map[string]bool{}
+	for _, gc := range gatewayClasses.Items {
+		if controllerGatewayControllerNames[nestedString(gc.Object, "spec", "controllerName")] {
+			controllerClasses[gc.GetName()] = true
+		}
+	}
+	if len(controllerClasses) == 0 {
+		return nil
+	}
+	return deleteResources(ctx, dynamicClient, gatewayGVR, "Gateway", func(obj map[string]interface{}) bool {
+		return controllerClasses[nestedString(obj, "spec", "gatewayClassName")]
+	})
+}
+
+func deleteResources(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, kind string, matches func(map[string]interface{}) bool) error {
+	list, err := dynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Debug("%s resources are not served by the cluster, skip", kind)
+			return nil
+		}
+		return fmt.Errorf("cannot list Kubernetes %ss: %w", kind, err)
+	}
+	for _, item := range list.Items {
+		if !matches(item.Object) {
+			continue
+		}
+		logger.Debug("deleting %s %s/%s", kind, item.GetNamespace(), item.GetName())
+		if err := dynamicClient.Resource(gvr).Namespace(item.GetNamespace()).Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			errStr := fmt.Sprintf("cannot delete Kubernetes %s %s/%s: %s", kind, item.GetNamespace(), item.GetName(), err)
+			if k8serrors.IsForbidden(err) {
+				errStr = fmt.Sprintf("%s (deleting a cluster requires permission to delete Kubernetes %ss)", errStr, kind)
+			}
+			return errors.New(errStr)
+		}
+	}
+	return nil
+}
+
+func nestedString(obj map[string]interface{}, fields ...string) string {
+	for i, field := range fields {
+		value, ok := obj[field]
+		if !ok {
+			return ""
+		}
+		if i == len(fields)-1 {
+			s, _ := value.(string)
+			return s
+		}
+		if obj, ok = value.(map[string]interface{}); !ok {
+			return ""
+		}
+	}
+	return ""
+}
+
+// getControllerLoadBalancers returns the ALBs and NLBs tagged by the AWS Load Balancer Controller as belonging
+// to the cluster, including those of Gateways and of Kubernetes objects that no longer exist
+func getControllerLoadBalancers(ctx context.Context, ec2API awsapi.EC2, elbv2API awsapi.ELBV2, clusterName string) ([]loadBalancer, error) {
+	loadBalancers := map[string]elbv2types.LoadBalancer{}
+	var arns []string
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(elbv2API, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot describe load balancers: %w", err)
+		}
+		for _, lb := range page.LoadBalancers {
+			switch lb.Type {
+			case elbv2types.LoadBalancerTypeEnumApplication, elbv2types.LoadBalancerTypeEnumNetwork:
+				arn := aws.ToString(lb.LoadBalancerArn)
+				loadBalancers[arn] = lb
+				arns = append(arns, arn)
+			}
+		}
+	}
+
+	owned, err := filterClusterResources(ctx, elbv2API, arns, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []loadBalancer
+	for _, arn := range owned {
+		lb := loadBalancers[arn]
+		kind := application
+		if lb.Type == elbv2types.LoadBalancerTypeEnumNetwork {
+			kind = network
+		}
+		securityGroupIDs, err := getSecurityGroupsOwnedByControllerLoadBalancer(ctx, ec2API, lb.SecurityGroups, clusterName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot obtain security groups for load balancer %s: %w", aws.ToString(lb.LoadBalancerName), err)
+		}
+		result = append(result, loadBalancer{
+			name:                  aws.ToString(lb.LoadBalancerName),
+			kind:                  kind,
+			arn:                   arn,
+			ownedSecurityGroupIDs: securityGroupIDs,
+		})
+	}
+	return result, nil
+}
+
+// filterClusterResources returns the ELBv2 resources tagged by the AWS Load Balancer Controller as belonging to the cluster
+func filterClusterResources(ctx context.Context, elbv2API awsapi.ELBV2, arns []string, clusterName string) ([]string, error) {
+	var owned []string
+	for i := 0; i < len(arns); i += maxDescribeTagsResources {
+		output, err := elbv2API.DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{
+			ResourceArns: arns[i:min(i+maxDescribeTagsResources, len(arns))],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot describe tags of load balancer resources: %w", err)
+		}
+		for _, desc := range output.TagDescriptions {
+			for _, tag := range desc.Tags {
+				if aws.ToString(tag.Key) == elbv2ClusterTagKey && aws.ToString(tag.Value) == clusterName {
+					owned = append(owned, aws.ToString(desc.ResourceArn))
+					break
+				}
+			}
+		}
+	}
+	return owned, nil
+}
+
+// getSecurityGroupsOwnedByControllerLoadBalancer returns the security groups created by the AWS Load Balancer
+// Controller for a load balancer, excluding the backend security group shared by all load balancers of the cluster
+func getSecurityGroupsOwnedByControllerLoadBalancer(ctx context.Context, ec2API awsapi.EC2, groupIDs []string, clusterName string) (map[string]struct{}, error) {
+	result := map[string]struct{}{}
+	if len(groupIDs) == 0 {
+		return result, nil
+	}
+	sgResponse, err := describeSecurityGroupsByID(ctx, ec2API, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, sg := range sgResponse.SecurityGroups {
+		var ownedByCluster, backend bool
+		for _, tag := range sg.Tags {
+			switch aws.ToString(tag.Key) {
+			case elbv2ClusterTagKey:
+				ownedByCluster = aws.ToString(tag.Value) == clusterName
+			case controllerResourceTagKey:
+				backend = aws.ToString(tag.Value) == controllerBackendSGValue
+			}
+		}
+		if ownedByCluster && !backend {
+			result[aws.ToString(sg.GroupId)] = struct{}{}
+		}
+	}
+	return result, nil
+}
+
+// deleteControllerLoadBalancer deletes a load balancer that was not deleted by the AWS Load Balancer Controller,
+// which is typically no longer running
+func deleteControllerLoadBalancer(ctx context.Context, elbv2API awsapi.ELBV2, lb loadBalancer) error {
+	logger.Info("deleting load balancer %s, which was not deleted by the AWS Load Balancer Controller", lb.name)
+	_, err := elbv2API.DeleteLoadBalancer(ctx, &elasticloadbalancingv2.DeleteLoadBalancerInput{
+		LoadBalancerArn: aws.String(lb.arn),
+	})
+	if err != nil && !isELBv2NotFoundErr(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteLoadBalancerSecurityGroups deletes the security groups of a load balancer deleted by eksctl, as the
+// AWS Load Balancer Controller is not around to delete them. Security groups that are still used by the network
+// interfaces of the load balancer are skipped, to be retried later on.
+func deleteLoadBalancerSecurityGroups(ctx context.Context, ec2API awsapi.EC2, lb loadBalancer) error {
+	for sgID := range lb.ownedSecurityGroupIDs {
+		_, err := ec2API.DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{
+			GroupId: aws.String(sgID),
+		})
+		if err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) {
+				switch ae.ErrorCode() {
+				case "DependencyViolation":
+					logger.Debug("failed to delete security group %s, possibly because the network interfaces of its load balancer are still being deleted", sgID)
+					continue
+				case "InvalidGroup.NotFound":
+					continue
+				}
+			}
+			return errors.Wrapf(err, "cannot delete security group %q", sgID)
+		}
+	}
+	return nil
+}
+
+// deleteControllerTargetGroups deletes the target groups tagged by the AWS Load Balancer Controller as belonging to
+// the cluster, which are left behind when their load balancers are not deleted by the controller. Target groups
+// referenced by TargetGroupBindings are created by users, and are therefore not deleted.
+func deleteControllerTargetGroups(ctx context.Context, elbv2API awsapi.ELBV2, clusterName string) error {
+	var arns []string
+	paginator := elasticloadbalancingv2.NewDescribeTargetGroupsPaginator(elbv2API, &elasticloadbalancingv2.DescribeTargetGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot describe target groups: %w", err)
+		}
+		for _, tg := range page.TargetGroups {
+			arns = append(arns, aws.ToString(tg.TargetGroupArn))
+		}
+	}
+
+	owned, err := filterClusterResources(ctx, elbv2API, arns, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, arn := range owned {
+		logger.Debug("deleting target group %s", arn)
+		if _, err := elbv2API.DeleteTargetGroup(ctx, &elasticloadbalancingv2.DeleteTargetGroupInput{
+			TargetGroupArn: aws.String(arn),
+		}); err != nil {
+			var inUse *elbv2types.ResourceInUseException
+			if errors.As(err, &inUse) {
+				logger.Warning("target group %s is still in use and was not deleted", arn)
+				continue
+			}
+			return fmt.Errorf("cannot delete target group %s: %w", arn, err)
+		}
+	}
+	return nil
+}
+
+// loadBalancerHasNetworkInterfaces returns true if any network interface of the load balancer still exists
+func loadBalancerHasNetworkInterfaces(ctx context.Context, ec2API awsapi.EC2, lb loadBalancer) (bool, error) {
+	output, err := ec2API.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("description"),
+				Values: []string{networkInterfaceDescription(lb)},
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot describe network interfaces: %w", err)
+	}
+	return len(output.NetworkInterfaces) > 0, nil
+}
+
+// networkInterfaceDescription returns the description of the network interfaces of a load balancer
+func networkInterfaceDescription(lb loadBalancer) string {
+	switch lb.kind {
+	case application:
+		return fmt.Sprintf("ELB app/%s/*", lb.name)
+	case network:
+		return fmt.Sprintf("ELB net/%s/*", lb.name)
+	default:
+		return "ELB " + lb.name
+	}
+}
+
+func loadBalancerNames(lbs map[string]loadBalancer) string {
+	names := make([]string, 0, len(lbs))
+	for name := range lbs {
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,224 @@
+package elb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("AWS Load Balancer Controller cleanup", func() {
+	DescribeTable("isControllerService", func(loadBalancerClass *string, annotations map[string]string, expected bool) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: corev1.ServiceSpec{
+				Type:              corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass: loadBalancerClass,
+			},
+		}
+		Expect(isControllerService(service)).To(Equal(expected))
+	},
+		Entry("in-tree classic load balancer", nil, nil, false),
+		Entry("in-tree NLB", nil, map[string]string{serviceAnnotationLoadBalancerType: "nlb"}, false),
+		Entry("external load balancer type", nil, map[string]string{serviceAnnotationLoadBalancerType: "external"}, true),
+		Entry("nlb-ip load balancer type", nil, map[string]string{serviceAnnotationLoadBalancerType: "nlb-ip"}, true),
+		Entry("controller load balancer class", aws.String("service.k8s.aws/nlb"), nil, true),
+		Entry("other load balancer class", aws.String("example.com/lb"), map[string]string{serviceAnnotationLoadBalancerType: "external"}, false),
+	)
+
+	Describe("deleteControllerResources", func() {
+		newObject := func(gvr schema.GroupVersionResource, kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": gvr.GroupVersion().String(),
+				"kind":       kind,
+				"metadata":   map[string]interface{}{"name": name},
+				"spec":       spec,
+			}}
+			if namespace != "" {
+				obj.SetNamespace(namespace)
+			}
+			return obj
+		}
+
+		It("deletes TargetGroupBindings and the Gateways of the controller", func() {
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				targetGroupBindingGVR: "TargetGroupBindingList",
+				gatewayGVR:            "GatewayList",
+				gatewayClassGVR:       "GatewayClassList",
+			})
+			// Objects are created with their GVR, as the fake client would otherwise guess the resource of Gateways as gatewaies
+			for _, o := range []struct {
+				gvr schema.GroupVersionResource
+				obj *unstructured.Unstructured
+			}{
+				{targetGroupBindingGVR, newObject(targetGroupBindingGVR, "TargetGroupBinding", "default", "web", nil)},
+				{gatewayClassGVR, newObject(gatewayClassGVR, "GatewayClass", "", "aws-alb", map[string]interface{}{"controllerName": "gateway.k8s.aws/alb"})},
+				{gatewayClassGVR, newObject(gatewayClassGVR, "GatewayClass", "", "istio", map[string]interface{}{"controllerName": "istio.io/gateway-controller"})},
+				{gatewayGVR, newObject(gatewayGVR, "Gateway", "default", "public", map[string]interface{}{"gatewayClassName": "aws-alb"})},
+				{gatewayGVR, newObject(gatewayGVR, "Gateway", "default", "mesh", map[string]interface{}{"gatewayClassName": "istio"})},
+			} {
+				_, err := dynamicClient.Resource(o.gvr).Namespace(o.obj.GetNamespace()).Create(context.Background(), o.obj, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(deleteControllerResources(context.Background(), dynamicClient)).To(Succeed())
+
+			bindings, err := dynamicClient.Resource(targetGroupBindingGVR).Namespace(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings.Items).To(BeEmpty())
+
+			gateways, err := dynamicClient.Resource(gatewayGVR).Namespace(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gateways.Items).To(HaveLen(1))
+			Expect(gateways.Items[0].GetName()).To(Equal("mesh"))
+		})
+	})
+
+	Describe("AWS resources", func() {
+		var p *mockprovider.MockProvider
+
+		clusterTags := func(arn, clusterName string) elbv2types.TagDescription {
+			return elbv2types.TagDescription{
+				ResourceArn: aws.String(arn),
+				Tags:        []elbv2types.Tag{{Key: aws.String(elbv2ClusterTagKey), Value: aws.String(clusterName)}},
+			}
+		}
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+		})
+
+		It("finds the load balancers of the cluster and the security groups they own", func() {
+			p.MockELBV2().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []elbv2types.LoadBalancer{
+					{
+						LoadBalancerArn:  aws.String("arn:alb"),
+						LoadBalancerName: aws.String("k8s-default-public-1234"),
+						Type:             elbv2types.LoadBalancerTypeEnumApplication,
+						SecurityGroups:   []string{"sg-frontend", "sg-backend"},
+					},
+					{
+						LoadBalancerArn:  aws.String("arn:nlb"),
+						LoadBalancerName: aws.String("k8s-default-other-5678"),
+						Type:             elbv2types.LoadBalancerTypeEnumNetwork,
+					},
+					{
+						LoadBalancerArn:  aws.String("arn:gwlb"),
+						LoadBalancerName: aws.String("gwlb"),
+						Type:             elbv2types.LoadBalancerTypeEnumGateway,
+					},
+				},
+			}, nil)
+			p.MockELBV2().On("DescribeTags", mock.Anything, &elasticloadbalancingv2.DescribeTagsInput{
+				ResourceArns: []string{"arn:alb", "arn:nlb"},
+			}).Return(&elasticloadbalancingv2.DescribeTagsOutput{
+				TagDescriptions: []elbv2types.TagDescription{
+					clusterTags("arn:alb", "my-cluster"),
+					clusterTags("arn:nlb", "other-cluster"),
+				},
+			}, nil)
+			p.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+				SecurityGroups: []ec2types.SecurityGroup{
+					{
+						GroupId: aws.String("sg-frontend"),
+						Tags:    []ec2types.Tag{{Key: aws.String(elbv2ClusterTagKey), Value: aws.String("my-cluster")}},
+					},
+					{
+						GroupId: aws.String("sg-backend"),
+						Tags: []ec2types.Tag{
+							{Key: aws.String(elbv2ClusterTagKey), Value: aws.String("my-cluster")},
+							{Key: aws.String(controllerResourceTagKey), Value: aws.String(controllerBackendSGValue)},
+						},
+					},
+				},
+			}, nil)
+
+			lbs, err := getControllerLoadBalancers(context.Background(), p.EC2(), p.ELBV2(), "my-cluster")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lbs).To(Equal([]loadBalancer{
+				{
+					name:                  "k8s-default-public-1234",
+					kind:                  application,
+					arn:                   "arn:alb",
+					ownedSecurityGroupIDs: map[string]struct{}{"sg-frontend": {}},
+				},
+			}))
+		})
+
+		It("deletes the target groups of the cluster", func() {
+			p.MockELBV2().On("DescribeTargetGroups", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+				TargetGroups: []elbv2types.TargetGroup{
+					{TargetGroupArn: aws.String("arn:tg-1")},
+					{TargetGroupArn: aws.String("arn:tg-2")},
+				},
+			}, nil)
+			p.MockELBV2().On("DescribeTags", mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeTagsOutput{
+				TagDescriptions: []elbv2types.TagDescription{
+					clusterTags("arn:tg-1", "my-cluster"),
+					{ResourceArn: aws.String("arn:tg-2")},
+				},
+			}, nil)
+			p.MockELBV2().On("DeleteTargetGroup", mock.Anything, &elasticloadbalancingv2.DeleteTargetGroupInput{
+				TargetGroupArn: aws.String("arn:tg-1"),
+			}).Return(&elasticloadbalancingv2.DeleteTargetGroupOutput{}, nil)
+
+			Expect(deleteControllerTargetGroups(context.Background(), p.ELBV2(), "my-cluster")).To(Succeed())
+			p.MockELBV2().AssertNumberOfCalls(GinkgoT(), "DeleteTargetGroup", 1)
+		})
+
+		When("a load balancer deleted by eksctl no longer exists", func() {
+			var lb loadBalancer
+
+			BeforeEach(func() {
+				lb = loadBalancer{
+					name:                  "k8s-default-public-1234",
+					kind:                  application,
+					arn:                   "arn:alb",
+					ownedSecurityGroupIDs: map[string]struct{}{"sg-frontend": {}},
+					deletedByEksctl:       true,
+				}
+				p.MockELBV2().On("DescribeLoadBalancers", mock.Anything, mock.Anything).Return(nil, &elbv2types.LoadBalancerNotFoundException{})
+			})
+
+			It("waits for its network interfaces to be released", func() {
+				p.MockEC2().On("DescribeNetworkInterfaces", mock.Anything, &ec2.DescribeNetworkInterfacesInput{
+					Filters: []ec2types.Filter{{Name: aws.String("description"), Values: []string{"ELB app/k8s-default-public-1234/*"}}},
+				}).Return(&ec2.DescribeNetworkInterfacesOutput{
+					NetworkInterfaces: []ec2types.NetworkInterface{{NetworkInterfaceId: aws.String("eni-1")}},
+				}, nil)
+
+				exists, err := loadBalancerExists(context.Background(), p.EC2(), p.ELB(), p.ELBV2(), lb)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeTrue())
+				p.MockEC2().AssertNotCalled(GinkgoT(), "DeleteSecurityGroup", mock.Anything, mock.Anything)
+			})
+
+			It("deletes its security groups once its network interfaces are released", func() {
+				p.MockEC2().On("DescribeNetworkInterfaces", mock.Anything, mock.Anything).Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+				p.MockEC2().On("DeleteSecurityGroup", mock.Anything, &ec2.DeleteSecurityGroupInput{
+					GroupId: aws.String("sg-frontend"),
+				}).Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+				p.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+
+				exists, err := loadBalancerExists(context.Background(), p.EC2(), p.ELB(), p.ELBV2(), lb)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+				p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 1)
+			})
+		})
+	})
+})
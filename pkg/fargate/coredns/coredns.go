@@ -29,12 +29,18 @@ const (
 	computeTypeFargate       = "fargate"
 )
 
+// podLabels are the labels of EKS' coredns pods.
+var podLabels = map[string]string{
+	"eks.amazonaws.com/component": "coredns",
+	"k8s-app":                     "kube-dns",
+}
+
 // IsSchedulableOnFargate analyzes the provided profiles to determine whether
 // EKS' coredns deployment should be scheduled onto Fargate.
 func IsSchedulableOnFargate(profiles []*api.FargateProfile) bool {
 	for _, profile := range profiles {
 		for _, selector := range profile.Selectors {
-			if selectsCoreDNS(selector) {
+			if selector.Matches(Namespace, podLabels) {
 				return true
 			}
 		}
@@ -42,10 +48,6 @@ func IsSchedulableOnFargate(profiles []*api.FargateProfile) bool {
 	return false
 }
 
-func selectsCoreDNS(selector api.FargateProfileSelector) bool {
-	return selector.Namespace == Namespace && len(selector.Labels) == 0
-}
-
 // IsScheduledOnFargate checks if EKS' coredns is scheduled onto Fargate.
 func IsScheduledOnFargate(clientSet kubeclient.Interface) (bool, error) {
 	isDepOnFargate, err := isDeploymentScheduledOnFargate(clientSet)
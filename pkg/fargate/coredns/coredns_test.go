@@ -103,6 +103,36 @@ var _ = Describe("coredns", func() {
 			Expect(coredns.IsSchedulableOnFargate(cfg.FargateProfiles)).To(BeTrue())
 		})
 
+		It("should return true when a Fargate profile matches kube-system with a wildcard", func() {
+			Expect(coredns.IsSchedulableOnFargate([]*api.FargateProfile{
+				{
+					Name: "selecting-coredns-by-wildcard",
+					Selectors: []api.FargateProfileSelector{
+						{Namespace: "kube-*"},
+					},
+				},
+			})).To(BeTrue())
+			Expect(coredns.IsSchedulableOnFargate([]*api.FargateProfile{
+				{
+					Name: "not-selecting-coredns-by-wildcard",
+					Selectors: []api.FargateProfileSelector{
+						{Namespace: "default-*"},
+					},
+				},
+			})).To(BeFalse())
+		})
+
+		It("should return true when a Fargate profile matches kube-system and the labels of coredns pods", func() {
+			Expect(coredns.IsSchedulableOnFargate([]*api.FargateProfile{
+				{
+					Name: "selecting-coredns-by-labels",
+					Selectors: []api.FargateProfileSelector{
+						{Namespace: "kube-system", Labels: map[string]string{"k8s-app": "kube-dns"}},
+					},
+				},
+			})).To(BeTrue())
+		})
+
 		It("should return false when a Fargate profile matches kube-system but has labels", func() {
 			Expect(coredns.IsSchedulableOnFargate(profileNotSelectingCoreDNSBecauseOfLabels)).To(BeFalse())
 		})
@@ -0,0 +1,322 @@
+package fargate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ProfileLabel is the pod label used to choose a profile when a pod matches several Fargate profiles.
+const ProfileLabel = "eks.amazonaws.com/fargate-profile"
+
+const defaultNamespace = "default"
+
+// Workload is a pod template which can be matched against Fargate profiles.
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Labels    map[string]string
+	PodSpec   corev1.PodSpec
+}
+
+// Match is the result of matching a workload against Fargate profiles.
+type Match struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Profiles are the names of the Fargate profiles selecting the workload.
+	Profiles []string `json:"profiles,omitempty"`
+	// Issues explain why pods of the workload would not run on Fargate although they are selected by a profile.
+	Issues []string `json:"issues,omitempty"`
+}
+
+// IsMatched returns true if the workload is selected by at least one Fargate profile.
+func (m *Match) IsMatched() bool {
+	return len(m.Profiles) > 0
+}
+
+// MatchWorkloads evaluates each workload against the Fargate profiles the same way EKS does when
+// scheduling pods: a selector matches when the namespace matches and all of its labels are set on the pod.
+func MatchWorkloads(profiles []*api.FargateProfile, workloads []Workload) []Match {
+	var matches []Match
+	for _, w := range workloads {
+		match := Match{
+			Kind:      w.Kind,
+			Namespace: w.Namespace,
+			Name:      w.Name,
+			Profiles:  MatchingProfiles(profiles, w.Namespace, w.Labels),
+		}
+		if match.IsMatched() {
+			match.Issues = Incompatibilities(w)
+			if len(match.Profiles) > 1 {
+				if chosen, ok := w.Labels[ProfileLabel]; ok && contains(match.Profiles, chosen) {
+					match.Profiles = []string{chosen}
+				} else {
+					match.Issues = append(match.Issues, fmt.Sprintf("matches several profiles, one is picked at random unless label %s is set", ProfileLabel))
+				}
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// MatchingProfiles returns the names of the profiles with a selector matching the given namespace and pod labels.
+func MatchingProfiles(profiles []*api.FargateProfile, namespace string, labels map[string]string) []string {
+	var names []string
+	for _, profile := range profiles {
+		for _, selector := range profile.Selectors {
+			if selector.Matches(namespace, labels) {
+				names = append(names, profile.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Incompatibilities returns the reasons why pods of the workload cannot run on Fargate.
+func Incompatibilities(w Workload) []string {
+	var issues []string
+	spec := w.PodSpec
+	if w.Kind == "DaemonSet" {
+		issues = append(issues, "DaemonSets are not supported")
+	}
+	if spec.HostNetwork {
+		issues = append(issues, "hostNetwork is not supported")
+	}
+	if spec.HostPID || spec.HostIPC {
+		issues = append(issues, "hostPID and hostIPC are not supported")
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			issues = append(issues, fmt.Sprintf("hostPath volume %q is not supported", volume.Name))
+		}
+	}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		if sc := container.SecurityContext; sc != nil && sc.Privileged != nil && *sc.Privileged {
+			issues = append(issues, fmt.Sprintf("privileged container %q is not supported", container.Name))
+		}
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				issues = append(issues, fmt.Sprintf("hostPort %d of container %q is not supported", port.HostPort, container.Name))
+			}
+		}
+		for resource := range container.Resources.Limits {
+			if strings.HasSuffix(string(resource), "/gpu") {
+				issues = append(issues, fmt.Sprintf("GPU resource %q of container %q is not supported", resource, container.Name))
+			}
+		}
+	}
+	return issues
+}
+
+// ListWorkloads lists the workloads running in the cluster. Pods, ReplicaSets and Jobs owned by
+// another object are represented by their owner.
+func ListWorkloads(ctx context.Context, clientSet kubeclient.Interface) ([]Workload, error) {
+	var objects []runtime.Object
+	listOptions := metav1.ListOptions{}
+
+	deployments, err := clientSet.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing deployments")
+	}
+	for i := range deployments.Items {
+		objects = append(objects, &deployments.Items[i])
+	}
+	statefulSets, err := clientSet.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing statefulsets")
+	}
+	for i := range statefulSets.Items {
+		objects = append(objects, &statefulSets.Items[i])
+	}
+	daemonSets, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing daemonsets")
+	}
+	for i := range daemonSets.Items {
+		objects = append(objects, &daemonSets.Items[i])
+	}
+	replicaSets, err := clientSet.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing replicasets")
+	}
+	for i := range replicaSets.Items {
+		if len(replicaSets.Items[i].OwnerReferences) == 0 {
+			objects = append(objects, &replicaSets.Items[i])
+		}
+	}
+	cronJobs, err := clientSet.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing cronjobs")
+	}
+	for i := range cronJobs.Items {
+		objects = append(objects, &cronJobs.Items[i])
+	}
+	jobs, err := clientSet.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing jobs")
+	}
+	for i := range jobs.Items {
+		if len(jobs.Items[i].OwnerReferences) == 0 {
+			objects = append(objects, &jobs.Items[i])
+		}
+	}
+	pods, err := clientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pods")
+	}
+	for i := range pods.Items {
+		if len(pods.Items[i].OwnerReferences) == 0 {
+			objects = append(objects, &pods.Items[i])
+		}
+	}
+	return toWorkloads(objects), nil
+}
+
+// ReadWorkloads reads the workloads defined in the given manifest files. Directories are read
+// non-recursively, and objects which do not define pods are ignored.
+func ReadWorkloads(paths []string) ([]Workload, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	sort.Strings(files)
+
+	var objects []runtime.Object
+	for _, file := range files {
+		fileObjects, err := readObjects(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", file)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return toWorkloads(objects), nil
+}
+
+func readObjects(file string) ([]runtime.Object, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objects []runtime.Object
+	decoder := yamlutil.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		decoded, err := decodeObjects(raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+}
+
+func decodeObjects(raw []byte) ([]runtime.Object, error) {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(raw, nil, nil)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			logger.Debug("ignoring object: %v", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	list, ok := obj.(*corev1.List)
+	if !ok {
+		return []runtime.Object{obj}, nil
+	}
+	var objects []runtime.Object
+	for _, item := range list.Items {
+		decoded, err := decodeObjects(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+func toWorkloads(objects []runtime.Object) []Workload {
+	var workloads []Workload
+	for _, obj := range objects {
+		var (
+			kind     string
+			meta     metav1.ObjectMeta
+			template corev1.PodTemplateSpec
+		)
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			kind, meta, template = "Deployment", o.ObjectMeta, o.Spec.Template
+		case *appsv1.StatefulSet:
+			kind, meta, template = "StatefulSet", o.ObjectMeta, o.Spec.Template
+		case *appsv1.DaemonSet:
+			kind, meta, template = "DaemonSet", o.ObjectMeta, o.Spec.Template
+		case *appsv1.ReplicaSet:
+			kind, meta, template = "ReplicaSet", o.ObjectMeta, o.Spec.Template
+		case *batchv1.Job:
+			kind, meta, template = "Job", o.ObjectMeta, o.Spec.Template
+		case *batchv1.CronJob:
+			kind, meta, template = "CronJob", o.ObjectMeta, o.Spec.JobTemplate.Spec.Template
+		case *corev1.Pod:
+			kind, meta, template = "Pod", o.ObjectMeta, corev1.PodTemplateSpec{ObjectMeta: o.ObjectMeta, Spec: o.Spec}
+		default:
+			continue
+		}
+		namespace := meta.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		workloads = append(workloads, Workload{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      meta.Name,
+			Labels:    template.Labels,
+			PodSpec:   template.Spec,
+		})
+	}
+	return workloads
+}
@@ -0,0 +1,203 @@
+package fargate_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("MatchWorkloads", func() {
+	profiles := []*api.FargateProfile{
+		{
+			Name:      "fp-default",
+			Selectors: []api.FargateProfileSelector{{Namespace: "default"}, {Namespace: "kube-system"}},
+		},
+		{
+			Name: "fp-prod",
+			Selectors: []api.FargateProfileSelector{
+				{Namespace: "prod-*", Labels: map[string]string{"app": "web-?"}},
+			},
+		},
+		{
+			Name:      "fp-web",
+			Selectors: []api.FargateProfileSelector{{Namespace: "prod-eu", Labels: map[string]string{"tier": "frontend"}}},
+		},
+	}
+
+	match := func(w fargate.Workload) fargate.Match {
+		matches := fargate.MatchWorkloads(profiles, []fargate.Workload{w})
+		Expect(matches).To(HaveLen(1))
+		return matches[0]
+	}
+
+	It("matches selectors on namespace", func() {
+		m := match(fargate.Workload{Kind: "Deployment", Namespace: "kube-system", Name: "coredns"})
+		Expect(m.Profiles).To(Equal([]string{"fp-default"}))
+		Expect(m.Issues).To(BeEmpty())
+	})
+
+	It("matches selectors with wildcards on namespace and labels", func() {
+		m := match(fargate.Workload{Kind: "Deployment", Namespace: "prod-us", Name: "web", Labels: map[string]string{"app": "web-1"}})
+		Expect(m.Profiles).To(Equal([]string{"fp-prod"}))
+	})
+
+	It("requires all selector labels to be set on the pod", func() {
+		m := match(fargate.Workload{Kind: "Deployment", Namespace: "prod-us", Name: "api", Labels: map[string]string{"app": "api"}})
+		Expect(m.IsMatched()).To(BeFalse())
+		Expect(m.Issues).To(BeEmpty())
+	})
+
+	It("reports workloads matching several profiles", func() {
+		m := match(fargate.Workload{Kind: "Deployment", Namespace: "prod-eu", Name: "web", Labels: map[string]string{"app": "web-1", "tier": "frontend"}})
+		Expect(m.Profiles).To(Equal([]string{"fp-prod", "fp-web"}))
+		Expect(m.Issues).To(ConsistOf(ContainSubstring("matches several profiles")))
+	})
+
+	It("uses the profile label to choose between several profiles", func() {
+		m := match(fargate.Workload{Kind: "Deployment", Namespace: "prod-eu", Name: "web", Labels: map[string]string{
+			"app": "web-1", "tier": "frontend", fargate.ProfileLabel: "fp-web",
+		}})
+		Expect(m.Profiles).To(Equal([]string{"fp-web"}))
+		Expect(m.Issues).To(BeEmpty())
+	})
+
+	It("reports specs which are not supported by Fargate", func() {
+		privileged := true
+		m := match(fargate.Workload{
+			Kind:      "DaemonSet",
+			Namespace: "kube-system",
+			Name:      "agent",
+			PodSpec: corev1.PodSpec{
+				HostNetwork: true,
+				Volumes: []corev1.Volume{{
+					Name:         "logs",
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}},
+				}},
+				Containers: []corev1.Container{{
+					Name:            "agent",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+					Ports:           []corev1.ContainerPort{{ContainerPort: 80, HostPort: 80}},
+				}},
+			},
+		})
+		Expect(m.Profiles).To(Equal([]string{"fp-default"}))
+		Expect(m.Issues).To(ConsistOf(
+			"DaemonSets are not supported",
+			"hostNetwork is not supported",
+			`hostPath volume "logs" is not supported`,
+			`privileged container "agent" is not supported`,
+			`hostPort 80 of container "agent" is not supported`,
+		))
+	})
+
+	It("prints matches as a table", func() {
+		matches := fargate.MatchWorkloads(profiles, []fargate.Workload{
+			{Kind: "Deployment", Namespace: "kube-system", Name: "coredns"},
+			{Kind: "Deployment", Namespace: "other", Name: "api"},
+		})
+		out := &bytes.Buffer{}
+		Expect(fargate.PrintMatches(matches, out, printers.TableType)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("coredns"))
+		Expect(out.String()).To(MatchRegexp(`Deployment\s+other\s+api\s+<none>`))
+	})
+})
+
+var _ = Describe("ListWorkloads", func() {
+	It("lists workloads and ignores pods owned by them", func() {
+		owner := []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-123"}}
+		clientSet := fake.NewSimpleClientset(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+				Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				}},
+			},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-123-abc", Namespace: "prod", OwnerReferences: owner}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "prod"}},
+		)
+		workloads, err := fargate.ListWorkloads(context.Background(), clientSet)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workloads).To(HaveLen(2))
+		Expect(workloads[0].Kind).To(Equal("Deployment"))
+		Expect(workloads[0].Labels).To(Equal(map[string]string{"app": "web"}))
+		Expect(workloads[1].Kind).To(Equal("Pod"))
+		Expect(workloads[1].Name).To(Equal("debug"))
+	})
+})
+
+var _ = Describe("ReadWorkloads", func() {
+	It("reads workloads from manifests and ignores other objects", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: unknown
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: batch/v1
+  kind: CronJob
+  metadata:
+    name: report
+    namespace: jobs
+  spec:
+    schedule: "@daily"
+    jobTemplate:
+      spec:
+        template:
+          metadata:
+            labels:
+              job: report
+          spec:
+            containers:
+            - name: report
+              image: busybox
+`), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0644)).To(Succeed())
+
+		workloads, err := fargate.ReadWorkloads([]string{dir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workloads).To(HaveLen(2))
+		Expect(workloads[0].Kind).To(Equal("Deployment"))
+		Expect(workloads[0].Namespace).To(Equal("default"))
+		Expect(workloads[0].Labels).To(Equal(map[string]string{"app": "web"}))
+		Expect(workloads[1].Kind).To(Equal("CronJob"))
+		Expect(workloads[1].Namespace).To(Equal("jobs"))
+		Expect(workloads[1].Labels).To(Equal(map[string]string{"job": "report"}))
+	})
+})
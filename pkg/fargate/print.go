@@ -14,19 +14,17 @@ const (
 )
 
 // PrintProfiles formats the provided profiles in the provided printer type
-// ("table", "json", "yaml") and prints them to the provided writer.
+// (e.g. "table", "json", "yaml") and prints them to the provided writer.
 func PrintProfiles(profiles []*api.FargateProfile, writer io.Writer, printerType printers.Type) error {
 	printer, err := printers.NewPrinter(printerType)
 	if err != nil {
 		return err
 	}
-	switch printerType {
-	case printers.TableType:
-		addFargateProfileColumns(printer.(*printers.TablePrinter))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addFargateProfileColumns(tablePrinter)
 		return printer.PrintObjWithKind(kindFargateProfiles, toTable(profiles), writer)
-	default:
-		return printer.PrintObjWithKind(kindFargateProfiles, profiles, writer)
 	}
+	return printer.PrintObjWithKind(kindFargateProfiles, profiles, writer)
 }
 
 type row struct {
@@ -81,3 +79,42 @@ func addFargateProfileColumns(printer *printers.TablePrinter) {
 		return r.Status
 	})
 }
+
+const kindFargateMatches = "fargatematches"
+
+// PrintMatches formats the result of matching workloads against Fargate profiles in the provided
+// printer type (e.g. "table", "json", "yaml") and prints it to the provided writer.
+func PrintMatches(matches []Match, writer io.Writer, printerType printers.Type) error {
+	printer, err := printers.NewPrinter(printerType)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addMatchColumns(tablePrinter)
+	}
+	if matches == nil {
+		matches = []Match{}
+	}
+	return printer.PrintObjWithKind(kindFargateMatches, matches, writer)
+}
+
+func addMatchColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("KIND", func(m Match) string {
+		return m.Kind
+	})
+	printer.AddColumn("NAMESPACE", func(m Match) string {
+		return m.Namespace
+	})
+	printer.AddColumn("NAME", func(m Match) string {
+		return m.Name
+	})
+	printer.AddColumn("PROFILE", func(m Match) string {
+		if !m.IsMatched() {
+			return "<none>"
+		}
+		return strings.Join(m.Profiles, ",")
+	})
+	printer.AddColumn("ISSUES", func(m Match) string {
+		return strings.Join(m.Issues, "; ")
+	})
+}
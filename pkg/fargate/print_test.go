@@ -41,7 +41,7 @@ var _ = Describe("fargate", func() {
 			out := bytes.NewBufferString("")
 			err := fargate.PrintProfiles(profiles, out, "foo")
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("unknown output printer type: expected {\"yaml\",\"json\",\"table\"} but got \"foo\""))
+			Expect(err.Error()).To(Equal("unknown output printer type: expected one of table, wide, json, yaml, custom-columns=HEADER:JSONPATH,..., jsonpath=TEMPLATE, go-template=TEMPLATE but got \"foo\""))
 		})
 	})
 })
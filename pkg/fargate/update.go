@@ -0,0 +1,187 @@
+package fargate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/names"
+)
+
+const (
+	replacementSuffixLength = 5
+	replacementSuffixChars  = "abcdef0123456789"
+
+	// maxQueuedAttempts bounds the number of times an operation is retried when another profile operation started
+	// between waiting for pending operations and sending the request.
+	maxQueuedAttempts = 5
+)
+
+// ProfileName returns the name the profile was configured with. Temporary profiles created by
+// `eksctl update fargateprofile` carry a generated name, and record the configured one in a tag.
+func ProfileName(profile *api.FargateProfile) string {
+	if name, ok := profile.Tags[api.FargateProfileNameTag]; ok && name != "" {
+		return name
+	}
+	return profile.Name
+}
+
+// FindProfile returns the existing profile configured with the provided name, preferring the profile with that name
+// over temporary profiles, or nil if there is none.
+func FindProfile(profiles []*api.FargateProfile, name string) *api.FargateProfile {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	for _, profile := range profiles {
+		if ProfileName(profile) == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+// TemporaryProfiles returns the temporary profiles standing in for the profile configured with the provided name.
+func TemporaryProfiles(profiles []*api.FargateProfile, name string) []*api.FargateProfile {
+	var temporaries []*api.FargateProfile
+	for _, profile := range profiles {
+		if profile.Name != name && ProfileName(profile) == name {
+			temporaries = append(temporaries, profile)
+		}
+	}
+	return temporaries
+}
+
+// NewTemporaryProfile returns a copy of the desired profile with a name which does not conflict with the profile it
+// stands in for while that profile is recreated, since profiles are immutable and their names cannot be reused until
+// they are deleted.
+func NewTemporaryProfile(desired *api.FargateProfile) *api.FargateProfile {
+	name := ProfileName(desired)
+	temporary := *desired
+	temporary.Name = fmt.Sprintf("%s-%s", name, names.RandomName(replacementSuffixLength, replacementSuffixChars))
+	temporary.Tags = map[string]string{}
+	for k, v := range desired.Tags {
+		temporary.Tags[k] = v
+	}
+	temporary.Tags[api.FargateProfileNameTag] = name
+	temporary.Status = ""
+	return &temporary
+}
+
+// RequiresReplacement returns true if the existing profile differs from the desired one. Fields left empty in the
+// desired profile are computed by EKS and are ignored.
+func RequiresReplacement(current, desired *api.FargateProfile) bool {
+	if !reflect.DeepEqual(sortedSelectors(current.Selectors), sortedSelectors(desired.Selectors)) {
+		return true
+	}
+	if desired.PodExecutionRoleARN != "" && desired.PodExecutionRoleARN != current.PodExecutionRoleARN {
+		return true
+	}
+	if len(desired.Subnets) > 0 && !reflect.DeepEqual(sortedStrings(desired.Subnets), sortedStrings(current.Subnets)) {
+		return true
+	}
+	for k, v := range desired.Tags {
+		if current.Tags[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSelectors(selectors []api.FargateProfileSelector) []api.FargateProfileSelector {
+	out := make([]api.FargateProfileSelector, len(selectors))
+	for i, selector := range selectors {
+		out[i] = api.FargateProfileSelector{Namespace: selector.Namespace}
+		if len(selector.Labels) > 0 {
+			out[i].Labels = selector.Labels
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return fmt.Sprint(out[i]) < fmt.Sprint(out[j])
+	})
+	return out
+}
+
+func sortedStrings(values []string) []string {
+	out := append([]string{}, values...)
+	sort.Strings(out)
+	return out
+}
+
+// WaitForPendingOperations waits until no Fargate profile of the cluster is being created or deleted, as EKS only
+// processes one Fargate profile operation per cluster at a time.
+func (c *Client) WaitForPendingOperations(ctx context.Context) error {
+	// Clone this client's policy to ensure this method is re-entrant/thread-safe:
+	retryPolicy := c.retryPolicy.Clone()
+	for !retryPolicy.Done() {
+		profiles, err := c.ReadProfiles(ctx)
+		if err != nil {
+			return err
+		}
+		pending := pendingProfile(profiles)
+		if pending == nil {
+			return nil
+		}
+		logger.Info("waiting for Fargate profile %q to leave status %s", pending.Name, pending.Status)
+
+		timer := time.NewTimer(retryPolicy.Duration())
+		select {
+		case <-timer.C:
+
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	return errors.New("timed out while waiting for pending Fargate profile operations")
+}
+
+func pendingProfile(profiles []*api.FargateProfile) *api.FargateProfile {
+	for _, profile := range profiles {
+		switch ekstypes.FargateProfileStatus(profile.Status) {
+		case ekstypes.FargateProfileStatusCreating, ekstypes.FargateProfileStatusDeleting:
+			return profile
+		}
+	}
+	return nil
+}
+
+// CreateProfileWhenIdle creates the profile once no other profile operation is in progress, and waits for it to
+// become active.
+func (c *Client) CreateProfileWhenIdle(ctx context.Context, profile *api.FargateProfile) error {
+	return c.whenIdle(ctx, func() error {
+		return c.CreateProfile(ctx, profile, true)
+	})
+}
+
+// DeleteProfileWhenIdle deletes the profile once no other profile operation is in progress, and waits for its
+// deletion.
+func (c *Client) DeleteProfileWhenIdle(ctx context.Context, name string) error {
+	return c.whenIdle(ctx, func() error {
+		return c.DeleteProfile(ctx, name, true)
+	})
+}
+
+func (c *Client) whenIdle(ctx context.Context, op func() error) error {
+	var err error
+	for attempt := 0; attempt < maxQueuedAttempts; attempt++ {
+		if err = c.WaitForPendingOperations(ctx); err != nil {
+			return err
+		}
+		err = op()
+		var resourceInUse *ekstypes.ResourceInUseException
+		if !errors.As(err, &resourceInUse) {
+			return err
+		}
+		logger.Debug("another Fargate profile operation is in progress, retrying: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+package fargate_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/utils/retry"
+)
+
+var _ = Describe("Update", func() {
+	Describe("RequiresReplacement", func() {
+		current := &api.FargateProfile{
+			Name:                "fp-dev-1a2b3",
+			PodExecutionRoleARN: "arn:aws:iam::123:role/fargate",
+			Subnets:             []string{"subnet-1", "subnet-2"},
+			Selectors: []api.FargateProfileSelector{
+				{Namespace: "dev", Labels: map[string]string{"app": "web"}},
+				{Namespace: "kube-system", Labels: map[string]string{}},
+			},
+			Tags: map[string]string{api.FargateProfileNameTag: "fp-dev", "team": "a"},
+		}
+
+		DescribeTable("compares the desired profile with the existing one", func(desired *api.FargateProfile, expected bool) {
+			Expect(fargate.RequiresReplacement(current, desired)).To(Equal(expected))
+		},
+			Entry("same selectors in a different order", &api.FargateProfile{
+				Name: "fp-dev",
+				Selectors: []api.FargateProfileSelector{
+					{Namespace: "kube-system"},
+					{Namespace: "dev", Labels: map[string]string{"app": "web"}},
+				},
+			}, false),
+			Entry("same subnets and tags", &api.FargateProfile{
+				Name:    "fp-dev",
+				Subnets: []string{"subnet-2", "subnet-1"},
+				Tags:    map[string]string{"team": "a"},
+				Selectors: []api.FargateProfileSelector{
+					{Namespace: "dev", Labels: map[string]string{"app": "web"}},
+					{Namespace: "kube-system"},
+				},
+			}, false),
+			Entry("different selectors", &api.FargateProfile{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "dev"}},
+			}, true),
+			Entry("different role", &api.FargateProfile{
+				Name:                "fp-dev",
+				PodExecutionRoleARN: "arn:aws:iam::123:role/other",
+				Selectors:           current.Selectors,
+			}, true),
+			Entry("different tags", &api.FargateProfile{
+				Name:      "fp-dev",
+				Tags:      map[string]string{"team": "b"},
+				Selectors: current.Selectors,
+			}, true),
+		)
+	})
+
+	It("names temporary profiles after the configured profile", func() {
+		desired := &api.FargateProfile{
+			Name:      "fp-dev",
+			Selectors: []api.FargateProfileSelector{{Namespace: "dev"}},
+			Tags:      map[string]string{"team": "a"},
+		}
+		temporary := fargate.NewTemporaryProfile(desired)
+		Expect(temporary.Name).To(MatchRegexp(`^fp-dev-[a-f0-9]{5}$`))
+		Expect(temporary.Tags).To(Equal(map[string]string{"team": "a", api.FargateProfileNameTag: "fp-dev"}))
+		Expect(desired.Tags).To(Equal(map[string]string{"team": "a"}))
+		Expect(fargate.ProfileName(temporary)).To(Equal("fp-dev"))
+		Expect(fargate.FindProfile([]*api.FargateProfile{temporary}, "fp-dev")).To(Equal(temporary))
+		Expect(fargate.FindProfile([]*api.FargateProfile{temporary, desired}, "fp-dev")).To(Equal(desired))
+		Expect(fargate.TemporaryProfiles([]*api.FargateProfile{temporary, desired}, "fp-dev")).To(Equal([]*api.FargateProfile{temporary}))
+
+		Expect(fargate.NewTemporaryProfile(temporary).Name).To(MatchRegexp(`^fp-dev-[a-f0-9]{5}$`))
+	})
+
+	Describe("CreateProfileWhenIdle", func() {
+		var (
+			mockClient  *mocksv2.EKS
+			retryPolicy *retry.ConstantBackoff
+			profile     *api.FargateProfile
+		)
+
+		BeforeEach(func() {
+			mockClient = &mocksv2.EKS{}
+			retryPolicy = &retry.ConstantBackoff{MaxRetries: 5, Time: 1, TimeUnit: time.Millisecond}
+			profile = apiFargateProfile(testGreen)
+			profile.Status = ""
+		})
+
+		It("waits for pending profile operations before creating the profile", func() {
+			mockListFargateProfiles(mockClient, testBlue)
+			mockDescribeFargateProfile(mockClient, testBlue, string(ekstypes.FargateProfileStatusDeleting))
+			mockListFargateProfiles(mockClient)
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, mock.Anything).Return(&eks.CreateFargateProfileOutput{}, nil).Once()
+			mockDescribeFargateProfile(mockClient, testGreen, string(ekstypes.FargateProfileStatusActive))
+
+			client := fargate.NewWithRetryPolicy(clusterName, mockClient, retryPolicy, (*manager.StackCollection)(nil))
+			Expect(client.CreateProfileWhenIdle(context.Background(), profile)).To(Succeed())
+			mockClient.AssertNumberOfCalls(GinkgoT(), "ListFargateProfiles", 2)
+			mockClient.AssertNumberOfCalls(GinkgoT(), "CreateFargateProfile", 1)
+		})
+
+		It("retries when another profile operation started in the meantime", func() {
+			mockListFargateProfiles(mockClient)
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceInUseException{
+				Message: aws.String("Cannot create Fargate Profile because cluster currently has Fargate profile in status CREATING"),
+			}).Once()
+			mockListFargateProfiles(mockClient)
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, mock.Anything).Return(&eks.CreateFargateProfileOutput{}, nil).Once()
+			mockDescribeFargateProfile(mockClient, testGreen, string(ekstypes.FargateProfileStatusActive))
+
+			client := fargate.NewWithRetryPolicy(clusterName, mockClient, retryPolicy, (*manager.StackCollection)(nil))
+			Expect(client.CreateProfileWhenIdle(context.Background(), profile)).To(Succeed())
+			mockClient.AssertNumberOfCalls(GinkgoT(), "CreateFargateProfile", 2)
+		})
+
+		It("times out if operations stay pending", func() {
+			for i := 0; i < 5; i++ {
+				mockListFargateProfiles(mockClient, testBlue)
+				mockDescribeFargateProfile(mockClient, testBlue, string(ekstypes.FargateProfileStatusCreating))
+			}
+
+			client := fargate.NewWithRetryPolicy(clusterName, mockClient, retryPolicy, (*manager.StackCollection)(nil))
+			Expect(client.CreateProfileWhenIdle(context.Background(), profile)).To(MatchError("timed out while waiting for pending Fargate profile operations"))
+			mockClient.AssertNotCalled(GinkgoT(), "CreateFargateProfile", mock.Anything, mock.Anything)
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	autoModeNodeClassGroup = "eks.amazonaws.com"
+	autoModeNodeClassKind  = "NodeClass"
+)
+
+var (
+	// AutoModeNodePoolResource is the resource of NodePools in clusters with EKS Auto Mode.
+	AutoModeNodePoolResource = schema.GroupVersionResource{Group: nodePoolGroup, Version: "v1", Resource: "nodepools"}
+	// AutoModeNodeClassResource is the resource of NodeClasses in clusters with EKS Auto Mode.
+	AutoModeNodeClassResource = schema.GroupVersionResource{Group: autoModeNodeClassGroup, Version: "v1", Resource: "nodeclasses"}
+)
+
+// AutoModeNodeClass holds the settings of an EKS Auto Mode NodeClass.
+type AutoModeNodeClass struct {
+	Name string
+	// Role is the name of the IAM role assumed by nodes.
+	Role      string
+	SubnetIDs []string
+	// EphemeralStorageSize is the size of the node's ephemeral storage in GiB.
+	EphemeralStorageSize *int
+}
+
+// NewAutoModeNodeClass builds an EKS Auto Mode NodeClass object.
+func NewAutoModeNodeClass(nc AutoModeNodeClass) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"role": nc.Role,
+	}
+	if len(nc.SubnetIDs) > 0 {
+		var terms []api.KarpenterSelectorTerm
+		for _, subnetID := range nc.SubnetIDs {
+			terms = append(terms, api.KarpenterSelectorTerm{ID: subnetID})
+		}
+		spec["subnetSelectorTerms"] = selectorTerms(terms)
+	}
+	if nc.EphemeralStorageSize != nil {
+		spec["ephemeralStorage"] = map[string]interface{}{
+			"size": fmt.Sprintf("%dGi", *nc.EphemeralStorageSize),
+		}
+	}
+	return newObject(AutoModeNodeClassResource, autoModeNodeClassKind, nc.Name, spec)
+}
+
+// NewAutoModeNodePool builds a NodePool object for a cluster with EKS Auto Mode.
+func NewAutoModeNodePool(np *api.KarpenterNodePool) *unstructured.Unstructured {
+	nodeClassRef := map[string]interface{}{
+		"group": autoModeNodeClassGroup,
+		"kind":  autoModeNodeClassKind,
+		"name":  np.NodeClass,
+	}
+	return newNodePool(AutoModeNodePoolResource, np, nodeClassRef, true)
+}
+
+// CreateAutoModeNodePool creates the NodeClass, if any, and the NodePool, skipping objects that already exist.
+func CreateAutoModeNodePool(ctx context.Context, client dynamic.Interface, nodeClass, nodePool *unstructured.Unstructured) error {
+	if nodeClass != nil {
+		if err := createIfNotExists(ctx, client, AutoModeNodeClassResource, nodeClass); err != nil {
+			return err
+		}
+	}
+	return createIfNotExists(ctx, client, AutoModeNodePoolResource, nodePool)
+}
+
+func createIfNotExists(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+	if _, err := client.Resource(gvr).Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			logger.Info("%s %q already exists", obj.GetKind(), obj.GetName())
+			return nil
+		}
+		return fmt.Errorf("creating %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	logger.Info("created %s %q", obj.GetKind(), obj.GetName())
+	return nil
+}
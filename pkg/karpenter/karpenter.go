@@ -18,13 +18,16 @@ const (
 	// DefaultServiceAccountName is the name of the service account which is needed for Karpenter
 	DefaultServiceAccountName = "karpenter"
 
-	aws                      = "aws"
-	clusterEndpoint          = "clusterEndpoint"
-	clusterName              = "clusterName"
-	create                   = "create"
-	defaultInstanceProfile   = "defaultInstanceProfile"
-	helmChartName            = "oci://public.ecr.aws/karpenter/karpenter"
-	releaseName              = "karpenter"
+	aws                    = "aws"
+	clusterEndpoint        = "clusterEndpoint"
+	clusterName            = "clusterName"
+	create                 = "create"
+	defaultInstanceProfile = "defaultInstanceProfile"
+	helmChartName          = "oci://public.ecr.aws/karpenter/karpenter"
+	crdHelmChartName       = "oci://public.ecr.aws/karpenter/karpenter-crd"
+	releaseName            = "karpenter"
+	// CRDReleaseName is the name of the Helm release owning the Karpenter CRDs
+	CRDReleaseName           = "karpenter-crd"
 	serviceAccount           = "serviceAccount"
 	serviceAccountAnnotation = "annotations"
 	serviceAccountName       = "name"
@@ -45,6 +48,8 @@ type Options struct {
 //counterfeiter:generate -o fakes/fake_chart_installer.go . ChartInstaller
 type ChartInstaller interface {
 	Install(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Uninstall(ctx context.Context) error
 }
 
 // Installer implements the Karpenter installer functionality.
@@ -64,6 +69,55 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 	logger.Info("adding Karpenter to cluster %s", k.ClusterConfig.Metadata.Name)
 	logger.Debug("cluster endpoint used by Karpenter: %s", k.ClusterConfig.Status.Endpoint)
 
+	options, err := k.chartOptions(helmChartName, releaseName, k.values(serviceAccountRoleARN, instanceProfileName))
+	if err != nil {
+		return err
+	}
+	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
+
+	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to install Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// Upgrade upgrades the Karpenter CRDs and then the Karpenter chart to the configured version.
+// Helm does not upgrade CRDs shipped with a chart, so they are managed by a separate release
+// of the karpenter-crd chart.
+func (k *Installer) Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error {
+	logger.Info("upgrading Karpenter CRDs in cluster %s to version %s", k.ClusterConfig.Metadata.Name, k.ClusterConfig.Karpenter.Version)
+	crdOptions, err := k.chartOptions(crdHelmChartName, CRDReleaseName, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	if err := k.HelmInstaller.UpgradeChart(ctx, crdOptions); err != nil {
+		return fmt.Errorf("failed to upgrade Karpenter CRD chart: %w", err)
+	}
+
+	logger.Info("upgrading Karpenter in cluster %s to version %s", k.ClusterConfig.Metadata.Name, k.ClusterConfig.Karpenter.Version)
+	options, err := k.chartOptions(helmChartName, releaseName, k.values(serviceAccountRoleARN, instanceProfileName))
+	if err != nil {
+		return err
+	}
+	logger.Debug("the following chartOptions will be applied to the upgrade: %+v", options)
+	if err := k.HelmInstaller.UpgradeChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to upgrade Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// Uninstall removes the Karpenter chart and the CRD chart, if any, from the cluster.
+func (k *Installer) Uninstall(ctx context.Context) error {
+	logger.Info("uninstalling Karpenter from cluster %s", k.ClusterConfig.Metadata.Name)
+	for _, name := range []string{releaseName, CRDReleaseName} {
+		if err := k.HelmInstaller.UninstallChart(ctx, name); err != nil {
+			return fmt.Errorf("failed to uninstall Karpenter: %w", err)
+		}
+	}
+	return nil
+}
+
+func (k *Installer) values(serviceAccountRoleARN string, instanceProfileName string) map[string]interface{} {
 	serviceAccountMap := map[string]interface{}{
 		create: api.IsEnabled(k.ClusterConfig.Karpenter.CreateServiceAccount),
 		serviceAccountAnnotation: map[string]interface{}{
@@ -87,35 +141,30 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 		serviceAccount: serviceAccountMap,
 	}
 
-	version := k.ClusterConfig.Karpenter.Version
-	compareVersions, err := utils.CompareVersions(version, "0.33.0")
+	compareVersions, err := utils.CompareVersions(k.ClusterConfig.Karpenter.Version, "0.33.0")
 	if err == nil && compareVersions < 0 {
 		values[settings] = map[string]interface{}{
 			aws: values[settings],
 		}
 	}
+	return values
+}
 
+func (k *Installer) chartOptions(chartName, release string, values map[string]interface{}) (providers.InstallChartOpts, error) {
 	registryClient, err := registry.NewClient(
 		registry.ClientOptEnableCache(true),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create registry client: %w", err)
+		return providers.InstallChartOpts{}, fmt.Errorf("failed to create registry client: %w", err)
 	}
 
-	options := providers.InstallChartOpts{
-		ChartName:       helmChartName,
+	return providers.InstallChartOpts{
+		ChartName:       chartName,
 		CreateNamespace: true,
 		Namespace:       DefaultNamespace,
-		ReleaseName:     releaseName,
+		ReleaseName:     release,
 		Values:          values,
-		Version:         version,
+		Version:         k.ClusterConfig.Karpenter.Version,
 		RegistryClient:  registryClient,
-	}
-
-	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
-
-	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
-		return fmt.Errorf("failed to install Karpenter chart: %w", err)
-	}
-	return nil
+	}, nil
 }
@@ -99,7 +99,6 @@ var _ = Describe("Install", func() {
 		When("install chart fails", func() {
 
 			BeforeEach(func() {
-				fakeHelmInstaller.AddRepoReturns(nil)
 				fakeHelmInstaller.InstallChartReturns(errors.New("nope"))
 			})
 
@@ -139,4 +138,61 @@ var _ = Describe("Install", func() {
 			})
 		})
 	})
+
+	Context("Upgrade", func() {
+		var (
+			fakeHelmInstaller  *fakes.FakeHelmInstaller
+			installerUnderTest *Installer
+			cfg                *api.ClusterConfig
+		)
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "test-cluster"
+			cfg.Karpenter = &api.Karpenter{
+				Version: "1.0.6",
+			}
+			cfg.Status = &api.ClusterStatus{
+				Endpoint: "https://endpoint.com",
+			}
+			fakeHelmInstaller = &fakes.FakeHelmInstaller{}
+			installerUnderTest = NewKarpenterInstaller(Options{
+				HelmInstaller: fakeHelmInstaller,
+				Namespace:     DefaultNamespace,
+				ClusterConfig: cfg,
+			})
+		})
+
+		It("upgrades the CRD chart before the Karpenter chart", func() {
+			Expect(installerUnderTest.Upgrade(context.Background(), "role/account", "role/profile")).To(Succeed())
+			Expect(fakeHelmInstaller.UpgradeChartCallCount()).To(Equal(2))
+
+			_, crdOpts := fakeHelmInstaller.UpgradeChartArgsForCall(0)
+			Expect(crdOpts.ChartName).To(Equal(crdHelmChartName))
+			Expect(crdOpts.ReleaseName).To(Equal(CRDReleaseName))
+			Expect(crdOpts.Version).To(Equal("1.0.6"))
+
+			_, opts := fakeHelmInstaller.UpgradeChartArgsForCall(1)
+			Expect(opts.ChartName).To(Equal(helmChartName))
+			Expect(opts.ReleaseName).To(Equal(releaseName))
+			Expect(opts.Version).To(Equal("1.0.6"))
+			Expect(opts.Values[settings]).To(HaveKeyWithValue(clusterName, cfg.Metadata.Name))
+		})
+
+		It("does not upgrade Karpenter when the CRD upgrade fails", func() {
+			fakeHelmInstaller.UpgradeChartReturnsOnCall(0, errors.New("nope"))
+			Expect(installerUnderTest.Upgrade(context.Background(), "role/account", "role/profile")).
+				To(MatchError(ContainSubstring("failed to upgrade Karpenter CRD chart: nope")))
+			Expect(fakeHelmInstaller.UpgradeChartCallCount()).To(Equal(1))
+		})
+
+		It("uninstalls the Karpenter and CRD releases", func() {
+			Expect(installerUnderTest.Uninstall(context.Background())).To(Succeed())
+			Expect(fakeHelmInstaller.UninstallChartCallCount()).To(Equal(2))
+			_, name := fakeHelmInstaller.UninstallChartArgsForCall(0)
+			Expect(name).To(Equal(releaseName))
+			_, name = fakeHelmInstaller.UninstallChartArgsForCall(1)
+			Expect(name).To(Equal(CRDReleaseName))
+		})
+	})
 })
@@ -0,0 +1,463 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+const (
+	nodePoolGroup  = "karpenter.sh"
+	nodeClassGroup = "karpenter.k8s.aws"
+
+	nodePoolKind  = "NodePool"
+	nodeClassKind = "EC2NodeClass"
+
+	managedByLabel      = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "eksctl"
+
+	clusterSecurityGroupTag = "aws:eks:cluster-name"
+	subnetClusterTag        = "kubernetes.io/cluster/%s"
+)
+
+// NodePoolResources returns the GroupVersionResources of NodePools and EC2NodeClasses
+// served by the given Karpenter version.
+func NodePoolResources(karpenterVersion string) (nodePools, nodeClasses schema.GroupVersionResource, err error) {
+	isV1, err := api.IsKarpenterV1(karpenterVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, schema.GroupVersionResource{}, err
+	}
+	apiVersion := "v1beta1"
+	if isV1 {
+		apiVersion = "v1"
+	}
+	return schema.GroupVersionResource{Group: nodePoolGroup, Version: apiVersion, Resource: "nodepools"},
+		schema.GroupVersionResource{Group: nodeClassGroup, Version: apiVersion, Resource: "ec2nodeclasses"},
+		nil
+}
+
+// NewNodeClass builds an EC2NodeClass object for the given NodeClass, defaulting
+// the node role, subnets and security groups from the cluster config.
+func NewNodeClass(cfg *api.ClusterConfig, nc *api.KarpenterNodeClass) (*unstructured.Unstructured, error) {
+	_, gvr, err := NodePoolResources(cfg.Karpenter.Version)
+	if err != nil {
+		return nil, err
+	}
+	isV1 := gvr.Version == "v1"
+
+	spec := map[string]interface{}{}
+	switch {
+	case nc.InstanceProfile != "":
+		spec["instanceProfile"] = nc.InstanceProfile
+	case nc.Role != "":
+		spec["role"] = nc.Role
+	default:
+		spec["role"] = fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeRoleName, cfg.Metadata.Name)
+	}
+
+	if len(nc.AMISelectorTerms) > 0 {
+		spec["amiSelectorTerms"] = selectorTerms(nc.AMISelectorTerms)
+	} else if isV1 {
+		spec["amiSelectorTerms"] = []interface{}{
+			map[string]interface{}{"alias": strings.ToLower(nc.AMIFamily) + "@latest"},
+		}
+	}
+	if !isV1 || len(nc.AMISelectorTerms) > 0 {
+		spec["amiFamily"] = nc.AMIFamily
+	}
+
+	subnetTerms := nc.SubnetSelectorTerms
+	if len(subnetTerms) == 0 {
+		subnetTerms = defaultSubnetSelectorTerms(cfg)
+	}
+	spec["subnetSelectorTerms"] = selectorTerms(subnetTerms)
+
+	securityGroupTerms := nc.SecurityGroupSelectorTerms
+	if len(securityGroupTerms) == 0 {
+		securityGroupTerms = []api.KarpenterSelectorTerm{
+			{Tags: map[string]string{clusterSecurityGroupTag: cfg.Metadata.Name}},
+		}
+	}
+	spec["securityGroupSelectorTerms"] = selectorTerms(securityGroupTerms)
+
+	if nc.VolumeSize != nil {
+		deviceName := "/dev/xvda"
+		if nc.AMIFamily == api.KarpenterAMIFamilyBottlerocket {
+			deviceName = "/dev/xvdb"
+		}
+		ebs := map[string]interface{}{
+			"volumeSize": fmt.Sprintf("%dGi", *nc.VolumeSize),
+			"encrypted":  true,
+		}
+		if nc.VolumeType != nil {
+			ebs["volumeType"] = *nc.VolumeType
+		}
+		spec["blockDeviceMappings"] = []interface{}{
+			map[string]interface{}{
+				"deviceName": deviceName,
+				"ebs":        ebs,
+			},
+		}
+	}
+
+	if len(nc.Tags) > 0 {
+		spec["tags"] = stringMap(nc.Tags)
+	}
+
+	return newObject(gvr, nodeClassKind, nc.Name, spec), nil
+}
+
+// NewNodePool builds a NodePool object for the given NodePool.
+func NewNodePool(cfg *api.ClusterConfig, np *api.KarpenterNodePool) (*unstructured.Unstructured, error) {
+	gvr, nodeClassGVR, err := NodePoolResources(cfg.Karpenter.Version)
+	if err != nil {
+		return nil, err
+	}
+	isV1 := gvr.Version == "v1"
+
+	nodeClassRef := map[string]interface{}{
+		"kind": nodeClassKind,
+		"name": np.NodeClass,
+	}
+	if isV1 {
+		nodeClassRef["group"] = nodeClassGroup
+	} else {
+		nodeClassRef["apiVersion"] = nodeClassGVR.GroupVersion().String()
+	}
+	return newNodePool(gvr, np, nodeClassRef, isV1), nil
+}
+
+func newNodePool(gvr schema.GroupVersionResource, np *api.KarpenterNodePool, nodeClassRef map[string]interface{}, isV1 bool) *unstructured.Unstructured {
+	var requirements []interface{}
+	for _, r := range np.Requirements {
+		requirement := map[string]interface{}{
+			"key":      r.Key,
+			"operator": r.Operator,
+		}
+		if len(r.Values) > 0 {
+			values := make([]interface{}, 0, len(r.Values))
+			for _, v := range r.Values {
+				values = append(values, v)
+			}
+			requirement["values"] = values
+		}
+		requirements = append(requirements, requirement)
+	}
+
+	templateSpec := map[string]interface{}{
+		"nodeClassRef": nodeClassRef,
+		"requirements": requirements,
+	}
+	if len(np.Taints) > 0 {
+		var taints []interface{}
+		for _, t := range np.Taints {
+			taint := map[string]interface{}{
+				"key":    t.Key,
+				"effect": string(t.Effect),
+			}
+			if t.Value != "" {
+				taint["value"] = t.Value
+			}
+			taints = append(taints, taint)
+		}
+		templateSpec["taints"] = taints
+	}
+
+	template := map[string]interface{}{
+		"spec": templateSpec,
+	}
+	if len(np.Labels) > 0 {
+		template["metadata"] = map[string]interface{}{
+			"labels": stringMap(np.Labels),
+		}
+	}
+
+	spec := map[string]interface{}{
+		"template": template,
+	}
+	if len(np.Limits) > 0 {
+		spec["limits"] = stringMap(np.Limits)
+	}
+	if np.Weight != nil {
+		spec["weight"] = int64(*np.Weight)
+	}
+	if d := np.Disruption; d != nil {
+		disruption := map[string]interface{}{}
+		if d.ConsolidationPolicy != "" {
+			disruption["consolidationPolicy"] = d.ConsolidationPolicy
+		}
+		if d.ConsolidateAfter != "" {
+			disruption["consolidateAfter"] = d.ConsolidateAfter
+		}
+		if d.ExpireAfter != "" {
+			if isV1 {
+				templateSpec["expireAfter"] = d.ExpireAfter
+			} else {
+				disruption["expireAfter"] = d.ExpireAfter
+			}
+		}
+		if len(disruption) > 0 {
+			spec["disruption"] = disruption
+		}
+	}
+
+	return newObject(gvr, nodePoolKind, np.Name, spec)
+}
+
+func defaultSubnetSelectorTerms(cfg *api.ClusterConfig) []api.KarpenterSelectorTerm {
+	if cfg.VPC != nil {
+		subnets := cfg.VPC.Subnets.Private
+		if len(subnets) == 0 {
+			subnets = cfg.VPC.Subnets.Public
+		}
+		var terms []api.KarpenterSelectorTerm
+		for _, subnet := range subnets.WithIDs() {
+			terms = append(terms, api.KarpenterSelectorTerm{ID: subnet})
+		}
+		if len(terms) > 0 {
+			return terms
+		}
+	}
+	return []api.KarpenterSelectorTerm{
+		{Tags: map[string]string{fmt.Sprintf(subnetClusterTag, cfg.Metadata.Name): "*"}},
+	}
+}
+
+func selectorTerms(terms []api.KarpenterSelectorTerm) []interface{} {
+	var ret []interface{}
+	for _, t := range terms {
+		term := map[string]interface{}{}
+		if t.ID != "" {
+			term["id"] = t.ID
+		}
+		if t.Name != "" {
+			term["name"] = t.Name
+		}
+		if len(t.Tags) > 0 {
+			term["tags"] = stringMap(t.Tags)
+		}
+		ret = append(ret, term)
+	}
+	return ret
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	ret := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func newObject(gvr schema.GroupVersionResource, kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	obj.SetAPIVersion(gvr.GroupVersion().String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{managedByLabel: managedByLabelValue})
+	return obj
+}
+
+// NodePoolManager creates, updates and deletes Karpenter NodePools and EC2NodeClasses.
+type NodePoolManager struct {
+	client        dynamic.Interface
+	clusterConfig *api.ClusterConfig
+}
+
+// NewNodePoolManager creates a new NodePoolManager.
+func NewNodePoolManager(client dynamic.Interface, clusterConfig *api.ClusterConfig) *NodePoolManager {
+	return &NodePoolManager{
+		client:        client,
+		clusterConfig: clusterConfig,
+	}
+}
+
+// Create creates the NodeClasses and NodePools defined in the cluster config, skipping those that already exist.
+func (m *NodePoolManager) Create(ctx context.Context) error {
+	return m.apply(ctx, false)
+}
+
+// Update creates or updates the NodeClasses and NodePools defined in the cluster config.
+func (m *NodePoolManager) Update(ctx context.Context) error {
+	return m.apply(ctx, true)
+}
+
+func (m *NodePoolManager) apply(ctx context.Context, update bool) error {
+	nodePoolGVR, nodeClassGVR, err := NodePoolResources(m.clusterConfig.Karpenter.Version)
+	if err != nil {
+		return err
+	}
+	// NodeClasses are applied first, as NodePools cannot launch nodes until the referenced NodeClass exists.
+	for _, nc := range m.clusterConfig.Karpenter.NodeClasses {
+		obj, err := NewNodeClass(m.clusterConfig, nc)
+		if err != nil {
+			return err
+		}
+		if err := m.applyObject(ctx, nodeClassGVR, obj, update); err != nil {
+			return err
+		}
+	}
+	for _, np := range m.clusterConfig.Karpenter.NodePools {
+		obj, err := NewNodePool(m.clusterConfig, np)
+		if err != nil {
+			return err
+		}
+		if err := m.applyObject(ctx, nodePoolGVR, obj, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *NodePoolManager) applyObject(ctx context.Context, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, update bool) error {
+	resourceClient := m.client.Resource(gvr)
+	existing, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("getting %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if _, err := resourceClient.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		logger.Info("created %s %q", obj.GetKind(), obj.GetName())
+		return nil
+	}
+
+	if !update {
+		logger.Info("%s %q already exists", obj.GetKind(), obj.GetName())
+		return nil
+	}
+	if existing.GetLabels()[managedByLabel] != managedByLabelValue {
+		logger.Warning("%s %q is not managed by eksctl, skipping update", obj.GetKind(), obj.GetName())
+		return nil
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if _, err := resourceClient.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	logger.Info("updated %s %q", obj.GetKind(), obj.GetName())
+	return nil
+}
+
+// Delete deletes the given NodePools and then the given NodeClasses. Karpenter drains
+// and terminates the nodes launched by deleted NodePools.
+func (m *NodePoolManager) Delete(ctx context.Context, nodePoolNames, nodeClassNames []string) error {
+	nodePoolGVR, nodeClassGVR, err := NodePoolResources(m.clusterConfig.Karpenter.Version)
+	if err != nil {
+		return err
+	}
+	for _, name := range nodePoolNames {
+		if err := m.deleteObject(ctx, nodePoolGVR, nodePoolKind, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range nodeClassNames {
+		if err := m.deleteObject(ctx, nodeClassGVR, nodeClassKind, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resources are the names of NodePools and NodeClasses.
+type Resources struct {
+	NodePools   []string
+	NodeClasses []string
+}
+
+// IsEmpty returns true if there are no NodePools and no NodeClasses.
+func (r Resources) IsEmpty() bool {
+	return len(r.NodePools) == 0 && len(r.NodeClasses) == 0
+}
+
+// List returns the NodePools and NodeClasses of the cluster, split into those created by eksctl and the others.
+func (m *NodePoolManager) List(ctx context.Context) (managed, unmanaged Resources, err error) {
+	nodePoolGVR, nodeClassGVR, err := NodePoolResources(m.clusterConfig.Karpenter.Version)
+	if err != nil {
+		return Resources{}, Resources{}, err
+	}
+	if managed.NodePools, unmanaged.NodePools, err = m.listNames(ctx, nodePoolGVR); err != nil {
+		return Resources{}, Resources{}, err
+	}
+	if managed.NodeClasses, unmanaged.NodeClasses, err = m.listNames(ctx, nodeClassGVR); err != nil {
+		return Resources{}, Resources{}, err
+	}
+	return managed, unmanaged, nil
+}
+
+func (m *NodePoolManager) listNames(ctx context.Context, gvr schema.GroupVersionResource) (managed, unmanaged []string, err error) {
+	list, err := m.client.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("listing %s: %w", gvr.Resource, err)
+	}
+	for _, item := range list.Items {
+		if item.GetLabels()[managedByLabel] == managedByLabelValue {
+			managed = append(managed, item.GetName())
+		} else {
+			unmanaged = append(unmanaged, item.GetName())
+		}
+	}
+	return managed, unmanaged, nil
+}
+
+func (m *NodePoolManager) deleteObject(ctx context.Context, gvr schema.GroupVersionResource, kind, name string) error {
+	if err := m.client.Resource(gvr).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("%s %q does not exist", kind, name)
+			return nil
+		}
+		return fmt.Errorf("deleting %s %q: %w", kind, name, err)
+	}
+	logger.Info("deleted %s %q", kind, name)
+	return nil
+}
+
+// WaitForNodePoolReady waits until the NodePool reports the Ready condition, which Karpenter sets
+// once the referenced NodeClass has been resolved and nodes can be launched.
+func WaitForNodePoolReady(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, name string, pollInterval time.Duration) error {
+	logger.Info("waiting for NodePool %q to become ready", name)
+	for {
+		nodePool, err := client.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("getting NodePool %q: %w", name, err)
+		}
+		if isReady(nodePool) {
+			logger.Info("NodePool %q is ready", name)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for NodePool %q to become ready: %w", name, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func isReady(obj *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
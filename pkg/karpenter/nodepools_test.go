@@ -0,0 +1,200 @@
+package karpenter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("NodePools", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: api.AZSubnetMapping{
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-1"},
+			},
+			Public: api.AZSubnetMapping{
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-2"},
+			},
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.0.6",
+			NodePools: []*api.KarpenterNodePool{
+				{
+					Name: "general",
+					Labels: map[string]string{
+						"team": "platform",
+					},
+					Disruption: &api.KarpenterDisruption{
+						ConsolidationPolicy: api.KarpenterConsolidationPolicyWhenEmptyOrUnderutilized,
+						ExpireAfter:         "720h",
+					},
+				},
+			},
+		}
+		api.SetClusterConfigDefaults(cfg)
+	})
+
+	Context("NewNodeClass", func() {
+		It("defaults the role, subnets and security groups from the cluster", func() {
+			obj, err := NewNodeClass(cfg, cfg.Karpenter.NodeClasses[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GetAPIVersion()).To(Equal("karpenter.k8s.aws/v1"))
+			Expect(obj.GetLabels()).To(HaveKeyWithValue(managedByLabel, managedByLabelValue))
+
+			role, _, _ := unstructured.NestedString(obj.Object, "spec", "role")
+			Expect(role).To(Equal("eksctl-KarpenterNodeRole-test-cluster"))
+			amiSelectorTerms, _, _ := unstructured.NestedSlice(obj.Object, "spec", "amiSelectorTerms")
+			Expect(amiSelectorTerms).To(ConsistOf(map[string]interface{}{"alias": "al2023@latest"}))
+			subnetSelectorTerms, _, _ := unstructured.NestedSlice(obj.Object, "spec", "subnetSelectorTerms")
+			Expect(subnetSelectorTerms).To(ConsistOf(map[string]interface{}{"id": "subnet-1"}))
+			securityGroupSelectorTerms, _, _ := unstructured.NestedSlice(obj.Object, "spec", "securityGroupSelectorTerms")
+			Expect(securityGroupSelectorTerms).To(ConsistOf(map[string]interface{}{
+				"tags": map[string]interface{}{clusterSecurityGroupTag: "test-cluster"},
+			}))
+		})
+
+		It("uses amiFamily for Karpenter versions prior to v1", func() {
+			cfg.Karpenter.Version = "0.37.0"
+			nodeClass := cfg.Karpenter.NodeClasses[0]
+			nodeClass.InstanceProfile = "custom-profile"
+			obj, err := NewNodeClass(cfg, nodeClass)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GetAPIVersion()).To(Equal("karpenter.k8s.aws/v1beta1"))
+
+			amiFamily, _, _ := unstructured.NestedString(obj.Object, "spec", "amiFamily")
+			Expect(amiFamily).To(Equal(api.KarpenterAMIFamilyAL2023))
+			instanceProfile, _, _ := unstructured.NestedString(obj.Object, "spec", "instanceProfile")
+			Expect(instanceProfile).To(Equal("custom-profile"))
+			_, found, _ := unstructured.NestedString(obj.Object, "spec", "role")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("NewNodePool", func() {
+		It("places expireAfter in the template for Karpenter v1", func() {
+			obj, err := NewNodePool(cfg, cfg.Karpenter.NodePools[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GetAPIVersion()).To(Equal("karpenter.sh/v1"))
+
+			nodeClassRef, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "spec", "nodeClassRef")
+			Expect(nodeClassRef).To(Equal(map[string]string{
+				"group": nodeClassGroup,
+				"kind":  nodeClassKind,
+				"name":  api.DefaultKarpenterNodeClassName,
+			}))
+			expireAfter, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "spec", "expireAfter")
+			Expect(expireAfter).To(Equal("720h"))
+			labels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+			Expect(labels).To(Equal(map[string]string{"team": "platform"}))
+		})
+
+		It("places expireAfter in the disruption block for Karpenter versions prior to v1", func() {
+			cfg.Karpenter.Version = "0.37.0"
+			cfg.Karpenter.NodePools[0].Disruption.ConsolidationPolicy = api.KarpenterConsolidationPolicyWhenUnderutilized
+			obj, err := NewNodePool(cfg, cfg.Karpenter.NodePools[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			apiVersion, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "spec", "nodeClassRef", "apiVersion")
+			Expect(apiVersion).To(Equal("karpenter.k8s.aws/v1beta1"))
+			expireAfter, _, _ := unstructured.NestedString(obj.Object, "spec", "disruption", "expireAfter")
+			Expect(expireAfter).To(Equal("720h"))
+		})
+	})
+
+	Context("NodePoolManager", func() {
+		var (
+			client  *dynamicfake.FakeDynamicClient
+			manager *NodePoolManager
+		)
+
+		BeforeEach(func() {
+			client = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			manager = NewNodePoolManager(client, cfg)
+		})
+
+		getNodePool := func() (*unstructured.Unstructured, error) {
+			nodePoolGVR, _, err := NodePoolResources(cfg.Karpenter.Version)
+			Expect(err).NotTo(HaveOccurred())
+			return client.Resource(nodePoolGVR).Get(context.Background(), "general", metav1.GetOptions{})
+		}
+
+		It("creates NodeClasses and NodePools", func() {
+			Expect(manager.Create(context.Background())).To(Succeed())
+			_, nodeClassGVR, err := NodePoolResources(cfg.Karpenter.Version)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.Resource(nodeClassGVR).Get(context.Background(), api.DefaultKarpenterNodeClassName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = getNodePool()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates only NodePools managed by eksctl", func() {
+			Expect(manager.Create(context.Background())).To(Succeed())
+			cfg.Karpenter.NodePools[0].Weight = int32Ptr(10)
+			Expect(manager.Update(context.Background())).To(Succeed())
+			nodePool, err := getNodePool()
+			Expect(err).NotTo(HaveOccurred())
+			weight, _, _ := unstructured.NestedInt64(nodePool.Object, "spec", "weight")
+			Expect(weight).To(BeEquivalentTo(10))
+
+			nodePool.SetLabels(nil)
+			nodePoolGVR, _, _ := NodePoolResources(cfg.Karpenter.Version)
+			_, err = client.Resource(nodePoolGVR).Update(context.Background(), nodePool, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			cfg.Karpenter.NodePools[0].Weight = int32Ptr(20)
+			Expect(manager.Update(context.Background())).To(Succeed())
+			nodePool, err = getNodePool()
+			Expect(err).NotTo(HaveOccurred())
+			weight, _, _ = unstructured.NestedInt64(nodePool.Object, "spec", "weight")
+			Expect(weight).To(BeEquivalentTo(10))
+		})
+
+		It("lists NodePools and NodeClasses split by whether eksctl manages them", func() {
+			nodePoolGVR, nodeClassGVR, err := NodePoolResources(cfg.Karpenter.Version)
+			Expect(err).NotTo(HaveOccurred())
+			client = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				nodePoolGVR:  "NodePoolList",
+				nodeClassGVR: "EC2NodeClassList",
+			})
+			manager = NewNodePoolManager(client, cfg)
+			Expect(manager.Create(context.Background())).To(Succeed())
+			nodePool, err := getNodePool()
+			Expect(err).NotTo(HaveOccurred())
+			nodePool.SetName("team-a")
+			nodePool.SetLabels(nil)
+			nodePool.SetResourceVersion("")
+			_, err = client.Resource(nodePoolGVR).Create(context.Background(), nodePool, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			managed, unmanaged, err := manager.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed.NodePools).To(ConsistOf("general"))
+			Expect(managed.NodeClasses).To(ConsistOf(api.DefaultKarpenterNodeClassName))
+			Expect(unmanaged.NodePools).To(ConsistOf("team-a"))
+			Expect(unmanaged.NodeClasses).To(BeEmpty())
+		})
+
+		It("deletes NodePools and tolerates missing objects", func() {
+			Expect(manager.Create(context.Background())).To(Succeed())
+			Expect(manager.Delete(context.Background(), []string{"general", "missing"}, nil)).To(Succeed())
+			_, err := getNodePool()
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+	})
+})
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
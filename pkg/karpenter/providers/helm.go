@@ -35,4 +35,9 @@ type HelmInstaller interface {
 	// InstallChart takes a releaseName's name and a chart name and installs it. If namespace is not empty
 	// it will install into that namespace and create the namespace. Version is required.
 	InstallChart(ctx context.Context, opts InstallChartOpts) error
+	// UpgradeChart upgrades an existing release to the given chart version, or installs it if
+	// the release does not exist yet. CRDs shipped in the chart's crds directory are not upgraded.
+	UpgradeChart(ctx context.Context, opts InstallChartOpts) error
+	// UninstallChart removes a release. It does not fail if the release does not exist.
+	UninstallChart(ctx context.Context, releaseName string) error
 }
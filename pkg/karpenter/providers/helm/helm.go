@@ -2,6 +2,7 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
@@ -74,3 +76,52 @@ func (i *Installer) InstallChart(ctx context.Context, opts providers.InstallChar
 	logger.Debug("successfully installed %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
 	return nil
 }
+
+// UpgradeChart upgrades an existing release to the given chart version, or installs it if
+// the release does not exist yet. CRDs shipped in the chart's crds directory are not upgraded.
+func (i *Installer) UpgradeChart(ctx context.Context, opts providers.InstallChartOpts) error {
+	history := action.NewHistory(i.ActionConfig)
+	history.Max = 1
+	if _, err := history.Run(opts.ReleaseName); err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			logger.Debug("release %s not found, installing it", opts.ReleaseName)
+			return i.InstallChart(ctx, opts)
+		}
+		return fmt.Errorf("failed to get history of release %s: %w", opts.ReleaseName, err)
+	}
+
+	i.ActionConfig.RegistryClient = opts.RegistryClient
+	client := action.NewUpgrade(i.ActionConfig)
+	client.Wait = true
+	client.Namespace = opts.Namespace
+	client.Version = opts.Version
+	client.Timeout = 10 * time.Minute
+
+	chartPath, err := client.ChartPathOptions.LocateChart(opts.ChartName, i.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to locate chart: %w", err)
+	}
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	release, err := client.RunWithContext(ctx, opts.ReleaseName, ch, opts.Values)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade chart: %w", err)
+	}
+	logger.Debug("successfully upgraded %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
+	return nil
+}
+
+// UninstallChart removes a release. It does not fail if the release does not exist.
+func (i *Installer) UninstallChart(_ context.Context, releaseName string) error {
+	client := action.NewUninstall(i.ActionConfig)
+	client.Wait = true
+	client.IgnoreNotFound = true
+	client.Timeout = 10 * time.Minute
+	if _, err := client.Run(releaseName); err != nil {
+		return fmt.Errorf("failed to uninstall release %s: %w", releaseName, err)
+	}
+	return nil
+}
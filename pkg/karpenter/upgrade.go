@@ -0,0 +1,93 @@
+package karpenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// v1Resources are the Karpenter objects whose storage version changes from v1beta1 to v1.
+var v1Resources = []schema.GroupVersionResource{
+	{Group: nodePoolGroup, Version: "v1", Resource: "nodepools"},
+	{Group: nodePoolGroup, Version: "v1", Resource: "nodeclaims"},
+	{Group: nodeClassGroup, Version: "v1", Resource: "ec2nodeclasses"},
+}
+
+// AdoptCRDs labels and annotates the Karpenter CRDs installed from the crds directory of the
+// Karpenter chart so that the karpenter-crd release can take ownership of them.
+func AdoptCRDs(ctx context.Context, client dynamic.Interface) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{
+				managedByLabel: "Helm",
+			},
+			"annotations": map[string]string{
+				"meta.helm.sh/release-name":      CRDReleaseName,
+				"meta.helm.sh/release-namespace": DefaultNamespace,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, gvr := range v1Resources {
+		name := crdName(gvr)
+		if _, err := client.Resource(crdResource).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("patching CRD %q: %w", name, err)
+		}
+		logger.Debug("prepared CRD %q for adoption by release %s", name, CRDReleaseName)
+	}
+	return nil
+}
+
+// MigrateStoredVersions rewrites all NodePools, NodeClaims and EC2NodeClasses so that they are
+// stored as v1, and then drops v1beta1 from the stored versions of their CRDs. This is required
+// before upgrading to Karpenter releases that no longer serve v1beta1.
+func MigrateStoredVersions(ctx context.Context, client dynamic.Interface) error {
+	for _, gvr := range v1Resources {
+		list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("listing %s: %w", gvr.Resource, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if _, err := client.Resource(gvr).Update(ctx, obj, metav1.UpdateOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("migrating %s %q to %s: %w", gvr.Resource, obj.GetName(), gvr.GroupVersion(), err)
+			}
+		}
+		logger.Info("migrated %d %s to %s", len(list.Items), gvr.Resource, gvr.GroupVersion())
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"status": map[string]interface{}{
+				"storedVersions": []string{gvr.Version},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		name := crdName(gvr)
+		if _, err := client.Resource(crdResource).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+			return fmt.Errorf("updating stored versions of CRD %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func crdName(gvr schema.GroupVersionResource) string {
+	return gvr.Resource + "." + gvr.Group
+}
@@ -0,0 +1,72 @@
+package karpenter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Upgrade", func() {
+	var client *dynamicfake.FakeDynamicClient
+
+	newObject := func(gvr schema.GroupVersionResource, kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(gvr.GroupVersion().String())
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return obj
+	}
+
+	newCRD := func(name string) *unstructured.Unstructured {
+		crd := newObject(crdResource, "CustomResourceDefinition", name)
+		Expect(unstructured.SetNestedStringSlice(crd.Object, []string{"v1beta1", "v1"}, "status", "storedVersions")).To(Succeed())
+		return crd
+	}
+
+	BeforeEach(func() {
+		client = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			v1Resources[0]: "NodePoolList",
+			v1Resources[1]: "NodeClaimList",
+			v1Resources[2]: "EC2NodeClassList",
+		},
+			newCRD("nodepools.karpenter.sh"),
+			newCRD("nodeclaims.karpenter.sh"),
+			newCRD("ec2nodeclasses.karpenter.k8s.aws"),
+			newObject(v1Resources[0], nodePoolKind, "general"),
+			newObject(v1Resources[2], nodeClassKind, "default"),
+		)
+	})
+
+	It("prepares the CRDs for adoption by the CRD release", func() {
+		Expect(AdoptCRDs(context.Background(), client)).To(Succeed())
+		crd, err := client.Resource(crdResource).Get(context.Background(), "nodepools.karpenter.sh", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(crd.GetLabels()).To(HaveKeyWithValue(managedByLabel, "Helm"))
+		Expect(crd.GetAnnotations()).To(HaveKeyWithValue("meta.helm.sh/release-name", CRDReleaseName))
+		Expect(crd.GetAnnotations()).To(HaveKeyWithValue("meta.helm.sh/release-namespace", DefaultNamespace))
+	})
+
+	It("migrates objects and the stored versions of the CRDs to v1", func() {
+		Expect(MigrateStoredVersions(context.Background(), client)).To(Succeed())
+		for _, name := range []string{"nodepools.karpenter.sh", "nodeclaims.karpenter.sh", "ec2nodeclasses.karpenter.k8s.aws"} {
+			crd, err := client.Resource(crdResource).Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			storedVersions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+			Expect(storedVersions).To(Equal([]string{"v1"}))
+		}
+
+		var updated []string
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "update" {
+				updated = append(updated, action.GetResource().Resource)
+			}
+		}
+		Expect(updated).To(ConsistOf("nodepools", "ec2nodeclasses"))
+	})
+})
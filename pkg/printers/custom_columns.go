@@ -0,0 +1,130 @@
+package printers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const noValue = "<none>"
+
+type customColumn struct {
+	header   string
+	jsonPath *jsonpath.JSONPath
+}
+
+// CustomColumnsPrinter is a printer that outputs an object formatted
+// as a table of columns given as JSONPath expressions
+type CustomColumnsPrinter struct {
+	columns []customColumn
+}
+
+// NewCustomColumnsPrinter creates a new CustomColumnsPrinter from a
+// list of columns, e.g. NAME:.Name,STATUS:.Status
+func NewCustomColumnsPrinter(spec string) (OutputPrinter, error) {
+	printer := &CustomColumnsPrinter{}
+	for _, column := range strings.Split(spec, ",") {
+		header, expression, found := strings.Cut(column, ":")
+		if !found || header == "" || expression == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:JSONPATH", column)
+		}
+		jsonPath, err := parseJSONPath(expression)
+		if err != nil {
+			return nil, err
+		}
+		printer.columns = append(printer.columns, customColumn{header: header, jsonPath: jsonPath})
+	}
+	return printer, nil
+}
+
+// PrintObj will print the passed object formatted as a table of the
+// custom columns to the supplied writer.
+func (c *CustomColumnsPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	return c.PrintObjWithKind("objects", obj, writer)
+}
+
+// PrintObjWithKind will print the passed object formatted as a table
+// of the custom columns to the supplied writer. Each item of a slice
+// is printed as a row, and fields are referred to by their names in
+// the JSON output.
+func (c *CustomColumnsPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	itemsValue := reflect.ValueOf(obj)
+	if itemsValue.Kind() != reflect.Slice {
+		return fmt.Errorf("custom-columns printer expects a slice but the kind was %v", itemsValue.Kind())
+	}
+
+	if itemsValue.Len() == 0 {
+		w := bufio.NewWriter(writer)
+		if _, err := w.WriteString(fmt.Sprintf("No %s found\n", strings.ToLower(kind))); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	items, _ := data.([]interface{})
+
+	w := tabwriter.NewWriter(writer, 0, 8, 1, '\t', 0)
+	var headers []string
+	for _, column := range c.columns {
+		headers = append(headers, column.header)
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
+		return err
+	}
+	for _, item := range items {
+		var values []string
+		for _, column := range c.columns {
+			value, err := column.value(item)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// LogObj will print the passed object formatted as a table of the
+// custom columns to the logger.
+func (c *CustomColumnsPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	return logObj(c, log, msgFmt, obj)
+}
+
+// value returns the values found by the JSONPath expression of the column, separated by commas
+func (c customColumn) value(item interface{}) (string, error) {
+	results, err := c.jsonPath.FindResults(item)
+	if err != nil {
+		return "", fmt.Errorf("evaluating column %s: %w", c.header, err)
+	}
+	var values []string
+	for _, result := range results {
+		for _, r := range result {
+			if r.Kind() == reflect.Interface && r.IsNil() {
+				continue
+			}
+			var b bytes.Buffer
+			if err := c.jsonPath.PrintResults(&b, []reflect.Value{r}); err != nil {
+				return "", fmt.Errorf("evaluating column %s: %w", c.header, err)
+			}
+			values = append(values, b.String())
+		}
+	}
+	if len(values) == 0 {
+		return noValue, nil
+	}
+	return strings.Join(values, ","), nil
+}
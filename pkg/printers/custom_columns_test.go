@@ -0,0 +1,47 @@
+package printers_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type columnsItem struct {
+	Name    string
+	Subnets []string
+	Tags    map[string]string
+}
+
+var _ = Describe("Custom columns printer", func() {
+	var printer OutputPrinter
+
+	BeforeEach(func() {
+		var err error
+		printer, err = NewCustomColumnsPrinter("NAME:.Name,SUBNETS:.Subnets[*],TEAM:.Tags.team")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("prints a row per item", func() {
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", []columnsItem{
+			{Name: "cluster-1", Subnets: []string{"subnet-1", "subnet-2"}, Tags: map[string]string{"team": "platform"}},
+			{Name: "cluster-2"},
+		}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("NAME\t\tSUBNETS\t\t\tTEAM\n" +
+			"cluster-1\tsubnet-1,subnet-2\tplatform\n" +
+			"cluster-2\t<none>\t\t\t<none>\n"))
+	})
+
+	It("prints a message when there are no items", func() {
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", []columnsItem{}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("No clusters found\n"))
+	})
+
+	It("rejects objects that are not slices", func() {
+		Expect(printer.PrintObjWithKind("clusters", columnsItem{}, &bytes.Buffer{})).To(MatchError(ContainSubstring("expects a slice")))
+	})
+})
@@ -1,8 +1,10 @@
 package printers
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kris-nova/logger"
 )
@@ -17,8 +19,20 @@ const (
 	JSONType = Type("json")
 	// TableType represents a printer of Table type.
 	TableType = Type("table")
+	// WideType represents a printer of Table type that includes additional columns.
+	WideType = Type("wide")
+	// CustomColumnsType represents a printer of Table type with columns given as
+	// custom-columns=HEADER:JSONPATH[,HEADER:JSONPATH...].
+	CustomColumnsType = Type("custom-columns")
+	// JSONPathType represents a printer of a JSONPath template given as jsonpath=TEMPLATE.
+	JSONPathType = Type("jsonpath")
+	// GoTemplateType represents a printer of a Go template given as go-template=TEMPLATE.
+	GoTemplateType = Type("go-template")
 )
 
+// ValidTypesDescription describes the valid printer types, for use in the help of flags.
+const ValidTypesDescription = "table, wide, json, yaml, custom-columns=HEADER:JSONPATH,..., jsonpath=TEMPLATE, go-template=TEMPLATE"
+
 // OutputPrinter is the interface that printer must implement. This allows
 // new printers to be added in the future.
 type OutputPrinter interface {
@@ -28,23 +42,68 @@ type OutputPrinter interface {
 }
 
 // NewPrinter creates a new printer based in the printer type requested.
+// Printer types that take an argument are given as type=argument.
 func NewPrinter(printerType Type) (OutputPrinter, error) {
 	var printer OutputPrinter
 
-	switch printerType {
+	name, arg, hasArg := strings.Cut(printerType, "=")
+	switch name {
+	case CustomColumnsType, JSONPathType, GoTemplateType:
+		if arg == "" {
+			return nil, fmt.Errorf("output printer type %q requires an argument, e.g. %s=...", name, name)
+		}
+	default:
+		if hasArg {
+			return nil, errInvalidPrinterType(printerType)
+		}
+	}
+
+	var err error
+	switch name {
 	case YAMLType:
 		printer = NewYAMLPrinter()
 	case JSONType:
 		printer = NewJSONPrinter()
 	case TableType:
 		printer = NewTablePrinter()
+	case WideType:
+		printer = NewWideTablePrinter()
+	case CustomColumnsType:
+		printer, err = NewCustomColumnsPrinter(arg)
+	case JSONPathType:
+		printer, err = NewJSONPathPrinter(arg)
+	case GoTemplateType:
+		printer, err = NewGoTemplatePrinter(arg)
 	default:
 		return nil, errInvalidPrinterType(printerType)
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	return printer, nil
 }
 
+// IsTableType returns true if the printer type prints the columns added to a TablePrinter.
+// Commands usually log to stderr for other printer types, so that their output can be parsed.
+func IsTableType(printerType Type) bool {
+	return printerType == TableType || printerType == WideType
+}
+
 func errInvalidPrinterType(printerType Type) error {
-	return fmt.Errorf("unknown output printer type: expected {%q,%q,%q} but got %q", YAMLType, JSONType, TableType, printerType)
+	return fmt.Errorf("unknown output printer type: expected one of %s but got %q", ValidTypesDescription, printerType)
+}
+
+// toGeneric converts obj to its JSON representation made of maps, slices and scalars, so that
+// templates refer to fields with the names used by the JSON printer.
+func toGeneric(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
 }
@@ -0,0 +1,43 @@
+package printers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("NewPrinter", func() {
+	DescribeTable("creates the printer of the given type", func(printerType Type, expected OutputPrinter) {
+		printer, err := NewPrinter(printerType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(printer).To(BeAssignableToTypeOf(expected))
+	},
+		Entry("table", "table", &TablePrinter{}),
+		Entry("wide", "wide", &TablePrinter{}),
+		Entry("json", "json", &JSONPrinter{}),
+		Entry("yaml", "yaml", &YAMLPrinter{}),
+		Entry("custom-columns", "custom-columns=NAME:.Name", &CustomColumnsPrinter{}),
+		Entry("jsonpath", "jsonpath={.Name}", &JSONPathPrinter{}),
+		Entry("go-template", "go-template={{.Name}}", &GoTemplatePrinter{}),
+	)
+
+	DescribeTable("rejects invalid printer types", func(printerType Type, expectedErr string) {
+		_, err := NewPrinter(printerType)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("unknown type", "xml", `unknown output printer type: expected one of table, wide, json, yaml`),
+		Entry("argument for a type without arguments", "json=.Name", `but got "json=.Name"`),
+		Entry("missing argument", "jsonpath", `output printer type "jsonpath" requires an argument`),
+		Entry("invalid custom column", "custom-columns=NAME", `invalid custom column "NAME", expected HEADER:JSONPATH`),
+		Entry("invalid jsonpath", "jsonpath={.Name", `parsing jsonpath "{.Name"`),
+		Entry("invalid go-template", "go-template={{.Name", "parsing go-template"),
+	)
+
+	It("treats table and wide as table types", func() {
+		Expect(IsTableType(TableType)).To(BeTrue())
+		Expect(IsTableType(WideType)).To(BeTrue())
+		Expect(IsTableType("custom-columns=NAME:.Name")).To(BeFalse())
+		Expect(IsTableType(JSONType)).To(BeFalse())
+	})
+})
@@ -18,6 +18,7 @@ import (
 type TablePrinter struct {
 	table      *tables.Table
 	columnames []string
+	wide       bool
 }
 
 // NewTablePrinter creates a new TablePrinter with defaults.
@@ -25,6 +26,12 @@ func NewTablePrinter() OutputPrinter {
 	return &TablePrinter{table: &tables.Table{}}
 }
 
+// NewWideTablePrinter creates a new TablePrinter that also prints
+// the columns added with AddWideColumn.
+func NewWideTablePrinter() OutputPrinter {
+	return &TablePrinter{table: &tables.Table{}, wide: true}
+}
+
 // PrintObj will print the passed object formatted as textual
 // table to the supplied writer.
 func (t *TablePrinter) PrintObj(obj interface{}, writer io.Writer) error {
@@ -68,3 +75,11 @@ func (t *TablePrinter) AddColumn(name string, getter interface{}) {
 	t.columnames = append(t.columnames, name)
 	t.table.AddColumn(name, getter)
 }
+
+// AddWideColumn adds a column to the table that will only be printed
+// with the wide output
+func (t *TablePrinter) AddWideColumn(name string, getter interface{}) {
+	if t.wide {
+		t.AddColumn(name, getter)
+	}
+}
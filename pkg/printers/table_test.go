@@ -17,6 +17,32 @@ import (
 
 var _ = Describe("Table Printer", func() {
 
+	Describe("When creating a wide Table printer", func() {
+		addColumns := func(printer *TablePrinter) {
+			printer.AddColumn("NAME", func(c *ekstypes.Cluster) string {
+				return *c.Name
+			})
+			printer.AddWideColumn("ARN", func(c *ekstypes.Cluster) string {
+				return *c.Arn
+			})
+		}
+		clusters := []*ekstypes.Cluster{{Name: aws.String("test-cluster"), Arn: aws.String("arn-12345678")}}
+
+		It("should only print wide columns with the wide printer", func() {
+			var table, wide bytes.Buffer
+			printer := NewTablePrinter().(*TablePrinter)
+			addColumns(printer)
+			Expect(printer.PrintObjWithKind("clusters", clusters, &table)).To(Succeed())
+			Expect(table.String()).NotTo(ContainSubstring("ARN"))
+
+			printer = NewWideTablePrinter().(*TablePrinter)
+			addColumns(printer)
+			Expect(printer.PrintObjWithKind("clusters", clusters, &wide)).To(Succeed())
+			Expect(wide.String()).To(ContainSubstring("ARN"))
+			Expect(wide.String()).To(ContainSubstring("arn-12345678"))
+		})
+	})
+
 	Describe("When creating New Table printer", func() {
 		var (
 			printer OutputPrinter
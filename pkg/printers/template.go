@@ -0,0 +1,126 @@
+package printers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter is a printer that outputs an object formatted
+// with a JSONPath template
+type JSONPathPrinter struct {
+	jsonPath *jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter creates a new JSONPathPrinter for the given template,
+// e.g. {.[*].Name} or {range .[*]}{.Name}{"\n"}{end}.
+func NewJSONPathPrinter(text string) (OutputPrinter, error) {
+	jsonPath, err := parseJSONPath(text)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONPathPrinter{jsonPath: jsonPath}, nil
+}
+
+// PrintObj will print the passed object formatted with the JSONPath
+// template to the supplied writer. Fields are referred to by their
+// names in the JSON output.
+func (j *JSONPathPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	return j.jsonPath.Execute(writer, data)
+}
+
+// PrintObjWithKind will print the passed object formatted with the
+// JSONPath template to the supplied writer. This printer ignores kind argument.
+func (j *JSONPathPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return j.PrintObj(obj, writer)
+}
+
+// LogObj will print the passed object formatted with the JSONPath
+// template to the logger.
+func (j *JSONPathPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	return logObj(j, log, msgFmt, obj)
+}
+
+// GoTemplatePrinter is a printer that outputs an object formatted
+// with a Go template
+type GoTemplatePrinter struct {
+	template *template.Template
+}
+
+// NewGoTemplatePrinter creates a new GoTemplatePrinter for the given template,
+// e.g. {{range .}}{{.Name}}{{"\n"}}{{end}}.
+func NewGoTemplatePrinter(text string) (OutputPrinter, error) {
+	t, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go-template: %w", err)
+	}
+	return &GoTemplatePrinter{template: t}, nil
+}
+
+// PrintObj will print the passed object formatted with the Go template
+// to the supplied writer. Fields are referred to by their names in the
+// JSON output.
+func (g *GoTemplatePrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	if err := g.template.Execute(writer, data); err != nil {
+		return fmt.Errorf("executing go-template: %w", err)
+	}
+	return nil
+}
+
+// PrintObjWithKind will print the passed object formatted with the Go
+// template to the supplied writer. This printer ignores kind argument.
+func (g *GoTemplatePrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return g.PrintObj(obj, writer)
+}
+
+// LogObj will print the passed object formatted with the Go template
+// to the logger.
+func (g *GoTemplatePrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	return logObj(g, log, msgFmt, obj)
+}
+
+// rootSliceExpression matches expressions starting with .[, which the JSONPath parser
+// does not resolve against a slice, unlike [
+var rootSliceExpression = regexp.MustCompile(`\{(range\s+)?\.\[`)
+
+// parseJSONPath parses a JSONPath template, also accepting a single
+// expression without braces or a leading dot, e.g. [*].Name
+func parseJSONPath(text string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(text, "{") {
+		if !strings.HasPrefix(text, ".") && !strings.HasPrefix(text, "[") {
+			text = "." + text
+		}
+		text = "{" + text + "}"
+	}
+	text = rootSliceExpression.ReplaceAllString(text, "{${1}[")
+	jsonPath := jsonpath.New("output").AllowMissingKeys(true)
+	if err := jsonPath.Parse(text); err != nil {
+		return nil, fmt.Errorf("parsing jsonpath %q: %w", text, err)
+	}
+	return jsonPath, nil
+}
+
+func logObj(printer OutputPrinter, log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := printer.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package printers_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type templateItem struct {
+	Name   string
+	Status string
+	Type   string `json:"NodeGroupType"`
+}
+
+var templateItems = []*templateItem{
+	{Name: "ng-1", Status: "ACTIVE", Type: "managed"},
+	{Name: "ng-2", Status: "CREATING", Type: "unmanaged"},
+}
+
+var _ = Describe("Template printers", func() {
+	DescribeTable("JSONPath printer", func(template, expected string) {
+		printer, err := NewJSONPathPrinter(template)
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("nodegroups", templateItems, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(expected))
+	},
+		Entry("all items", "{[*].Name}", "ng-1 ng-2"),
+		Entry("all items with a leading dot", "{.[*].Name}", "ng-1 ng-2"),
+		Entry("expression without braces", "[0].Status", "ACTIVE"),
+		Entry("range", `{range .[*]}{.Name}{"\t"}{.Status}{"\n"}{end}`, "ng-1\tACTIVE\nng-2\tCREATING\n"),
+		Entry("JSON field names", "{[*].NodeGroupType}", "managed unmanaged"),
+		Entry("filter", `{[?(@.Status=="ACTIVE")].Name}`, "ng-1"),
+	)
+
+	It("prints Go templates", func() {
+		printer, err := NewGoTemplatePrinter(`{{range .}}{{.Name}}={{.NodeGroupType}}{{"\n"}}{{end}}`)
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("nodegroups", templateItems, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("ng-1=managed\nng-2=unmanaged\n"))
+	})
+})
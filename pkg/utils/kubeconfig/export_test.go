@@ -18,6 +18,8 @@ func SetNewVersionManager(f func() kubectl.KubernetesVersionManager) {
 	newVersionManager = f
 }
 
-func SetLookupAuthenticator(f func() (string, bool)) {
+func SetLookupAuthenticator(f func() (string, bool)) (restore func()) {
+	original := lookupAuthenticator
 	lookupAuthenticator = f
+	return func() { lookupAuthenticator = original }
 }
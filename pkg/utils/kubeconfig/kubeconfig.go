@@ -28,6 +28,8 @@ const (
 	AWSIAMAuthenticator = "aws-iam-authenticator"
 	// AWSEKSAuthenticator defines the recently added `aws eks get-token` command
 	AWSEKSAuthenticator = "aws"
+	// EksctlAuthenticator defines the `eksctl get-token` command, which needs no other binaries
+	EksctlAuthenticator = "eksctl"
 	// AWSIAMAuthenticatorMinimumBetaVersion this is the minimum version at which aws-iam-authenticator uses v1beta1 as APIVersion
 	AWSIAMAuthenticatorMinimumBetaVersion = "0.5.3"
 	// AWSCLIv1MinimumBetaVersion this is the minimum version at which aws-cli v1 uses v1beta1 as APIVersion
@@ -62,11 +64,12 @@ func DefaultPath() string {
 	return clientcmd.RecommendedHomeFile
 }
 
-// authenticatorCommands returns all authenticator commands.
+// authenticatorCommands returns all authenticator commands, in order of preference.
 func authenticatorCommands() []string {
 	return []string{
 		AWSIAMAuthenticator,
 		AWSEKSAuthenticator,
+		EksctlAuthenticator,
 	}
 }
 
@@ -149,21 +152,46 @@ func NewForUser(cluster ClusterInfo, username string) *clientcmdapi.Config {
 
 // NewForKubectl creates configuration for a user with kubectl by configuring
 // a suitable authenticator and respecting provider settings
-func NewForKubectl(cluster ClusterInfo, username, roleARN, profile string) *clientcmdapi.Config {
+func NewForKubectl(cluster ClusterInfo, username, roleARN string, profile api.Profile) *clientcmdapi.Config {
+	return NewForKubectlWithAuthenticator(cluster, username, roleARN, profile, "")
+}
+
+// NewForKubectlWithAuthenticator creates configuration for a user with kubectl using the given
+// authenticator, or a suitable one if authenticator is empty
+func NewForKubectlWithAuthenticator(cluster ClusterInfo, username, roleARN string, profile api.Profile, authenticator string) *clientcmdapi.Config {
 	config := NewForUser(cluster, username)
-	authenticator, found := lookupAuthenticator()
-	if !found {
-		// fall back to aws-iam-authenticator
-		authenticator = AWSIAMAuthenticator
+	if authenticator == "" {
+		if profile.AssumeRole.IsSet() {
+			// only eksctl can assume the same roles as the command that writes the kubeconfig
+			authenticator = EksctlAuthenticator
+		} else {
+			var found bool
+			authenticator, found = lookupAuthenticator()
+			if !found {
+				// fall back to eksctl, which needs no other binaries
+				authenticator = EksctlAuthenticator
+			}
+		}
 	}
 	AppendAuthenticator(config, cluster, authenticator, roleARN, profile)
 	return config
 }
 
+// ValidateAuthenticator returns an error if authenticator is not one of the supported authenticator commands
+func ValidateAuthenticator(authenticator string) error {
+	for _, cmd := range authenticatorCommands() {
+		if authenticator == cmd {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid authenticator %q, valid authenticators are: %s", authenticator,
+		strings.Join(authenticatorCommands(), ", "))
+}
+
 // AppendAuthenticator appends the AWS IAM  authenticator, and
-// if profile is non-empty string it sets AWS_PROFILE environment
+// if profile has a name it sets AWS_PROFILE environment
 // variable also
-func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authenticatorCMD, roleARN, profile string) {
+func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authenticatorCMD, roleARN string, profile api.Profile) {
 	var (
 		args        []string
 		roleARNFlag string
@@ -212,6 +240,23 @@ func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authe
 		if meta.Region != "" {
 			args = append(args, "--region", meta.Region)
 		}
+
+	case EksctlAuthenticator:
+		// eksctl get-token writes the ExecCredential version requested by client-go, falling back to v1beta1
+		execConfig.APIVersion = betaAPIVersion
+		args = []string{"get-token", "--cluster-name", cluster.ID()}
+		roleARNFlag = "--role-arn"
+		if meta.Region != "" {
+			args = append(args, "--region", meta.Region)
+		}
+		args = append(args, assumeRoleArgs(profile.AssumeRole)...)
+		if profile.AssumeRole.MFASerial != "" {
+			// eksctl get-token reads the MFA token code from stdin when the cached credentials have expired
+			execConfig.InteractiveMode = clientcmdapi.IfAvailableExecInteractiveMode
+		}
+	}
+	if profile.AssumeRole.IsSet() && authenticatorCMD != EksctlAuthenticator {
+		logger.Warning("%s cannot assume roles passed with --assume-role-arn, kubectl will authenticate with the credentials of the profile", authenticatorCMD)
 	}
 	// If the alpha API version is selected, check the kubectl version
 	// If kubectl 1.24.0 or above is detected, override with the beta API version
@@ -232,10 +277,10 @@ func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authe
 
 	execConfig.Args = args
 
-	if profile != "" {
+	if profile.Name != "" {
 		execConfig.Env = append(execConfig.Env, clientcmdapi.ExecEnvVar{
 			Name:  "AWS_PROFILE",
-			Value: profile,
+			Value: profile.Name,
 		})
 	}
 
@@ -244,6 +289,24 @@ func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authe
 	}
 }
 
+// assumeRoleArgs returns the eksctl flags to assume the same roles
+func assumeRoleArgs(assumeRole api.AssumeRole) []string {
+	var args []string
+	for _, roleARN := range assumeRole.RoleARNs {
+		args = append(args, "--assume-role-arn", roleARN)
+	}
+	for _, flag := range []struct{ name, value string }{
+		{"--external-id", assumeRole.ExternalID},
+		{"--mfa-serial", assumeRole.MFASerial},
+		{"--role-session-name", assumeRole.RoleSessionName},
+	} {
+		if flag.value != "" {
+			args = append(args, flag.name, flag.value)
+		}
+	}
+	return args
+}
+
 // AWSAuthenticatorVersionFormat is the format in which aws-iam-authenticator displays version information:
 // {"Version":"0.5.5","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}
 type AWSAuthenticatorVersionFormat struct {
@@ -392,42 +392,42 @@ var _ = Describe("Kubeconfig", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `{"Version":"0.5.1","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("writes the right api version if aws-iam-authenticator version is above 0.5.3", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `{"Version":"0.5.5","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
 		})
 		It("writes the right api version if aws-iam-authenticator version equals 0.5.3", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `{"Version":"0.5.3","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
 		})
 		It("defaults to alpha1 if we fail to detect aws-iam-authenticator version", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), "fail")
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("defaults to alpha1 if we fail to parse the output", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), "not-json-output")
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("defaults to alpha1 if we can't parse the version because it's a dev version", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `{"Version":"git-85e50980","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("defaults to beta1 if we detect kubectl 1.24.0 or above", func() {
@@ -436,7 +436,7 @@ var _ = Describe("Kubeconfig", func() {
 				fakeClient.ClientVersionReturns("1.24.0", nil)
 				return fakeClient
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
 		})
 		It("doesn't default to beta1 if we detect kubectl version lower than 1.24.0", func() {
@@ -445,37 +445,78 @@ var _ = Describe("Kubeconfig", func() {
 				fakeClient.ClientVersionReturns("1.23.6", nil)
 				return fakeClient
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSIAMAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("defaults to beta1 if we detect aws-cli v1 is at or above 1.23.9", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `aws-cli/1.23.9 Python/3.8.8 Linux/5.4.181-109.354.amzn2int.x86_64 exe/x86_64.amzn.2 prompt/off`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
 		})
 		It("doesn't default to beta1 if we detect aws-cli v1 is below 1.23.9", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `aws-cli/1.21.9 Python/3.8.8 Linux/5.4.181-109.354.amzn2int.x86_64 exe/x86_64.amzn.2 prompt/off`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
 		It("defaults to beta1 if we detect aws-cli v2 is at or above 2.6.3", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `aws-cli/2.6.3 Python/3.8.8 Linux/5.4.181-109.354.amzn2int.x86_64 exe/x86_64.amzn.2 prompt/off`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
 		})
 		It("doesn't default to beta1 if we detect aws-cli v2 below 2.6.3", func() {
 			kubeconfig.SetExecCommand(func(name string, arg ...string) *exec.Cmd {
 				return exec.Command(filepath.Join("testdata", "fake-version"), `aws-cli/2.4.3 Python/3.8.8 Linux/5.4.181-109.354.amzn2int.x86_64 exe/x86_64.amzn.2 prompt/off`)
 			})
-			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", "")
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.AWSEKSAuthenticator, "", eksctlapi.Profile{})
 			Expect(config.AuthInfos["test"].Exec.APIVersion).To(Equal("client.authentication.k8s.io/v1alpha1"))
 		})
+		It("uses eksctl get-token with the beta1 api version", func() {
+			kubeconfig.AppendAuthenticator(config, clusterInfo, kubeconfig.EksctlAuthenticator, "arn:aws:iam::123456789012:role/admin", eksctlapi.Profile{Name: "dev"})
+			exec := config.AuthInfos["test"].Exec
+			Expect(exec.APIVersion).To(Equal("client.authentication.k8s.io/v1beta1"))
+			Expect(exec.Command).To(Equal("eksctl"))
+			Expect(exec.Args).To(Equal([]string{"get-token", "--cluster-name", "name", "--region", "us-west-2", "--role-arn", "arn:aws:iam::123456789012:role/admin"}))
+			Expect(exec.Env).To(ContainElement(clientcmdapi.ExecEnvVar{Name: "AWS_PROFILE", Value: "dev"}))
+		})
+		It("passes the roles to assume to eksctl get-token", func() {
+			clusterInfo.Status = &eksctlapi.ClusterStatus{Endpoint: "https://endpoint"}
+			config := kubeconfig.NewForKubectl(clusterInfo, "user", "", eksctlapi.Profile{
+				Name: "dev",
+				AssumeRole: eksctlapi.AssumeRole{
+					RoleARNs:   []string{"arn:aws:iam::111111111111:role/hop", "arn:aws:iam::222222222222:role/admin"},
+					ExternalID: "external-id",
+					MFASerial:  "arn:aws:iam::111111111111:mfa/user",
+				},
+			})
+			exec := config.AuthInfos[config.CurrentContext].Exec
+			Expect(exec.Command).To(Equal("eksctl"))
+			Expect(exec.Args).To(Equal([]string{"get-token", "--cluster-name", "name", "--region", "us-west-2",
+				"--assume-role-arn", "arn:aws:iam::111111111111:role/hop", "--assume-role-arn", "arn:aws:iam::222222222222:role/admin",
+				"--external-id", "external-id", "--mfa-serial", "arn:aws:iam::111111111111:mfa/user"}))
+			Expect(exec.InteractiveMode).To(Equal(clientcmdapi.IfAvailableExecInteractiveMode))
+		})
+		It("falls back to eksctl get-token when no other authenticator is installed", func() {
+			DeferCleanup(kubeconfig.SetLookupAuthenticator(func() (string, bool) {
+				return "", false
+			}))
+			clusterInfo.Status = &eksctlapi.ClusterStatus{Endpoint: "https://endpoint"}
+			config := kubeconfig.NewForKubectl(clusterInfo, "user", "", eksctlapi.Profile{})
+			exec := config.AuthInfos[config.CurrentContext].Exec
+			Expect(exec.Command).To(Equal("eksctl"))
+			Expect(exec.Args).To(Equal([]string{"get-token", "--cluster-name", "name", "--region", "us-west-2"}))
+		})
+	})
+
+	It("validates authenticators", func() {
+		Expect(kubeconfig.ValidateAuthenticator(kubeconfig.EksctlAuthenticator)).To(Succeed())
+		Expect(kubeconfig.ValidateAuthenticator(kubeconfig.AWSEKSAuthenticator)).To(Succeed())
+		Expect(kubeconfig.ValidateAuthenticator("kubelogin")).To(MatchError(`invalid authenticator "kubelogin", valid authenticators are: aws-iam-authenticator, aws, eksctl`))
 	})
 
 	type checkAllCommandsEntry struct {
@@ -524,7 +565,7 @@ var _ = Describe("Kubeconfig", func() {
 			mockKubernetesVersionManager: func() (mockManagerFunc func() kubectl.KubernetesVersionManager, assertFakeManagerCalls func()) {
 				return mockManagerFunc, assertFakeManagerCalls
 			},
-			expectedErr: "could not find any of the authenticator commands",
+			expectedErr: "could not find any of the authenticator commands: aws-iam-authenticator, aws, eksctl",
 		}),
 
 		Entry("fails to lookup kubectl", checkAllCommandsEntry{
@@ -0,0 +1,307 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/weaveworks/eksctl/pkg/utils/file"
+)
+
+const eksctlClusterSuffix = ".eksctl.io"
+
+// Context is a context written by eksctl in a kubeconfig file
+type Context struct {
+	Name string
+	// ClusterName and Region identify the EKS cluster of the context
+	ClusterName string
+	Region      string
+	// Path is the kubeconfig file the context is in
+	Path    string
+	Current bool
+	// Credentials are the AWS credentials the user of the context authenticates with, or nil if the user does
+	// not use one of the authenticators eksctl writes
+	Credentials *Credentials `json:",omitempty"`
+}
+
+// Credentials are the AWS credentials an authenticator written by eksctl gets tokens with
+type Credentials struct {
+	// Profile is the AWS_PROFILE set for the authenticator, empty if it uses the default credential chain
+	Profile string `json:",omitempty"`
+	// RoleARNs are the roles the authenticator assumes, in order
+	RoleARNs        []string `json:",omitempty"`
+	ExternalID      string   `json:",omitempty"`
+	MFASerial       string   `json:",omitempty"`
+	RoleSessionName string   `json:",omitempty"`
+}
+
+// Key identifies the credentials, so that clusters are looked up once per set of credentials
+func (c *Credentials) Key() string {
+	return strings.Join([]string{c.Profile, strings.Join(c.RoleARNs, ","), c.ExternalID, c.MFASerial, c.RoleSessionName}, "|")
+}
+
+// AutoDir returns the directory of the auto-generated kubeconfig files, which hold a cluster each
+func AutoDir() string {
+	return filepath.Dir(AutoPath("cluster"))
+}
+
+// ConfigPaths returns the kubeconfig files at kubeconfigPath, which may be a list of paths as in KUBECONFIG,
+// and in dir, skipping files that do not exist
+func ConfigPaths(kubeconfigPath, dir string) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+	add := func(p string) {
+		if !seen[p] && file.Exists(p) {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	for _, p := range filepath.SplitList(kubeconfigPath) {
+		add(p)
+	}
+	if dir == "" {
+		return paths, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return paths, nil
+		}
+		return nil, fmt.Errorf("reading kubeconfig directory %q: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			add(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// ListContexts returns the contexts written by eksctl in the given kubeconfig files, in the order of the files
+// and then by name. Contexts are recognised by the name eksctl gives to clusters, <name>.<region>.eksctl.io, so
+// that renamed contexts are still listed.
+func ListContexts(paths []string) ([]Context, error) {
+	var contexts []Context
+	for _, p := range paths {
+		config, err := clientcmd.LoadFromFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kubeconfig %q: %w", p, err)
+		}
+		var fileContexts []Context
+		for name, c := range config.Contexts {
+			clusterName, region, ok := parseClusterName(c.Cluster)
+			if !ok {
+				continue
+			}
+			fileContexts = append(fileContexts, Context{
+				Name:        name,
+				ClusterName: clusterName,
+				Region:      region,
+				Path:        p,
+				Current:     config.CurrentContext == name,
+				Credentials: parseCredentials(config.AuthInfos[c.AuthInfo]),
+			})
+		}
+		sort.Slice(fileContexts, func(i, j int) bool {
+			return fileContexts[i].Name < fileContexts[j].Name
+		})
+		contexts = append(contexts, fileContexts...)
+	}
+	return contexts, nil
+}
+
+// PruneContexts removes the given contexts, along with their users and clusters when no other context refers
+// to them. Files in dir that are left without contexts are deleted.
+func PruneContexts(contexts []Context, dir string) error {
+	byPath := map[string][]string{}
+	var paths []string
+	for _, c := range contexts {
+		if _, ok := byPath[c.Path]; !ok {
+			paths = append(paths, c.Path)
+		}
+		byPath[c.Path] = append(byPath[c.Path], c.Name)
+	}
+
+	for _, p := range paths {
+		err := modifyConfigFile(p, func(config *clientcmdapi.Config) (bool, error) {
+			for _, name := range byPath[p] {
+				removeContext(config, name)
+			}
+			if len(config.Contexts) == 0 && dir != "" && filepath.Dir(p) == filepath.Clean(dir) {
+				logger.Debug("removing kubeconfig %q left without contexts", p)
+				if err := os.Remove(p); err != nil {
+					return false, fmt.Errorf("removing kubeconfig %q: %w", p, err)
+				}
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameContext renames a context in the kubeconfig file it is in, along with its user if the user has the name
+// of the context, as users written by eksctl do. It returns the path of the file.
+func RenameContext(paths []string, oldName, newName string) (string, error) {
+	for _, p := range paths {
+		config, err := clientcmd.LoadFromFile(p)
+		if err != nil {
+			return "", fmt.Errorf("unable to load kubeconfig %q: %w", p, err)
+		}
+		if _, ok := config.Contexts[oldName]; !ok {
+			continue
+		}
+
+		err = modifyConfigFile(p, func(config *clientcmdapi.Config) (bool, error) {
+			c, ok := config.Contexts[oldName]
+			if !ok {
+				return false, fmt.Errorf("context %q was removed from kubeconfig %q", oldName, p)
+			}
+			if _, exists := config.Contexts[newName]; exists {
+				return false, fmt.Errorf("context %q already exists in kubeconfig %q", newName, p)
+			}
+			delete(config.Contexts, oldName)
+			config.Contexts[newName] = c
+			if authInfo, ok := config.AuthInfos[oldName]; ok && c.AuthInfo == oldName && config.AuthInfos[newName] == nil {
+				delete(config.AuthInfos, oldName)
+				config.AuthInfos[newName] = authInfo
+				c.AuthInfo = newName
+			}
+			if config.CurrentContext == oldName {
+				config.CurrentContext = newName
+			}
+			return true, nil
+		})
+		return p, err
+	}
+	return "", fmt.Errorf("context %q not found", oldName)
+}
+
+// UseContext sets the current context of the kubeconfig file at path
+func UseContext(path, name string) error {
+	return modifyConfigFile(path, func(config *clientcmdapi.Config) (bool, error) {
+		if _, ok := config.Contexts[name]; !ok {
+			return false, fmt.Errorf("context %q not found in kubeconfig %q", name, path)
+		}
+		config.CurrentContext = name
+		return true, nil
+	})
+}
+
+// modifyConfigFile loads the kubeconfig file at path while holding its lock, and writes it back if modify
+// returns true
+func modifyConfigFile(path string, modify func(config *clientcmdapi.Config) (bool, error)) error {
+	fl, err := lockConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlockConfigFile(fl); err != nil {
+			logger.Critical(err.Error())
+		}
+	}()
+
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig %q: %w", path, err)
+	}
+	write, err := modify(config)
+	if err != nil || !write {
+		return err
+	}
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		return fmt.Errorf("unable to write kubeconfig %q: %w", path, err)
+	}
+	return nil
+}
+
+// removeContext removes a context, along with its user and cluster unless other contexts refer to them
+func removeContext(config *clientcmdapi.Config, name string) {
+	c, ok := config.Contexts[name]
+	if !ok {
+		return
+	}
+	delete(config.Contexts, name)
+	logger.Debug("removed context %q from kubeconfig", name)
+
+	authInfoUsed, clusterUsed := false, false
+	for _, other := range config.Contexts {
+		authInfoUsed = authInfoUsed || other.AuthInfo == c.AuthInfo
+		clusterUsed = clusterUsed || other.Cluster == c.Cluster
+	}
+	if !authInfoUsed {
+		delete(config.AuthInfos, c.AuthInfo)
+	}
+	if !clusterUsed {
+		delete(config.Clusters, c.Cluster)
+	}
+	if config.CurrentContext == name {
+		config.CurrentContext = ""
+	}
+}
+
+// parseCredentials returns the credentials of the authenticator of the user, as written by AppendAuthenticator
+func parseCredentials(authInfo *clientcmdapi.AuthInfo) *Credentials {
+	if authInfo == nil || authInfo.Exec == nil {
+		return nil
+	}
+	exec := authInfo.Exec
+	switch filepath.Base(exec.Command) {
+	case AWSIAMAuthenticator, AWSEKSAuthenticator, EksctlAuthenticator:
+	default:
+		return nil
+	}
+
+	credentials := &Credentials{}
+	for _, env := range exec.Env {
+		if env.Name == "AWS_PROFILE" {
+			credentials.Profile = env.Value
+		}
+	}
+	// the role passed with --role-arn or -r is assumed with the credentials of the roles passed with --assume-role-arn
+	var roleARN string
+	for i := 0; i < len(exec.Args)-1; i++ {
+		value := exec.Args[i+1]
+		switch exec.Args[i] {
+		case "--role-arn", "-r":
+			roleARN = value
+		case "--assume-role-arn":
+			credentials.RoleARNs = append(credentials.RoleARNs, value)
+		case "--external-id":
+			credentials.ExternalID = value
+		case "--mfa-serial":
+			credentials.MFASerial = value
+		case "--role-session-name":
+			credentials.RoleSessionName = value
+		default:
+			continue
+		}
+		i++
+	}
+	if roleARN != "" {
+		credentials.RoleARNs = append(credentials.RoleARNs, roleARN)
+	}
+	return credentials
+}
+
+// parseClusterName returns the name and region of a cluster named <name>.<region>.eksctl.io
+func parseClusterName(cluster string) (name, region string, ok bool) {
+	nameAndRegion, ok := strings.CutSuffix(cluster, eksctlClusterSuffix)
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndex(nameAndRegion, ".")
+	if i <= 0 || i == len(nameAndRegion)-1 {
+		return "", "", false
+	}
+	return nameAndRegion[:i], nameAndRegion[i+1:], true
+}
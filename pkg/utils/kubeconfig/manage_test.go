@@ -0,0 +1,164 @@
+package kubeconfig_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+var _ = Describe("Managing contexts", func() {
+	var (
+		dir, splitDir, mainPath, splitPath string
+	)
+
+	newConfig := func(current string, contexts map[string]string) *clientcmdapi.Config {
+		config := clientcmdapi.NewConfig()
+		for name, cluster := range contexts {
+			config.Clusters[cluster] = &clientcmdapi.Cluster{Server: "https://" + cluster}
+			config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+			config.Contexts[name] = &clientcmdapi.Context{Cluster: cluster, AuthInfo: name}
+		}
+		config.CurrentContext = current
+		return config
+	}
+
+	load := func(p string) *clientcmdapi.Config {
+		config, err := clientcmd.LoadFromFile(p)
+		Expect(err).NotTo(HaveOccurred())
+		return config
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		splitDir = filepath.Join(dir, "clusters")
+		Expect(os.Mkdir(splitDir, 0755)).To(Succeed())
+		mainPath = filepath.Join(dir, "config")
+		splitPath = filepath.Join(splitDir, "split")
+
+		Expect(clientcmd.WriteToFile(*newConfig("user@dev.us-west-2.eksctl.io", map[string]string{
+			"user@dev.us-west-2.eksctl.io":    "dev.us-west-2.eksctl.io",
+			"user@prod.eu-west-1.eksctl.io":   "prod.eu-west-1.eksctl.io",
+			"minikube":                        "minikube",
+			"renamed-dev-context-for-testing": "dev.us-west-2.eksctl.io",
+		}), mainPath)).To(Succeed())
+		Expect(clientcmd.WriteToFile(*newConfig("user@split.us-east-1.eksctl.io", map[string]string{
+			"user@split.us-east-1.eksctl.io": "split.us-east-1.eksctl.io",
+		}), splitPath)).To(Succeed())
+	})
+
+	It("finds kubeconfig files in a list of paths and a directory", func() {
+		paths, err := kubeconfig.ConfigPaths(mainPath+string(os.PathListSeparator)+filepath.Join(dir, "missing"), splitDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{mainPath, splitPath}))
+
+		paths, err = kubeconfig.ConfigPaths(mainPath, filepath.Join(dir, "missing"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{mainPath}))
+	})
+
+	It("lists the contexts of clusters written by eksctl", func() {
+		contexts, err := kubeconfig.ListContexts([]string{mainPath, splitPath})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contexts).To(Equal([]kubeconfig.Context{
+			{Name: "renamed-dev-context-for-testing", ClusterName: "dev", Region: "us-west-2", Path: mainPath},
+			{Name: "user@dev.us-west-2.eksctl.io", ClusterName: "dev", Region: "us-west-2", Path: mainPath, Current: true},
+			{Name: "user@prod.eu-west-1.eksctl.io", ClusterName: "prod", Region: "eu-west-1", Path: mainPath},
+			{Name: "user@split.us-east-1.eksctl.io", ClusterName: "split", Region: "us-east-1", Path: splitPath, Current: true},
+		}))
+	})
+
+	It("lists the credentials the authenticators of contexts use", func() {
+		config := newConfig("", map[string]string{
+			"eksctl@dev.us-west-2.eksctl.io": "dev.us-west-2.eksctl.io",
+			"aws@prod.eu-west-1.eksctl.io":   "prod.eu-west-1.eksctl.io",
+			"other@test.us-east-1.eksctl.io": "test.us-east-1.eksctl.io",
+		})
+		config.AuthInfos["eksctl@dev.us-west-2.eksctl.io"] = &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+			Command: "/usr/local/bin/eksctl",
+			Args: []string{"get-token", "--cluster-name", "dev", "--region", "us-west-2", "--assume-role-arn", "arn:aws:iam::111:role/first",
+				"--external-id", "id", "--role-session-name", "session", "--role-arn", "arn:aws:iam::222:role/admin"},
+			Env: []clientcmdapi.ExecEnvVar{{Name: "AWS_STS_REGIONAL_ENDPOINTS", Value: "regional"}, {Name: "AWS_PROFILE", Value: "dev"}},
+		}}
+		config.AuthInfos["aws@prod.eu-west-1.eksctl.io"] = &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+			Command: "aws",
+			Args:    []string{"eks", "get-token", "--output", "json", "--cluster-name", "prod", "--region", "eu-west-1"},
+		}}
+		config.AuthInfos["other@test.us-east-1.eksctl.io"] = &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+			Command: "kubelogin",
+		}}
+		path := filepath.Join(dir, "credentials")
+		Expect(clientcmd.WriteToFile(*config, path)).To(Succeed())
+
+		contexts, err := kubeconfig.ListContexts([]string{path})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contexts).To(HaveLen(3))
+		Expect(contexts[0].Credentials).To(Equal(&kubeconfig.Credentials{}))
+		Expect(contexts[1].Credentials).To(Equal(&kubeconfig.Credentials{
+			Profile:         "dev",
+			RoleARNs:        []string{"arn:aws:iam::111:role/first", "arn:aws:iam::222:role/admin"},
+			ExternalID:      "id",
+			RoleSessionName: "session",
+		}))
+		Expect(contexts[2].Credentials).To(BeNil())
+	})
+
+	It("prunes contexts, keeping clusters used by other contexts and deleting split files left empty", func() {
+		Expect(kubeconfig.PruneContexts([]kubeconfig.Context{
+			{Name: "user@dev.us-west-2.eksctl.io", Path: mainPath},
+			{Name: "user@prod.eu-west-1.eksctl.io", Path: mainPath},
+			{Name: "user@split.us-east-1.eksctl.io", Path: splitPath},
+		}, splitDir)).To(Succeed())
+
+		config := load(mainPath)
+		Expect(config.Contexts).To(HaveLen(2))
+		Expect(config.Contexts).To(HaveKey("minikube"))
+		Expect(config.Contexts).To(HaveKey("renamed-dev-context-for-testing"))
+		Expect(config.AuthInfos).NotTo(HaveKey("user@dev.us-west-2.eksctl.io"))
+		Expect(config.Clusters).To(HaveKey("dev.us-west-2.eksctl.io"))
+		Expect(config.Clusters).NotTo(HaveKey("prod.eu-west-1.eksctl.io"))
+		Expect(config.CurrentContext).To(BeEmpty())
+
+		Expect(splitPath).NotTo(BeAnExistingFile())
+	})
+
+	It("keeps files outside the split directory left without contexts", func() {
+		Expect(kubeconfig.PruneContexts([]kubeconfig.Context{
+			{Name: "user@split.us-east-1.eksctl.io", Path: splitPath},
+		}, "")).To(Succeed())
+		Expect(splitPath).To(BeAnExistingFile())
+		Expect(load(splitPath).Contexts).To(BeEmpty())
+	})
+
+	It("renames a context along with its user", func() {
+		p, err := kubeconfig.RenameContext([]string{mainPath, splitPath}, "user@split.us-east-1.eksctl.io", "split")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(splitPath))
+
+		config := load(splitPath)
+		Expect(config.Contexts).To(HaveKey("split"))
+		Expect(config.Contexts["split"].AuthInfo).To(Equal("split"))
+		Expect(config.AuthInfos).To(HaveKey("split"))
+		Expect(config.CurrentContext).To(Equal("split"))
+	})
+
+	It("does not rename a context to the name of another context", func() {
+		_, err := kubeconfig.RenameContext([]string{mainPath}, "user@dev.us-west-2.eksctl.io", "minikube")
+		Expect(err).To(MatchError(ContainSubstring(`context "minikube" already exists`)))
+
+		_, err = kubeconfig.RenameContext([]string{mainPath}, "missing", "other")
+		Expect(err).To(MatchError(`context "missing" not found`))
+	})
+
+	It("sets the current context", func() {
+		Expect(kubeconfig.UseContext(mainPath, "user@prod.eu-west-1.eksctl.io")).To(Succeed())
+		Expect(load(mainPath).CurrentContext).To(Equal("user@prod.eu-west-1.eksctl.io"))
+
+		Expect(kubeconfig.UseContext(mainPath, "missing")).To(MatchError(ContainSubstring(`context "missing" not found`)))
+	})
+})
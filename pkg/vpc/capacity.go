@@ -0,0 +1,315 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+const (
+	// prefixSize is the number of IPs in a /28 prefix assigned to an ENI with prefix delegation.
+	prefixSize = 16
+	// hostNetworkPods is the number of pods using the host network, which the max pods formula accounts for.
+	hostNetworkPods = 2
+	// defaultPrefixDelegationMaxPods is the max pods recommended for nodes using prefix delegation.
+	defaultPrefixDelegationMaxPods = 110
+)
+
+// NodeGroupIPUsage describes the nodes a nodegroup launches into its subnets.
+type NodeGroupIPUsage struct {
+	Name      string
+	SubnetIDs []string
+	// DesiredNodes is the number of nodes launched right away.
+	DesiredNodes int
+	// MaxNodes is the number of nodes the nodegroup can scale to.
+	MaxNodes int
+	// IPsPerNode is the number of subnet IPs a node can consume.
+	IPsPerNode int
+}
+
+// IPUsageOptions describes how the VPC CNI assigns IPs to pods.
+type IPUsageOptions struct {
+	// PrefixDelegation is true when the VPC CNI assigns /28 prefixes rather than individual IPs to ENIs.
+	PrefixDelegation bool
+	// CustomNetworking is true when pods get IPs from pod subnets rather than from the subnets of nodes.
+	CustomNetworking bool
+	// IPv6 is true when pods get IPv6 addresses.
+	IPv6 bool
+}
+
+// SubnetCapacity compares the free IPs of a subnet with the IPs its nodegroups can consume.
+type SubnetCapacity struct {
+	SubnetID     string   `json:"subnetID"`
+	AZ           string   `json:"availabilityZone"`
+	CIDR         string   `json:"cidr"`
+	AvailableIPs int      `json:"availableIPs"`
+	DesiredIPs   int      `json:"desiredIPs"`
+	MaxIPs       int      `json:"maxIPs"`
+	NodeGroups   []string `json:"nodeGroups"`
+}
+
+// FitsDesired returns true if the subnet has enough free IPs for the desired number of nodes.
+func (s SubnetCapacity) FitsDesired() bool {
+	return s.AvailableIPs >= s.DesiredIPs
+}
+
+// FitsMax returns true if the subnet has enough free IPs for its nodegroups at their max size.
+func (s SubnetCapacity) FitsMax() bool {
+	return s.AvailableIPs >= s.MaxIPs
+}
+
+// IPsPerNode returns the number of subnet IPs a node of the instance type can consume, including the IPs warmed up
+// by the VPC CNI. A maxPods of 0 means the max pods of the instance type is used.
+func IPsPerNode(networkInfo *ec2types.NetworkInfo, maxPods int, options IPUsageOptions) int {
+	if networkInfo == nil {
+		return 0
+	}
+	enis := int(aws.ToInt32(networkInfo.MaximumNetworkInterfaces))
+	ipsPerENI := int(aws.ToInt32(networkInfo.Ipv4AddressesPerInterface))
+	if enis == 0 || ipsPerENI < 2 {
+		return 0
+	}
+	if options.CustomNetworking || options.IPv6 {
+		// pods get IPs from pod subnets or IPv6 prefixes, and nodes only use the primary IP of their primary ENI
+		return 1
+	}
+
+	if options.PrefixDelegation {
+		if maxPods == 0 {
+			maxPods = defaultPrefixDelegationMaxPods
+		}
+		prefixes := ceilDiv(maxPods, prefixSize) + 1
+		enisUsed := min(enis, ceilDiv(prefixes, ipsPerENI-1))
+		// each ENI has a primary IP besides its prefixes
+		return prefixes*prefixSize + enisUsed
+	}
+
+	if maxPods == 0 {
+		return enis * ipsPerENI
+	}
+	podIPs := max(maxPods-hostNetworkPods, 1)
+	// the VPC CNI keeps a spare ENI attached to assign IPs to new pods quickly
+	enisUsed := min(enis, ceilDiv(podIPs, ipsPerENI-1)+1)
+	return enisUsed * ipsPerENI
+}
+
+// NodeGroupSubnetIDs returns the IDs of the subnets the nodegroup launches nodes into, as configured in the cluster
+// config. Subnets which are not part of the cluster config are returned as set on the nodegroup.
+func NodeGroupSubnetIDs(np api.NodePool, clusterConfig *api.ClusterConfig) []string {
+	ng := np.BaseNodeGroup()
+	var (
+		publicSubnets, privateSubnets api.AZSubnetMapping
+		zones                         []string
+	)
+	if nodeGroup, ok := np.(*api.NodeGroup); ok && len(nodeGroup.LocalZones) > 0 {
+		zones = nodeGroup.LocalZones
+		if clusterConfig.VPC.LocalZoneSubnets != nil {
+			publicSubnets, privateSubnets = clusterConfig.VPC.LocalZoneSubnets.Public, clusterConfig.VPC.LocalZoneSubnets.Private
+		}
+	} else {
+		zones = ng.AvailabilityZones
+		if clusterConfig.VPC.Subnets != nil {
+			publicSubnets, privateSubnets = clusterConfig.VPC.Subnets.Public, clusterConfig.VPC.Subnets.Private
+		}
+	}
+	subnetMapping := publicSubnets
+	if ng.PrivateNetworking {
+		subnetMapping = privateSubnets
+	}
+
+	var subnetIDs []string
+	if len(ng.Subnets) > 0 {
+		for _, name := range ng.Subnets {
+			subnet, ok := findInConfiguredVPC(name, privateSubnets)
+			if !ok {
+				subnet, ok = findInConfiguredVPC(name, publicSubnets)
+			}
+			if ok {
+				subnetIDs = append(subnetIDs, subnet.ID)
+			} else {
+				subnetIDs = append(subnetIDs, name)
+			}
+		}
+	} else {
+		if len(zones) == 0 {
+			zones = clusterConfig.AvailabilityZones
+		}
+		for _, s := range subnetMapping {
+			if s.ID != "" && (len(zones) == 0 || slices.Contains(zones, s.AZ)) {
+				subnetIDs = append(subnetIDs, s.ID)
+			}
+		}
+		sort.Strings(subnetIDs)
+	}
+
+	if api.IsEnabled(ng.EFAEnabled) && len(subnetIDs) > 0 {
+		subnetIDs = subnetIDs[:1]
+	}
+	return subnetIDs
+}
+
+// NewNodeGroupIPUsage returns the IP usage of the nodegroups in the cluster config. Nodegroups without instance types,
+// e.g. using an instance selector which has not been resolved yet, are skipped.
+func NewNodeGroupIPUsage(ctx context.Context, ec2API awsapi.EC2, clusterConfig *api.ClusterConfig, nodePools []api.NodePool, options IPUsageOptions) ([]NodeGroupIPUsage, error) {
+	instanceTypes := map[string]struct{}{}
+	for _, np := range nodePools {
+		for _, it := range np.InstanceTypeList() {
+			if it != "mixed" {
+				instanceTypes[it] = struct{}{}
+			}
+		}
+	}
+	networkInfo, err := describeNetworkInfo(ctx, ec2API, instanceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []NodeGroupIPUsage
+	for _, np := range nodePools {
+		ng := np.BaseNodeGroup()
+		ipsPerNode := 0
+		for _, it := range np.InstanceTypeList() {
+			ipsPerNode = max(ipsPerNode, IPsPerNode(networkInfo[it], ng.MaxPodsPerNode, options))
+		}
+		if ipsPerNode == 0 {
+			logger.Debug("skipping nodegroup %q without known instance types", ng.Name)
+			continue
+		}
+		usages = append(usages, NodeGroupIPUsage{
+			Name:         ng.Name,
+			SubnetIDs:    NodeGroupSubnetIDs(np, clusterConfig),
+			DesiredNodes: aws.ToInt(ng.DesiredCapacity),
+			MaxNodes:     max(aws.ToInt(ng.MaxSize), aws.ToInt(ng.DesiredCapacity)),
+			IPsPerNode:   ipsPerNode,
+		})
+	}
+	return usages, nil
+}
+
+// InstanceTypesIPsPerNode returns the largest number of subnet IPs a node of any of the instance types can consume.
+func InstanceTypesIPsPerNode(ctx context.Context, ec2API awsapi.EC2, instanceTypes []string, maxPods int, options IPUsageOptions) (int, error) {
+	types := map[string]struct{}{}
+	for _, it := range instanceTypes {
+		types[it] = struct{}{}
+	}
+	networkInfo, err := describeNetworkInfo(ctx, ec2API, types)
+	if err != nil {
+		return 0, err
+	}
+	ipsPerNode := 0
+	for _, info := range networkInfo {
+		ipsPerNode = max(ipsPerNode, IPsPerNode(info, maxPods, options))
+	}
+	return ipsPerNode, nil
+}
+
+// SubnetCapacities returns the capacity of each subnet used by the nodegroups, spreading the nodes of each nodegroup
+// evenly across its subnets as Auto Scaling balances them across availability zones.
+func SubnetCapacities(ctx context.Context, ec2API awsapi.EC2, usages []NodeGroupIPUsage) ([]SubnetCapacity, error) {
+	capacities := map[string]*SubnetCapacity{}
+	var subnetIDs []string
+	for _, u := range usages {
+		for _, id := range u.SubnetIDs {
+			c, ok := capacities[id]
+			if !ok {
+				c = &SubnetCapacity{SubnetID: id}
+				capacities[id] = c
+				subnetIDs = append(subnetIDs, id)
+			}
+			c.DesiredIPs += ceilDiv(u.DesiredNodes, len(u.SubnetIDs)) * u.IPsPerNode
+			c.MaxIPs += ceilDiv(u.MaxNodes, len(u.SubnetIDs)) * u.IPsPerNode
+			c.NodeGroups = append(c.NodeGroups, u.Name)
+		}
+	}
+	if len(subnetIDs) == 0 {
+		return nil, nil
+	}
+
+	subnets, err := describeSubnets(ctx, ec2API, "", subnetIDs, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("describing subnets %v: %w", subnetIDs, err)
+	}
+	for _, s := range subnets {
+		c, ok := capacities[aws.ToString(s.SubnetId)]
+		if !ok {
+			continue
+		}
+		c.AZ = aws.ToString(s.AvailabilityZone)
+		c.CIDR = aws.ToString(s.CidrBlock)
+		c.AvailableIPs = int(aws.ToInt32(s.AvailableIpAddressCount))
+	}
+
+	var result []SubnetCapacity
+	for _, id := range subnetIDs {
+		result = append(result, *capacities[id])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AZ != result[j].AZ {
+			return result[i].AZ < result[j].AZ
+		}
+		return result[i].SubnetID < result[j].SubnetID
+	})
+	return result, nil
+}
+
+// CheckSubnetCapacities warns about subnets which cannot fit their nodegroups at max size, and returns an error for
+// subnets which cannot fit the desired number of nodes.
+func CheckSubnetCapacities(capacities []SubnetCapacity) error {
+	var insufficient []string
+	for _, c := range capacities {
+		switch {
+		case !c.FitsDesired():
+			insufficient = append(insufficient, fmt.Sprintf("subnet %s (%s) has %d free IPs, but nodegroup(s) %s need up to %d IPs",
+				c.SubnetID, c.AZ, c.AvailableIPs, strings.Join(c.NodeGroups, ", "), c.DesiredIPs))
+		case !c.FitsMax():
+			logger.Warning("subnet %s (%s) has %d free IPs, but nodegroup(s) %s need up to %d IPs at max size",
+				c.SubnetID, c.AZ, c.AvailableIPs, strings.Join(c.NodeGroups, ", "), c.MaxIPs)
+		}
+	}
+	if len(insufficient) > 0 {
+		return errors.New("insufficient free IPs in subnets:\n" + strings.Join(insufficient, "\n"))
+	}
+	return nil
+}
+
+func describeNetworkInfo(ctx context.Context, ec2API awsapi.EC2, instanceTypes map[string]struct{}) (map[string]*ec2types.NetworkInfo, error) {
+	if len(instanceTypes) == 0 {
+		return nil, nil
+	}
+	var types []ec2types.InstanceType
+	for it := range instanceTypes {
+		types = append(types, ec2types.InstanceType(it))
+	}
+	networkInfo := map[string]*ec2types.NetworkInfo{}
+	paginator := ec2.NewDescribeInstanceTypesPaginator(ec2API, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: types,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing instance types: %w", err)
+		}
+		for _, it := range output.InstanceTypes {
+			networkInfo[string(it.InstanceType)] = it.NetworkInfo
+		}
+	}
+	return networkInfo, nil
+}
+
+func ceilDiv(a, b int) int {
+	if b == 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
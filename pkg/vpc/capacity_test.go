@@ -0,0 +1,135 @@
+package vpc
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Subnet capacity", func() {
+	m5Large := &ec2types.NetworkInfo{
+		MaximumNetworkInterfaces:  aws.Int32(3),
+		Ipv4AddressesPerInterface: aws.Int32(10),
+	}
+
+	DescribeTable("IPsPerNode", func(maxPods int, options IPUsageOptions, expectedIPs int) {
+		Expect(IPsPerNode(m5Large, maxPods, options)).To(Equal(expectedIPs))
+	},
+		Entry("uses all ENIs by default", 0, IPUsageOptions{}, 30),
+		Entry("uses the ENIs required for maxPodsPerNode, plus a spare ENI", 8, IPUsageOptions{}, 20),
+		Entry("is capped at the ENI limit", 100, IPUsageOptions{}, 30),
+		Entry("uses /28 prefixes with prefix delegation", 0, IPUsageOptions{PrefixDelegation: true}, 8*16+1),
+		Entry("uses prefixes for maxPodsPerNode with prefix delegation", 20, IPUsageOptions{PrefixDelegation: true}, 3*16+1),
+		Entry("uses a single IP with custom networking", 0, IPUsageOptions{CustomNetworking: true}, 1),
+		Entry("uses a single IP with IPv6", 0, IPUsageOptions{IPv6: true}, 1),
+	)
+
+	Describe("NodeGroupSubnetIDs", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b"}
+			cfg.VPC.Subnets = &api.ClusterSubnets{
+				Public: api.AZSubnetMapping{
+					"us-west-2a": {ID: "subnet-public-a", AZ: "us-west-2a"},
+					"us-west-2b": {ID: "subnet-public-b", AZ: "us-west-2b"},
+				},
+				Private: api.AZSubnetMapping{
+					"us-west-2a": {ID: "subnet-private-a", AZ: "us-west-2a"},
+					"us-west-2b": {ID: "subnet-private-b", AZ: "us-west-2b"},
+				},
+			}
+		})
+
+		It("uses the subnets of the nodegroup zones", func() {
+			ng := api.NewManagedNodeGroup()
+			ng.PrivateNetworking = true
+			ng.AvailabilityZones = []string{"us-west-2b"}
+			Expect(NodeGroupSubnetIDs(ng, cfg)).To(Equal([]string{"subnet-private-b"}))
+		})
+
+		It("uses the subnets of the cluster zones", func() {
+			ng := api.NewNodeGroup()
+			Expect(NodeGroupSubnetIDs(ng, cfg)).To(Equal([]string{"subnet-public-a", "subnet-public-b"}))
+		})
+
+		It("resolves subnets by name and ID", func() {
+			ng := api.NewNodeGroup()
+			ng.Subnets = []string{"us-west-2a", "subnet-private-b", "subnet-other"}
+			Expect(NodeGroupSubnetIDs(ng, cfg)).To(Equal([]string{"subnet-private-a", "subnet-private-b", "subnet-other"}))
+		})
+	})
+
+	Describe("SubnetCapacities", func() {
+		var p *mockprovider.MockProvider
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			p.MockEC2().On("DescribeSubnets", Anything, &ec2.DescribeSubnetsInput{
+				SubnetIds: []string{"subnet-a", "subnet-b"},
+			}).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []ec2types.Subnet{
+					{
+						SubnetId:                aws.String("subnet-b"),
+						AvailabilityZone:        aws.String("us-west-2b"),
+						CidrBlock:               aws.String("192.168.32.0/24"),
+						AvailableIpAddressCount: aws.Int32(100),
+					},
+					{
+						SubnetId:                aws.String("subnet-a"),
+						AvailabilityZone:        aws.String("us-west-2a"),
+						CidrBlock:               aws.String("192.168.0.0/24"),
+						AvailableIpAddressCount: aws.Int32(250),
+					},
+				},
+			}, nil)
+		})
+
+		It("spreads the nodes of each nodegroup across its subnets", func() {
+			capacities, err := SubnetCapacities(context.Background(), p.EC2(), []NodeGroupIPUsage{
+				{Name: "ng-1", SubnetIDs: []string{"subnet-a", "subnet-b"}, DesiredNodes: 2, MaxNodes: 6, IPsPerNode: 30},
+				{Name: "ng-2", SubnetIDs: []string{"subnet-a"}, DesiredNodes: 1, MaxNodes: 1, IPsPerNode: 30},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacities).To(Equal([]SubnetCapacity{
+				{
+					SubnetID:     "subnet-a",
+					AZ:           "us-west-2a",
+					CIDR:         "192.168.0.0/24",
+					AvailableIPs: 250,
+					DesiredIPs:   60,
+					MaxIPs:       120,
+					NodeGroups:   []string{"ng-1", "ng-2"},
+				},
+				{
+					SubnetID:     "subnet-b",
+					AZ:           "us-west-2b",
+					CIDR:         "192.168.32.0/24",
+					AvailableIPs: 100,
+					DesiredIPs:   30,
+					MaxIPs:       90,
+					NodeGroups:   []string{"ng-1"},
+				},
+			}))
+			Expect(CheckSubnetCapacities(capacities)).To(Succeed())
+		})
+
+		It("refuses subnets without enough free IPs for the desired nodes", func() {
+			capacities, err := SubnetCapacities(context.Background(), p.EC2(), []NodeGroupIPUsage{
+				{Name: "ng-1", SubnetIDs: []string{"subnet-a", "subnet-b"}, DesiredNodes: 8, MaxNodes: 8, IPsPerNode: 30},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(CheckSubnetCapacities(capacities)).To(MatchError(ContainSubstring("subnet subnet-b (us-west-2b) has 100 free IPs, but nodegroup(s) ng-1 need up to 120 IPs")))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+const (
+	defaultIPAMNetmaskLength = 16
+	// reservedIPsPerSubnet is the number of IPs AWS reserves in each subnet.
+	reservedIPsPerSubnet = 5
+)
+
+// IPAMNetmaskLength returns the netmask length of the VPC CIDR to allocate from the IPAM pool. Unless it is set
+// explicitly, it is the length of the smallest VPC whose private subnets, one per zone, fit vpc.capacity.
+func IPAMNetmaskLength(vpc *api.ClusterVPC, zonesTotal int) (int, error) {
+	if vpc.IPAMNetmaskLength != nil {
+		return *vpc.IPAMNetmaskLength, nil
+	}
+	if vpc.Capacity == nil {
+		return defaultIPAMNetmaskLength, nil
+	}
+	if zonesTotal == 0 {
+		return 0, fmt.Errorf("cannot size the VPC without availability zones")
+	}
+
+	maxPodsPerNode := vpc.Capacity.MaxPodsPerNode
+	if maxPodsPerNode == 0 {
+		maxPodsPerNode = api.DefaultVPCMaxPodsPerNode
+	}
+	nodesPerZone := (vpc.Capacity.MaxNodes + zonesTotal - 1) / zonesTotal
+	// each node uses one IP for its primary interface, and one IP per pod
+	requiredIPs := nodesPerZone*(maxPodsPerNode+1) + reservedIPsPerSubnet
+	subnetLength := 32 - bits.Len(uint(requiredIPs-1))
+
+	// the VPC CIDR is split into as many subnets as SetSubnets does
+	networkBits := 3
+	if zonesTotal*2 > 8 {
+		networkBits = 4
+	}
+	netmaskLength := subnetLength - networkBits
+	if netmaskLength < 16 {
+		return 0, fmt.Errorf("a VPC for %d nodes with %d pods each requires subnets of %d IPs, which do not fit a /16 VPC",
+			vpc.Capacity.MaxNodes, maxPodsPerNode, requiredIPs)
+	}
+	if netmaskLength > 24 {
+		netmaskLength = 24
+	}
+	return netmaskLength, nil
+}
+
+// AllocateFromIPAM sets the VPC CIDR to the next free CIDR of the IPAM pool. The CIDR is only previewed here, and is
+// allocated to the VPC when CloudFormation creates it from the pool.
+// It must be called before SetSubnets, so that the subnets are carved from the allocated CIDR.
+func AllocateFromIPAM(ctx context.Context, ec2API awsapi.EC2, vpc *api.ClusterVPC, zonesTotal int) error {
+	netmaskLength, err := IPAMNetmaskLength(vpc, zonesTotal)
+	if err != nil {
+		return err
+	}
+	out, err := ec2API.AllocateIpamPoolCidr(ctx, &ec2.AllocateIpamPoolCidrInput{
+		IpamPoolId:      aws.String(vpc.IPAMPoolID),
+		NetmaskLength:   aws.Int32(int32(netmaskLength)),
+		PreviewNextCidr: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("previewing a /%d CIDR from IPAM pool %q: %w", netmaskLength, vpc.IPAMPoolID, err)
+	}
+	if out.IpamPoolAllocation == nil || out.IpamPoolAllocation.Cidr == nil {
+		return fmt.Errorf("IPAM pool %q has no free /%d CIDR", vpc.IPAMPoolID, netmaskLength)
+	}
+	cidr, err := ipnet.ParseCIDR(*out.IpamPoolAllocation.Cidr)
+	if err != nil {
+		return fmt.Errorf("parsing CIDR allocated from IPAM pool %q: %w", vpc.IPAMPoolID, err)
+	}
+	logger.Info("using CIDR %s from IPAM pool %q", cidr.String(), vpc.IPAMPoolID)
+	vpc.CIDR = cidr
+	return nil
+}
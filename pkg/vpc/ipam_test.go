@@ -0,0 +1,101 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("IPAM", func() {
+	type netmaskLengthEntry struct {
+		vpc        *api.ClusterVPC
+		zonesTotal int
+
+		expectedLength int
+		expectedErr    string
+	}
+
+	DescribeTable("IPAMNetmaskLength", func(e netmaskLengthEntry) {
+		length, err := IPAMNetmaskLength(e.vpc, e.zonesTotal)
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(length).To(Equal(e.expectedLength))
+	},
+		Entry("defaults to /16", netmaskLengthEntry{
+			vpc:            &api.ClusterVPC{IPAMPoolID: "ipam-pool-1"},
+			zonesTotal:     3,
+			expectedLength: 16,
+		}),
+		Entry("uses the configured netmask length", netmaskLengthEntry{
+			vpc:            &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", IPAMNetmaskLength: aws.Int(20)},
+			zonesTotal:     3,
+			expectedLength: 20,
+		}),
+		Entry("sizes the VPC from capacity", netmaskLengthEntry{
+			vpc:            &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", Capacity: &api.VPCCapacity{MaxNodes: 30}},
+			zonesTotal:     3,
+			expectedLength: 18,
+		}),
+		Entry("accounts for the larger split with more than 4 zones", netmaskLengthEntry{
+			vpc:            &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", Capacity: &api.VPCCapacity{MaxNodes: 50}},
+			zonesTotal:     5,
+			expectedLength: 17,
+		}),
+		Entry("does not allocate VPCs smaller than /24", netmaskLengthEntry{
+			vpc:            &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", Capacity: &api.VPCCapacity{MaxNodes: 3, MaxPodsPerNode: 10}},
+			zonesTotal:     3,
+			expectedLength: 24,
+		}),
+		Entry("fails when the capacity does not fit a /16", netmaskLengthEntry{
+			vpc:         &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", Capacity: &api.VPCCapacity{MaxNodes: 3000}},
+			zonesTotal:  3,
+			expectedErr: "do not fit a /16 VPC",
+		}),
+	)
+
+	Describe("AllocateFromIPAM", func() {
+		var (
+			p   *mockprovider.MockProvider
+			vpc *api.ClusterVPC
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			vpc = &api.ClusterVPC{IPAMPoolID: "ipam-pool-1", Capacity: &api.VPCCapacity{MaxNodes: 30}}
+		})
+
+		It("previews the next CIDR of the pool and uses it for the VPC and subnets", func() {
+			p.MockEC2().On("AllocateIpamPoolCidr", Anything, MatchedBy(func(input *ec2.AllocateIpamPoolCidrInput) bool {
+				return *input.IpamPoolId == "ipam-pool-1" && *input.NetmaskLength == 18 && *input.PreviewNextCidr
+			})).Return(&ec2.AllocateIpamPoolCidrOutput{
+				IpamPoolAllocation: &ec2types.IpamPoolAllocation{Cidr: aws.String("10.4.64.0/18")},
+			}, nil)
+
+			Expect(AllocateFromIPAM(context.Background(), p.EC2(), vpc, 3)).To(Succeed())
+			Expect(vpc.CIDR.String()).To(Equal("10.4.64.0/18"))
+
+			Expect(SetSubnets(vpc, []string{"us-west-2a", "us-west-2b", "us-west-2c"}, nil)).To(Succeed())
+			Expect(vpc.Subnets.Private["us-west-2a"].CIDR.String()).To(Equal("10.4.88.0/21"))
+		})
+
+		It("returns an error when the pool has no free CIDR", func() {
+			p.MockEC2().On("AllocateIpamPoolCidr", Anything, Anything).Return(nil, errors.New("no free CIDR"))
+
+			err := AllocateFromIPAM(context.Background(), p.EC2(), vpc, 3)
+			Expect(err).To(MatchError(ContainSubstring(`previewing a /18 CIDR from IPAM pool "ipam-pool-1"`)))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// SetPodSubnets divides the pod subnets CIDR into one subnet per availability zone,
+// it must be called after SetAvailabilityZones.
+func SetPodSubnets(vpc *api.ClusterVPC, availabilityZones []string) error {
+	if vpc.PodSubnets == nil || vpc.PodSubnets.CIDR == nil || len(availabilityZones) == 0 {
+		return nil
+	}
+	podSubnets := vpc.PodSubnets
+	size := 1 << bits.Len(uint(len(availabilityZones)-1))
+	prefix, _ := podSubnets.CIDR.Mask.Size()
+	networkLength := prefix + bits.TrailingZeros(uint(size))
+	zoneCIDRs, err := SplitInto(&podSubnets.CIDR.IPNet, size, networkLength)
+	if err != nil {
+		return fmt.Errorf("dividing pod subnets CIDR %s: %w", podSubnets.CIDR.String(), err)
+	}
+	logger.Debug("pod subnets CIDR (%s) was divided into %d subnets %v", podSubnets.CIDR.String(), len(zoneCIDRs), zoneCIDRs)
+
+	podSubnets.Subnets = api.NewAZSubnetMapping()
+	for i, zone := range availabilityZones {
+		podSubnets.Subnets[zone] = api.AZSubnetSpec{
+			AZ:        zone,
+			CIDR:      &ipnet.IPNet{IPNet: *zoneCIDRs[i]},
+			CIDRIndex: i,
+		}
+	}
+	return nil
+}
+
+// importPodSubnets imports the pre-existing pod subnets specified by ID.
+func importPodSubnets(ctx context.Context, ec2API awsapi.EC2, spec *api.ClusterConfig) error {
+	if !spec.HasCustomNetworking() || len(spec.VPC.PodSubnets.Subnets) == 0 {
+		return nil
+	}
+	subnetMapping := spec.VPC.PodSubnets.Subnets
+	subnets, err := describeSubnets(ctx, ec2API, spec.VPC.ID, subnetMapping.WithIDs(), nil, nil)
+	if err != nil {
+		return err
+	}
+	return ImportSubnets(ctx, ec2API, spec, subnetMapping, subnets, nil)
+}
@@ -0,0 +1,36 @@
+package vpc
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+var _ = Describe("SetPodSubnets", func() {
+	It("divides the pod subnets CIDR into a subnet per availability zone", func() {
+		vpc := &api.ClusterVPC{PodSubnets: &api.PodSubnets{CIDR: ipnet.MustParseCIDR("100.64.0.0/16")}}
+		Expect(SetPodSubnets(vpc, []string{"us-west-2a", "us-west-2b", "us-west-2c"})).To(Succeed())
+
+		subnets := vpc.PodSubnets.Subnets
+		Expect(subnets).To(HaveLen(3))
+		Expect(subnets["us-west-2a"].AZ).To(Equal("us-west-2a"))
+		Expect(subnets["us-west-2a"].CIDR.String()).To(Equal("100.64.0.0/18"))
+		Expect(subnets["us-west-2b"].CIDR.String()).To(Equal("100.64.64.0/18"))
+		Expect(subnets["us-west-2c"].CIDR.String()).To(Equal("100.64.128.0/18"))
+	})
+
+	It("uses the whole CIDR for a single availability zone", func() {
+		vpc := &api.ClusterVPC{PodSubnets: &api.PodSubnets{CIDR: ipnet.MustParseCIDR("100.64.0.0/20")}}
+		Expect(SetPodSubnets(vpc, []string{"us-west-2a"})).To(Succeed())
+		Expect(vpc.PodSubnets.Subnets["us-west-2a"].CIDR.String()).To(Equal("100.64.0.0/20"))
+	})
+
+	It("leaves pre-existing pod subnets untouched", func() {
+		subnets := api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{"us-west-2a": {ID: "subnet-1"}})
+		vpc := &api.ClusterVPC{PodSubnets: &api.PodSubnets{Subnets: subnets}}
+		Expect(SetPodSubnets(vpc, []string{"us-west-2a"})).To(Succeed())
+		Expect(vpc.PodSubnets.Subnets).To(Equal(subnets))
+	})
+})
@@ -377,6 +377,14 @@ func UseFromClusterStack(ctx context.Context, provider api.ClusterProvider, stac
 		outputs.ClusterSubnetsPublicLocal: func(v string) error {
 			return importSubnetsFromIDList(spec.VPC.LocalZoneSubnets.Public, v)
 		},
+		outputs.ClusterSubnetsPod: func(v string) error {
+			if spec.VPC.PodSubnets == nil {
+				spec.VPC.PodSubnets = &api.PodSubnets{}
+			}
+			spec.VPC.PodSubnets.CIDR = nil
+			spec.VPC.PodSubnets.Subnets = api.NewAZSubnetMapping()
+			return importSubnetsFromIDList(spec.VPC.PodSubnets.Subnets, v)
+		},
 		outputs.ClusterSubnetsPrivateExtended: func(v string) error {
 			return ImportSubnetsByIDsWithAlias(ctx, provider.EC2(), spec, spec.VPC.Subnets.Private, splitOutputValue(v), MakeExtendedSubnetAliasFunc())
 		},
@@ -676,6 +684,9 @@ func ImportSubnetsFromSpec(ctx context.Context, ec2API awsapi.EC2, spec *api.Clu
 	if err := importSubnetsForTopology(ctx, ec2API, spec, api.SubnetTopologyPublic); err != nil {
 		return err
 	}
+	if err := importPodSubnets(ctx, ec2API, spec); err != nil {
+		return err
+	}
 	// to clean up invalid subnets based on AZ after importing both private and public subnets
 	cleanupSubnets(spec)
 	return nil